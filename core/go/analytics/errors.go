@@ -0,0 +1,190 @@
+// Package analytics holds what the analytics packages share: the error
+// registry that gives their error codes SOD scores and mitigations.
+package analytics
+
+import (
+	"github.com/your-github-org/ai-scaffolder/core/go/errors"
+)
+
+// Error codes used by the analytics packages
+const (
+	// Data validation (analytics/validation)
+	ErrCodeSchemaValidation = "VALIDATION-001"
+	ErrCodeOutOfRange       = "VALIDATION-002"
+	ErrCodeRequiredFields   = "VALIDATION-003"
+	ErrCodeFutureTimestamp  = "VALIDATION-004"
+	ErrCodeStaleTimestamp   = "VALIDATION-005"
+
+	// Model registry and tracking (analytics/mlflow)
+	ErrCodeModelGetFailed        = "MLFLOW-001"
+	ErrCodeModelCreateFailed     = "MLFLOW-002"
+	ErrCodeRunGetFailed          = "MLFLOW-003"
+	ErrCodeStageTransitionFailed = "MLFLOW-004"
+	ErrCodeModelSearchFailed     = "MLFLOW-005"
+	ErrCodeBatchLogFailed        = "MLFLOW-006"
+	ErrCodeRunCreateFailed       = "MLFLOW-007"
+	ErrCodeRunUpdateFailed       = "MLFLOW-008"
+)
+
+// Errors is the error registry for the analytics packages
+var Errors = errors.NewErrorRegistry().WithComponent("Analytics")
+
+func init() {
+	// Data validation errors
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeSchemaValidation,
+		Severity:    errors.SeverityMedium,
+		Description: "Schema validation failed",
+		SODScore:    72, // 4 × 6 × 3
+		Severity_S:  4,
+		Occurrence:  6,
+		Detect_D:    3,
+		Mitigation:  "Check the failed checks against the schema; fix the producer or update the schema if the data is valid",
+		Example:     "Telemetry record missing a required field or with a string where a float is expected",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeOutOfRange,
+		Severity:    errors.SeverityMedium,
+		Description: "Value outside the allowed range",
+		SODScore:    60, // 4 × 5 × 3
+		Severity_S:  4,
+		Occurrence:  5,
+		Detect_D:    3,
+		Mitigation:  "Check the sensor for faults or calibration drift; widen the range only if the reading is genuine",
+		Example:     "Temperature sensor reports 250°C against a maximum of 100°C",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeRequiredFields,
+		Severity:    errors.SeverityMedium,
+		Description: "Required fields are missing or null",
+		SODScore:    40, // 4 × 5 × 2
+		Severity_S:  4,
+		Occurrence:  5,
+		Detect_D:    2,
+		Mitigation:  "Ensure the producer populates every required field before publishing",
+		Example:     "Event published with a null device_id",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeFutureTimestamp,
+		Severity:    errors.SeverityMedium,
+		Description: "Timestamp is in the future",
+		SODScore:    80, // 5 × 4 × 4
+		Severity_S:  5,
+		Occurrence:  4,
+		Detect_D:    4,
+		Mitigation:  "Sync the device clock (NTP); raise the allowed clock skew only for known drift",
+		Example:     "Device with an unsynchronized clock reports readings an hour ahead",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeStaleTimestamp,
+		Severity:    errors.SeverityLow,
+		Description: "Timestamp is older than the maximum allowed age",
+		SODScore:    60, // 3 × 5 × 4
+		Severity_S:  3,
+		Occurrence:  5,
+		Detect_D:    4,
+		Mitigation:  "Backfill old data through a batch path, or raise the maximum age for devices that buffer offline",
+		Example:     "Device reconnects after two days offline and uploads its buffered readings",
+	})
+
+	// MLflow errors
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeModelGetFailed,
+		Severity:    errors.SeverityHigh,
+		Description: "Failed to retrieve model from MLflow",
+		SODScore:    63, // 7 × 3 × 3
+		Severity_S:  7,
+		Occurrence:  3,
+		Detect_D:    3,
+		Mitigation:  "Check MLflow availability and that the model name and version exist in the registry",
+		Example:     "Inference service starts while MLflow is down or the model version was deleted",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeModelCreateFailed,
+		Severity:    errors.SeverityHigh,
+		Description: "Failed to create model version in MLflow",
+		SODScore:    63, // 7 × 3 × 3
+		Severity_S:  7,
+		Occurrence:  3,
+		Detect_D:    3,
+		Mitigation:  "Check MLflow availability, that the registered model exists and that the run ID and source are valid",
+		Example:     "Training pipeline registers a version for a model that was never created",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeRunGetFailed,
+		Severity:    errors.SeverityMedium,
+		Description: "Failed to retrieve run from MLflow",
+		SODScore:    45, // 5 × 3 × 3
+		Severity_S:  5,
+		Occurrence:  3,
+		Detect_D:    3,
+		Mitigation:  "Check MLflow availability and that the run ID exists",
+		Example:     "Run lookup with an ID from another tracking server",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeStageTransitionFailed,
+		Severity:    errors.SeverityHigh,
+		Description: "Failed to transition model version stage in MLflow",
+		SODScore:    84, // 7 × 3 × 4
+		Severity_S:  7,
+		Occurrence:  3,
+		Detect_D:    4,
+		Mitigation:  "Check MLflow availability and that the model version exists; rerun the promotion, which is safe to repeat",
+		Example:     "CD pipeline promotes a version to Production while MLflow is restarting",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeModelSearchFailed,
+		Severity:    errors.SeverityMedium,
+		Description: "Failed to search model versions in MLflow",
+		SODScore:    45, // 5 × 3 × 3
+		Severity_S:  5,
+		Occurrence:  3,
+		Detect_D:    3,
+		Mitigation:  "Check MLflow availability and the filter syntax (e.g. name='model' and current_stage='Production')",
+		Example:     "Search filter quotes a value with double quotes, which MLflow rejects",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeBatchLogFailed,
+		Severity:    errors.SeverityMedium,
+		Description: "Failed to log a batch of metrics, params or tags to MLflow",
+		SODScore:    48, // 4 × 4 × 3
+		Severity_S:  4,
+		Occurrence:  4,
+		Detect_D:    3,
+		Mitigation:  "Check MLflow availability and that the run is active; chunks sent before the failure are already logged",
+		Example:     "Evaluation job logs to a run that was already marked FINISHED",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeRunCreateFailed,
+		Severity:    errors.SeverityMedium,
+		Description: "Failed to create run in MLflow",
+		SODScore:    60, // 5 × 4 × 3
+		Severity_S:  5,
+		Occurrence:  4,
+		Detect_D:    3,
+		Mitigation:  "Check MLflow availability and that the experiment exists and isn't deleted",
+		Example:     "Evaluation service starts a run in an experiment ID from another environment",
+	})
+
+	Errors.Register(&errors.ErrorDefinition{
+		Code:        ErrCodeRunUpdateFailed,
+		Severity:    errors.SeverityMedium,
+		Description: "Failed to update run status in MLflow",
+		SODScore:    48, // 4 × 3 × 4
+		Severity_S:  4,
+		Occurrence:  3,
+		Detect_D:    4,
+		Mitigation:  "Retry the update; runs left RUNNING can be ended from the MLflow UI",
+		Example:     "MLflow restarts while the evaluation service marks its run FINISHED",
+	})
+}
@@ -0,0 +1,79 @@
+package analytics
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/errors"
+)
+
+// errorCodePattern matches codes like VALIDATION-001 or MLFLOW-002
+var errorCodePattern = regexp.MustCompile(`^[A-Z]+(-[A-Z]+)*-\d{3}$`)
+
+// TestErrors_EveryCodeInAnalyticsIsRegistered scans the analytics sources for
+// error code literals and constants, so a new ad-hoc code fails the build
+func TestErrors_EveryCodeInAnalyticsIsRegistered(t *testing.T) {
+	fset := token.NewFileSet()
+	found := map[string]string{}
+
+	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			lit, ok := n.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			if value, err := strconv.Unquote(lit.Value); err == nil && errorCodePattern.MatchString(value) {
+				found[value] = fset.Position(lit.Pos()).String()
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to scan analytics sources: %v", err)
+	}
+
+	if len(found) == 0 {
+		t.Fatal("no error codes found; is the scan pattern out of date?")
+	}
+	for code, pos := range found {
+		if _, ok := Errors.Get(code); !ok {
+			t.Errorf("%s: error code %s is not registered in analytics.Errors", pos, code)
+		}
+	}
+}
+
+func TestErrors_DefinitionsAreComplete(t *testing.T) {
+	for code, def := range Errors.GetAll() {
+		if def.Description == "" || def.Mitigation == "" || def.Example == "" {
+			t.Errorf("%s: description, mitigation and example are required", code)
+		}
+		if want := errors.CalculateSOD(def.Severity_S, def.Occurrence, def.Detect_D); def.SODScore != want {
+			t.Errorf("%s: SODScore = %d, want %d (S × O × D)", code, def.SODScore, want)
+		}
+	}
+}
+
+func TestErrors_CreatedErrorsCarryComponent(t *testing.T) {
+	err := Errors.CreateError(ErrCodeOutOfRange)
+	if err.Severity != errors.SeverityMedium {
+		t.Errorf("Severity = %v, want %v", err.Severity, errors.SeverityMedium)
+	}
+	if got := err.Component(); got != "Analytics" {
+		t.Errorf("Component() = %q, want Analytics", got)
+	}
+}
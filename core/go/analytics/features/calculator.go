@@ -18,18 +18,41 @@ type DataPoint struct {
 
 // Calculator provides feature engineering utilities
 type Calculator struct {
-	logger *logger.Logger
+	logger   *logger.Logger
+	holidays map[date]bool
 }
 
 // Config holds calculator configuration
 type Config struct {
 	Logger *logger.Logger
+
+	// Optional: dates flagged IsHoliday (and not business days) by
+	// ExtractTimeFeatures; only the calendar date of each time is used
+	Holidays []time.Time
+}
+
+// date is a calendar date, for holiday lookups
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	year, month, day := t.Date()
+	return date{year, month, day}
 }
 
 // NewCalculator creates a new feature calculator with dependency injection
 func NewCalculator(cfg Config) *Calculator {
+	holidays := make(map[date]bool, len(cfg.Holidays))
+	for _, h := range cfg.Holidays {
+		holidays[dateOf(h)] = true
+	}
+
 	return &Calculator{
-		logger: cfg.Logger,
+		logger:   cfg.Logger,
+		holidays: holidays,
 	}
 }
 
@@ -110,11 +133,7 @@ func (c *Calculator) ComputeRollingStats(ctx context.Context, points []DataPoint
 	stats.Sum = sum
 	stats.Mean = sum / float64(windowSize)
 
-	// Standard deviation: sqrt(E[X^2] - E[X]^2)
-	variance := (sumSq / float64(windowSize)) - (stats.Mean * stats.Mean)
-	if variance > 0 {
-		stats.StdDev = math.Sqrt(variance)
-	}
+	stats.StdDev = stdDev(sum, sumSq, windowSize)
 
 	c.logger.Debug("Computed rolling stats",
 		zap.Int("window_size", windowSize),
@@ -125,6 +144,107 @@ func (c *Calculator) ComputeRollingStats(ctx context.Context, points []DataPoint
 	return stats
 }
 
+// stdDev returns the population standard deviation of n values with the given
+// sum and sum of squares: sqrt(E[X^2] - E[X]^2)
+func stdDev(sum, sumSq float64, n int) float64 {
+	mean := sum / float64(n)
+	variance := (sumSq / float64(n)) - (mean * mean)
+	if variance > 0 {
+		return math.Sqrt(variance)
+	}
+	return 0
+}
+
+// ComputeRollingStdDev calculates the standard deviation of each window of
+// windowSize points, sliding one point at a time like ComputeRollingAverage.
+// Each result carries the timestamp of its window's last point.
+func (c *Calculator) ComputeRollingStdDev(ctx context.Context, points []DataPoint, windowSize int) []DataPoint {
+	if len(points) < windowSize || windowSize <= 0 {
+		c.logger.Debug("Insufficient data for rolling standard deviation",
+			zap.Int("points", len(points)),
+			zap.Int("window_size", windowSize),
+		)
+		return []DataPoint{}
+	}
+
+	result := make([]DataPoint, 0, len(points)-windowSize+1)
+
+	var sum, sumSq float64
+	for i, point := range points {
+		sum += point.Value
+		sumSq += point.Value * point.Value
+		if i >= windowSize {
+			// Slide: drop the point that left the window
+			old := points[i-windowSize].Value
+			sum -= old
+			sumSq -= old * old
+		}
+		if i < windowSize-1 {
+			continue
+		}
+
+		result = append(result, DataPoint{
+			Timestamp: point.Timestamp,
+			Value:     stdDev(sum, sumSq, windowSize),
+		})
+	}
+
+	c.logger.Debug("Computed rolling standard deviation",
+		zap.Int("input_points", len(points)),
+		zap.Int("output_points", len(result)),
+		zap.Int("window_size", windowSize),
+	)
+
+	return result
+}
+
+// ComputeZScore scores each point against the windowSize points before it:
+// (value - mean) / stddev of that trailing window. The window excludes the
+// point itself so a spike doesn't dampen its own score. A zero stddev (flat
+// window) scores 0. The first windowSize points have no full window and are
+// skipped.
+func (c *Calculator) ComputeZScore(ctx context.Context, points []DataPoint, windowSize int) []DataPoint {
+	if len(points) <= windowSize || windowSize <= 0 {
+		c.logger.Debug("Insufficient data for z-score",
+			zap.Int("points", len(points)),
+			zap.Int("window_size", windowSize),
+		)
+		return []DataPoint{}
+	}
+
+	result := make([]DataPoint, 0, len(points)-windowSize)
+
+	var sum, sumSq float64
+	for _, point := range points[:windowSize] {
+		sum += point.Value
+		sumSq += point.Value * point.Value
+	}
+
+	for i := windowSize; i < len(points); i++ {
+		var z float64
+		if sd := stdDev(sum, sumSq, windowSize); sd > 0 {
+			z = (points[i].Value - sum/float64(windowSize)) / sd
+		}
+		result = append(result, DataPoint{
+			Timestamp: points[i].Timestamp,
+			Value:     z,
+		})
+
+		// Slide the window onto this point
+		old := points[i-windowSize].Value
+		sum += points[i].Value - old
+		sumSq += points[i].Value*points[i].Value - old*old
+	}
+
+	c.logger.Debug("Computed z-scores",
+		zap.Int("input_points", len(points)),
+		zap.Int("output_points", len(result)),
+		zap.Int("window_size", windowSize),
+	)
+
+	return result
+}
+
 // ComputePercentile calculates the specified percentile (0-100)
 func (c *Calculator) ComputePercentile(ctx context.Context, points []DataPoint, percentile float64) float64 {
 	if len(points) == 0 {
@@ -170,24 +290,12 @@ func (c *Calculator) ComputePercentile(ctx context.Context, points []DataPoint,
 
 // ComputePercentiles calculates P50, P95, P99 in one pass
 func (c *Calculator) ComputePercentiles(ctx context.Context, points []DataPoint) map[string]float64 {
-	if len(points) == 0 {
-		return map[string]float64{
-			"p50": 0,
-			"p95": 0,
-			"p99": 0,
-		}
-	}
-
-	values := make([]float64, len(points))
-	for i, p := range points {
-		values[i] = p.Value
-	}
-	sort.Float64s(values)
+	batch := c.ComputePercentilesBatch(ctx, points, []float64{50, 95, 99})
 
 	percentiles := map[string]float64{
-		"p50": c.calculatePercentileFromSorted(values, 50),
-		"p95": c.calculatePercentileFromSorted(values, 95),
-		"p99": c.calculatePercentileFromSorted(values, 99),
+		"p50": batch[50],
+		"p95": batch[95],
+		"p99": batch[99],
 	}
 
 	c.logger.Debug("Computed percentiles",
@@ -199,6 +307,45 @@ func (c *Calculator) ComputePercentiles(ctx context.Context, points []DataPoint)
 	return percentiles
 }
 
+// ComputePercentilesBatch calculates any number of percentiles (0-100) with a
+// single sort, keyed by the requested percentile. Use it instead of repeated
+// ComputePercentile calls, which sort the values every time. Percentiles
+// outside 0-100 are left out of the result; with no points every requested
+// percentile is 0.
+func (c *Calculator) ComputePercentilesBatch(ctx context.Context, points []DataPoint, percentiles []float64) map[float64]float64 {
+	result := make(map[float64]float64, len(percentiles))
+
+	var values []float64
+	if len(points) > 0 {
+		values = make([]float64, len(points))
+		for i, p := range points {
+			values[i] = p.Value
+		}
+		sort.Float64s(values)
+	}
+
+	for _, percentile := range percentiles {
+		if percentile < 0 || percentile > 100 {
+			c.logger.Warn("Invalid percentile value",
+				zap.Float64("percentile", percentile),
+			)
+			continue
+		}
+		if len(values) == 0 {
+			result[percentile] = 0
+			continue
+		}
+		result[percentile] = c.calculatePercentileFromSorted(values, percentile)
+	}
+
+	c.logger.Debug("Computed percentile batch",
+		zap.Int("percentiles", len(result)),
+		zap.Int("data_points", len(points)),
+	)
+
+	return result
+}
+
 func (c *Calculator) calculatePercentileFromSorted(sorted []float64, percentile float64) float64 {
 	rank := (percentile / 100.0) * float64(len(sorted)-1)
 	lowerIndex := int(math.Floor(rank))
@@ -276,6 +423,13 @@ type TimeBasedFeatures struct {
 	Month         int
 	IsWeekend     bool
 	IsBusinessDay bool
+	IsHoliday     bool // Date is in Config.Holidays
+
+	// Cyclical encodings: 23:00 is next to 00:00 and Saturday next to Sunday
+	HourSin      float64 // sin(2π × hour / 24)
+	HourCos      float64 // cos(2π × hour / 24)
+	DayOfWeekSin float64 // sin(2π × day_of_week / 7), Sunday = 0
+	DayOfWeekCos float64 // cos(2π × day_of_week / 7)
 }
 
 // ExtractTimeFeatures extracts time-based features for ML models
@@ -290,13 +444,22 @@ func (c *Calculator) ExtractTimeFeatures(ctx context.Context, t time.Time) *Time
 	// Weekend detection (Saturday=6, Sunday=0)
 	features.IsWeekend = features.DayOfWeek == 0 || features.DayOfWeek == 6
 
-	// Business day (Monday-Friday, excluding weekends)
-	features.IsBusinessDay = features.DayOfWeek >= 1 && features.DayOfWeek <= 5
+	// Holidays are matched on t's calendar date
+	features.IsHoliday = c.holidays[dateOf(t)]
+
+	// Business day (Monday-Friday, excluding weekends and holidays)
+	features.IsBusinessDay = features.DayOfWeek >= 1 && features.DayOfWeek <= 5 && !features.IsHoliday
+
+	hourAngle := 2 * math.Pi * float64(features.HourOfDay) / 24
+	features.HourSin, features.HourCos = math.Sin(hourAngle), math.Cos(hourAngle)
+	dayAngle := 2 * math.Pi * float64(features.DayOfWeek) / 7
+	features.DayOfWeekSin, features.DayOfWeekCos = math.Sin(dayAngle), math.Cos(dayAngle)
 
 	c.logger.Debug("Extracted time features",
 		zap.Int("hour", features.HourOfDay),
 		zap.Int("day_of_week", features.DayOfWeek),
 		zap.Bool("is_weekend", features.IsWeekend),
+		zap.Bool("is_holiday", features.IsHoliday),
 	)
 
 	return features
@@ -334,6 +497,75 @@ func (c *Calculator) ComputeExponentialMovingAverage(ctx context.Context, points
 	return result
 }
 
+// DecomposeSeasonal splits a series into trend + seasonal + residual with a
+// classical additive decomposition. period is the number of points per cycle
+// (e.g. 24 for hourly data with a daily cycle); points must be evenly spaced
+// and at least two cycles long.
+//
+// The trend is a centered moving average over one period, so it (and the
+// residual) has no value for the first and last period/2 points. The seasonal
+// component covers every point and sums to zero over a cycle. Feed the
+// residual to DetectOutliers so regular peaks aren't flagged.
+func (c *Calculator) DecomposeSeasonal(ctx context.Context, points []DataPoint, period int) (trend, seasonal, residual []DataPoint) {
+	if period < 2 || len(points) < 2*period {
+		c.logger.Debug("Insufficient data for seasonal decomposition",
+			zap.Int("points", len(points)),
+			zap.Int("period", period),
+		)
+		return []DataPoint{}, []DataPoint{}, []DataPoint{}
+	}
+
+	// Trend: centered moving average. An even period has no middle point, so
+	// the window spans period+1 points with the two ends at half weight.
+	half := period / 2
+	trend = make([]DataPoint, 0, len(points)-2*half)
+	for i := half; i < len(points)-half; i++ {
+		var sum float64
+		for j := i - half; j <= i+half; j++ {
+			sum += points[j].Value
+		}
+		if period%2 == 0 {
+			sum -= (points[i-half].Value + points[i+half].Value) / 2
+		}
+		trend = append(trend, DataPoint{Timestamp: points[i].Timestamp, Value: sum / float64(period)})
+	}
+
+	// Seasonal: average detrended value at each position in the cycle,
+	// centered so the cycle sums to zero
+	phaseSums := make([]float64, period)
+	phaseCounts := make([]int, period)
+	for k, t := range trend {
+		i := k + half
+		phaseSums[i%period] += points[i].Value - t.Value
+		phaseCounts[i%period]++
+	}
+	indices := make([]float64, period)
+	var mean float64
+	for phase := range indices {
+		indices[phase] = phaseSums[phase] / float64(phaseCounts[phase])
+		mean += indices[phase] / float64(period)
+	}
+
+	seasonal = make([]DataPoint, len(points))
+	for i, point := range points {
+		seasonal[i] = DataPoint{Timestamp: point.Timestamp, Value: indices[i%period] - mean}
+	}
+
+	residual = make([]DataPoint, len(trend))
+	for k, t := range trend {
+		i := k + half
+		residual[k] = DataPoint{Timestamp: t.Timestamp, Value: points[i].Value - t.Value - seasonal[i].Value}
+	}
+
+	c.logger.Debug("Decomposed seasonal series",
+		zap.Int("input_points", len(points)),
+		zap.Int("trend_points", len(trend)),
+		zap.Int("period", period),
+	)
+
+	return trend, seasonal, residual
+}
+
 // DetectOutliers identifies outliers using IQR method
 func (c *Calculator) DetectOutliers(ctx context.Context, points []DataPoint, threshold float64) []DataPoint {
 	if len(points) < 4 {
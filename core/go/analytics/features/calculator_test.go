@@ -2,10 +2,12 @@ package features
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"go.uber.org/zap"
 )
 
 func TestComputeRollingAverage(t *testing.T) {
@@ -89,6 +91,104 @@ func TestComputeRollingStats(t *testing.T) {
 	}
 }
 
+func TestComputeRollingStdDev(t *testing.T) {
+	log, _ := logger.New(logger.Config{
+		ServiceName: "test",
+		Environment: "test",
+		LogLevel:    "debug",
+	})
+
+	calc := NewCalculator(Config{Logger: log})
+
+	now := time.Now()
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	points := make([]DataPoint, len(values))
+	for i, v := range values {
+		points[i] = DataPoint{Timestamp: now.Add(time.Duration(i) * time.Minute), Value: v}
+	}
+
+	result := calc.ComputeRollingStdDev(context.Background(), points, 2)
+
+	// Window of two: stddev is half the difference
+	want := []float64{1, 0, 0, 0.5, 0, 1, 1}
+	if len(result) != len(want) {
+		t.Fatalf("Expected %d results, got %d", len(want), len(result))
+	}
+	for i := range want {
+		if math.Abs(result[i].Value-want[i]) > 1e-9 {
+			t.Errorf("Window %d: expected stddev %v, got %v", i, want[i], result[i].Value)
+		}
+		if !result[i].Timestamp.Equal(points[i+1].Timestamp) {
+			t.Errorf("Window %d: expected timestamp of the window's last point", i)
+		}
+	}
+
+	// The whole series (a textbook example) has stddev 2, matching ComputeRollingStats
+	full := calc.ComputeRollingStdDev(context.Background(), points, len(points))
+	if len(full) != 1 || math.Abs(full[0].Value-2) > 1e-9 {
+		t.Errorf("Expected one stddev of 2 for the whole series, got %v", full)
+	}
+	if stats := calc.ComputeRollingStats(context.Background(), points, len(points)); math.Abs(stats.StdDev-full[0].Value) > 1e-9 {
+		t.Errorf("ComputeRollingStats stddev %v differs from %v", stats.StdDev, full[0].Value)
+	}
+
+	if got := calc.ComputeRollingStdDev(context.Background(), points, 10); len(got) != 0 {
+		t.Errorf("Expected no results for a window longer than the series, got %d", len(got))
+	}
+}
+
+func TestComputeZScore(t *testing.T) {
+	log, _ := logger.New(logger.Config{
+		ServiceName: "test",
+		Environment: "test",
+		LogLevel:    "debug",
+	})
+
+	calc := NewCalculator(Config{Logger: log})
+
+	now := time.Now()
+	series := func(values ...float64) []DataPoint {
+		points := make([]DataPoint, len(values))
+		for i, v := range values {
+			points[i] = DataPoint{Timestamp: now.Add(time.Duration(i) * time.Minute), Value: v}
+		}
+		return points
+	}
+
+	t.Run("spike scores high", func(t *testing.T) {
+		// Trailing window of 4: mean 10.5, stddev 0.5
+		result := calc.ComputeZScore(context.Background(), series(10, 11, 10, 11, 30, 10), 4)
+		if len(result) != 2 {
+			t.Fatalf("Expected 2 results, got %d", len(result))
+		}
+		if math.Abs(result[0].Value-39) > 1e-9 {
+			t.Errorf("Expected spike z-score 39, got %v", result[0].Value)
+		}
+		// The spike is now in the window, so an ordinary value scores below zero
+		if result[1].Value >= 0 {
+			t.Errorf("Expected a negative z-score after the spike, got %v", result[1].Value)
+		}
+	})
+
+	t.Run("flat series scores zero", func(t *testing.T) {
+		result := calc.ComputeZScore(context.Background(), series(5, 5, 5, 5, 5, 5), 3)
+		if len(result) != 3 {
+			t.Fatalf("Expected 3 results, got %d", len(result))
+		}
+		for i, p := range result {
+			if p.Value != 0 || math.IsNaN(p.Value) {
+				t.Errorf("Point %d: expected z-score 0, got %v", i, p.Value)
+			}
+		}
+	})
+
+	t.Run("too few points", func(t *testing.T) {
+		if result := calc.ComputeZScore(context.Background(), series(1, 2, 3), 3); len(result) != 0 {
+			t.Errorf("Expected no results, got %d", len(result))
+		}
+	})
+}
+
 func TestComputePercentile(t *testing.T) {
 	log, _ := logger.New(logger.Config{
 		ServiceName: "test",
@@ -156,6 +256,80 @@ func TestComputePercentiles(t *testing.T) {
 	}
 }
 
+func TestComputePercentilesBatch(t *testing.T) {
+	log, _ := logger.New(logger.Config{
+		ServiceName: "test",
+		Environment: "test",
+		LogLevel:    "debug",
+	})
+
+	calc := NewCalculator(Config{Logger: log})
+
+	// Shuffled 1..100
+	now := time.Now()
+	points := make([]DataPoint, 100)
+	for i := 0; i < 100; i++ {
+		points[i] = DataPoint{
+			Timestamp: now.Add(time.Duration(i) * time.Minute),
+			Value:     float64((i*37)%100 + 1),
+		}
+	}
+
+	requested := []float64{0, 25, 50, 90, 99.9, 100, 101}
+	result := calc.ComputePercentilesBatch(context.Background(), points, requested)
+
+	if _, ok := result[101]; ok {
+		t.Error("Expected invalid percentile 101 to be left out")
+	}
+	for _, p := range requested[:6] {
+		want := calc.ComputePercentile(context.Background(), points, p)
+		if got, ok := result[p]; !ok || math.Abs(got-want) > 1e-9 {
+			t.Errorf("P%v: expected %v (as ComputePercentile), got %v", p, want, got)
+		}
+	}
+
+	empty := calc.ComputePercentilesBatch(context.Background(), nil, []float64{50, 99})
+	if len(empty) != 2 || empty[50] != 0 || empty[99] != 0 {
+		t.Errorf("Expected zeros for no points, got %v", empty)
+	}
+}
+
+// benchmarkPoints returns n points with pseudo-random values
+func benchmarkPoints(n int) []DataPoint {
+	now := time.Now()
+	points := make([]DataPoint, n)
+	for i := range points {
+		points[i] = DataPoint{Timestamp: now.Add(time.Duration(i) * time.Second), Value: float64((i * 7919) % n)}
+	}
+	return points
+}
+
+var benchmarkPercentiles = []float64{50, 75, 90, 95, 99, 99.9}
+
+func BenchmarkComputePercentile_100k(b *testing.B) {
+	calc := NewCalculator(Config{Logger: &logger.Logger{Logger: zap.NewNop()}})
+	points := benchmarkPoints(100_000)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range benchmarkPercentiles {
+			calc.ComputePercentile(ctx, points, p)
+		}
+	}
+}
+
+func BenchmarkComputePercentilesBatch_100k(b *testing.B) {
+	calc := NewCalculator(Config{Logger: &logger.Logger{Logger: zap.NewNop()}})
+	points := benchmarkPoints(100_000)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		calc.ComputePercentilesBatch(ctx, points, benchmarkPercentiles)
+	}
+}
+
 func TestComputeRateOfChange(t *testing.T) {
 	log, _ := logger.New(logger.Config{
 		ServiceName: "test",
@@ -279,6 +453,61 @@ func TestExtractTimeFeatures(t *testing.T) {
 	}
 }
 
+func TestExtractTimeFeatures_CyclicalEncoding(t *testing.T) {
+	calc := NewCalculator(Config{Logger: &logger.Logger{Logger: zap.NewNop()}})
+	ctx := context.Background()
+
+	// 06:00 is a quarter of the way round the clock
+	six := calc.ExtractTimeFeatures(ctx, time.Date(2025, 12, 17, 6, 0, 0, 0, time.UTC))
+	if math.Abs(six.HourSin-1) > 1e-9 || math.Abs(six.HourCos) > 1e-9 {
+		t.Errorf("Expected 06:00 to encode as (1, 0), got (%v, %v)", six.HourSin, six.HourCos)
+	}
+
+	// 23:00 is as close to 00:00 as 01:00 is
+	distance := func(a, b *TimeBasedFeatures) float64 {
+		return math.Hypot(a.HourSin-b.HourSin, a.HourCos-b.HourCos)
+	}
+	midnight := calc.ExtractTimeFeatures(ctx, time.Date(2025, 12, 17, 0, 0, 0, 0, time.UTC))
+	late := calc.ExtractTimeFeatures(ctx, time.Date(2025, 12, 16, 23, 0, 0, 0, time.UTC))
+	early := calc.ExtractTimeFeatures(ctx, time.Date(2025, 12, 17, 1, 0, 0, 0, time.UTC))
+	if math.Abs(distance(late, midnight)-distance(early, midnight)) > 1e-9 {
+		t.Errorf("Expected 23:00 and 01:00 equidistant from midnight, got %v and %v", distance(late, midnight), distance(early, midnight))
+	}
+
+	// Sunday (0) encodes as (0, 1)
+	sunday := calc.ExtractTimeFeatures(ctx, time.Date(2025, 12, 21, 12, 0, 0, 0, time.UTC))
+	if math.Abs(sunday.DayOfWeekSin) > 1e-9 || math.Abs(sunday.DayOfWeekCos-1) > 1e-9 {
+		t.Errorf("Expected Sunday to encode as (0, 1), got (%v, %v)", sunday.DayOfWeekSin, sunday.DayOfWeekCos)
+	}
+}
+
+func TestExtractTimeFeatures_Holidays(t *testing.T) {
+	calc := NewCalculator(Config{
+		Logger:   &logger.Logger{Logger: zap.NewNop()},
+		Holidays: []time.Time{time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)},
+	})
+	ctx := context.Background()
+
+	// Any time on the date matches
+	christmasEvening := time.Date(2025, 12, 25, 18, 45, 0, 0, time.UTC)
+	christmas := calc.ExtractTimeFeatures(ctx, christmasEvening)
+	if !christmas.IsHoliday {
+		t.Error("Expected is_holiday to be true on a configured holiday")
+	}
+	if christmas.IsBusinessDay {
+		t.Error("Expected a weekday holiday not to be a business day")
+	}
+
+	boxingDay := calc.ExtractTimeFeatures(ctx, time.Date(2025, 12, 26, 9, 0, 0, 0, time.UTC))
+	if boxingDay.IsHoliday || !boxingDay.IsBusinessDay {
+		t.Error("Expected the day after to be an ordinary business day")
+	}
+
+	if NewCalculator(Config{Logger: &logger.Logger{Logger: zap.NewNop()}}).ExtractTimeFeatures(ctx, christmasEvening).IsHoliday {
+		t.Error("Expected no holidays without Config.Holidays")
+	}
+}
+
 func TestComputeExponentialMovingAverage(t *testing.T) {
 	log, _ := logger.New(logger.Config{
 		ServiceName: "test",
@@ -318,6 +547,70 @@ func TestComputeExponentialMovingAverage(t *testing.T) {
 	}
 }
 
+func TestDecomposeSeasonal(t *testing.T) {
+	log, _ := logger.New(logger.Config{
+		ServiceName: "test",
+		Environment: "test",
+		LogLevel:    "debug",
+	})
+
+	calc := NewCalculator(Config{Logger: log})
+
+	// Ten days of hourly readings: a rising trend plus a daily sine wave
+	const period = 24
+	now := time.Now()
+	points := make([]DataPoint, 10*period)
+	for i := range points {
+		points[i] = DataPoint{
+			Timestamp: now.Add(time.Duration(i) * time.Hour),
+			Value:     20 + 0.1*float64(i) + 5*math.Sin(2*math.Pi*float64(i)/period),
+		}
+	}
+
+	trend, seasonal, residual := calc.DecomposeSeasonal(context.Background(), points, period)
+
+	if len(seasonal) != len(points) {
+		t.Fatalf("Expected %d seasonal points, got %d", len(points), len(seasonal))
+	}
+	if len(trend) != len(points)-period || len(residual) != len(trend) {
+		t.Fatalf("Expected %d trend and residual points, got %d and %d", len(points)-period, len(trend), len(residual))
+	}
+
+	for k, p := range trend {
+		i := k + period/2
+		if !p.Timestamp.Equal(points[i].Timestamp) {
+			t.Fatalf("Trend point %d has the wrong timestamp", k)
+		}
+		if want := 20 + 0.1*float64(i); math.Abs(p.Value-want) > 1e-9 {
+			t.Errorf("Trend at %d: expected %v, got %v", i, want, p.Value)
+		}
+		if math.Abs(residual[k].Value) > 1e-9 {
+			t.Errorf("Residual at %d: expected 0, got %v", i, residual[k].Value)
+		}
+	}
+	for i, p := range seasonal {
+		if want := 5 * math.Sin(2*math.Pi*float64(i)/period); math.Abs(p.Value-want) > 1e-9 {
+			t.Errorf("Seasonal at %d: expected %v, got %v", i, want, p.Value)
+		}
+	}
+
+	// With some noise, the daily peaks aren't outliers once the season is
+	// removed; a spike is
+	for i := range points {
+		points[i].Value += math.Sin(float64(i) * 12.9898)
+	}
+	points[100].Value += 10
+	_, _, residual = calc.DecomposeSeasonal(context.Background(), points, period)
+	outliers := calc.DetectOutliers(context.Background(), residual, 3)
+	if len(outliers) != 1 || !outliers[0].Timestamp.Equal(points[100].Timestamp) {
+		t.Errorf("Expected only the spike at point 100 as an outlier, got %v", outliers)
+	}
+
+	if trend, seasonal, residual := calc.DecomposeSeasonal(context.Background(), points[:period], period); len(trend)+len(seasonal)+len(residual) != 0 {
+		t.Error("Expected empty results for less than two cycles")
+	}
+}
+
 func TestDetectOutliers(t *testing.T) {
 	log, _ := logger.New(logger.Config{
 		ServiceName: "test",
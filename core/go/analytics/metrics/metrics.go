@@ -68,6 +68,14 @@ var (
 		[]string{"dataset", "error_type"},
 	)
 
+	DataValidationWarnings = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "analytics_data_validation_warnings_total",
+			Help: "Total number of non-blocking data validation warnings",
+		},
+		[]string{"dataset", "field"},
+	)
+
 	DataNullPercentage = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "analytics_data_null_percentage",
@@ -296,6 +304,12 @@ func RecordDataQuality(dataset, dimension string, score float64) {
 	DataQualityScore.WithLabelValues(dataset, dimension).Set(score)
 }
 
+// RecordMLflowCircuitBreakerState records the MLflow circuit breaker state
+// (0=closed, 1=half_open, 2=open)
+func RecordMLflowCircuitBreakerState(state int) {
+	MLflowCircuitBreakerState.WithLabelValues().Set(float64(state))
+}
+
 // RecordFeatureCompute records feature computation metrics
 func RecordFeatureCompute(featureName string, durationSeconds float64) {
 	FeatureComputeDuration.WithLabelValues(featureName).Observe(durationSeconds)
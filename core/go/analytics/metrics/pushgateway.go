@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// JobPusher pushes job metrics to a Prometheus Pushgateway.
+// Short-lived jobs (e.g. scheduled aggregations) exit before Prometheus can
+// scrape them, so they push their metrics once on completion instead.
+// A JobPusher created without a URL is a no-op.
+type JobPusher struct {
+	pusher *push.Pusher
+}
+
+// PushGateway creates a pusher for the given job that carries the aggregation
+// metrics (duration, records processed, errors). Returns a no-op pusher when
+// url is empty so callers don't need to branch on configuration.
+func PushGateway(url, job string) *JobPusher {
+	if url == "" {
+		return &JobPusher{}
+	}
+
+	pusher := push.New(url, job).
+		Collector(AggregationDuration).
+		Collector(AggregationRecordsProcessed).
+		Collector(AggregationErrors)
+
+	return &JobPusher{pusher: pusher}
+}
+
+// Grouping adds a grouping label (e.g. instance, granularity) to the push
+func (p *JobPusher) Grouping(name, value string) *JobPusher {
+	if p.pusher != nil {
+		p.pusher = p.pusher.Grouping(name, value)
+	}
+	return p
+}
+
+// Collector adds an additional collector to push alongside the job metrics
+func (p *JobPusher) Collector(c prometheus.Collector) *JobPusher {
+	if p.pusher != nil {
+		p.pusher = p.pusher.Collector(c)
+	}
+	return p
+}
+
+// Enabled reports whether a Pushgateway URL was configured
+func (p *JobPusher) Enabled() bool {
+	return p != nil && p.pusher != nil
+}
+
+// Push replaces the job's metrics on the Pushgateway.
+// Call once when the job completes; no-op when unconfigured.
+func (p *JobPusher) Push(ctx context.Context) error {
+	if !p.Enabled() {
+		return nil
+	}
+	return p.pusher.PushContext(ctx)
+}
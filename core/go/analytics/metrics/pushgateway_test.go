@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+func TestPushGateway_PushesJobMetrics(t *testing.T) {
+	var (
+		gotMethod string
+		gotPath   string
+		families  = map[string]*dto.MetricFamily{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+
+		decoder := expfmt.NewDecoder(r.Body, expfmt.ResponseFormat(r.Header))
+		for {
+			mf := &dto.MetricFamily{}
+			if err := decoder.Decode(mf); err != nil {
+				if err != io.EOF {
+					t.Errorf("failed to decode pushed metrics: %v", err)
+				}
+				break
+			}
+			families[mf.GetName()] = mf
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	RecordAggregation("hourly_rollup", "hour", 42, 1.5)
+
+	err := PushGateway(server.URL, "aggregation_job").
+		Grouping("instance", "worker-1").
+		Push(context.Background())
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	if gotMethod != http.MethodPut {
+		t.Errorf("method = %v, want %v", gotMethod, http.MethodPut)
+	}
+	if want := "/metrics/job/aggregation_job/instance/worker-1"; gotPath != want {
+		t.Errorf("path = %v, want %v", gotPath, want)
+	}
+
+	for _, name := range []string{
+		"analytics_aggregation_duration_seconds",
+		"analytics_aggregation_records_processed_total",
+	} {
+		if _, ok := families[name]; !ok {
+			t.Errorf("pushed payload missing metric %s", name)
+		}
+	}
+
+	records := families["analytics_aggregation_records_processed_total"]
+	if records == nil {
+		t.FailNow()
+	}
+	var found bool
+	for _, m := range records.GetMetric() {
+		labels := map[string]string{}
+		for _, lp := range m.GetLabel() {
+			labels[lp.GetName()] = lp.GetValue()
+		}
+		if labels["aggregation_type"] == "hourly_rollup" && labels["granularity"] == "hour" {
+			found = true
+			if got := m.GetCounter().GetValue(); got < 42 {
+				t.Errorf("records processed = %v, want >= 42", got)
+			}
+		}
+	}
+	if !found {
+		t.Error("pushed payload missing hourly_rollup series")
+	}
+}
+
+func TestPushGateway_NoOpWhenUnconfigured(t *testing.T) {
+	pusher := PushGateway("", "aggregation_job").Grouping("instance", "worker-1")
+
+	if pusher.Enabled() {
+		t.Error("Enabled() = true, want false for empty URL")
+	}
+	if err := pusher.Push(context.Background()); err != nil {
+		t.Errorf("Push() error = %v, want nil", err)
+	}
+}
+
+func TestPushGateway_ReturnsGatewayError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := PushGateway(server.URL, "aggregation_job").Push(context.Background()); err == nil {
+		t.Error("Push() error = nil, want error for 500 response")
+	}
+}
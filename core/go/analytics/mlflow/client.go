@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
-	"github.com/your-github-org/ai-scaffolder/core/go/errors"
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics"
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics/metrics"
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
 	"github.com/your-github-org/ai-scaffolder/core/go/reliability"
 	"go.uber.org/zap"
@@ -28,6 +31,17 @@ type Config struct {
 	BaseURL string
 	Timeout time.Duration
 	Logger  *logger.Logger
+
+	// DefaultHeaders are set on every request, e.g. a tenant header
+	DefaultHeaders map[string]string
+
+	// BearerTokenProvider, if set, is called per request for the
+	// Authorization token so it can be refreshed
+	BearerTokenProvider func(ctx context.Context) (string, error)
+
+	// LogRequests logs each request and response at debug level, with the
+	// Authorization header redacted
+	LogRequests bool
 }
 
 // Model represents an MLflow model
@@ -37,6 +51,7 @@ type Model struct {
 	Description     string            `json:"description,omitempty"`
 	Tags            map[string]string `json:"tags,omitempty"`
 	Status          string            `json:"status,omitempty"`
+	CurrentStage    string            `json:"current_stage,omitempty"`
 	CreationTime    int64             `json:"creation_timestamp,omitempty"`
 	LastUpdatedTime int64             `json:"last_updated_timestamp,omitempty"`
 	RunID           string            `json:"run_id,omitempty"`
@@ -73,18 +88,59 @@ type Run struct {
 	Tags         map[string]string  `json:"tags,omitempty"`
 }
 
+// MetricEntry is a metric value logged with LogBatch
+type MetricEntry struct {
+	Key       string  `json:"key"`
+	Value     float64 `json:"value"`
+	Timestamp int64   `json:"timestamp"`
+	Step      int64   `json:"step,omitempty"`
+}
+
+// ParamEntry is a run parameter logged with LogBatch
+type ParamEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// TagEntry is a run tag logged with LogBatch
+type TagEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// MLflow log-batch limits per request
+const (
+	maxBatchItems  = 1000
+	maxBatchParams = 100
+	maxBatchTags   = 100
+)
+
 // NewClient creates a new MLflow client with circuit breaker
 func NewClient(cfg Config) *Client {
 	if cfg.Timeout == 0 {
 		cfg.Timeout = 30 * time.Second
 	}
 
+	// Mirror breaker transitions into analytics_mlflow_circuit_breaker_state
+	circuitBreaker := reliability.NewCircuitBreaker("mlflow", 5, 60*time.Second)
+	metrics.RecordMLflowCircuitBreakerState(int(circuitBreaker.GetState()))
+	circuitBreaker.OnStateChange(func(_, to reliability.CircuitState) {
+		metrics.RecordMLflowCircuitBreakerState(int(to))
+	})
+
 	return &Client{
 		baseURL: cfg.BaseURL,
 		httpClient: &http.Client{
 			Timeout: cfg.Timeout,
+			Transport: &headerTransport{
+				base:          http.DefaultTransport,
+				headers:       cfg.DefaultHeaders,
+				tokenProvider: cfg.BearerTokenProvider,
+				logRequests:   cfg.LogRequests,
+				logger:        cfg.Logger,
+			},
 		},
-		circuitBreaker: reliability.NewCircuitBreaker("mlflow", 5, 60*time.Second),
+		circuitBreaker: circuitBreaker,
 		logger:         cfg.Logger,
 	}
 }
@@ -142,12 +198,7 @@ func (c *Client) GetModel(ctx context.Context, name, version string) (*Model, er
 	})
 
 	if err != nil {
-		return nil, &errors.ServiceError{
-			Code:       "MLFLOW-001",
-			Message:    "Failed to retrieve model from MLflow",
-			Severity:   errors.SeverityHigh,
-			Underlying: err,
-		}
+		return nil, analytics.Errors.WrapError(err, analytics.ErrCodeModelGetFailed)
 	}
 
 	return model, nil
@@ -262,17 +313,158 @@ func (c *Client) CreateModelVersion(ctx context.Context, name, runID, source str
 	})
 
 	if err != nil {
-		return nil, &errors.ServiceError{
-			Code:       "MLFLOW-002",
-			Message:    "Failed to create model version in MLflow",
-			Severity:   errors.SeverityHigh,
-			Underlying: err,
-		}
+		return nil, analytics.Errors.WrapError(err, analytics.ErrCodeModelCreateFailed)
 	}
 
 	return modelVersion, nil
 }
 
+// Model version stages, for TransitionModelVersionStage
+const (
+	StageNone       = "None"
+	StageStaging    = "Staging"
+	StageProduction = "Production"
+	StageArchived   = "Archived"
+)
+
+// TransitionModelVersionStage moves a model version to stage. With
+// archiveExisting, versions already in stage are archived, e.g. the previous
+// production model when promoting a new one.
+func (c *Client) TransitionModelVersionStage(ctx context.Context, name, version, stage string, archiveExisting bool) error {
+	err := c.circuitBreaker.Execute(func() error {
+		url := fmt.Sprintf("%s/api/2.0/mlflow/model-versions/transition-stage", c.baseURL)
+
+		payload := map[string]interface{}{
+			"name":                      name,
+			"version":                   version,
+			"stage":                     stage,
+			"archive_existing_versions": archiveExisting,
+		}
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			c.logger.Error("Failed to transition model version stage in MLflow",
+				zap.String("model_name", name),
+				zap.String("version", version),
+				zap.String("stage", stage),
+				zap.Error(err),
+			)
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("mlflow api error: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+		}
+
+		c.logger.Info("Transitioned model version stage in MLflow",
+			zap.String("model_name", name),
+			zap.String("version", version),
+			zap.String("stage", stage),
+			zap.Bool("archive_existing", archiveExisting),
+		)
+
+		return nil
+	})
+
+	if err != nil {
+		return analytics.Errors.WrapError(err, analytics.ErrCodeStageTransitionFailed)
+	}
+
+	return nil
+}
+
+// searchPageSize is the most model versions requested per search page
+const searchPageSize = 1000
+
+// SearchModelVersions returns the model versions matching filter, e.g.
+// "name='anomaly-detector' and current_stage='Production'", sorted by orderBy
+// (e.g. "version_number DESC"). Pages are fetched until maxResults versions
+// are found or there are no more; maxResults <= 0 means all of them.
+func (c *Client) SearchModelVersions(ctx context.Context, filter string, orderBy []string, maxResults int) ([]*Model, error) {
+	var models []*Model
+	pageToken := ""
+
+	for {
+		pageSize := searchPageSize
+		if maxResults > 0 && maxResults-len(models) < pageSize {
+			pageSize = maxResults - len(models)
+		}
+
+		var page struct {
+			ModelVersions []*Model `json:"model_versions"`
+			NextPageToken string   `json:"next_page_token"`
+		}
+
+		err := c.circuitBreaker.Execute(func() error {
+			query := url.Values{}
+			query.Set("filter", filter)
+			query.Set("max_results", strconv.Itoa(pageSize))
+			for _, order := range orderBy {
+				query.Add("order_by", order)
+			}
+			if pageToken != "" {
+				query.Set("page_token", pageToken)
+			}
+			endpoint := fmt.Sprintf("%s/api/2.0/mlflow/model-versions/search?%s", c.baseURL, query.Encode())
+
+			req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+			if err != nil {
+				return err
+			}
+
+			resp, err := c.httpClient.Do(req)
+			if err != nil {
+				c.logger.Error("Failed to search model versions in MLflow",
+					zap.String("filter", filter),
+					zap.Error(err),
+				)
+				return err
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				bodyBytes, _ := io.ReadAll(resp.Body)
+				return fmt.Errorf("mlflow api error: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+			}
+
+			return json.NewDecoder(resp.Body).Decode(&page)
+		})
+		if err != nil {
+			return nil, analytics.Errors.WrapError(err, analytics.ErrCodeModelSearchFailed)
+		}
+
+		models = append(models, page.ModelVersions...)
+		pageToken = page.NextPageToken
+		if pageToken == "" || len(page.ModelVersions) == 0 || (maxResults > 0 && len(models) >= maxResults) {
+			break
+		}
+	}
+
+	if maxResults > 0 && len(models) > maxResults {
+		models = models[:maxResults]
+	}
+
+	c.logger.Debug("Searched model versions in MLflow",
+		zap.String("filter", filter),
+		zap.Int("results", len(models)),
+	)
+
+	return models, nil
+}
+
 // LogMetric logs a metric to MLflow
 func (c *Client) LogMetric(ctx context.Context, runID, key string, value float64, timestamp int64) error {
 	return c.circuitBreaker.Execute(func() error {
@@ -322,6 +514,217 @@ func (c *Client) LogMetric(ctx context.Context, runID, key string, value float64
 	})
 }
 
+// LogBatch logs metrics, params and tags to a run, splitting them into as
+// few log-batch requests as MLflow's per-request limits allow
+func (c *Client) LogBatch(ctx context.Context, runID string, metrics []MetricEntry, params []ParamEntry, tags []TagEntry) error {
+	chunks := 0
+	for len(metrics) > 0 || len(params) > 0 || len(tags) > 0 {
+		nParams := min(len(params), maxBatchParams)
+		nTags := min(len(tags), maxBatchTags)
+		nMetrics := min(len(metrics), maxBatchItems-nParams-nTags)
+
+		payload := map[string]interface{}{
+			"run_id":  runID,
+			"metrics": metrics[:nMetrics],
+			"params":  params[:nParams],
+			"tags":    tags[:nTags],
+		}
+		if err := c.logBatchChunk(ctx, runID, payload); err != nil {
+			c.logger.Error("Failed to log batch to MLflow",
+				zap.String("run_id", runID),
+				zap.Int("chunk", chunks+1),
+				zap.Error(err),
+			)
+			return analytics.Errors.WrapError(err, analytics.ErrCodeBatchLogFailed)
+		}
+
+		metrics, params, tags = metrics[nMetrics:], params[nParams:], tags[nTags:]
+		chunks++
+	}
+
+	c.logger.Debug("Logged batch to MLflow",
+		zap.String("run_id", runID),
+		zap.Int("chunks", chunks),
+	)
+
+	return nil
+}
+
+// logBatchChunk sends a single log-batch request
+func (c *Client) logBatchChunk(ctx context.Context, runID string, payload map[string]interface{}) error {
+	return c.circuitBreaker.Execute(func() error {
+		url := fmt.Sprintf("%s/api/2.0/mlflow/runs/log-batch", c.baseURL)
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("mlflow api error: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+		}
+
+		return nil
+	})
+}
+
+// Run statuses, for UpdateRun
+const (
+	RunStatusRunning   = "RUNNING"
+	RunStatusScheduled = "SCHEDULED"
+	RunStatusFinished  = "FINISHED"
+	RunStatusFailed    = "FAILED"
+	RunStatusKilled    = "KILLED"
+)
+
+// CreateRun starts a run in an experiment, with its start time set to now
+func (c *Client) CreateRun(ctx context.Context, experimentID string, tags map[string]string) (*Run, error) {
+	var run *Run
+
+	err := c.circuitBreaker.Execute(func() error {
+		url := fmt.Sprintf("%s/api/2.0/mlflow/runs/create", c.baseURL)
+
+		runTags := make([]TagEntry, 0, len(tags))
+		for key, value := range tags {
+			runTags = append(runTags, TagEntry{Key: key, Value: value})
+		}
+
+		payload := map[string]interface{}{
+			"experiment_id": experimentID,
+			"start_time":    time.Now().UnixMilli(),
+			"tags":          runTags,
+		}
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			c.logger.Error("Failed to create run in MLflow",
+				zap.String("experiment_id", experimentID),
+				zap.Error(err),
+			)
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("mlflow api error: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+		}
+
+		var response struct {
+			Run struct {
+				Info *Run `json:"info"`
+			} `json:"run"`
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			return err
+		}
+		if response.Run.Info == nil {
+			return fmt.Errorf("mlflow api error: response has no run info")
+		}
+
+		run = response.Run.Info
+		run.Tags = tags
+
+		c.logger.Info("Created run in MLflow",
+			zap.String("experiment_id", experimentID),
+			zap.String("run_id", run.ID),
+		)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, analytics.Errors.WrapError(err, analytics.ErrCodeRunCreateFailed)
+	}
+
+	return run, nil
+}
+
+// UpdateRun sets a run's status, e.g. RunStatusFinished when it's done.
+// A zero endTime is set to now for FINISHED, FAILED and KILLED runs.
+func (c *Client) UpdateRun(ctx context.Context, runID, status string, endTime int64) error {
+	if endTime == 0 && (status == RunStatusFinished || status == RunStatusFailed || status == RunStatusKilled) {
+		endTime = time.Now().UnixMilli()
+	}
+
+	err := c.circuitBreaker.Execute(func() error {
+		url := fmt.Sprintf("%s/api/2.0/mlflow/runs/update", c.baseURL)
+
+		payload := map[string]interface{}{
+			"run_id": runID,
+			"status": status,
+		}
+		if endTime != 0 {
+			payload["end_time"] = endTime
+		}
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			c.logger.Error("Failed to update run in MLflow",
+				zap.String("run_id", runID),
+				zap.String("status", status),
+				zap.Error(err),
+			)
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("mlflow api error: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+		}
+
+		c.logger.Info("Updated run in MLflow",
+			zap.String("run_id", runID),
+			zap.String("status", status),
+		)
+
+		return nil
+	})
+
+	if err != nil {
+		return analytics.Errors.WrapError(err, analytics.ErrCodeRunUpdateFailed)
+	}
+
+	return nil
+}
+
 // GetRun retrieves a run by ID
 func (c *Client) GetRun(ctx context.Context, runID string) (*Run, error) {
 	var run *Run
@@ -368,12 +771,7 @@ func (c *Client) GetRun(ctx context.Context, runID string) (*Run, error) {
 	})
 
 	if err != nil {
-		return nil, &errors.ServiceError{
-			Code:       "MLFLOW-003",
-			Message:    "Failed to retrieve run from MLflow",
-			Severity:   errors.SeverityMedium,
-			Underlying: err,
-		}
+		return nil, analytics.Errors.WrapError(err, analytics.ErrCodeRunGetFailed)
 	}
 
 	return run, nil
@@ -0,0 +1,409 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics"
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics/metrics"
+	"github.com/your-github-org/ai-scaffolder/core/go/errors"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newTestClient returns a client for an MLflow server that handles requests with handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(Config{BaseURL: server.URL, Logger: &logger.Logger{Logger: zap.NewNop()}})
+}
+
+func TestTransitionModelVersionStage(t *testing.T) {
+	var got map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/2.0/mlflow/model-versions/transition-stage" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		w.Write([]byte(`{"model_version": {"name": "anomaly", "version": "7", "current_stage": "Production"}}`))
+	})
+
+	if err := client.TransitionModelVersionStage(context.Background(), "anomaly", "7", StageProduction, true); err != nil {
+		t.Fatalf("TransitionModelVersionStage() error = %v", err)
+	}
+
+	want := map[string]interface{}{"name": "anomaly", "version": "7", "stage": "Production", "archive_existing_versions": true}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("request %s = %v, want %v", key, got[key], value)
+		}
+	}
+}
+
+func TestTransitionModelVersionStage_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error_code": "RESOURCE_DOES_NOT_EXIST"}`))
+	})
+
+	err := client.TransitionModelVersionStage(context.Background(), "anomaly", "99", StageProduction, false)
+
+	serviceErr, ok := err.(*errors.ServiceError)
+	if !ok {
+		t.Fatalf("error = %T %v, want *errors.ServiceError", err, err)
+	}
+	if serviceErr.Code != analytics.ErrCodeStageTransitionFailed {
+		t.Errorf("Code = %s, want %s", serviceErr.Code, analytics.ErrCodeStageTransitionFailed)
+	}
+}
+
+func TestSearchModelVersions_Pagination(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"model_versions": [{"name": "anomaly", "version": "3"}, {"name": "anomaly", "version": "2"}], "next_page_token": "p2"}`,
+		"p2": `{"model_versions": [{"name": "anomaly", "version": "1", "current_stage": "Archived"}]}`,
+	}
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		if r.Method != http.MethodGet || r.URL.Path != "/api/2.0/mlflow/model-versions/search" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if got := query.Get("filter"); got != "name='anomaly'" {
+			t.Errorf("filter = %q", got)
+		}
+		if got := query["order_by"]; len(got) != 2 || got[0] != "version_number DESC" || got[1] != "creation_timestamp" {
+			t.Errorf("order_by = %v", got)
+		}
+		w.Write([]byte(pages[query.Get("page_token")]))
+	})
+
+	models, err := client.SearchModelVersions(context.Background(), "name='anomaly'",
+		[]string{"version_number DESC", "creation_timestamp"}, 0)
+	if err != nil {
+		t.Fatalf("SearchModelVersions() error = %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+	if len(models) != 3 {
+		t.Fatalf("len(models) = %d, want 3", len(models))
+	}
+	if models[0].Version != "3" || models[2].Version != "1" || models[2].CurrentStage != "Archived" {
+		t.Errorf("models = %+v %+v %+v", models[0], models[1], models[2])
+	}
+}
+
+func TestSearchModelVersions_MaxResults(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.URL.Query().Get("max_results"); got != "2" {
+			t.Errorf("max_results = %q, want 2", got)
+		}
+		w.Write([]byte(`{"model_versions": [{"version": "5"}, {"version": "4"}], "next_page_token": "more"}`))
+	})
+
+	models, err := client.SearchModelVersions(context.Background(), "name='anomaly'", nil, 2)
+	if err != nil {
+		t.Fatalf("SearchModelVersions() error = %v", err)
+	}
+	if requests != 1 || len(models) != 2 {
+		t.Errorf("requests = %d, len(models) = %d, want 1 and 2", requests, len(models))
+	}
+}
+
+func TestSearchModelVersions_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error_code": "INVALID_PARAMETER_VALUE"}`))
+	})
+
+	_, err := client.SearchModelVersions(context.Background(), `name="anomaly"`, nil, 10)
+
+	serviceErr, ok := err.(*errors.ServiceError)
+	if !ok {
+		t.Fatalf("error = %T %v, want *errors.ServiceError", err, err)
+	}
+	if serviceErr.Code != analytics.ErrCodeModelSearchFailed {
+		t.Errorf("Code = %s, want %s", serviceErr.Code, analytics.ErrCodeModelSearchFailed)
+	}
+}
+
+func TestLogBatch_Chunking(t *testing.T) {
+	type logBatchRequest struct {
+		RunID   string        `json:"run_id"`
+		Metrics []MetricEntry `json:"metrics"`
+		Params  []ParamEntry  `json:"params"`
+		Tags    []TagEntry    `json:"tags"`
+	}
+	var batches []logBatchRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/2.0/mlflow/runs/log-batch" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		var batch logBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		batches = append(batches, batch)
+		w.Write([]byte(`{}`))
+	})
+
+	metrics := make([]MetricEntry, 2500)
+	for i := range metrics {
+		metrics[i] = MetricEntry{Key: "loss", Value: float64(i), Timestamp: 1700000000000, Step: int64(i)}
+	}
+	params := make([]ParamEntry, 150)
+	for i := range params {
+		params[i] = ParamEntry{Key: fmt.Sprintf("p%d", i), Value: "v"}
+	}
+	tags := []TagEntry{{Key: "job", Value: "evaluation"}}
+
+	if err := client.LogBatch(context.Background(), "run-1", metrics, params, tags); err != nil {
+		t.Fatalf("LogBatch() error = %v", err)
+	}
+
+	var gotMetrics, gotParams, gotTags int
+	for i, batch := range batches {
+		items := len(batch.Metrics) + len(batch.Params) + len(batch.Tags)
+		if items > maxBatchItems || len(batch.Params) > maxBatchParams || len(batch.Tags) > maxBatchTags {
+			t.Errorf("batch %d has %d metrics, %d params, %d tags", i, len(batch.Metrics), len(batch.Params), len(batch.Tags))
+		}
+		if batch.RunID != "run-1" {
+			t.Errorf("batch %d run_id = %q", i, batch.RunID)
+		}
+		gotMetrics += len(batch.Metrics)
+		gotParams += len(batch.Params)
+		gotTags += len(batch.Tags)
+	}
+	if len(batches) != 3 {
+		t.Errorf("requests = %d, want 3", len(batches))
+	}
+	if gotMetrics != 2500 || gotParams != 150 || gotTags != 1 {
+		t.Errorf("logged %d metrics, %d params, %d tags", gotMetrics, gotParams, gotTags)
+	}
+}
+
+func TestLogBatch_Error(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error_code": "INVALID_STATE"}`))
+	})
+
+	metrics := make([]MetricEntry, 1500)
+	err := client.LogBatch(context.Background(), "run-1", metrics, nil, nil)
+
+	serviceErr, ok := err.(*errors.ServiceError)
+	if !ok {
+		t.Fatalf("error = %T %v, want *errors.ServiceError", err, err)
+	}
+	if serviceErr.Code != analytics.ErrCodeBatchLogFailed {
+		t.Errorf("Code = %s, want %s", serviceErr.Code, analytics.ErrCodeBatchLogFailed)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 (stop after the failed chunk)", requests)
+	}
+}
+
+func TestCircuitBreakerStateGauge(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	gauge := metrics.MLflowCircuitBreakerState.WithLabelValues()
+
+	if got := testutil.ToFloat64(gauge); got != 0 {
+		t.Fatalf("gauge = %v before failures, want 0 (closed)", got)
+	}
+
+	for i := 0; i < 5; i++ {
+		client.GetRun(context.Background(), "run-1")
+	}
+
+	if got := testutil.ToFloat64(gauge); got != 2 {
+		t.Errorf("gauge = %v after 5 failures, want 2 (open)", got)
+	}
+	client.HealthCheck(context.Background())
+	if requests != 5 {
+		t.Errorf("requests = %d, want 5 (open breaker rejects without calling MLflow)", requests)
+	}
+}
+
+func TestCreateRun(t *testing.T) {
+	var got struct {
+		ExperimentID string     `json:"experiment_id"`
+		StartTime    int64      `json:"start_time"`
+		Tags         []TagEntry `json:"tags"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/2.0/mlflow/runs/create" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		w.Write([]byte(`{"run": {"info": {"run_id": "run-1", "experiment_id": "42", "status": "RUNNING", "start_time": 1700000000000}}}`))
+	})
+
+	before := time.Now().UnixMilli()
+	run, err := client.CreateRun(context.Background(), "42", map[string]string{"job": "evaluation"})
+	if err != nil {
+		t.Fatalf("CreateRun() error = %v", err)
+	}
+
+	if got.ExperimentID != "42" || len(got.Tags) != 1 || got.Tags[0] != (TagEntry{Key: "job", Value: "evaluation"}) {
+		t.Errorf("request = %+v", got)
+	}
+	if got.StartTime < before || got.StartTime > time.Now().UnixMilli() {
+		t.Errorf("start_time = %d, want now", got.StartTime)
+	}
+	if run.ID != "run-1" || run.Status != RunStatusRunning || run.Tags["job"] != "evaluation" {
+		t.Errorf("run = %+v", run)
+	}
+}
+
+func TestUpdateRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		endTime     int64
+		wantEndTime bool
+	}{
+		{"finished defaults end time", RunStatusFinished, 0, true},
+		{"explicit end time", RunStatusFailed, 1700000000000, true},
+		{"running has no end time", RunStatusRunning, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]interface{}
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/2.0/mlflow/runs/update" {
+					t.Errorf("request = %s %s", r.Method, r.URL.Path)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("invalid request body: %v", err)
+				}
+				w.Write([]byte(`{"run_info": {}}`))
+			})
+
+			if err := client.UpdateRun(context.Background(), "run-1", tt.status, tt.endTime); err != nil {
+				t.Fatalf("UpdateRun() error = %v", err)
+			}
+
+			if got["run_id"] != "run-1" || got["status"] != tt.status {
+				t.Errorf("request = %v", got)
+			}
+			endTime, ok := got["end_time"].(float64)
+			if ok != tt.wantEndTime {
+				t.Fatalf("end_time = %v, want present = %v", got["end_time"], tt.wantEndTime)
+			}
+			if tt.endTime != 0 && int64(endTime) != tt.endTime {
+				t.Errorf("end_time = %v, want %d", endTime, tt.endTime)
+			}
+		})
+	}
+}
+
+func TestUpdateRun_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	err := client.UpdateRun(context.Background(), "missing", RunStatusFinished, 0)
+
+	serviceErr, ok := err.(*errors.ServiceError)
+	if !ok {
+		t.Fatalf("error = %T %v, want *errors.ServiceError", err, err)
+	}
+	if serviceErr.Code != analytics.ErrCodeRunUpdateFailed {
+		t.Errorf("Code = %s, want %s", serviceErr.Code, analytics.ErrCodeRunUpdateFailed)
+	}
+}
+
+func TestClientHeaders(t *testing.T) {
+	var authHeaders, tenantHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		tenantHeaders = append(tenantHeaders, r.Header.Get("X-Tenant-ID"))
+	}))
+	defer server.Close()
+
+	core, logs := observer.New(zap.DebugLevel)
+	tokens := 0
+	client := NewClient(Config{
+		BaseURL:        server.URL,
+		Logger:         &logger.Logger{Logger: zap.New(core)},
+		DefaultHeaders: map[string]string{"X-Tenant-ID": "acme"},
+		BearerTokenProvider: func(ctx context.Context) (string, error) {
+			tokens++
+			return fmt.Sprintf("token-%d", tokens), nil
+		},
+		LogRequests: true,
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := client.HealthCheck(context.Background()); err != nil {
+			t.Fatalf("HealthCheck() error = %v", err)
+		}
+	}
+
+	if authHeaders[0] != "Bearer token-1" || authHeaders[1] != "Bearer token-2" {
+		t.Errorf("Authorization = %v, want a fresh token per request", authHeaders)
+	}
+	if tenantHeaders[0] != "acme" || tenantHeaders[1] != "acme" {
+		t.Errorf("X-Tenant-ID = %v, want acme", tenantHeaders)
+	}
+
+	requests := logs.FilterMessage("MLflow request").All()
+	if len(requests) != 2 {
+		t.Fatalf("logged %d requests, want 2", len(requests))
+	}
+	logged := fmt.Sprint(requests[0].ContextMap()["headers"])
+	if strings.Contains(logged, "token-1") || !strings.Contains(logged, "[REDACTED]") {
+		t.Errorf("logged headers = %s, want Authorization redacted", logged)
+	}
+	if logs.FilterMessage("MLflow response").Len() != 2 {
+		t.Errorf("logged %d responses, want 2", logs.FilterMessage("MLflow response").Len())
+	}
+}
+
+func TestClientHeaders_TokenError(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{
+		BaseURL: server.URL,
+		Logger:  &logger.Logger{Logger: zap.NewNop()},
+		BearerTokenProvider: func(ctx context.Context) (string, error) {
+			return "", fmt.Errorf("token expired")
+		},
+	})
+
+	err := client.HealthCheck(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "token expired") {
+		t.Errorf("HealthCheck() error = %v, want token error", err)
+	}
+	if requests != 0 {
+		t.Errorf("requests = %d, want 0 without a token", requests)
+	}
+}
@@ -0,0 +1,77 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"go.uber.org/zap"
+)
+
+// headerTransport adds the configured headers and bearer token to each
+// request, optionally logging it
+type headerTransport struct {
+	base          http.RoundTripper
+	headers       map[string]string
+	tokenProvider func(ctx context.Context) (string, error)
+	logRequests   bool
+	logger        *logger.Logger
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the caller's request
+	req = req.Clone(req.Context())
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+
+	if t.tokenProvider != nil {
+		token, err := t.tokenProvider(req.Context())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get mlflow bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if !t.logRequests {
+		return t.base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	t.logger.Debug("MLflow request",
+		zap.String("method", req.Method),
+		zap.String("url", req.URL.String()),
+		zap.Any("headers", redactHeaders(req.Header)),
+	)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.logger.Debug("MLflow request failed",
+			zap.String("method", req.Method),
+			zap.String("url", req.URL.String()),
+			zap.Duration("duration", time.Since(start)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	t.logger.Debug("MLflow response",
+		zap.String("method", req.Method),
+		zap.String("url", req.URL.String()),
+		zap.Int("status", resp.StatusCode),
+		zap.Duration("duration", time.Since(start)),
+	)
+	return resp, nil
+}
+
+// redactHeaders returns a copy of headers safe to log
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	if redacted.Get("Authorization") != "" {
+		redacted.Set("Authorization", "[REDACTED]")
+	}
+	return redacted
+}
@@ -3,16 +3,37 @@ package timeseries
 import (
 	"context"
 	"math"
+	"sort"
 	"time"
 
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics/metrics"
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
 	"go.uber.org/zap"
 )
 
-// DataPoint represents a time-series data point
+// DataPoint represents a time-series data point. Timestamp is the event time
+// (when the value was measured); IngestTime, when known, is when it arrived.
 type DataPoint struct {
-	Timestamp time.Time
-	Value     float64
+	Timestamp  time.Time
+	IngestTime time.Time
+	Value      float64
+}
+
+// TimeDomain selects which of a point's times windowing uses
+type TimeDomain string
+
+const (
+	EventTime  TimeDomain = "event"  // DataPoint.Timestamp (default)
+	IngestTime TimeDomain = "ingest" // DataPoint.IngestTime, or Timestamp when unknown
+)
+
+// Time returns the point's time in the given domain. A point without an
+// ingest time is placed by its event time.
+func (d DataPoint) Time(domain TimeDomain) time.Time {
+	if domain == IngestTime && !d.IngestTime.IsZero() {
+		return d.IngestTime
+	}
+	return d.Timestamp
 }
 
 // Window represents a time window
@@ -33,31 +54,80 @@ const (
 
 // Processor provides time-series processing capabilities
 type Processor struct {
-	logger *logger.Logger
+	logger        *logger.Logger
+	timeDomain    TimeDomain
+	enableMetrics bool
 }
 
 // Config holds processor configuration
 type Config struct {
-	Logger *logger.Logger
+	Logger     *logger.Logger
+	TimeDomain TimeDomain // Time windows are built on; defaults to EventTime
+
+	// EnableMetrics records window counts and interpolated points in the
+	// analytics Prometheus metrics
+	EnableMetrics bool
 }
 
 // NewProcessor creates a new time-series processor with dependency injection
 func NewProcessor(cfg Config) *Processor {
+	timeDomain := cfg.TimeDomain
+	if timeDomain == "" {
+		timeDomain = EventTime
+	}
 	return &Processor{
-		logger: cfg.Logger,
+		logger:        cfg.Logger,
+		timeDomain:    timeDomain,
+		enableMetrics: cfg.EnableMetrics,
+	}
+}
+
+// recordWindows sets the window count metric for a windowing strategy
+func (p *Processor) recordWindows(windowType WindowType, windows []Window) {
+	if p.enableMetrics {
+		metrics.TimeSeriesWindowCount.WithLabelValues(string(windowType)).Set(float64(len(windows)))
 	}
 }
 
-// CreateTumblingWindows creates non-overlapping time windows
+// WithTimeDomain returns a processor that windows on the given time domain
+func (p *Processor) WithTimeDomain(domain TimeDomain) *Processor {
+	clone := *p
+	clone.timeDomain = domain
+	return &clone
+}
+
+// time returns a point's time in the processor's time domain
+func (p *Processor) time(point DataPoint) time.Time {
+	return point.Time(p.timeDomain)
+}
+
+// ordered returns points in ascending order of the processor's time domain.
+// Points arriving late relative to that time (out of event-time order when
+// windowing by event time) are moved into place; sorted input is returned as is.
+func (p *Processor) ordered(points []DataPoint) []DataPoint {
+	less := func(i, j int) bool { return p.time(points[i]).Before(p.time(points[j])) }
+	if sort.SliceIsSorted(points, less) {
+		return points
+	}
+
+	sorted := append([]DataPoint(nil), points...)
+	sort.SliceStable(sorted, func(i, j int) bool { return p.time(sorted[i]).Before(p.time(sorted[j])) })
+	return sorted
+}
+
+// CreateTumblingWindows creates non-overlapping time windows. Points may be
+// in any order (e.g. newest first, as ScyllaDB history queries return them);
+// unsorted input is windowed from a sorted copy.
 func (p *Processor) CreateTumblingWindows(ctx context.Context, points []DataPoint, windowSize time.Duration) []Window {
 	if len(points) == 0 {
 		return []Window{}
 	}
 
 	windows := []Window{}
+	points = p.ordered(points)
 
 	// Find first window start (aligned to window size)
-	firstTimestamp := points[0].Timestamp
+	firstTimestamp := p.time(points[0])
 	windowStart := firstTimestamp.Truncate(windowSize)
 
 	currentWindow := Window{
@@ -68,7 +138,7 @@ func (p *Processor) CreateTumblingWindows(ctx context.Context, points []DataPoin
 
 	for _, point := range points {
 		// Check if point belongs to current window
-		if point.Timestamp.Before(currentWindow.End) {
+		if p.time(point).Before(currentWindow.End) {
 			currentWindow.Points = append(currentWindow.Points, point)
 		} else {
 			// Save current window and start new one
@@ -77,7 +147,7 @@ func (p *Processor) CreateTumblingWindows(ctx context.Context, points []DataPoin
 			}
 
 			// Calculate new window boundaries
-			windowStart = point.Timestamp.Truncate(windowSize)
+			windowStart = p.time(point).Truncate(windowSize)
 			currentWindow = Window{
 				Start:  windowStart,
 				End:    windowStart.Add(windowSize),
@@ -91,6 +161,7 @@ func (p *Processor) CreateTumblingWindows(ctx context.Context, points []DataPoin
 		windows = append(windows, currentWindow)
 	}
 
+	p.recordWindows(WindowTypeTumbling, windows)
 	p.logger.Debug("Created tumbling windows",
 		zap.Int("input_points", len(points)),
 		zap.Int("windows", len(windows)),
@@ -100,16 +171,17 @@ func (p *Processor) CreateTumblingWindows(ctx context.Context, points []DataPoin
 	return windows
 }
 
-// CreateSlidingWindows creates overlapping time windows
+// CreateSlidingWindows creates overlapping time windows; points may be in any order
 func (p *Processor) CreateSlidingWindows(ctx context.Context, points []DataPoint, windowSize, slideInterval time.Duration) []Window {
 	if len(points) == 0 {
 		return []Window{}
 	}
 
 	windows := []Window{}
+	points = p.ordered(points)
 
-	firstTimestamp := points[0].Timestamp
-	lastTimestamp := points[len(points)-1].Timestamp
+	firstTimestamp := p.time(points[0])
+	lastTimestamp := p.time(points[len(points)-1])
 
 	// Create windows at slide intervals
 	for windowStart := firstTimestamp.Truncate(slideInterval); windowStart.Before(lastTimestamp); windowStart = windowStart.Add(slideInterval) {
@@ -123,7 +195,7 @@ func (p *Processor) CreateSlidingWindows(ctx context.Context, points []DataPoint
 
 		// Add points that fall within this window
 		for _, point := range points {
-			if !point.Timestamp.Before(windowStart) && point.Timestamp.Before(windowEnd) {
+			if t := p.time(point); !t.Before(windowStart) && t.Before(windowEnd) {
 				window.Points = append(window.Points, point)
 			}
 		}
@@ -133,6 +205,7 @@ func (p *Processor) CreateSlidingWindows(ctx context.Context, points []DataPoint
 		}
 	}
 
+	p.recordWindows(WindowTypeSliding, windows)
 	p.logger.Debug("Created sliding windows",
 		zap.Int("input_points", len(points)),
 		zap.Int("windows", len(windows)),
@@ -143,41 +216,43 @@ func (p *Processor) CreateSlidingWindows(ctx context.Context, points []DataPoint
 	return windows
 }
 
-// CreateSessionWindows creates windows based on gaps in activity
+// CreateSessionWindows creates windows based on gaps in activity; points may be in any order
 func (p *Processor) CreateSessionWindows(ctx context.Context, points []DataPoint, gapTimeout time.Duration) []Window {
 	if len(points) == 0 {
 		return []Window{}
 	}
 
 	windows := []Window{}
+	points = p.ordered(points)
 
 	currentWindow := Window{
-		Start:  points[0].Timestamp,
+		Start:  p.time(points[0]),
 		Points: []DataPoint{points[0]},
 	}
 
 	for i := 1; i < len(points); i++ {
-		gap := points[i].Timestamp.Sub(points[i-1].Timestamp)
+		gap := p.time(points[i]).Sub(p.time(points[i-1]))
 
 		if gap <= gapTimeout {
 			// Continue current session
 			currentWindow.Points = append(currentWindow.Points, points[i])
 		} else {
 			// End current session and start new one
-			currentWindow.End = points[i-1].Timestamp
+			currentWindow.End = p.time(points[i-1])
 			windows = append(windows, currentWindow)
 
 			currentWindow = Window{
-				Start:  points[i].Timestamp,
+				Start:  p.time(points[i]),
 				Points: []DataPoint{points[i]},
 			}
 		}
 	}
 
 	// Add last window
-	currentWindow.End = points[len(points)-1].Timestamp
+	currentWindow.End = p.time(points[len(points)-1])
 	windows = append(windows, currentWindow)
 
+	p.recordWindows(WindowTypeSession, windows)
 	p.logger.Debug("Created session windows",
 		zap.Int("input_points", len(points)),
 		zap.Int("windows", len(windows)),
@@ -192,7 +267,7 @@ func (p *Processor) BucketByTime(ctx context.Context, points []DataPoint, bucket
 	buckets := make(map[time.Time][]DataPoint)
 
 	for _, point := range points {
-		bucketTime := point.Timestamp.Truncate(bucketSize)
+		bucketTime := p.time(point).Truncate(bucketSize)
 		buckets[bucketTime] = append(buckets[bucketTime], point)
 	}
 
@@ -205,6 +280,55 @@ func (p *Processor) BucketByTime(ctx context.Context, points []DataPoint, bucket
 	return buckets
 }
 
+// Gap is a stretch of a series with missing readings. Start and End are the
+// timestamps of the readings either side of it.
+type Gap struct {
+	Start        time.Time
+	End          time.Time
+	MissingCount int // Readings expected between Start and End
+}
+
+// gapAt is a gap together with the index of the point that ends it
+type gapAt struct {
+	Gap
+	index int
+}
+
+// findGaps returns the gaps in points, which must be sorted by timestamp. A
+// gap is reported only when at least one whole interval is missing.
+func findGaps(points []DataPoint, expectedInterval time.Duration) []gapAt {
+	var gaps []gapAt
+	for i := 1; i < len(points); i++ {
+		prev, curr := points[i-1].Timestamp, points[i].Timestamp
+		if missing := int(curr.Sub(prev)/expectedInterval) - 1; missing > 0 {
+			gaps = append(gaps, gapAt{Gap: Gap{Start: prev, End: curr, MissingCount: missing}, index: i})
+		}
+	}
+	return gaps
+}
+
+// DetectGaps reports where readings are missing without filling them, e.g. to
+// alert when a device stops reporting. Points must be sorted by timestamp. The
+// gaps are exactly the ones InterpolateMissing fills, with MissingCount the
+// number of points it would add.
+func (p *Processor) DetectGaps(ctx context.Context, points []DataPoint, expectedInterval time.Duration) []Gap {
+	found := findGaps(points, expectedInterval)
+	gaps := make([]Gap, len(found))
+	missing := 0
+	for i, g := range found {
+		gaps[i] = g.Gap
+		missing += g.MissingCount
+	}
+
+	p.logger.Debug("Detected gaps",
+		zap.Int("points", len(points)),
+		zap.Int("gaps", len(gaps)),
+		zap.Int("missing_points", missing),
+	)
+
+	return gaps
+}
+
 // InterpolationType defines the interpolation method
 type InterpolationType string
 
@@ -213,44 +337,49 @@ const (
 	InterpolationNearest  InterpolationType = "nearest"
 	InterpolationForward  InterpolationType = "forward"
 	InterpolationBackward InterpolationType = "backward"
+	InterpolationSpline   InterpolationType = "spline" // Cubic, through the neighbouring points
 )
 
-// InterpolateMissing fills missing values in time-series data
+// InterpolateMissing fills missing values in time-series data. Filled values
+// are weighted by their actual time between the surrounding points, so gaps
+// that aren't a whole number of intervals are filled correctly.
 func (p *Processor) InterpolateMissing(ctx context.Context, points []DataPoint, expectedInterval time.Duration, method InterpolationType) []DataPoint {
 	if len(points) < 2 {
 		return points
 	}
 
 	result := []DataPoint{points[0]}
+	gaps := findGaps(points, expectedInterval)
 
 	for i := 1; i < len(points); i++ {
 		prev := points[i-1]
 		curr := points[i]
 
-		gap := curr.Timestamp.Sub(prev.Timestamp)
-
-		// Check if there's a gap that needs filling
-		if gap > expectedInterval {
-			missingCount := int(gap / expectedInterval)
+		// Fill the gap, if any, between prev and curr
+		if len(gaps) > 0 && gaps[0].index == i {
+			gap := curr.Timestamp.Sub(prev.Timestamp)
 
-			for j := 1; j < missingCount; j++ {
+			for j := 1; j <= gaps[0].MissingCount; j++ {
 				missingTime := prev.Timestamp.Add(expectedInterval * time.Duration(j))
+				fraction := float64(missingTime.Sub(prev.Timestamp)) / float64(gap)
 
 				var interpolatedValue float64
 				switch method {
 				case InterpolationLinear:
 					// Linear interpolation
-					fraction := float64(j) / float64(missingCount)
 					interpolatedValue = prev.Value + fraction*(curr.Value-prev.Value)
 
 				case InterpolationNearest:
-					// Use nearest neighbor
-					if j < missingCount/2 {
+					// Use nearest neighbor in time; from the midpoint on, curr is nearest
+					if missingTime.Sub(prev.Timestamp) < curr.Timestamp.Sub(missingTime) {
 						interpolatedValue = prev.Value
 					} else {
 						interpolatedValue = curr.Value
 					}
 
+				case InterpolationSpline:
+					interpolatedValue = splineValue(points, i, fraction)
+
 				case InterpolationForward:
 					// Forward fill
 					interpolatedValue = prev.Value
@@ -265,11 +394,15 @@ func (p *Processor) InterpolateMissing(ctx context.Context, points []DataPoint,
 					Value:     interpolatedValue,
 				})
 			}
+			gaps = gaps[1:]
 		}
 
 		result = append(result, curr)
 	}
 
+	if p.enableMetrics {
+		metrics.TimeSeriesInterpolatedPoints.WithLabelValues(string(method)).Add(float64(len(result) - len(points)))
+	}
 	p.logger.Debug("Interpolated missing values",
 		zap.Int("input_points", len(points)),
 		zap.Int("output_points", len(result)),
@@ -279,7 +412,37 @@ func (p *Processor) InterpolateMissing(ctx context.Context, points []DataPoint,
 	return result
 }
 
-// Resample resamples time-series data to a different frequency
+// splineValue evaluates a cubic Hermite spline between points[i-1] and
+// points[i] at fraction of the way through the gap. The tangent at each end
+// is the slope between that point's neighbours (Catmull-Rom), falling back to
+// the gap's own slope at the ends of the series, so a two-point series is
+// interpolated linearly.
+func splineValue(points []DataPoint, i int, fraction float64) float64 {
+	prev, curr := points[i-1], points[i]
+	gap := curr.Timestamp.Sub(prev.Timestamp).Seconds()
+	slope := (curr.Value - prev.Value) / gap
+
+	m0, m1 := slope, slope
+	if i >= 2 {
+		before := points[i-2]
+		m0 = (curr.Value - before.Value) / curr.Timestamp.Sub(before.Timestamp).Seconds()
+	}
+	if i+1 < len(points) {
+		after := points[i+1]
+		m1 = (after.Value - prev.Value) / after.Timestamp.Sub(prev.Timestamp).Seconds()
+	}
+
+	s := fraction
+	s2, s3 := s*s, s*s*s
+	h00 := 2*s3 - 3*s2 + 1
+	h10 := s3 - 2*s2 + s
+	h01 := -2*s3 + 3*s2
+	h11 := s3 - s2
+	return h00*prev.Value + h10*gap*m0 + h01*curr.Value + h11*gap*m1
+}
+
+// Resample resamples time-series data to a different frequency. The result is
+// sorted by timestamp.
 func (p *Processor) Resample(ctx context.Context, points []DataPoint, newInterval time.Duration, aggregation string) []DataPoint {
 	if len(points) == 0 {
 		return []DataPoint{}
@@ -344,6 +507,9 @@ func (p *Processor) Resample(ctx context.Context, points []DataPoint, newInterva
 		})
 	}
 
+	// Map iteration order is random; return the buckets in time order
+	sort.Slice(result, func(i, j int) bool { return result[i].Timestamp.Before(result[j].Timestamp) })
+
 	p.logger.Debug("Resampled time-series data",
 		zap.Int("input_points", len(points)),
 		zap.Int("output_points", len(result)),
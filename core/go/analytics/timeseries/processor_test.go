@@ -0,0 +1,304 @@
+package timeseries
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics/metrics"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"go.uber.org/zap"
+)
+
+func newTestProcessor() *Processor {
+	return NewProcessor(Config{Logger: &logger.Logger{Logger: zap.NewNop()}})
+}
+
+// arrivals returns points in ingest order; the 10:03 reading arrives late,
+// after the 10:07 one, because the device was offline
+func arrivals() []DataPoint {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	return []DataPoint{
+		{Timestamp: base.Add(1 * time.Minute), IngestTime: base.Add(1 * time.Minute), Value: 1},
+		{Timestamp: base.Add(2 * time.Minute), IngestTime: base.Add(2 * time.Minute), Value: 2},
+		{Timestamp: base.Add(7 * time.Minute), IngestTime: base.Add(7 * time.Minute), Value: 7},
+		{Timestamp: base.Add(3 * time.Minute), IngestTime: base.Add(8 * time.Minute), Value: 3},
+	}
+}
+
+func windowValues(windows []Window) [][]float64 {
+	values := make([][]float64, len(windows))
+	for i, w := range windows {
+		for _, p := range w.Points {
+			values[i] = append(values[i], p.Value)
+		}
+	}
+	return values
+}
+
+func TestCreateTumblingWindows_EventTimePlacesLateArrivals(t *testing.T) {
+	windows := newTestProcessor().CreateTumblingWindows(context.Background(), arrivals(), 5*time.Minute)
+
+	got := windowValues(windows)
+	want := [][]float64{{1, 2, 3}, {7}}
+	if len(got) != len(want) {
+		t.Fatalf("windows = %v, want %v", got, want)
+	}
+	for i := range want {
+		if len(got[i]) != len(want[i]) {
+			t.Fatalf("windows = %v, want %v", got, want)
+		}
+		for j := range want[i] {
+			if got[i][j] != want[i][j] {
+				t.Errorf("windows = %v, want %v", got, want)
+			}
+		}
+	}
+	if start := windows[0].Start; start.Minute() != 0 {
+		t.Errorf("first window start = %v, want 10:00", start)
+	}
+}
+
+func TestCreateTumblingWindows_IngestTime(t *testing.T) {
+	windows := newTestProcessor().WithTimeDomain(IngestTime).CreateTumblingWindows(context.Background(), arrivals(), 5*time.Minute)
+
+	// By arrival, the late reading lands in the second window
+	got := windowValues(windows)
+	if len(got) != 2 || len(got[0]) != 2 || len(got[1]) != 2 || got[1][1] != 3 {
+		t.Errorf("windows = %v, want [[1 2] [7 3]]", got)
+	}
+}
+
+func TestDataPointTime_IngestFallsBackToEventTime(t *testing.T) {
+	eventTime := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	point := DataPoint{Timestamp: eventTime, Value: 1}
+	if got := point.Time(IngestTime); !got.Equal(eventTime) {
+		t.Errorf("Time(IngestTime) = %v, want event time %v", got, eventTime)
+	}
+
+	windows := newTestProcessor().WithTimeDomain(IngestTime).CreateTumblingWindows(context.Background(), []DataPoint{point}, 5*time.Minute)
+	if len(windows) != 1 || !windows[0].Start.Equal(eventTime) {
+		t.Errorf("windows = %v, want one window starting at %v", windows, eventTime)
+	}
+}
+
+func TestCreateSessionWindows_EventTime(t *testing.T) {
+	// Ordered by event time the readings are at most 4 minutes apart
+	windows := newTestProcessor().CreateSessionWindows(context.Background(), arrivals(), 4*time.Minute)
+	if len(windows) != 1 || len(windows[0].Points) != 4 {
+		t.Errorf("windows = %v, want one session of 4 points", windowValues(windows))
+	}
+}
+
+func TestWithTimeDomain_LeavesOriginalUnchanged(t *testing.T) {
+	p := newTestProcessor()
+	p.WithTimeDomain(IngestTime)
+	if p.timeDomain != EventTime {
+		t.Errorf("timeDomain = %v, want %v", p.timeDomain, EventTime)
+	}
+}
+
+func TestBucketByTime_EventTime(t *testing.T) {
+	buckets := newTestProcessor().BucketByTime(context.Background(), arrivals(), 5*time.Minute)
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	if got := len(buckets[base]); got != 3 {
+		t.Errorf("10:00 bucket = %d points, want 3", got)
+	}
+}
+
+func TestCreateTumblingWindows_DescendingInput(t *testing.T) {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	var ascending []DataPoint
+	for i := 0; i < 12; i++ {
+		ascending = append(ascending, DataPoint{Timestamp: base.Add(time.Duration(i) * time.Minute), Value: float64(i)})
+	}
+	// Newest first, like GetTelemetryHistory (ORDER BY timestamp DESC)
+	descending := make([]DataPoint, len(ascending))
+	for i, p := range ascending {
+		descending[len(ascending)-1-i] = p
+	}
+
+	p := newTestProcessor()
+	want := p.CreateTumblingWindows(context.Background(), ascending, 5*time.Minute)
+	got := p.CreateTumblingWindows(context.Background(), descending, 5*time.Minute)
+
+	if len(got) != len(want) || len(want) != 3 {
+		t.Fatalf("descending input gave %d windows, ascending %d, want 3", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || len(got[i].Points) != len(want[i].Points) {
+			t.Errorf("window %d = %v with %d points, want %v with %d",
+				i, got[i].Start, len(got[i].Points), want[i].Start, len(want[i].Points))
+		}
+	}
+	if !descending[0].Timestamp.Equal(base.Add(11 * time.Minute)) {
+		t.Error("input slice was reordered")
+	}
+}
+
+// series builds points at the given minute offsets with the given values
+func series(minutes []int, values []float64) []DataPoint {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	points := make([]DataPoint, len(minutes))
+	for i, m := range minutes {
+		points[i] = DataPoint{Timestamp: base.Add(time.Duration(m) * time.Minute), Value: values[i]}
+	}
+	return points
+}
+
+func TestInterpolateMissing_NearestSwitchesAtMidpointTime(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []DataPoint
+		want   []float64
+	}{
+		// Fills at 10, 20, 30, 40; the midpoint is 25, so 20 is still nearer prev
+		{name: "midpoint between fills", points: series([]int{0, 50}, []float64{1, 2}), want: []float64{1, 1, 1, 2, 2, 2}},
+		// Fills at 10, 20, 30; 20 is exactly the midpoint and goes to curr
+		{name: "fill at midpoint", points: series([]int{0, 40}, []float64{1, 2}), want: []float64{1, 1, 2, 2, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := newTestProcessor().InterpolateMissing(context.Background(), tt.points, 10*time.Minute, InterpolationNearest)
+			if len(result) != len(tt.want) {
+				t.Fatalf("got %d points, want %d", len(result), len(tt.want))
+			}
+			for i, p := range result {
+				if p.Value != tt.want[i] {
+					t.Errorf("point %d (%s) = %v, want %v", i, p.Timestamp.Format("15:04"), p.Value, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInterpolateMissing_LinearWeightsByTime(t *testing.T) {
+	// 25 minutes at a 10 minute interval: one fill at 10, 40% of the way
+	result := newTestProcessor().InterpolateMissing(context.Background(), series([]int{0, 25}, []float64{0, 25}), 10*time.Minute, InterpolationLinear)
+	if len(result) != 3 || result[1].Value != 10 {
+		t.Errorf("result = %v, want a fill of 10 at 10:10", result)
+	}
+}
+
+func TestInterpolateMissing_Spline(t *testing.T) {
+	p := newTestProcessor()
+	ctx := context.Background()
+
+	// Linear data stays linear
+	linear := p.InterpolateMissing(ctx, series([]int{0, 10, 40, 50}, []float64{0, 10, 40, 50}), 10*time.Minute, InterpolationSpline)
+	for _, point := range linear {
+		if want := point.Timestamp.Sub(linear[0].Timestamp).Minutes(); math.Abs(point.Value-want) > 1e-9 {
+			t.Errorf("spline at %s = %v, want %v", point.Timestamp.Format("15:04"), point.Value, want)
+		}
+	}
+
+	// On a curve (y = t²) the spline tracks the data better than a straight line
+	curve := series([]int{0, 1, 2, 4, 5}, []float64{0, 1, 4, 16, 25})
+	spline := p.InterpolateMissing(ctx, curve, time.Minute, InterpolationSpline)
+	straight := p.InterpolateMissing(ctx, curve, time.Minute, InterpolationLinear)
+	if len(spline) != 6 || len(straight) != 6 {
+		t.Fatalf("got %d and %d points, want 6", len(spline), len(straight))
+	}
+	if splineErr, linearErr := math.Abs(spline[3].Value-9), math.Abs(straight[3].Value-9); splineErr >= linearErr {
+		t.Errorf("spline fill = %v, linear = %v; want the spline closer to 9", spline[3].Value, straight[3].Value)
+	}
+}
+
+func TestProcessor_EnableMetrics(t *testing.T) {
+	ctx := context.Background()
+	p := NewProcessor(Config{Logger: &logger.Logger{Logger: zap.NewNop()}, EnableMetrics: true})
+
+	p.CreateTumblingWindows(ctx, arrivals(), 5*time.Minute)
+	if got := testutil.ToFloat64(metrics.TimeSeriesWindowCount.WithLabelValues("tumbling")); got != 2 {
+		t.Errorf("tumbling window count = %v, want 2", got)
+	}
+	p.CreateSessionWindows(ctx, arrivals(), time.Minute)
+	if got := testutil.ToFloat64(metrics.TimeSeriesWindowCount.WithLabelValues("session")); got != 2 {
+		t.Errorf("session window count = %v, want 2", got)
+	}
+	p.CreateSlidingWindows(ctx, arrivals(), 5*time.Minute, 5*time.Minute)
+	if got := testutil.ToFloat64(metrics.TimeSeriesWindowCount.WithLabelValues("sliding")); got != 2 {
+		t.Errorf("sliding window count = %v, want 2", got)
+	}
+
+	interpolated := metrics.TimeSeriesInterpolatedPoints.WithLabelValues("forward")
+	before := testutil.ToFloat64(interpolated)
+	p.InterpolateMissing(ctx, series([]int{0, 40}, []float64{1, 2}), 10*time.Minute, InterpolationForward)
+	if got := testutil.ToFloat64(interpolated) - before; got != 3 {
+		t.Errorf("interpolated points = %v, want 3", got)
+	}
+
+	// Disabled by default
+	before = testutil.ToFloat64(interpolated)
+	newTestProcessor().InterpolateMissing(ctx, series([]int{0, 40}, []float64{1, 2}), 10*time.Minute, InterpolationForward)
+	if got := testutil.ToFloat64(interpolated) - before; got != 0 {
+		t.Errorf("interpolated points with metrics disabled = %v, want 0", got)
+	}
+}
+
+func TestDetectGaps(t *testing.T) {
+	points := series([]int{0, 10, 40, 55, 60, 90}, []float64{1, 2, 3, 4, 5, 6})
+	gaps := newTestProcessor().DetectGaps(context.Background(), points, 10*time.Minute)
+
+	// 10→40 misses 20 and 30; 55 is off-grid but under two intervals; 60→90 misses 70 and 80
+	want := []Gap{
+		{Start: points[1].Timestamp, End: points[2].Timestamp, MissingCount: 2},
+		{Start: points[4].Timestamp, End: points[5].Timestamp, MissingCount: 2},
+	}
+	if len(gaps) != len(want) {
+		t.Fatalf("gaps = %+v, want %+v", gaps, want)
+	}
+	for i := range want {
+		if !gaps[i].Start.Equal(want[i].Start) || !gaps[i].End.Equal(want[i].End) || gaps[i].MissingCount != want[i].MissingCount {
+			t.Errorf("gap %d = %+v, want %+v", i, gaps[i], want[i])
+		}
+	}
+
+	// InterpolateMissing fills exactly the detected points
+	filled := newTestProcessor().InterpolateMissing(context.Background(), points, 10*time.Minute, InterpolationForward)
+	if got := len(filled) - len(points); got != 4 {
+		t.Errorf("InterpolateMissing added %d points, want 4", got)
+	}
+}
+
+func TestDetectGaps_NoGaps(t *testing.T) {
+	p := newTestProcessor()
+	if gaps := p.DetectGaps(context.Background(), series([]int{0, 10, 20}, []float64{1, 2, 3}), 10*time.Minute); len(gaps) != 0 {
+		t.Errorf("gaps = %+v, want none", gaps)
+	}
+	if gaps := p.DetectGaps(context.Background(), nil, 10*time.Minute); len(gaps) != 0 {
+		t.Errorf("gaps for empty series = %+v, want none", gaps)
+	}
+}
+
+func TestResample_SortedAndDeterministic(t *testing.T) {
+	minutes := make([]int, 60)
+	values := make([]float64, 60)
+	for i := range minutes {
+		minutes[i], values[i] = i, float64(i)
+	}
+	points := series(minutes, values)
+	p := newTestProcessor()
+
+	first := p.Resample(context.Background(), points, 5*time.Minute, "mean")
+	if len(first) != 12 {
+		t.Fatalf("got %d points, want 12", len(first))
+	}
+	for i := 1; i < len(first); i++ {
+		if !first[i-1].Timestamp.Before(first[i].Timestamp) {
+			t.Fatalf("result not sorted: %s before %s", first[i-1].Timestamp.Format("15:04"), first[i].Timestamp.Format("15:04"))
+		}
+	}
+
+	for run := 0; run < 10; run++ {
+		got := p.Resample(context.Background(), points, 5*time.Minute, "mean")
+		for i := range first {
+			if !got[i].Timestamp.Equal(first[i].Timestamp) || got[i].Value != first[i].Value {
+				t.Fatalf("run %d: point %d = %+v, want %+v", run, i, got[i], first[i])
+			}
+		}
+	}
+}
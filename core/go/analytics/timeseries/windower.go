@@ -0,0 +1,138 @@
+package timeseries
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidWindowSize      = errors.New("windower: window size must be positive")
+	ErrInvalidAllowedLateness = errors.New("windower: allowed lateness must be positive")
+)
+
+// WindowerConfig configures a streaming tumbling windower
+type WindowerConfig struct {
+	WindowSize time.Duration // Must be positive
+
+	// AllowedLateness is how far behind the latest event time a point may
+	// arrive and still be added to its window (the watermark delay). Must be
+	// positive.
+	AllowedLateness time.Duration
+
+	// OnLate receives points that arrive after their window was finalized
+	// (optional; such points are dropped either way). It is called from Add
+	// and must not call back into the windower.
+	OnLate func(DataPoint)
+}
+
+// Windower assigns a stream of points to tumbling windows as they arrive,
+// which may be out of order. The watermark trails the latest time seen by
+// AllowedLateness; a window is finalized once the watermark passes its end,
+// so late points within the allowed lateness still land in their window.
+// It is safe for concurrent use.
+type Windower struct {
+	processor *Processor
+	cfg       WindowerConfig
+
+	mu        sync.Mutex
+	open      map[time.Time]*Window // By window start
+	maxSeen   time.Time
+	watermark time.Time
+	started   bool
+	dropped   int64
+}
+
+// NewWindower creates a streaming windower using the processor's time domain.
+// It fails with ErrInvalidWindowSize or ErrInvalidAllowedLateness when either
+// duration is not positive.
+func (p *Processor) NewWindower(cfg WindowerConfig) (*Windower, error) {
+	if cfg.WindowSize <= 0 {
+		return nil, ErrInvalidWindowSize
+	}
+	if cfg.AllowedLateness <= 0 {
+		return nil, ErrInvalidAllowedLateness
+	}
+	return &Windower{
+		processor: p,
+		cfg:       cfg,
+		open:      make(map[time.Time]*Window),
+	}, nil
+}
+
+// Add adds a point and returns the windows it finalized, oldest first
+func (w *Windower) Add(point DataPoint) []Window {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	t := w.processor.time(point)
+	start := t.Truncate(w.cfg.WindowSize)
+	end := start.Add(w.cfg.WindowSize)
+
+	if w.started && !end.After(w.watermark) {
+		// Its window was already finalized
+		w.dropped++
+		w.processor.logger.Debug("Dropped late data point",
+			zap.Time("time", t),
+			zap.Time("watermark", w.watermark))
+		if w.cfg.OnLate != nil {
+			w.cfg.OnLate(point)
+		}
+		return nil
+	}
+
+	window, ok := w.open[start]
+	if !ok {
+		window = &Window{Start: start, End: end, Points: []DataPoint{}}
+		w.open[start] = window
+	}
+	window.Points = append(window.Points, point)
+
+	if !w.started || t.After(w.maxSeen) {
+		w.started = true
+		w.maxSeen = t
+		w.watermark = t.Add(-w.cfg.AllowedLateness)
+	}
+
+	return w.finalize(func(window *Window) bool { return !window.End.After(w.watermark) })
+}
+
+// Flush finalizes and returns every open window, oldest first
+func (w *Windower) Flush() []Window {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.finalize(func(*Window) bool { return true })
+}
+
+// Watermark returns the current watermark: the latest time seen minus the
+// allowed lateness
+func (w *Windower) Watermark() time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.watermark
+}
+
+// Dropped returns how many points arrived too late to be windowed
+func (w *Windower) Dropped() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.dropped
+}
+
+// finalize removes and returns the open windows that done reports as
+// complete, oldest first, with points ordered by time; callers hold w.mu
+func (w *Windower) finalize(done func(*Window) bool) []Window {
+	var windows []Window
+	for start, window := range w.open {
+		if done(window) {
+			window.Points = w.processor.ordered(window.Points)
+			windows = append(windows, *window)
+			delete(w.open, start)
+		}
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].Start.Before(windows[j].Start) })
+	return windows
+}
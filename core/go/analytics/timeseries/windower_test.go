@@ -0,0 +1,103 @@
+package timeseries
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWindower_Watermark(t *testing.T) {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int, value float64) DataPoint {
+		return DataPoint{Timestamp: base.Add(time.Duration(minutes) * time.Minute), Value: value}
+	}
+
+	var late []DataPoint
+	w, err := newTestProcessor().NewWindower(WindowerConfig{
+		WindowSize:      5 * time.Minute,
+		AllowedLateness: 2 * time.Minute,
+		OnLate:          func(p DataPoint) { late = append(late, p) },
+	})
+	if err != nil {
+		t.Fatalf("NewWindower() error = %v", err)
+	}
+
+	// On time: [10:00, 10:05) stays open until the watermark passes 10:05
+	for _, p := range []DataPoint{at(1, 1), at(4, 4), at(6, 6)} {
+		if windows := w.Add(p); len(windows) != 0 {
+			t.Fatalf("Add(%v) finalized %d windows, want 0", p.Timestamp, len(windows))
+		}
+	}
+	if got := w.Watermark(); !got.Equal(base.Add(4 * time.Minute)) {
+		t.Errorf("Watermark() = %v, want 10:04", got)
+	}
+
+	// Within lateness: 10:03 arrives after 10:06 but its window is still open
+	if windows := w.Add(at(3, 3)); len(windows) != 0 {
+		t.Fatalf("late Add() finalized %d windows, want 0", len(windows))
+	}
+
+	// 10:07 moves the watermark to 10:05, finalizing the first window
+	windows := w.Add(at(7, 7))
+	if len(windows) != 1 {
+		t.Fatalf("Add(10:07) finalized %d windows, want 1", len(windows))
+	}
+	if got := windowValues(windows)[0]; len(got) != 3 || got[0] != 1 || got[1] != 3 || got[2] != 4 {
+		t.Errorf("first window = %v, want [1 3 4] in time order", got)
+	}
+
+	// Too late: the first window is finalized, so 10:02 is dropped
+	if windows := w.Add(at(2, 2)); len(windows) != 0 {
+		t.Errorf("too-late Add() finalized %d windows, want 0", len(windows))
+	}
+	if w.Dropped() != 1 || len(late) != 1 || late[0].Value != 2 {
+		t.Errorf("Dropped() = %d, late = %v, want the 10:02 point", w.Dropped(), late)
+	}
+
+	// Flush finalizes what's left
+	windows = w.Flush()
+	if got := windowValues(windows); len(got) != 1 || len(got[0]) != 2 {
+		t.Errorf("Flush() = %v, want [[6 7]]", got)
+	}
+	if windows := w.Flush(); len(windows) != 0 {
+		t.Errorf("second Flush() = %d windows, want 0", len(windows))
+	}
+}
+
+func TestWindower_FinalizesSeveralWindowsInOrder(t *testing.T) {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	w, err := newTestProcessor().NewWindower(WindowerConfig{WindowSize: time.Minute, AllowedLateness: time.Minute})
+	if err != nil {
+		t.Fatalf("NewWindower() error = %v", err)
+	}
+
+	w.Add(DataPoint{Timestamp: base.Add(30 * time.Second)})
+	w.Add(DataPoint{Timestamp: base.Add(90 * time.Second)})
+	windows := w.Add(DataPoint{Timestamp: base.Add(10 * time.Minute)})
+
+	if len(windows) != 2 || !windows[0].Start.Equal(base) || !windows[1].Start.Equal(base.Add(time.Minute)) {
+		t.Errorf("windows = %+v, want 10:00 and 10:01 in order", windows)
+	}
+}
+
+func TestNewWindower_RejectsNonPositiveDurations(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     WindowerConfig
+		wantErr error
+	}{
+		{"zero window size", WindowerConfig{AllowedLateness: time.Minute}, ErrInvalidWindowSize},
+		{"negative window size", WindowerConfig{WindowSize: -time.Minute, AllowedLateness: time.Minute}, ErrInvalidWindowSize},
+		{"zero lateness", WindowerConfig{WindowSize: time.Minute}, ErrInvalidAllowedLateness},
+		{"negative lateness", WindowerConfig{WindowSize: time.Minute, AllowedLateness: -time.Second}, ErrInvalidAllowedLateness},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := newTestProcessor().NewWindower(tt.cfg)
+			if !errors.Is(err, tt.wantErr) || w != nil {
+				t.Errorf("NewWindower() = %v, %v, want nil, %v", w, err, tt.wantErr)
+			}
+		})
+	}
+}
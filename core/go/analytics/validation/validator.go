@@ -2,10 +2,20 @@ package validation
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
 	"time"
 
-	"github.com/your-github-org/ai-scaffolder/core/go/errors"
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics"
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics/metrics"
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
 	"go.uber.org/zap"
 )
@@ -13,6 +23,9 @@ import (
 // Validator provides data quality validation capabilities
 type Validator struct {
 	logger *logger.Logger
+
+	// Compiled SchemaField.Pattern values for schemas that weren't compiled
+	patterns sync.Map // pattern -> *regexp.Regexp or error
 }
 
 // Config holds validator configuration
@@ -20,34 +33,92 @@ type Config struct {
 	Logger *logger.Logger
 }
 
-// ValidationResult represents the outcome of a validation check
+// ValidationResult represents the outcome of a validation check.
+// Warnings are non-blocking advisories: they never affect IsValid.
 type ValidationResult struct {
 	IsValid      bool
 	ErrorCode    string
 	ErrorMessage string
 	FailedChecks []string
+	Warnings     []string
 	Metadata     map[string]interface{}
 }
 
+// HasWarnings reports whether the result carries any advisories
+func (r *ValidationResult) HasWarnings() bool {
+	return len(r.Warnings) > 0
+}
+
 // SchemaField defines expected structure for a field
 type SchemaField struct {
 	Name       string
-	Type       string // "string", "int", "float", "bool", "timestamp"
+	Type       string // "string", "int", "float", "decimal", "bool", "timestamp", "object", "array"
 	Required   bool
 	AllowNull  bool
 	MinValue   *float64
 	MaxValue   *float64
 	MinLength  *int
 	MaxLength  *int
-	Pattern    string // Regex pattern for string validation
+	Pattern    string // Regex pattern for string validation; see Schema.Compile
 	EnumValues []string
+
+	// Soft bounds checked by ValidateWithWarnings: values outside them are
+	// accepted with a warning (e.g. a reading close to MaxValue)
+	WarnMinValue *float64
+	WarnMaxValue *float64
+
+	// Decimal constraints for numeric fields such as monetary amounts
+	MaxDecimalPlaces *int
+	NonNegative      bool
+
+	// Nested is the schema of an "object" field's value
+	Nested *Schema
+	// Items validates every element of an "array" field (its Name is unused)
+	Items *SchemaField
+
+	compiled *regexp.Regexp // Pattern, set by Schema.Compile
 }
 
 // Schema defines the expected structure of data
 type Schema struct {
+	Name   string // Dataset name, used to label warning metrics
 	Fields []SchemaField
 }
 
+// Compile compiles the Pattern of every field, including nested fields and
+// array items, so invalid patterns are reported when the schema is defined
+// rather than on each validation. Call it once after building the schema;
+// schemas that aren't compiled still work, with patterns compiled on first use.
+func (s *Schema) Compile() error {
+	return compileFields(s.Fields, "")
+}
+
+func compileFields(fields []SchemaField, prefix string) error {
+	var errs []error
+	for i := range fields {
+		errs = append(errs, compileField(&fields[i], prefix+fields[i].Name))
+	}
+	return errors.Join(errs...)
+}
+
+func compileField(field *SchemaField, path string) error {
+	var errs []error
+	if field.Pattern != "" {
+		re, err := regexp.Compile(field.Pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("field '%s' has invalid pattern: %w", path, err))
+		}
+		field.compiled = re
+	}
+	if field.Nested != nil {
+		errs = append(errs, compileFields(field.Nested.Fields, path+"."))
+	}
+	if field.Items != nil {
+		errs = append(errs, compileField(field.Items, path+"[]"))
+	}
+	return errors.Join(errs...)
+}
+
 // NewValidator creates a new data validator with dependency injection
 func NewValidator(cfg Config) *Validator {
 	return &Validator{
@@ -57,99 +128,261 @@ func NewValidator(cfg Config) *Validator {
 
 // ValidateSchema validates data against a schema definition
 func (v *Validator) ValidateSchema(ctx context.Context, data map[string]interface{}, schema *Schema) *ValidationResult {
+	result, _ := v.validateRecord(data, schema)
+
+	if !result.IsValid {
+		v.logger.Warn("Schema validation failed",
+			zap.Int("failed_checks", len(result.FailedChecks)),
+			zap.Strings("failures", result.FailedChecks),
+		)
+	} else {
+		v.logger.Debug("Schema validation passed",
+			zap.Int("fields_validated", len(schema.Fields)),
+		)
+	}
+
+	return result
+}
+
+// validateRecord is ValidateSchema without logging; it also returns the
+// failures behind result.FailedChecks
+func (v *Validator) validateRecord(data map[string]interface{}, schema *Schema) (*ValidationResult, []fieldFailure) {
 	result := &ValidationResult{
 		IsValid:      true,
 		FailedChecks: []string{},
+		Warnings:     []string{},
 		Metadata:     make(map[string]interface{}),
 	}
 
-	for _, field := range schema.Fields {
-		value, exists := data[field.Name]
+	failures := v.validateFields(data, schema.Fields, "")
+	for _, failure := range failures {
+		result.FailedChecks = append(result.FailedChecks, failure.message)
+	}
+	result.IsValid = len(result.FailedChecks) == 0
 
-		// Check required fields
-		if field.Required && !exists {
-			result.IsValid = false
-			result.FailedChecks = append(result.FailedChecks,
-				fmt.Sprintf("Required field '%s' is missing", field.Name))
+	if !result.IsValid {
+		result.ErrorCode = analytics.ErrCodeSchemaValidation
+		result.ErrorMessage = fmt.Sprintf("Schema validation failed with %d errors", len(result.FailedChecks))
+	}
+
+	return result, failures
+}
+
+// BatchValidationResult summarizes the validation of a batch of records
+type BatchValidationResult struct {
+	Total   int
+	Valid   int
+	Invalid int
+
+	// QualityScore is Valid/Total (1 for an empty batch)
+	QualityScore float64
+
+	// FieldFailures counts failed checks by field path; array indices are
+	// dropped so "readings[]" counts failures across all elements
+	FieldFailures map[string]int
+
+	// Results holds each record's result, in input order
+	Results []*ValidationResult
+}
+
+// arrayIndex matches the element index in a failure path such as "readings[2]"
+var arrayIndex = regexp.MustCompile(`\[\d+\]`)
+
+// ValidateBatch validates each record like ValidateSchema and scores the
+// batch, e.g. to reject it below a quality threshold before storing it. It
+// sets analytics_data_quality_score{dataset=schema.Name, dimension="validity"}
+// and counts each failed check in analytics_data_validation_errors_total by
+// check type (required, type, range, ...). Records aren't logged one by one.
+func (v *Validator) ValidateBatch(ctx context.Context, records []map[string]interface{}, schema *Schema) *BatchValidationResult {
+	batch := &BatchValidationResult{
+		Total:         len(records),
+		QualityScore:  1,
+		FieldFailures: make(map[string]int),
+		Results:       make([]*ValidationResult, len(records)),
+	}
+
+	for i, record := range records {
+		result, failures := v.validateRecord(record, schema)
+		batch.Results[i] = result
+		if result.IsValid {
+			batch.Valid++
 			continue
 		}
 
-		if !exists {
-			continue // Optional field not present
+		batch.Invalid++
+		for _, failure := range failures {
+			batch.FieldFailures[arrayIndex.ReplaceAllString(failure.path, "[]")]++
+			metrics.DataValidationErrors.WithLabelValues(schema.Name, failure.check).Inc()
+		}
+	}
+
+	if batch.Total > 0 {
+		batch.QualityScore = float64(batch.Valid) / float64(batch.Total)
+		metrics.RecordDataQuality(schema.Name, "validity", batch.QualityScore)
+	}
+
+	v.logger.Info("Validated batch",
+		zap.String("dataset", schema.Name),
+		zap.Int("total", batch.Total),
+		zap.Int("invalid", batch.Invalid),
+		zap.Float64("quality_score", batch.QualityScore),
+	)
+
+	return batch
+}
+
+// fieldFailure is one failed check on one field
+type fieldFailure struct {
+	path    string // e.g. "location.lat" or "readings[2]"
+	check   string // required, null, type, range, length, pattern, enum, negative, precision
+	message string
+}
+
+// validateFields checks data against fields and returns the failed checks.
+// prefix is the dotted path of data within the record ("" at the top level).
+func (v *Validator) validateFields(data map[string]interface{}, fields []SchemaField, prefix string) []fieldFailure {
+	var failed []fieldFailure
+	for _, field := range fields {
+		value, exists := data[field.Name]
+		failed = append(failed, v.validateField(value, exists, field, prefix+field.Name)...)
+	}
+	return failed
+}
+
+// validateField checks one value against field; path names it in failures,
+// e.g. "location.lat" or "readings[2]"
+func (v *Validator) validateField(value interface{}, exists bool, field SchemaField, path string) []fieldFailure {
+	var failed []fieldFailure
+
+	// Check required fields
+	if field.Required && !exists {
+		return []fieldFailure{{path, "required", fmt.Sprintf("Required field '%s' is missing", path)}}
+	}
+
+	if !exists {
+		return nil // Optional field not present
+	}
+
+	// Check null values
+	if value == nil {
+		if !field.AllowNull {
+			failed = append(failed, fieldFailure{path, "null", fmt.Sprintf("Field '%s' cannot be null", path)})
 		}
+		return failed
+	}
 
-		// Check null values
-		if value == nil {
-			if !field.AllowNull {
-				result.IsValid = false
-				result.FailedChecks = append(result.FailedChecks,
-					fmt.Sprintf("Field '%s' cannot be null", field.Name))
+	// Type validation
+	if !v.validateType(value, field.Type) {
+		return []fieldFailure{{path, "type", fmt.Sprintf("Field '%s' has invalid type, expected %s", path, field.Type)}}
+	}
+
+	// Precision and sign validation for numeric types
+	if isNumericType(field.Type) {
+		if field.NonNegative && v.isNegative(value) {
+			failed = append(failed, fieldFailure{path, "negative", fmt.Sprintf("Field '%s' value %v must not be negative", path, value)})
+		}
+		if field.MaxDecimalPlaces != nil {
+			if places := v.decimalPlaces(value); places > *field.MaxDecimalPlaces {
+				failed = append(failed, fieldFailure{path, "precision",
+					fmt.Sprintf("Field '%s' value %v has %d decimal places, maximum is %d", path, value, places, *field.MaxDecimalPlaces)})
 			}
-			continue
 		}
+	}
 
-		// Type validation
-		if !v.validateType(value, field.Type) {
-			result.IsValid = false
-			result.FailedChecks = append(result.FailedChecks,
-				fmt.Sprintf("Field '%s' has invalid type, expected %s", field.Name, field.Type))
-			continue
+	// Range validation for numeric types
+	if isNumericType(field.Type) {
+		numValue := v.toFloat64(value)
+		if field.MinValue != nil && numValue < *field.MinValue {
+			failed = append(failed, fieldFailure{path, "range", fmt.Sprintf("Field '%s' value %f is below minimum %f", path, numValue, *field.MinValue)})
 		}
+		if field.MaxValue != nil && numValue > *field.MaxValue {
+			failed = append(failed, fieldFailure{path, "range", fmt.Sprintf("Field '%s' value %f exceeds maximum %f", path, numValue, *field.MaxValue)})
+		}
+	}
 
-		// Range validation for numeric types
-		if field.Type == "int" || field.Type == "float" {
-			numValue := v.toFloat64(value)
-			if field.MinValue != nil && numValue < *field.MinValue {
-				result.IsValid = false
-				result.FailedChecks = append(result.FailedChecks,
-					fmt.Sprintf("Field '%s' value %f is below minimum %f", field.Name, numValue, *field.MinValue))
+	// Length and pattern validation for strings
+	if field.Type == "string" {
+		strValue, ok := value.(string)
+		if ok {
+			length := len(strValue)
+			if field.MinLength != nil && length < *field.MinLength {
+				failed = append(failed, fieldFailure{path, "length", fmt.Sprintf("Field '%s' length %d is below minimum %d", path, length, *field.MinLength)})
 			}
-			if field.MaxValue != nil && numValue > *field.MaxValue {
-				result.IsValid = false
-				result.FailedChecks = append(result.FailedChecks,
-					fmt.Sprintf("Field '%s' value %f exceeds maximum %f", field.Name, numValue, *field.MaxValue))
+			if field.MaxLength != nil && length > *field.MaxLength {
+				failed = append(failed, fieldFailure{path, "length", fmt.Sprintf("Field '%s' length %d exceeds maximum %d", path, length, *field.MaxLength)})
 			}
-		}
-
-		// Length validation for strings
-		if field.Type == "string" {
-			strValue, ok := value.(string)
-			if ok {
-				length := len(strValue)
-				if field.MinLength != nil && length < *field.MinLength {
-					result.IsValid = false
-					result.FailedChecks = append(result.FailedChecks,
-						fmt.Sprintf("Field '%s' length %d is below minimum %d", field.Name, length, *field.MinLength))
-				}
-				if field.MaxLength != nil && length > *field.MaxLength {
-					result.IsValid = false
-					result.FailedChecks = append(result.FailedChecks,
-						fmt.Sprintf("Field '%s' length %d exceeds maximum %d", field.Name, length, *field.MaxLength))
+			if field.Pattern != "" {
+				if re, err := v.pattern(field); err != nil {
+					failed = append(failed, fieldFailure{path, "pattern", fmt.Sprintf("Field '%s' has invalid pattern %q", path, field.Pattern)})
+				} else if !re.MatchString(strValue) {
+					failed = append(failed, fieldFailure{path, "pattern", fmt.Sprintf("Field '%s' value does not match pattern %q", path, field.Pattern)})
 				}
 			}
 		}
+	}
 
-		// Enum validation
-		if len(field.EnumValues) > 0 {
-			if !v.isInEnum(value, field.EnumValues) {
-				result.IsValid = false
-				result.FailedChecks = append(result.FailedChecks,
-					fmt.Sprintf("Field '%s' value is not in allowed values", field.Name))
-			}
+	// Enum validation
+	if len(field.EnumValues) > 0 {
+		if !v.isInEnum(value, field.EnumValues) {
+			failed = append(failed, fieldFailure{path, "enum", fmt.Sprintf("Field '%s' value is not in allowed values", path)})
 		}
 	}
 
-	if !result.IsValid {
-		result.ErrorCode = "VALIDATION-001"
-		result.ErrorMessage = fmt.Sprintf("Schema validation failed with %d errors", len(result.FailedChecks))
+	// Nested objects and array elements
+	if field.Type == "object" && field.Nested != nil {
+		failed = append(failed, v.validateFields(value.(map[string]interface{}), field.Nested.Fields, path+".")...)
+	}
+	if field.Type == "array" && field.Items != nil {
+		items := reflect.ValueOf(value)
+		for i := 0; i < items.Len(); i++ {
+			failed = append(failed, v.validateField(items.Index(i).Interface(), true, *field.Items, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
 
-		v.logger.Warn("Schema validation failed",
-			zap.Int("failed_checks", len(result.FailedChecks)),
-			zap.Strings("failures", result.FailedChecks),
-		)
-	} else {
-		v.logger.Debug("Schema validation passed",
-			zap.Int("fields_validated", len(schema.Fields)),
+	return failed
+}
+
+// ValidateWithWarnings validates data like ValidateSchema and additionally checks
+// the soft WarnMinValue/WarnMaxValue bounds. Soft-bound violations are reported
+// in Warnings and counted in analytics_data_validation_warnings_total, but the
+// record is still accepted unless a hard check failed.
+func (v *Validator) ValidateWithWarnings(ctx context.Context, data map[string]interface{}, schema *Schema) *ValidationResult {
+	result := v.ValidateSchema(ctx, data, schema)
+
+	for _, field := range schema.Fields {
+		if field.WarnMinValue == nil && field.WarnMaxValue == nil {
+			continue
+		}
+		if !isNumericType(field.Type) {
+			continue
+		}
+		value, exists := data[field.Name]
+		if !exists || value == nil || !v.validateType(value, field.Type) {
+			continue // Missing or mistyped fields are already reported as errors
+		}
+
+		numValue := v.toFloat64(value)
+		var warning string
+		switch {
+		case field.MinValue != nil && numValue < *field.MinValue,
+			field.MaxValue != nil && numValue > *field.MaxValue:
+			continue // Already a hard failure
+		case field.WarnMinValue != nil && numValue < *field.WarnMinValue:
+			warning = fmt.Sprintf("Field '%s' value %f is below warning threshold %f", field.Name, numValue, *field.WarnMinValue)
+		case field.WarnMaxValue != nil && numValue > *field.WarnMaxValue:
+			warning = fmt.Sprintf("Field '%s' value %f exceeds warning threshold %f", field.Name, numValue, *field.WarnMaxValue)
+		default:
+			continue
+		}
+
+		result.Warnings = append(result.Warnings, warning)
+		metrics.DataValidationWarnings.WithLabelValues(schema.Name, field.Name).Inc()
+	}
+
+	if result.HasWarnings() {
+		v.logger.Info("Validation passed with warnings",
+			zap.Bool("is_valid", result.IsValid),
+			zap.Strings("warnings", result.Warnings),
 		)
 	}
 
@@ -166,7 +399,7 @@ func (v *Validator) ValidateRange(ctx context.Context, value float64, min, max f
 
 	if value < min {
 		result.IsValid = false
-		result.ErrorCode = "VALIDATION-002"
+		result.ErrorCode = analytics.ErrCodeOutOfRange
 		result.ErrorMessage = fmt.Sprintf("%s value %f is below minimum %f", fieldName, value, min)
 		result.FailedChecks = append(result.FailedChecks, result.ErrorMessage)
 
@@ -179,7 +412,7 @@ func (v *Validator) ValidateRange(ctx context.Context, value float64, min, max f
 
 	if value > max {
 		result.IsValid = false
-		result.ErrorCode = "VALIDATION-002"
+		result.ErrorCode = analytics.ErrCodeOutOfRange
 		result.ErrorMessage = fmt.Sprintf("%s value %f exceeds maximum %f", fieldName, value, max)
 		result.FailedChecks = append(result.FailedChecks, result.ErrorMessage)
 
@@ -212,7 +445,7 @@ func (v *Validator) ValidateNotNull(ctx context.Context, data map[string]interfa
 	}
 
 	if !result.IsValid {
-		result.ErrorCode = "VALIDATION-003"
+		result.ErrorCode = analytics.ErrCodeRequiredFields
 		result.ErrorMessage = fmt.Sprintf("Null validation failed for %d fields", len(result.FailedChecks))
 
 		v.logger.Warn("Null validation failed",
@@ -224,7 +457,8 @@ func (v *Validator) ValidateNotNull(ctx context.Context, data map[string]interfa
 	return result
 }
 
-// DetectOutliers identifies statistical outliers using IQR method
+// DetectOutliers identifies statistical outliers using IQR method and returns
+// their indices. Quartiles are interpolated as in features.DetectOutliers.
 func (v *Validator) DetectOutliers(ctx context.Context, values []float64, threshold float64) []int {
 	if len(values) < 4 {
 		return []int{}
@@ -233,21 +467,10 @@ func (v *Validator) DetectOutliers(ctx context.Context, values []float64, thresh
 	// Calculate quartiles
 	sorted := make([]float64, len(values))
 	copy(sorted, values)
+	sort.Float64s(sorted)
 
-	// Simple sort for quartile calculation
-	for i := 0; i < len(sorted); i++ {
-		for j := i + 1; j < len(sorted); j++ {
-			if sorted[i] > sorted[j] {
-				sorted[i], sorted[j] = sorted[j], sorted[i]
-			}
-		}
-	}
-
-	q1Index := len(sorted) / 4
-	q3Index := 3 * len(sorted) / 4
-
-	q1 := sorted[q1Index]
-	q3 := sorted[q3Index]
+	q1 := percentileFromSorted(sorted, 25)
+	q3 := percentileFromSorted(sorted, 75)
 	iqr := q3 - q1
 
 	lowerBound := q1 - threshold*iqr
@@ -270,8 +493,66 @@ func (v *Validator) DetectOutliers(ctx context.Context, values []float64, thresh
 	return outlierIndices
 }
 
+// DetectOutliersMAD identifies outliers using the median absolute deviation
+// and returns their indices, like DetectOutliers. Each value's modified
+// z-score, 0.6745 × (value - median) / MAD, is compared with threshold;
+// 3.5 is the usual cutoff. The median and MAD ignore up to half the values
+// being extreme, so bursts of spikes don't mask each other as they can
+// with IQR. If the MAD is zero (over half the values are identical), the
+// mean absolute deviation is used instead.
+func (v *Validator) DetectOutliersMAD(ctx context.Context, values []float64, threshold float64) []int {
+	if len(values) < 4 {
+		return []int{}
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	median := percentileFromSorted(sorted, 50)
+
+	deviations := make([]float64, len(values))
+	var sumDeviation float64
+	for i, val := range values {
+		deviations[i] = math.Abs(val - median)
+		sumDeviation += deviations[i]
+	}
+	sort.Float64s(deviations)
+	mad := percentileFromSorted(deviations, 50)
+
+	// Scale so the score is comparable to a z-score for normal data
+	scale := mad / 0.6745
+	if mad == 0 {
+		scale = 1.253314 * sumDeviation / float64(len(values))
+	}
+
+	outlierIndices := []int{}
+	if scale == 0 {
+		return outlierIndices // All values identical
+	}
+	for i, val := range values {
+		if math.Abs(val-median)/scale > threshold {
+			outlierIndices = append(outlierIndices, i)
+		}
+	}
+
+	v.logger.Debug("Detected outliers (MAD)",
+		zap.Int("total_values", len(values)),
+		zap.Int("outliers", len(outlierIndices)),
+		zap.Float64("median", median),
+		zap.Float64("mad", mad),
+	)
+
+	return outlierIndices
+}
+
 // ValidateTimestamp checks if timestamp is within acceptable range
 func (v *Validator) ValidateTimestamp(ctx context.Context, timestamp time.Time, maxAge time.Duration) *ValidationResult {
+	return v.ValidateTimestampWithSkew(ctx, timestamp, maxAge, 0)
+}
+
+// ValidateTimestampWithSkew is ValidateTimestamp for clocks that may run
+// ahead: timestamps up to maxFutureSkew in the future are accepted
+func (v *Validator) ValidateTimestampWithSkew(ctx context.Context, timestamp time.Time, maxAge, maxFutureSkew time.Duration) *ValidationResult {
 	result := &ValidationResult{
 		IsValid:      true,
 		FailedChecks: []string{},
@@ -282,9 +563,9 @@ func (v *Validator) ValidateTimestamp(ctx context.Context, timestamp time.Time,
 	age := now.Sub(timestamp)
 
 	// Check if timestamp is in the future
-	if timestamp.After(now) {
+	if timestamp.After(now.Add(maxFutureSkew)) {
 		result.IsValid = false
-		result.ErrorCode = "VALIDATION-004"
+		result.ErrorCode = analytics.ErrCodeFutureTimestamp
 		result.ErrorMessage = "Timestamp is in the future"
 		result.FailedChecks = append(result.FailedChecks, result.ErrorMessage)
 
@@ -297,7 +578,7 @@ func (v *Validator) ValidateTimestamp(ctx context.Context, timestamp time.Time,
 	// Check if timestamp is too old
 	if age > maxAge {
 		result.IsValid = false
-		result.ErrorCode = "VALIDATION-005"
+		result.ErrorCode = analytics.ErrCodeStaleTimestamp
 		result.ErrorMessage = fmt.Sprintf("Timestamp age %v exceeds maximum allowed %v", age, maxAge)
 		result.FailedChecks = append(result.FailedChecks, result.ErrorMessage)
 
@@ -317,18 +598,47 @@ func (v *Validator) ToServiceError(result *ValidationResult) error {
 		return nil
 	}
 
-	return &errors.ServiceError{
-		Code:     result.ErrorCode,
-		Message:  result.ErrorMessage,
-		Severity: errors.SeverityMedium,
-		Context: map[string]interface{}{
-			"failed_checks": result.FailedChecks,
-		},
-	}
+	// The registry supplies severity and component; the result has the details
+	serviceErr := analytics.Errors.CreateError(result.ErrorCode)
+	serviceErr.Message = result.ErrorMessage
+	serviceErr.Context["failed_checks"] = result.FailedChecks
+	return serviceErr
 }
 
 // Helper methods
 
+// percentileFromSorted interpolates linearly between the closest ranks, like
+// the features calculator, so both packages flag the same outliers
+func percentileFromSorted(sorted []float64, percentile float64) float64 {
+	rank := (percentile / 100.0) * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	fraction := rank - float64(lower)
+	return sorted[lower] + fraction*(sorted[upper]-sorted[lower])
+}
+
+// pattern returns field's compiled Pattern, from Schema.Compile or else from
+// the validator's cache
+func (v *Validator) pattern(field SchemaField) (*regexp.Regexp, error) {
+	if field.compiled != nil {
+		return field.compiled, nil
+	}
+	if cached, ok := v.patterns.Load(field.Pattern); ok {
+		if err, isErr := cached.(error); isErr {
+			return nil, err
+		}
+		return cached.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(field.Pattern)
+	if err != nil {
+		v.patterns.Store(field.Pattern, err)
+		return nil, err
+	}
+	v.patterns.Store(field.Pattern, re)
+	return re, nil
+}
+
 func (v *Validator) validateType(value interface{}, expectedType string) bool {
 	switch expectedType {
 	case "string":
@@ -346,6 +656,9 @@ func (v *Validator) validateType(value interface{}, expectedType string) bool {
 			return true
 		}
 		return false
+	case "decimal":
+		_, ok := v.toRat(value)
+		return ok
 	case "bool":
 		_, ok := value.(bool)
 		return ok
@@ -355,6 +668,12 @@ func (v *Validator) validateType(value interface{}, expectedType string) bool {
 			_, ok = value.(string) // Accept ISO8601 strings
 		}
 		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		kind := reflect.ValueOf(value).Kind()
+		return kind == reflect.Slice || kind == reflect.Array
 	default:
 		return true
 	}
@@ -372,9 +691,61 @@ func (v *Validator) toFloat64(value interface{}) float64 {
 		return float64(v)
 	case int64:
 		return float64(v)
+	case string, json.Number:
+		f, _ := strconv.ParseFloat(fmt.Sprint(v), 64)
+		return f
+	default:
+		return 0
+	}
+}
+
+func isNumericType(fieldType string) bool {
+	return fieldType == "int" || fieldType == "float" || fieldType == "decimal"
+}
+
+// toRat converts a numeric value to an exact rational. Floats are converted
+// from their shortest decimal representation, so 0.1 is exactly 1/10 rather
+// than the nearest binary fraction.
+func (v *Validator) toRat(value interface{}) (*big.Rat, bool) {
+	var text string
+	switch n := value.(type) {
+	case float64:
+		text = strconv.FormatFloat(n, 'f', -1, 64)
+	case float32:
+		text = strconv.FormatFloat(float64(n), 'f', -1, 32)
+	case int, int32, int64:
+		text = fmt.Sprint(n)
+	case string:
+		text = n
+	case json.Number:
+		text = n.String()
 	default:
+		return nil, false
+	}
+	return new(big.Rat).SetString(text)
+}
+
+func (v *Validator) isNegative(value interface{}) bool {
+	r, ok := v.toRat(value)
+	return ok && r.Sign() < 0
+}
+
+// maxCountedDecimalPlaces bounds decimalPlaces for values with long expansions
+const maxCountedDecimalPlaces = 30
+
+// decimalPlaces returns the number of significant digits after the decimal point
+func (v *Validator) decimalPlaces(value interface{}) int {
+	r, ok := v.toRat(value)
+	if !ok {
 		return 0
 	}
+	ten := big.NewRat(10, 1)
+	places := 0
+	for !r.IsInt() && places < maxCountedDecimalPlaces {
+		r.Mul(r, ten)
+		places++
+	}
+	return places
 }
 
 func (v *Validator) isInEnum(value interface{}, enumValues []string) bool {
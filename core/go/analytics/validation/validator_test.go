@@ -0,0 +1,466 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics"
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics/features"
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics/metrics"
+	"github.com/your-github-org/ai-scaffolder/core/go/errors"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"go.uber.org/zap"
+)
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func newTestValidator() *Validator {
+	return NewValidator(Config{Logger: &logger.Logger{Logger: zap.NewNop()}})
+}
+
+func temperatureSchema() *Schema {
+	return &Schema{
+		Name: "telemetry-test",
+		Fields: []SchemaField{
+			{
+				Name:         "temperature",
+				Type:         "float",
+				Required:     true,
+				MinValue:     float64Ptr(-50),
+				MaxValue:     float64Ptr(100),
+				WarnMinValue: float64Ptr(-40),
+				WarnMaxValue: float64Ptr(90),
+			},
+		},
+	}
+}
+
+func TestValidateWithWarnings(t *testing.T) {
+	tests := []struct {
+		name         string
+		temperature  float64
+		wantValid    bool
+		wantWarnings int
+		wantFailures int
+	}{
+		{name: "normal reading", temperature: 20, wantValid: true},
+		{name: "near maximum is accepted with a warning", temperature: 95, wantValid: true, wantWarnings: 1},
+		{name: "near minimum is accepted with a warning", temperature: -45, wantValid: true, wantWarnings: 1},
+		{name: "over maximum is rejected", temperature: 105, wantValid: false, wantFailures: 1},
+		{name: "warning threshold itself is not a warning", temperature: 90, wantValid: true},
+	}
+
+	v := newTestValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateWithWarnings(context.Background(), map[string]interface{}{"temperature": tt.temperature}, temperatureSchema())
+
+			if result.IsValid != tt.wantValid {
+				t.Errorf("IsValid = %v, want %v", result.IsValid, tt.wantValid)
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %v, want %d", result.Warnings, tt.wantWarnings)
+			}
+			if len(result.FailedChecks) != tt.wantFailures {
+				t.Errorf("FailedChecks = %v, want %d", result.FailedChecks, tt.wantFailures)
+			}
+			if err := v.ToServiceError(result); (err == nil) != tt.wantValid {
+				t.Errorf("ToServiceError() = %v, want error only when rejected", err)
+			}
+		})
+	}
+}
+
+func TestValidateWithWarnings_CountsWarnings(t *testing.T) {
+	counter := metrics.DataValidationWarnings.WithLabelValues("telemetry-test", "temperature")
+	before := testutil.ToFloat64(counter)
+
+	newTestValidator().ValidateWithWarnings(context.Background(), map[string]interface{}{"temperature": 95.0}, temperatureSchema())
+
+	if got := testutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("warnings counted = %v, want 1", got)
+	}
+}
+
+func TestValidateSchema_IgnoresSoftBounds(t *testing.T) {
+	result := newTestValidator().ValidateSchema(context.Background(), map[string]interface{}{"temperature": 95.0}, temperatureSchema())
+
+	if !result.IsValid {
+		t.Errorf("IsValid = false, want true (failures %v)", result.FailedChecks)
+	}
+	if result.HasWarnings() {
+		t.Errorf("Warnings = %v, want none from ValidateSchema", result.Warnings)
+	}
+}
+
+func TestValidateSchema_Decimal(t *testing.T) {
+	places := 2
+	schema := &Schema{
+		Fields: []SchemaField{
+			{Name: "amount", Type: "decimal", Required: true, MaxDecimalPlaces: &places, NonNegative: true},
+		},
+	}
+	tenth, fifth := 0.1, 0.2 // variables, so the sum is computed in float64
+
+	tests := []struct {
+		name      string
+		amount    interface{}
+		wantValid bool
+	}{
+		{name: "two places", amount: 12.34, wantValid: true},
+		{name: "whole number", amount: 12, wantValid: true},
+		{name: "decimal string", amount: "0.30", wantValid: true},
+		{name: "json number", amount: json.Number("19.99"), wantValid: true},
+		{name: "float rounding error", amount: tenth + fifth, wantValid: false},
+		{name: "three places", amount: "1.005", wantValid: false},
+		{name: "negative", amount: -0.01, wantValid: false},
+		{name: "not a number", amount: "ten", wantValid: false},
+	}
+
+	v := newTestValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateSchema(context.Background(), map[string]interface{}{"amount": tt.amount}, schema)
+			if result.IsValid != tt.wantValid {
+				t.Errorf("IsValid = %v, want %v (failures %v)", result.IsValid, tt.wantValid, result.FailedChecks)
+			}
+		})
+	}
+}
+
+// devicePayloadSchema has a nested location object and an array of readings
+func devicePayloadSchema() *Schema {
+	return &Schema{
+		Name: "device-payload-test",
+		Fields: []SchemaField{
+			{Name: "deviceId", Type: "string", Required: true},
+			{
+				Name:     "location",
+				Type:     "object",
+				Required: true,
+				Nested: &Schema{Fields: []SchemaField{
+					{Name: "lat", Type: "float", Required: true, MinValue: float64Ptr(-90), MaxValue: float64Ptr(90)},
+					{Name: "lng", Type: "float", Required: true, MinValue: float64Ptr(-180), MaxValue: float64Ptr(180)},
+				}},
+			},
+			{
+				Name:  "readings",
+				Type:  "array",
+				Items: &SchemaField{Type: "float", MinValue: float64Ptr(0), MaxValue: float64Ptr(100)},
+			},
+		},
+	}
+}
+
+func TestValidateSchema_Nested(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		wantChecks []string
+	}{
+		{
+			name:    "valid payload",
+			payload: `{"deviceId": "d-1", "location": {"lat": 51.5, "lng": -0.1}, "readings": [20.5, 21]}`,
+		},
+		{
+			name:       "missing nested required field",
+			payload:    `{"deviceId": "d-1", "location": {"lat": 51.5}}`,
+			wantChecks: []string{"Required field 'location.lng' is missing"},
+		},
+		{
+			name:       "array element out of range",
+			payload:    `{"deviceId": "d-1", "location": {"lat": 51.5, "lng": -0.1}, "readings": [20.5, 150, 21]}`,
+			wantChecks: []string{"Field 'readings[1]' value 150.000000 exceeds maximum 100.000000"},
+		},
+		{
+			name:       "object of the wrong type",
+			payload:    `{"deviceId": "d-1", "location": "London"}`,
+			wantChecks: []string{"Field 'location' has invalid type, expected object"},
+		},
+	}
+
+	v := newTestValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.payload), &data); err != nil {
+				t.Fatalf("invalid test payload: %v", err)
+			}
+
+			result := v.ValidateSchema(context.Background(), data, devicePayloadSchema())
+
+			if result.IsValid != (len(tt.wantChecks) == 0) {
+				t.Errorf("IsValid = %v, failed checks %v", result.IsValid, result.FailedChecks)
+			}
+			if len(result.FailedChecks) != len(tt.wantChecks) {
+				t.Fatalf("FailedChecks = %v, want %v", result.FailedChecks, tt.wantChecks)
+			}
+			for i, want := range tt.wantChecks {
+				if result.FailedChecks[i] != want {
+					t.Errorf("FailedChecks[%d] = %q, want %q", i, result.FailedChecks[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSchema_Pattern(t *testing.T) {
+	schema := &Schema{Fields: []SchemaField{
+		{Name: "deviceId", Type: "string", Required: true, Pattern: `^dev-[0-9]{4}$`},
+	}}
+	if err := schema.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	v := newTestValidator()
+	tests := []struct {
+		name      string
+		deviceID  string
+		wantValid bool
+	}{
+		{name: "match", deviceID: "dev-0042", wantValid: true},
+		{name: "no match", deviceID: "device-42", wantValid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateSchema(context.Background(), map[string]interface{}{"deviceId": tt.deviceID}, schema)
+			if result.IsValid != tt.wantValid {
+				t.Errorf("IsValid = %v, want %v (failed checks %v)", result.IsValid, tt.wantValid, result.FailedChecks)
+			}
+		})
+	}
+
+	// Without Compile the pattern is compiled on first use
+	uncompiled := &Schema{Fields: []SchemaField{{Name: "deviceId", Type: "string", Pattern: `^dev-`}}}
+	if result := v.ValidateSchema(context.Background(), map[string]interface{}{"deviceId": "sensor"}, uncompiled); result.IsValid {
+		t.Error("Expected uncompiled pattern to be applied")
+	}
+}
+
+func TestSchemaCompile_InvalidPattern(t *testing.T) {
+	schema := &Schema{Fields: []SchemaField{
+		{Name: "location", Type: "object", Nested: &Schema{Fields: []SchemaField{
+			{Name: "zone", Type: "string", Pattern: `([a-z`},
+		}}},
+	}}
+
+	err := schema.Compile()
+	if err == nil {
+		t.Fatal("Compile() error = nil, want an invalid pattern error")
+	}
+	if !strings.Contains(err.Error(), "location.zone") {
+		t.Errorf("Compile() error = %v, want it to name location.zone", err)
+	}
+
+	// Validating anyway fails the field rather than panicking
+	result := newTestValidator().ValidateSchema(context.Background(),
+		map[string]interface{}{"location": map[string]interface{}{"zone": "a"}}, schema)
+	if result.IsValid {
+		t.Error("Expected a field with an invalid pattern to fail validation")
+	}
+}
+
+func TestValidateBatch(t *testing.T) {
+	schema := devicePayloadSchema()
+	schema.Name = "batch-test"
+
+	var records []map[string]interface{}
+	for _, payload := range []string{
+		`{"deviceId": "d-1", "location": {"lat": 51.5, "lng": -0.1}, "readings": [20, 21]}`,
+		`{"deviceId": "d-2", "location": {"lat": 48.9, "lng": 2.3}}`,
+		`{"deviceId": "d-3", "location": {"lat": 51.5}, "readings": [150, 200]}`,
+		`{"location": {"lat": 40.7, "lng": -74}}`,
+	} {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(payload), &record); err != nil {
+			t.Fatalf("invalid test payload: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	rangeErrors := metrics.DataValidationErrors.WithLabelValues("batch-test", "range")
+	before := testutil.ToFloat64(rangeErrors)
+
+	batch := newTestValidator().ValidateBatch(context.Background(), records, schema)
+
+	if batch.Total != 4 || batch.Valid != 2 || batch.Invalid != 2 {
+		t.Errorf("Total/Valid/Invalid = %d/%d/%d, want 4/2/2", batch.Total, batch.Valid, batch.Invalid)
+	}
+	if batch.QualityScore != 0.5 {
+		t.Errorf("QualityScore = %v, want 0.5", batch.QualityScore)
+	}
+	want := map[string]int{"readings[]": 2, "location.lng": 1, "deviceId": 1}
+	if len(batch.FieldFailures) != len(want) {
+		t.Errorf("FieldFailures = %v, want %v", batch.FieldFailures, want)
+	}
+	for field, count := range want {
+		if batch.FieldFailures[field] != count {
+			t.Errorf("FieldFailures[%q] = %d, want %d", field, batch.FieldFailures[field], count)
+		}
+	}
+	if len(batch.Results) != 4 || batch.Results[0].IsValid != true || batch.Results[2].IsValid != false {
+		t.Errorf("Results don't match the records in order")
+	}
+
+	if got := testutil.ToFloat64(metrics.DataQualityScore.WithLabelValues("batch-test", "validity")); got != 0.5 {
+		t.Errorf("analytics_data_quality_score = %v, want 0.5", got)
+	}
+	if got := testutil.ToFloat64(rangeErrors) - before; got != 2 {
+		t.Errorf("range validation errors = %v, want 2", got)
+	}
+}
+
+func TestValidateBatch_Empty(t *testing.T) {
+	batch := newTestValidator().ValidateBatch(context.Background(), nil, devicePayloadSchema())
+	if batch.Total != 0 || batch.QualityScore != 1 {
+		t.Errorf("Total = %d, QualityScore = %v; want 0 and 1", batch.Total, batch.QualityScore)
+	}
+}
+
+// outlierSeries returns n readings around 20 with a few spikes
+func outlierSeries(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = 20 + float64((i*7919)%13)/4
+		if i%97 == 0 {
+			values[i] = 60
+		}
+	}
+	return values
+}
+
+func TestDetectOutliers_MatchesFeaturesCalculator(t *testing.T) {
+	calc := features.NewCalculator(features.Config{Logger: &logger.Logger{Logger: zap.NewNop()}})
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, n := range []int{4, 5, 10, 99, 1000} {
+		values := outlierSeries(n)
+		points := make([]features.DataPoint, n)
+		for i, v := range values {
+			points[i] = features.DataPoint{Timestamp: base.Add(time.Duration(i) * time.Minute), Value: v}
+		}
+
+		got := newTestValidator().DetectOutliers(context.Background(), values, 1.5)
+		var want []int
+		for _, p := range calc.DetectOutliers(context.Background(), points, 1.5) {
+			want = append(want, int(p.Timestamp.Sub(base)/time.Minute))
+		}
+
+		if len(got) != len(want) {
+			t.Fatalf("n=%d: validation flagged %v, features flagged %v", n, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("n=%d: validation flagged %v, features flagged %v", n, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestDetectOutliersMAD_HeavyTail(t *testing.T) {
+	// 70 steady readings and a heavy tail of 30 spikes: the spikes push Q3,
+	// and with it the IQR fence, up to where none of them is flagged
+	var values []float64
+	for i := 0; i < 70; i++ {
+		values = append(values, 20+float64(i%5)*0.5)
+	}
+	for i := 0; i < 30; i++ {
+		values = append(values, 80+float64(i))
+	}
+
+	v := newTestValidator()
+	if iqr := v.DetectOutliers(context.Background(), values, 1.5); len(iqr) != 0 {
+		t.Errorf("IQR flagged %d values; expected the tail to mask itself", len(iqr))
+	}
+
+	mad := v.DetectOutliersMAD(context.Background(), values, 3.5)
+	if len(mad) != 30 {
+		t.Fatalf("MAD flagged %d values, want the 30 spikes", len(mad))
+	}
+	for i, idx := range mad {
+		if idx != 70+i {
+			t.Errorf("MAD flagged index %d, want %d", idx, 70+i)
+		}
+	}
+}
+
+func TestDetectOutliersMAD_ZeroMAD(t *testing.T) {
+	v := newTestValidator()
+
+	// Over half the values are identical, so the MAD is zero
+	got := v.DetectOutliersMAD(context.Background(), []float64{5, 5, 5, 5, 5, 6, 50}, 3.5)
+	if len(got) != 1 || got[0] != 6 {
+		t.Errorf("DetectOutliersMAD() = %v, want [6]", got)
+	}
+
+	if got := v.DetectOutliersMAD(context.Background(), []float64{5, 5, 5, 5}, 3.5); len(got) != 0 {
+		t.Errorf("DetectOutliersMAD() on identical values = %v, want none", got)
+	}
+}
+
+func BenchmarkDetectOutliers_5000(b *testing.B) {
+	v := newTestValidator()
+	values := outlierSeries(5000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.DetectOutliers(context.Background(), values, 1.5)
+	}
+}
+
+func TestValidateTimestampWithSkew(t *testing.T) {
+	v := newTestValidator()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		wantValid bool
+		wantCode  string
+	}{
+		{name: "recent", timestamp: now.Add(-time.Minute), wantValid: true},
+		{name: "ahead within skew", timestamp: now.Add(time.Minute), wantValid: true},
+		{name: "ahead beyond skew", timestamp: now.Add(time.Hour), wantCode: "VALIDATION-004"},
+		{name: "too old", timestamp: now.Add(-48 * time.Hour), wantCode: "VALIDATION-005"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateTimestampWithSkew(context.Background(), tt.timestamp, 24*time.Hour, 5*time.Minute)
+			if result.IsValid != tt.wantValid || result.ErrorCode != tt.wantCode {
+				t.Errorf("ValidateTimestampWithSkew() = valid %v code %q, want valid %v code %q",
+					result.IsValid, result.ErrorCode, tt.wantValid, tt.wantCode)
+			}
+		})
+	}
+
+	// Without skew any future timestamp is rejected
+	if result := v.ValidateTimestamp(context.Background(), now.Add(time.Minute), 24*time.Hour); result.IsValid {
+		t.Error("ValidateTimestamp() accepted a future timestamp")
+	}
+}
+
+func TestToServiceError_UsesAnalyticsRegistry(t *testing.T) {
+	v := newTestValidator()
+	result := v.ValidateTimestamp(context.Background(), time.Now().Add(-48*time.Hour), 24*time.Hour)
+
+	err := v.ToServiceError(result)
+	serviceErr, ok := err.(*errors.ServiceError)
+	if !ok {
+		t.Fatalf("ToServiceError() = %T, want *errors.ServiceError", err)
+	}
+	if serviceErr.Code != analytics.ErrCodeStaleTimestamp || serviceErr.Severity != errors.SeverityLow {
+		t.Errorf("error = %s/%s, want %s/%s", serviceErr.Code, serviceErr.Severity, analytics.ErrCodeStaleTimestamp, errors.SeverityLow)
+	}
+	if serviceErr.Message != result.ErrorMessage || serviceErr.Component() != "Analytics" {
+		t.Errorf("message, component = %q, %q; want %q, Analytics", serviceErr.Message, serviceErr.Component(), result.ErrorMessage)
+	}
+	if v.ToServiceError(&ValidationResult{IsValid: true}) != nil {
+		t.Error("ToServiceError() of a valid result should be nil")
+	}
+}
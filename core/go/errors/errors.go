@@ -1,7 +1,13 @@
 package errors
 
 import (
+	"encoding/json"
 	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Severity levels for errors
@@ -35,6 +41,13 @@ func (e *ServiceError) Unwrap() error {
 	return e.Underlying
 }
 
+// Is reports whether target is a *ServiceError with the same code, so
+// errors.Is matches on codes rather than pointer identity
+func (e *ServiceError) Is(target error) bool {
+	t, ok := target.(*ServiceError)
+	return ok && t != nil && e.Code == t.Code
+}
+
 // WithContext adds additional context to the error
 func (e *ServiceError) WithContext(key string, value interface{}) *ServiceError {
 	if e.Context == nil {
@@ -53,18 +66,70 @@ func (e *ServiceError) GetContext(key string) (interface{}, bool) {
 	return val, ok
 }
 
+// ContextKeyComponent is the error context key holding the component that raised the error
+const ContextKeyComponent = "component"
+
+// Component returns the component recorded in the error context, or "" if unset
+func (e *ServiceError) Component() string {
+	if comp, ok := e.GetContext(ContextKeyComponent); ok {
+		if s, ok := comp.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// Hook is invoked for every ServiceError created via New, Wrap, or an ErrorRegistry.
+// Used to record errors (metrics, SOD occurrences) without per-call-site bookkeeping.
+type Hook func(err *ServiceError)
+
+var (
+	hooksMu sync.RWMutex
+	hooks   = map[int]Hook{}
+	nextID  int
+)
+
+// RegisterHook registers a hook called whenever a ServiceError is created.
+// Returns a function that unregisters the hook.
+func RegisterHook(hook Hook) func() {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	id := nextID
+	nextID++
+	hooks[id] = hook
+
+	return func() {
+		hooksMu.Lock()
+		defer hooksMu.Unlock()
+		delete(hooks, id)
+	}
+}
+
+// notifyHooks runs all registered hooks for a newly created error
+func notifyHooks(err *ServiceError) {
+	hooksMu.RLock()
+	defer hooksMu.RUnlock()
+	for _, hook := range hooks {
+		hook(err)
+	}
+}
+
 // New creates a new ServiceError
 func New(code, severity, message string) *ServiceError {
-	return &ServiceError{
-		Code:     code,
-		Message:  message,
-		Severity: severity,
-		Context:  make(map[string]interface{}),
-	}
+	err := newError(nil, code, severity, message)
+	notifyHooks(err)
+	return err
 }
 
 // Wrap wraps an existing error with ServiceError metadata
 func Wrap(err error, code, severity, message string) *ServiceError {
+	serviceErr := newError(err, code, severity, message)
+	notifyHooks(serviceErr)
+	return serviceErr
+}
+
+func newError(err error, code, severity, message string) *ServiceError {
 	return &ServiceError{
 		Code:       code,
 		Message:    message,
@@ -76,20 +141,24 @@ func Wrap(err error, code, severity, message string) *ServiceError {
 
 // ErrorDefinition represents a registered error with SOD scores
 type ErrorDefinition struct {
-	Code        string // Error code (e.g., "INGEST-001")
-	Severity    string // Severity level
-	Description string // Detailed description
-	SODScore    int    // Severity × Occurrence × Detectability (1-1000)
-	Severity_S  int    // Severity score (1-10)
-	Occurrence  int    // Occurrence score (1-10)
-	Detect_D    int    // Detectability score (1-10)
-	Mitigation  string // How to resolve this error
-	Example     string // Example scenario when this error occurs
+	Code        string `json:"code" yaml:"code"`                     // Error code (e.g., "INGEST-001")
+	Severity    string `json:"severity" yaml:"severity"`             // Severity level
+	Description string `json:"description" yaml:"description"`       // Detailed description
+	SODScore    int    `json:"sod_score" yaml:"sod_score"`           // Severity × Occurrence × Detectability (1-1000)
+	Severity_S  int    `json:"severity_score" yaml:"severity_score"` // Severity score (1-10)
+	Occurrence  int    `json:"occurrence" yaml:"occurrence"`         // Occurrence score (1-10)
+	Detect_D    int    `json:"detectability" yaml:"detectability"`   // Detectability score (1-10)
+	Mitigation  string `json:"mitigation" yaml:"mitigation"`         // How to resolve this error
+	Example     string `json:"example" yaml:"example"`               // Example scenario when this error occurs
+
+	// HTTPStatus overrides the status derived from the code (0 = derive)
+	HTTPStatus int `json:"http_status,omitempty" yaml:"http_status,omitempty"`
 }
 
 // ErrorRegistry manages registered error definitions
 type ErrorRegistry struct {
 	definitions map[string]*ErrorDefinition
+	component   string
 }
 
 // NewErrorRegistry creates a new error registry
@@ -99,11 +168,43 @@ func NewErrorRegistry() *ErrorRegistry {
 	}
 }
 
-// Register adds an error definition to the registry
+// WithComponent sets the component stamped into the context of every error
+// created by this registry (used as the "component" metric label)
+func (r *ErrorRegistry) WithComponent(component string) *ErrorRegistry {
+	r.component = component
+	return r
+}
+
+// warnf reports overwritten definitions. Registries are usually filled from
+// init, before any service logger exists, so this uses the standard logger.
+var warnf = log.Printf
+
+// Register adds an error definition to the registry, warning if it replaces
+// an existing definition with the same code
 func (r *ErrorRegistry) Register(def *ErrorDefinition) {
+	if _, exists := r.definitions[def.Code]; exists {
+		warnf("errors: duplicate registration of code %s%s; overwriting previous definition", def.Code, r.componentSuffix())
+	}
 	r.definitions[def.Code] = def
 }
 
+// RegisterStrict adds an error definition, failing if the code is already
+// registered
+func (r *ErrorRegistry) RegisterStrict(def *ErrorDefinition) error {
+	if _, exists := r.definitions[def.Code]; exists {
+		return fmt.Errorf("error code %s is already registered%s", def.Code, r.componentSuffix())
+	}
+	r.definitions[def.Code] = def
+	return nil
+}
+
+func (r *ErrorRegistry) componentSuffix() string {
+	if r.component == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (component %s)", r.component)
+}
+
 // Get retrieves an error definition by code
 func (r *ErrorRegistry) Get(code string) (*ErrorDefinition, bool) {
 	def, ok := r.definitions[code]
@@ -115,34 +216,104 @@ func (r *ErrorRegistry) GetAll() map[string]*ErrorDefinition {
 	return r.definitions
 }
 
-// CreateError creates a ServiceError from a registered error definition
-func (r *ErrorRegistry) CreateError(code string, messageArgs ...interface{}) *ServiceError {
-	def, ok := r.Get(code)
-	if !ok {
-		return New(code, SeverityMedium, fmt.Sprintf("Unknown error: %s", code))
+// GetBySODRange returns the definitions with min <= SODScore <= max, highest
+// score first, e.g. GetBySODRange(200, 1000) for errors that should page
+func (r *ErrorRegistry) GetBySODRange(min, max int) []*ErrorDefinition {
+	return r.filter(func(def *ErrorDefinition) bool {
+		return def.SODScore >= min && def.SODScore <= max
+	})
+}
+
+// GetBySeverity returns the definitions with the given severity level,
+// highest SOD score first
+func (r *ErrorRegistry) GetBySeverity(severity string) []*ErrorDefinition {
+	return r.filter(func(def *ErrorDefinition) bool {
+		return def.Severity == severity
+	})
+}
+
+// filter returns the matching definitions sorted by SOD score descending,
+// then by code so the order is stable
+func (r *ErrorRegistry) filter(match func(*ErrorDefinition) bool) []*ErrorDefinition {
+	var defs []*ErrorDefinition
+	for _, def := range r.definitions {
+		if match(def) {
+			defs = append(defs, def)
+		}
 	}
+	sort.Slice(defs, func(i, j int) bool {
+		if defs[i].SODScore != defs[j].SODScore {
+			return defs[i].SODScore > defs[j].SODScore
+		}
+		return defs[i].Code < defs[j].Code
+	})
+	return defs
+}
 
-	message := def.Description
-	if len(messageArgs) > 0 {
-		message = fmt.Sprintf(def.Description, messageArgs...)
+// ExportJSON serializes every registered definition as a JSON array sorted by
+// code, e.g. to publish the catalog for runbook generation
+func (r *ErrorRegistry) ExportJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(r.sortedByCode(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to export error catalog: %w", err)
 	}
+	return data, nil
+}
 
-	return New(code, def.Severity, message)
+// ExportYAML serializes every registered definition as a YAML list sorted by
+// code
+func (r *ErrorRegistry) ExportYAML() ([]byte, error) {
+	data, err := yaml.Marshal(r.sortedByCode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to export error catalog: %w", err)
+	}
+	return data, nil
+}
+
+func (r *ErrorRegistry) sortedByCode() []*ErrorDefinition {
+	defs := make([]*ErrorDefinition, 0, len(r.definitions))
+	for _, def := range r.definitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Code < defs[j].Code })
+	return defs
+}
+
+// CreateError creates a ServiceError from a registered error definition
+func (r *ErrorRegistry) CreateError(code string, messageArgs ...interface{}) *ServiceError {
+	return r.build(nil, code, messageArgs...)
 }
 
 // WrapError wraps an existing error using a registered error definition
 func (r *ErrorRegistry) WrapError(err error, code string, messageArgs ...interface{}) *ServiceError {
+	return r.build(err, code, messageArgs...)
+}
+
+// build creates the error, stamps the registry component and the definition's
+// HTTP status, then notifies hooks
+func (r *ErrorRegistry) build(err error, code string, messageArgs ...interface{}) *ServiceError {
+	var serviceErr *ServiceError
+
 	def, ok := r.Get(code)
 	if !ok {
-		return Wrap(err, code, SeverityMedium, fmt.Sprintf("Unknown error: %s", code))
+		serviceErr = newError(err, code, SeverityMedium, fmt.Sprintf("Unknown error: %s", code))
+	} else {
+		message := def.Description
+		if len(messageArgs) > 0 {
+			message = fmt.Sprintf(def.Description, messageArgs...)
+		}
+		serviceErr = newError(err, code, def.Severity, message)
+		if def.HTTPStatus != 0 {
+			serviceErr.Context[ContextKeyHTTPStatus] = def.HTTPStatus
+		}
 	}
 
-	message := def.Description
-	if len(messageArgs) > 0 {
-		message = fmt.Sprintf(def.Description, messageArgs...)
+	if r.component != "" {
+		serviceErr.Context[ContextKeyComponent] = r.component
 	}
 
-	return Wrap(err, code, def.Severity, message)
+	notifyHooks(serviceErr)
+	return serviceErr
 }
 
 // CalculateSOD calculates the SOD score (Severity × Occurrence × Detectability)
@@ -1,8 +1,14 @@
 package errors
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestNew(t *testing.T) {
@@ -86,6 +92,35 @@ func TestServiceError_Unwrap(t *testing.T) {
 	}
 }
 
+func TestServiceError_Is(t *testing.T) {
+	notFound := New("TEST-007", SeverityLow, "not found")
+	underlying := errors.New("connection reset")
+	var nilTarget *ServiceError
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"same code, different message", New("TEST-007", SeverityHigh, "order 42 not found"), notFound, true},
+		{"different code", New("TEST-008", SeverityLow, "not found"), notFound, false},
+		{"wrapped with fmt.Errorf", fmt.Errorf("get order: %w", New("TEST-007", SeverityLow, "x")), notFound, true},
+		{"code on underlying ServiceError", Wrap(New("TEST-007", SeverityLow, "x"), "TEST-009", SeverityHigh, "lookup failed"), notFound, true},
+		{"underlying plain error", Wrap(underlying, "TEST-009", SeverityHigh, "lookup failed"), underlying, true},
+		{"non-ServiceError target", New("TEST-007", SeverityLow, "x"), errors.New("[TEST-007] LOW: x"), false},
+		{"nil ServiceError target", New("TEST-007", SeverityLow, "x"), nilTarget, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestServiceError_WithContext(t *testing.T) {
 	err := New("TEST-007", SeverityMedium, "test message")
 
@@ -179,6 +214,42 @@ func TestErrorRegistry_Register(t *testing.T) {
 	}
 }
 
+func TestErrorRegistry_RegisterDuplicate(t *testing.T) {
+	var warnings []string
+	orig := warnf
+	warnf = func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+	defer func() { warnf = orig }()
+
+	registry := NewErrorRegistry().WithComponent("OrderService")
+	first := &ErrorDefinition{Code: "TEST-050", Severity: SeverityHigh, Description: "first"}
+	second := &ErrorDefinition{Code: "TEST-050", Severity: SeverityLow, Description: "second"}
+
+	if err := registry.RegisterStrict(first); err != nil {
+		t.Fatalf("RegisterStrict() first registration error = %v", err)
+	}
+	if err := registry.RegisterStrict(second); err == nil {
+		t.Error("RegisterStrict() duplicate code error = nil, want error")
+	} else if !strings.Contains(err.Error(), "TEST-050") {
+		t.Errorf("RegisterStrict() error = %v, want it to name the code", err)
+	}
+	if def, _ := registry.Get("TEST-050"); def != first {
+		t.Error("RegisterStrict() replaced the existing definition")
+	}
+	if len(warnings) != 0 {
+		t.Errorf("RegisterStrict() logged %v, want no warnings", warnings)
+	}
+
+	registry.Register(second)
+	if def, _ := registry.Get("TEST-050"); def != second {
+		t.Error("Register() did not overwrite the existing definition")
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "TEST-050") || !strings.Contains(warnings[0], "OrderService") {
+		t.Errorf("Register() warnings = %v, want one naming TEST-050 and OrderService", warnings)
+	}
+}
+
 func TestErrorRegistry_Get(t *testing.T) {
 	registry := NewErrorRegistry()
 	def := &ErrorDefinition{
@@ -235,6 +306,120 @@ func TestErrorRegistry_GetAll(t *testing.T) {
 	}
 }
 
+func TestErrorRegistry_GetBySODRange(t *testing.T) {
+	registry := NewErrorRegistry()
+	registry.Register(&ErrorDefinition{Code: "TEST-020", Severity: SeverityLow, SODScore: 199})
+	registry.Register(&ErrorDefinition{Code: "TEST-021", Severity: SeverityHigh, SODScore: 200})
+	registry.Register(&ErrorDefinition{Code: "TEST-022", Severity: SeverityCritical, SODScore: 500})
+	registry.Register(&ErrorDefinition{Code: "TEST-023", Severity: SeverityHigh, SODScore: 200})
+	registry.Register(&ErrorDefinition{Code: "TEST-024", Severity: SeverityCritical, SODScore: 1000})
+
+	tests := []struct {
+		name     string
+		min, max int
+		want     []string
+	}{
+		{"inclusive lower bound", 200, 499, []string{"TEST-021", "TEST-023"}},
+		{"inclusive upper bound", 500, 1000, []string{"TEST-024", "TEST-022"}},
+		{"single score", 199, 199, []string{"TEST-020"}},
+		{"paging threshold", 200, 1000, []string{"TEST-024", "TEST-022", "TEST-021", "TEST-023"}},
+		{"no matches", 201, 499, nil},
+		{"inverted range", 1000, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := codes(registry.GetBySODRange(tt.min, tt.max))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("GetBySODRange(%d, %d) = %v, want %v", tt.min, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorRegistry_GetBySeverity(t *testing.T) {
+	registry := NewErrorRegistry()
+	registry.Register(&ErrorDefinition{Code: "TEST-030", Severity: SeverityHigh, SODScore: 100})
+	registry.Register(&ErrorDefinition{Code: "TEST-031", Severity: SeverityCritical, SODScore: 900})
+	registry.Register(&ErrorDefinition{Code: "TEST-032", Severity: SeverityHigh, SODScore: 300})
+
+	if got := codes(registry.GetBySeverity(SeverityHigh)); strings.Join(got, ",") != "TEST-032,TEST-030" {
+		t.Errorf("GetBySeverity(HIGH) = %v, want [TEST-032 TEST-030]", got)
+	}
+	if got := registry.GetBySeverity(SeverityLow); len(got) != 0 {
+		t.Errorf("GetBySeverity(LOW) = %v, want none", codes(got))
+	}
+}
+
+func codes(defs []*ErrorDefinition) []string {
+	var out []string
+	for _, def := range defs {
+		out = append(out, def.Code)
+	}
+	return out
+}
+
+func TestErrorRegistry_Export(t *testing.T) {
+	registry := NewErrorRegistry()
+	for _, code := range []string{"TEST-042", "TEST-040", "TEST-041"} {
+		registry.Register(&ErrorDefinition{
+			Code:        code,
+			Severity:    SeverityHigh,
+			Description: "Failure in " + code,
+			SODScore:    CalculateSOD(8, 5, 4),
+			Severity_S:  8,
+			Occurrence:  5,
+			Detect_D:    4,
+			Mitigation:  "Retry",
+		})
+	}
+	want := []string{"TEST-040", "TEST-041", "TEST-042"}
+
+	formats := []struct {
+		name      string
+		export    func(*ErrorRegistry) ([]byte, error)
+		unmarshal func([]byte, interface{}) error
+	}{
+		{"json", (*ErrorRegistry).ExportJSON, json.Unmarshal},
+		{"yaml", (*ErrorRegistry).ExportYAML, yaml.Unmarshal},
+	}
+
+	for _, f := range formats {
+		t.Run(f.name, func(t *testing.T) {
+			data, err := f.export(registry)
+			if err != nil {
+				t.Fatalf("export error = %v", err)
+			}
+
+			var defs []*ErrorDefinition
+			if err := f.unmarshal(data, &defs); err != nil {
+				t.Fatalf("unmarshal error = %v\n%s", err, data)
+			}
+			if got := codes(defs); strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("exported codes = %v, want %v", got, want)
+			}
+			for _, def := range defs {
+				if orig, _ := registry.Get(def.Code); !reflect.DeepEqual(def, orig) {
+					t.Errorf("round-tripped %+v, want %+v", def, orig)
+				}
+			}
+
+			// Re-registering the exported definitions must export identically
+			reloaded := NewErrorRegistry()
+			for _, def := range defs {
+				reloaded.Register(def)
+			}
+			again, err := f.export(reloaded)
+			if err != nil {
+				t.Fatalf("re-export error = %v", err)
+			}
+			if string(again) != string(data) {
+				t.Errorf("re-export differs:\n%s\nwant:\n%s", again, data)
+			}
+		})
+	}
+}
+
 func TestErrorRegistry_CreateError(t *testing.T) {
 	registry := NewErrorRegistry()
 	def := &ErrorDefinition{
@@ -357,3 +542,41 @@ func TestCalculateSOD(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterHook(t *testing.T) {
+	var seen []*ServiceError
+	unregister := RegisterHook(func(err *ServiceError) {
+		seen = append(seen, err)
+	})
+
+	registry := NewErrorRegistry().WithComponent("TestComponent")
+	registry.Register(&ErrorDefinition{
+		Code:        "TEST-HOOK-001",
+		Severity:    SeverityHigh,
+		Description: "Hooked error: %v",
+	})
+
+	New("TEST-HOOK-002", SeverityLow, "direct")
+	Wrap(errors.New("cause"), "TEST-HOOK-003", SeverityMedium, "wrapped")
+	registry.CreateError("TEST-HOOK-001", "value")
+	registry.WrapError(errors.New("cause"), "TEST-HOOK-001", "value")
+
+	if len(seen) != 4 {
+		t.Fatalf("hook calls = %v, want 4", len(seen))
+	}
+	if seen[0].Component() != "" {
+		t.Errorf("Component() = %v, want empty for direct error", seen[0].Component())
+	}
+	if seen[2].Component() != "TestComponent" {
+		t.Errorf("Component() = %v, want TestComponent", seen[2].Component())
+	}
+	if seen[3].Underlying == nil {
+		t.Error("hook should see the underlying error for WrapError")
+	}
+
+	unregister()
+	New("TEST-HOOK-004", SeverityLow, "after unregister")
+	if len(seen) != 4 {
+		t.Errorf("hook calls after unregister = %v, want 4", len(seen))
+	}
+}
@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ContextKeyHTTPStatus is the error context key holding the HTTP status set by
+// the error's definition
+const ContextKeyHTTPStatus = "http_status"
+
+// correlationIDHeader matches middleware.CorrelationIDHeader, which sets it on
+// the response before the handler runs
+const correlationIDHeader = "X-Correlation-ID"
+
+// codeCategoryStatus maps a code's category segment (PAT-VAL-001 -> VAL) to
+// an HTTP status for definitions without an explicit HTTPStatus
+var codeCategoryStatus = map[string]int{
+	"VAL":   http.StatusBadRequest,
+	"AUTH":  http.StatusUnauthorized,
+	"INFRA": http.StatusServiceUnavailable,
+}
+
+// HTTPStatus returns the HTTP status for the error: the definition's
+// HTTPStatus if set, else one derived from the code's category (VAL -> 400,
+// AUTH -> 401, INFRA -> 503), else 500
+func (e *ServiceError) HTTPStatus() int {
+	if status, ok := e.GetContext(ContextKeyHTTPStatus); ok {
+		if code, ok := status.(int); ok && code != 0 {
+			return code
+		}
+	}
+	for _, segment := range strings.Split(e.Code, "-") {
+		if status, ok := codeCategoryStatus[segment]; ok {
+			return status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// httpError is the JSON body written by WriteHTTPError
+type httpError struct {
+	Error         string `json:"error"`
+	Code          string `json:"code,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// WriteHTTPError writes err as a JSON error response. If err is, or wraps, a
+// ServiceError its code and HTTPStatus are used; other errors are 500s. The
+// correlation ID is taken from the X-Correlation-ID response header.
+func WriteHTTPError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var serviceErr *ServiceError
+	if errors.As(err, &serviceErr) {
+		status = serviceErr.HTTPStatus()
+	}
+	WriteHTTPErrorStatus(w, err, status)
+}
+
+// WriteHTTPErrorStatus writes err as a JSON error response with the given
+// status, for callers that map errors without a ServiceError themselves. The
+// body matches WriteHTTPError's.
+func WriteHTTPErrorStatus(w http.ResponseWriter, err error, status int) {
+	body := httpError{
+		Error:         err.Error(),
+		CorrelationID: w.Header().Get(correlationIDHeader),
+	}
+	var serviceErr *ServiceError
+	if errors.As(err, &serviceErr) {
+		body.Code = serviceErr.Code
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
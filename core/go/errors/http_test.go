@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServiceError_HTTPStatus(t *testing.T) {
+	registry := NewErrorRegistry()
+	registry.Register(&ErrorDefinition{Code: "TEST-ORD-001", Severity: SeverityLow, Description: "Order not found: %v", HTTPStatus: http.StatusNotFound})
+	registry.Register(&ErrorDefinition{Code: "TEST-INFRA-001", Severity: SeverityCritical, Description: "Database down"})
+	registry.Register(&ErrorDefinition{Code: "TEST-VAL-001", Severity: SeverityLow, Description: "Invalid request", HTTPStatus: http.StatusUnprocessableEntity})
+
+	tests := []struct {
+		name string
+		err  *ServiceError
+		want int
+	}{
+		{"definition status", registry.CreateError("TEST-ORD-001", "42"), http.StatusNotFound},
+		{"definition overrides category", registry.CreateError("TEST-VAL-001"), http.StatusUnprocessableEntity},
+		{"infra category", registry.WrapError(errors.New("dial tcp"), "TEST-INFRA-001"), http.StatusServiceUnavailable},
+		{"validation category", New("SVC-VAL-002", SeverityLow, "missing name"), http.StatusBadRequest},
+		{"auth category", New("SVC-AUTH-001", SeverityMedium, "token expired"), http.StatusUnauthorized},
+		{"unknown category", New("SVC-ORD-009", SeverityHigh, "boom"), http.StatusInternalServerError},
+		{"unregistered code", registry.CreateError("TEST-XYZ-001"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.HTTPStatus(); got != tt.want {
+				t.Errorf("HTTPStatus() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteHTTPError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		correlationID string
+		wantStatus    int
+		wantCode      string
+	}{
+		{
+			name:          "wrapped service error",
+			err:           fmt.Errorf("get order: %w", New("SVC-VAL-001", SeverityLow, "bad id")),
+			correlationID: "corr-123",
+			wantStatus:    http.StatusBadRequest,
+			wantCode:      "SVC-VAL-001",
+		},
+		{
+			name:       "plain error",
+			err:        errors.New("unexpected"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			if tt.correlationID != "" {
+				rec.Header().Set("X-Correlation-ID", tt.correlationID)
+			}
+
+			WriteHTTPError(rec, tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+			if body["error"] != tt.err.Error() || body["code"] != tt.wantCode || body["correlation_id"] != tt.correlationID {
+				t.Errorf("body = %v, want error %q, code %q, correlation_id %q", body, tt.err.Error(), tt.wantCode, tt.correlationID)
+			}
+		})
+	}
+}
+
+func TestWriteHTTPErrorStatus(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("X-Correlation-ID", "corr-123")
+	err := errors.New("order not found")
+
+	WriteHTTPErrorStatus(rec, err, http.StatusNotFound)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	if body["error"] != err.Error() || body["code"] != "" || body["correlation_id"] != "corr-123" {
+		t.Errorf("body = %v, want error %q and the correlation ID", body, err.Error())
+	}
+}
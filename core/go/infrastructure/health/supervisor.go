@@ -0,0 +1,181 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"go.uber.org/zap"
+)
+
+// ReconnectFunc tears down a broken connection and establishes a new one
+type ReconnectFunc func(ctx context.Context) error
+
+// SupervisorConfig holds reconnection supervisor configuration
+type SupervisorConfig struct {
+	Name      string        // Backend name used in logs and status callbacks (e.g. "redis")
+	Check     CheckFunc     // Health check against the current connection
+	Reconnect ReconnectFunc // Re-establishes the connection
+	Logger    *logger.Logger
+
+	Interval       time.Duration // Time between health checks (default 15s)
+	CheckTimeout   time.Duration // Timeout for each check and reconnect attempt (default 5s)
+	InitialBackoff time.Duration // First retry delay after a failed reconnect (default 1s)
+	MaxBackoff     time.Duration // Retry delay cap (default 30s)
+
+	// OnStatusChange is called when the backend transitions between healthy and unhealthy
+	OnStatusChange func(name string, status Status)
+}
+
+// Supervisor periodically checks a backend connection and, when it becomes
+// unhealthy, reconnects with exponential backoff until the backend returns
+type Supervisor struct {
+	cfg    SupervisorConfig
+	logger *logger.ContextLogger
+
+	mu     sync.RWMutex
+	status Status
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewSupervisor creates a reconnection supervisor; call Start to begin monitoring
+func NewSupervisor(cfg SupervisorConfig) *Supervisor {
+	if cfg.Logger == nil {
+		cfg.Logger, _ = logger.NewProduction("health-supervisor", "1.0")
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 15 * time.Second
+	}
+	if cfg.CheckTimeout <= 0 {
+		cfg.CheckTimeout = 5 * time.Second
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		cfg.MaxBackoff = cfg.InitialBackoff
+	}
+
+	return &Supervisor{
+		cfg:    cfg,
+		logger: cfg.Logger.WithComponent("HealthSupervisor"),
+		status: StatusHealthy,
+	}
+}
+
+// Start begins monitoring in the background until ctx is cancelled or Stop is called
+func (s *Supervisor) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		s.run(ctx)
+	}()
+}
+
+// Stop stops monitoring and waits for the supervisor goroutine to exit
+func (s *Supervisor) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+}
+
+// Status returns the last observed status of the backend
+func (s *Supervisor) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+func (s *Supervisor) run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := s.check(ctx)
+		if err == nil {
+			s.setStatus(StatusHealthy)
+			continue
+		}
+
+		s.logger.Warn("Backend unhealthy - reconnecting",
+			zap.String("backend", s.cfg.Name),
+			zap.String("error_code", "INFRA-HEALTH-CHECK-FAILED"),
+			zap.Error(err))
+		s.setStatus(StatusUnhealthy)
+		s.reconnect(ctx)
+	}
+}
+
+// reconnect retries Reconnect with exponential backoff until a reconnect
+// succeeds and the follow-up health check passes, or ctx is cancelled
+func (s *Supervisor) reconnect(ctx context.Context) {
+	backoff := s.cfg.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		reconnectCtx, cancel := context.WithTimeout(ctx, s.cfg.CheckTimeout)
+		err := s.cfg.Reconnect(reconnectCtx)
+		cancel()
+		if err == nil {
+			err = s.check(ctx)
+		}
+
+		if err == nil {
+			s.logger.Info("Backend reconnected",
+				zap.String("backend", s.cfg.Name),
+				zap.Int("attempt", attempt))
+			s.setStatus(StatusHealthy)
+			return
+		}
+
+		s.logger.Warn("Reconnect attempt failed",
+			zap.String("backend", s.cfg.Name),
+			zap.String("error_code", "INFRA-HEALTH-RECONNECT-FAILED"),
+			zap.Int("attempt", attempt),
+			zap.Duration("next_retry", backoff),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > s.cfg.MaxBackoff {
+			backoff = s.cfg.MaxBackoff
+		}
+	}
+}
+
+func (s *Supervisor) check(ctx context.Context) error {
+	checkCtx, cancel := context.WithTimeout(ctx, s.cfg.CheckTimeout)
+	defer cancel()
+	return s.cfg.Check(checkCtx)
+}
+
+func (s *Supervisor) setStatus(status Status) {
+	s.mu.Lock()
+	changed := s.status != status
+	s.status = status
+	s.mu.Unlock()
+
+	if changed && s.cfg.OnStatusChange != nil {
+		s.cfg.OnStatusChange(s.cfg.Name, status)
+	}
+}
@@ -0,0 +1,160 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+)
+
+// fakeBackend simulates a backend whose connection breaks while it is down
+type fakeBackend struct {
+	mu         sync.Mutex
+	down       bool
+	broken     bool // current connection dropped; stays broken until reconnected
+	reconnects int
+}
+
+func (b *fakeBackend) check(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.broken {
+		return errors.New("connection reset by peer")
+	}
+	return nil
+}
+
+func (b *fakeBackend) reconnect(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reconnects++
+	if b.down {
+		return errors.New("connection refused")
+	}
+	b.broken = false
+	return nil
+}
+
+func (b *fakeBackend) setDown(down bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.down = down
+	if down {
+		b.broken = true
+	}
+}
+
+func (b *fakeBackend) reconnectCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.reconnects
+}
+
+// waitFor polls cond until it is true or the timeout elapses
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestSupervisor_RecoversAfterConnectionDrop(t *testing.T) {
+	appLogger, _ := logger.NewProduction("health-test", "1.0.0")
+	defer appLogger.Sync()
+
+	backend := &fakeBackend{}
+
+	var mu sync.Mutex
+	var transitions []Status
+
+	supervisor := NewSupervisor(SupervisorConfig{
+		Name:           "redis",
+		Check:          backend.check,
+		Reconnect:      backend.reconnect,
+		Logger:         appLogger,
+		Interval:       10 * time.Millisecond,
+		CheckTimeout:   50 * time.Millisecond,
+		InitialBackoff: 5 * time.Millisecond,
+		MaxBackoff:     20 * time.Millisecond,
+		OnStatusChange: func(name string, status Status) {
+			mu.Lock()
+			defer mu.Unlock()
+			transitions = append(transitions, status)
+		},
+	})
+	supervisor.Start(context.Background())
+	defer supervisor.Stop()
+
+	// Connection drops and the backend stays down for a while
+	backend.setDown(true)
+	if !waitFor(t, time.Second, func() bool { return supervisor.Status() == StatusUnhealthy }) {
+		t.Fatal("supervisor did not detect the dropped connection")
+	}
+	if !waitFor(t, time.Second, func() bool { return backend.reconnectCount() >= 2 }) {
+		t.Fatal("supervisor did not retry reconnecting while backend was down")
+	}
+	if supervisor.Status() != StatusUnhealthy {
+		t.Errorf("Status() = %v while backend down, want %v", supervisor.Status(), StatusUnhealthy)
+	}
+
+	// Backend returns
+	backend.setDown(false)
+	if !waitFor(t, time.Second, func() bool { return supervisor.Status() == StatusHealthy }) {
+		t.Fatal("supervisor did not recover once the backend returned")
+	}
+	if err := backend.check(context.Background()); err != nil {
+		t.Errorf("connection still broken after recovery: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(transitions) != 2 || transitions[0] != StatusUnhealthy || transitions[1] != StatusHealthy {
+		t.Errorf("transitions = %v, want [unhealthy healthy]", transitions)
+	}
+}
+
+func TestSupervisor_StopHaltsChecks(t *testing.T) {
+	appLogger, _ := logger.NewProduction("health-test", "1.0.0")
+	defer appLogger.Sync()
+
+	var mu sync.Mutex
+	checks := 0
+
+	supervisor := NewSupervisor(SupervisorConfig{
+		Name: "scylladb",
+		Check: func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			checks++
+			return nil
+		},
+		Reconnect: func(ctx context.Context) error { return nil },
+		Logger:    appLogger,
+		Interval:  5 * time.Millisecond,
+	})
+	supervisor.Start(context.Background())
+	time.Sleep(30 * time.Millisecond)
+	supervisor.Stop()
+
+	mu.Lock()
+	stopped := checks
+	mu.Unlock()
+	if stopped == 0 {
+		t.Fatal("no health checks ran before Stop")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if checks != stopped {
+		t.Errorf("checks after Stop = %v, want %v", checks, stopped)
+	}
+}
@@ -15,6 +15,14 @@ type ConsumerConfig struct {
 	Topic   string
 	GroupID string
 	Logger  *logger.Logger
+
+	// FromOldest starts a new consumer group at the oldest retained offset
+	// instead of the newest, e.g. to rebuild state by replaying a topic
+	FromOldest bool
+
+	// ReadCommitted hides messages from aborted or still-open transactions.
+	// Enable it when consuming topics written by a TransactionalProducer.
+	ReadCommitted bool
 }
 
 // MessageHandler processes consumed messages
@@ -138,6 +146,12 @@ func NewConsumer(cfg ConsumerConfig) (Consumer, error) {
 	config.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
 	config.Consumer.Offsets.Initial = sarama.OffsetNewest
 	config.Version = sarama.V2_8_0_0
+	if cfg.FromOldest {
+		config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	}
+	if cfg.ReadCommitted {
+		config.Consumer.IsolationLevel = sarama.ReadCommitted
+	}
 
 	// Create consumer group
 	consumerGroup, err := sarama.NewConsumerGroup(cfg.Brokers, cfg.GroupID, config)
@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/IBM/sarama"
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
@@ -13,6 +14,13 @@ import (
 type ProducerConfig struct {
 	Brokers []string
 	Logger  *logger.Logger
+
+	// Idempotent makes broker-side retries exactly-once per partition
+	// (no duplicates from producer retries). Implied by TransactionalID.
+	Idempotent bool
+
+	// TransactionalID enables Kafka transactions; see NewTransactionalProducer
+	TransactionalID string
 }
 
 // Producer interface for sending messages
@@ -26,6 +34,10 @@ type Producer interface {
 type syncProducer struct {
 	producer sarama.SyncProducer
 	logger   *logger.Logger
+
+	// txnMu serializes Transact: sarama allows one open transaction per
+	// producer, so concurrent transactions would share it
+	txnMu sync.Mutex
 }
 
 // NewProducer creates a new Kafka producer
@@ -52,6 +64,11 @@ func NewProducer(cfg ProducerConfig) (Producer, error) {
 	config.Producer.RequiredAcks = sarama.WaitForAll
 	config.Producer.Retry.Max = 3
 	config.Producer.Compression = sarama.CompressionSnappy
+	if cfg.TransactionalID != "" {
+		transactionalConfig(config, cfg.TransactionalID)
+	} else if cfg.Idempotent {
+		idempotentConfig(config)
+	}
 
 	// Create sync producer
 	producer, err := sarama.NewSyncProducer(cfg.Brokers, config)
@@ -77,8 +94,17 @@ func NewProducer(cfg ProducerConfig) (Producer, error) {
 	}, nil
 }
 
-// SendMessage sends a message to Kafka
+// SendMessage sends a message to Kafka. A transactional producer only sends
+// through Transact and returns ErrSendOutsideTransaction here.
 func (p *syncProducer) SendMessage(ctx context.Context, topic, key string, value []byte, headers map[string]string) error {
+	if p.producer.IsTransactional() {
+		return ErrSendOutsideTransaction
+	}
+	return p.send(ctx, topic, key, value, headers)
+}
+
+// send publishes a message, inside the open transaction if there is one
+func (p *syncProducer) send(ctx context.Context, topic, key string, value []byte, headers map[string]string) error {
 	// Build message headers
 	var recordHeaders []sarama.RecordHeader
 	for k, v := range headers {
@@ -0,0 +1,141 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"go.uber.org/zap"
+)
+
+// TransactionalProducer publishes messages, and optionally consumer offsets,
+// in Kafka transactions. Consumers reading with ReadCommitted see all of a
+// transaction's messages or none of them, upgrading a relay that consumes,
+// publishes and commits offsets from at-least-once to exactly-once.
+type TransactionalProducer interface {
+	Producer
+
+	// Transact runs fn in a transaction. The transaction commits if fn returns
+	// nil and is aborted if fn fails, ctx is cancelled, or the commit fails.
+	// Concurrent calls run one at a time.
+	Transact(ctx context.Context, fn func(tx Transaction) error) error
+}
+
+// Transaction is the scope of a single Kafka transaction
+type Transaction interface {
+	// SendMessage adds a message to the transaction
+	SendMessage(ctx context.Context, topic, key string, value []byte, headers map[string]string) error
+
+	// MarkConsumed commits msg's offset for groupID when the transaction commits
+	MarkConsumed(msg *sarama.ConsumerMessage, groupID string) error
+}
+
+var (
+	// ErrTransactionAborted wraps the cause of an aborted transaction
+	ErrTransactionAborted = errors.New("kafka transaction aborted")
+
+	// ErrSendOutsideTransaction is returned by a transactional producer's
+	// SendMessage; its messages must be sent through Transact
+	ErrSendOutsideTransaction = errors.New("transactional producer can only send inside Transact")
+)
+
+// NewTransactionalProducer creates an idempotent, transactional producer.
+// cfg.TransactionalID is required and must be stable across restarts of the
+// same producer instance so Kafka can fence zombie instances.
+func NewTransactionalProducer(cfg ProducerConfig) (TransactionalProducer, error) {
+	if cfg.TransactionalID == "" {
+		err := fmt.Errorf("Kafka transactional ID cannot be empty")
+		if cfg.Logger != nil {
+			cfg.Logger.WithComponent("KafkaProducer").Error("Invalid configuration - no transactional ID",
+				zap.Error(err),
+				zap.String("error_code", "INFRA-KAFKA-CONFIG-ERROR"))
+		}
+		return nil, err
+	}
+
+	p, err := NewProducer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return p.(*syncProducer), nil
+}
+
+// transactionalConfig applies the settings Kafka requires for transactions
+func transactionalConfig(config *sarama.Config, transactionalID string) {
+	idempotentConfig(config)
+	config.Producer.Transaction.ID = transactionalID
+}
+
+// idempotentConfig applies the settings Kafka requires for idempotent producers:
+// acks=all, a single in-flight request and a broker version with producer IDs
+func idempotentConfig(config *sarama.Config) {
+	config.Version = sarama.V2_8_0_0
+	config.Producer.Idempotent = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Net.MaxOpenRequests = 1
+}
+
+// Transact implements TransactionalProducer
+func (p *syncProducer) Transact(ctx context.Context, fn func(tx Transaction) error) error {
+	if !p.producer.IsTransactional() {
+		return fmt.Errorf("producer is not transactional; set ProducerConfig.TransactionalID")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p.txnMu.Lock()
+	defer p.txnMu.Unlock()
+
+	if err := p.producer.BeginTxn(); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(&transaction{producer: p}); err != nil {
+		return p.abort(err)
+	}
+	// Don't commit work the caller has given up on
+	if err := ctx.Err(); err != nil {
+		return p.abort(err)
+	}
+
+	if err := p.producer.CommitTxn(); err != nil {
+		return p.abort(fmt.Errorf("failed to commit transaction: %w", err))
+	}
+	return nil
+}
+
+// abort rolls back the open transaction so none of its messages become visible
+func (p *syncProducer) abort(cause error) error {
+	if err := p.producer.AbortTxn(); err != nil {
+		if p.logger != nil {
+			p.logger.WithComponent("KafkaProducer").Error("Failed to abort transaction",
+				zap.Error(err),
+				zap.NamedError("cause", cause),
+				zap.String("error_code", "INFRA-KAFKA-TXN-ERROR"))
+		}
+		return fmt.Errorf("%w: %w (abort failed: %v)", ErrTransactionAborted, cause, err)
+	}
+
+	if p.logger != nil {
+		p.logger.WithComponent("KafkaProducer").Warn("Transaction aborted", zap.Error(cause))
+	}
+	return fmt.Errorf("%w: %w", ErrTransactionAborted, cause)
+}
+
+// transaction sends through the producer's open transaction
+type transaction struct {
+	producer *syncProducer
+}
+
+func (t *transaction) SendMessage(ctx context.Context, topic, key string, value []byte, headers map[string]string) error {
+	return t.producer.send(ctx, topic, key, value, headers)
+}
+
+func (t *transaction) MarkConsumed(msg *sarama.ConsumerMessage, groupID string) error {
+	if err := t.producer.producer.AddMessageToTxn(msg, groupID, nil); err != nil {
+		return fmt.Errorf("failed to add consumed offset to transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+)
+
+// txnBroker is a sarama.SyncProducer that models read_committed visibility:
+// messages sent in a transaction become visible only when it commits
+type txnBroker struct {
+	sarama.SyncProducer
+
+	visible   []*sarama.ProducerMessage
+	pending   []*sarama.ProducerMessage
+	offsets   []int64
+	inTxn     bool
+	commitErr error
+	aborts    int
+}
+
+func (b *txnBroker) IsTransactional() bool { return true }
+
+func (b *txnBroker) BeginTxn() error {
+	if b.inTxn {
+		return errors.New("transaction already open")
+	}
+	b.inTxn = true
+	return nil
+}
+
+func (b *txnBroker) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	if !b.inTxn {
+		return 0, 0, errors.New("send outside transaction")
+	}
+	b.pending = append(b.pending, msg)
+	return 0, int64(len(b.pending)), nil
+}
+
+func (b *txnBroker) AddMessageToTxn(msg *sarama.ConsumerMessage, groupID string, metadata *string) error {
+	b.offsets = append(b.offsets, msg.Offset)
+	return nil
+}
+
+func (b *txnBroker) CommitTxn() error {
+	if b.commitErr != nil {
+		return b.commitErr
+	}
+	b.visible = append(b.visible, b.pending...)
+	b.pending, b.inTxn = nil, false
+	return nil
+}
+
+func (b *txnBroker) AbortTxn() error {
+	b.aborts++
+	b.pending, b.offsets, b.inTxn = nil, nil, false
+	return nil
+}
+
+func relay(ctx context.Context, p TransactionalProducer, consumed *sarama.ConsumerMessage, fail error) error {
+	return p.Transact(ctx, func(tx Transaction) error {
+		for _, topic := range []string{"orders.events", "billing.events"} {
+			if err := tx.SendMessage(ctx, topic, "order-1", consumed.Value, nil); err != nil {
+				return err
+			}
+		}
+		if err := tx.MarkConsumed(consumed, "outbox-relay"); err != nil {
+			return err
+		}
+		return fail
+	})
+}
+
+func TestTransact_CommitPublishesAtomically(t *testing.T) {
+	broker := &txnBroker{}
+	producer := &syncProducer{producer: broker}
+	consumed := &sarama.ConsumerMessage{Topic: "outbox", Offset: 41, Value: []byte(`{}`)}
+
+	if err := relay(context.Background(), producer, consumed, nil); err != nil {
+		t.Fatalf("Transact() error = %v", err)
+	}
+	if len(broker.visible) != 2 {
+		t.Errorf("visible messages = %d, want 2", len(broker.visible))
+	}
+	if len(broker.offsets) != 1 || broker.offsets[0] != 41 {
+		t.Errorf("offsets in transaction = %v, want [41]", broker.offsets)
+	}
+}
+
+func TestTransact_FailureLeavesNothingVisible(t *testing.T) {
+	tests := []struct {
+		name      string
+		commitErr error
+		fnErr     error
+		cancel    bool
+	}{
+		{name: "commit fails", commitErr: errors.New("coordinator not available")},
+		{name: "handler fails after sending", fnErr: errors.New("downstream rejected")},
+		{name: "context cancelled before commit", cancel: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			broker := &txnBroker{commitErr: tt.commitErr}
+			producer := &syncProducer{producer: broker}
+			if tt.cancel {
+				producer.producer = &cancellingBroker{txnBroker: broker, cancel: cancel}
+			}
+			consumed := &sarama.ConsumerMessage{Topic: "outbox", Offset: 7, Value: []byte(`{}`)}
+
+			err := relay(ctx, producer, consumed, tt.fnErr)
+			if !errors.Is(err, ErrTransactionAborted) {
+				t.Fatalf("Transact() error = %v, want ErrTransactionAborted", err)
+			}
+			if len(broker.visible) != 0 {
+				t.Errorf("visible messages = %d, want none after a failed transaction", len(broker.visible))
+			}
+			if broker.aborts != 1 || len(broker.offsets) != 0 {
+				t.Errorf("aborts = %d offsets = %v, want 1 abort and no committed offsets", broker.aborts, broker.offsets)
+			}
+		})
+	}
+}
+
+func TestTransact_ConcurrentCallsDoNotShareTransaction(t *testing.T) {
+	broker := &txnBroker{}
+	producer := &syncProducer{producer: broker}
+	ctx := context.Background()
+
+	// Half the callers fail after sending; their messages must be rolled
+	// back without touching the others' transactions
+	const callers = 20
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("order-%d", i)
+			errs[i] = producer.Transact(ctx, func(tx Transaction) error {
+				for _, topic := range []string{"orders.events", "billing.events"} {
+					if err := tx.SendMessage(ctx, topic, key, []byte(`{}`), nil); err != nil {
+						return err
+					}
+					runtime.Gosched()
+				}
+				if i%2 == 1 {
+					return errors.New("downstream rejected")
+				}
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	perKey := make(map[string]int)
+	for _, msg := range broker.visible {
+		key, _ := msg.Key.Encode()
+		perKey[string(key)]++
+	}
+	for i, err := range errs {
+		key := fmt.Sprintf("order-%d", i)
+		if i%2 == 0 {
+			if err != nil {
+				t.Errorf("caller %d Transact() error = %v", i, err)
+			}
+			if perKey[key] != 2 {
+				t.Errorf("visible messages for %s = %d, want 2", key, perKey[key])
+			}
+		} else {
+			if !errors.Is(err, ErrTransactionAborted) {
+				t.Errorf("caller %d Transact() error = %v, want ErrTransactionAborted", i, err)
+			}
+			if perKey[key] != 0 {
+				t.Errorf("visible messages for %s = %d, want 0 after abort", key, perKey[key])
+			}
+		}
+	}
+}
+
+func TestSendMessage_TransactionalProducerRequiresTransact(t *testing.T) {
+	broker := &txnBroker{}
+	producer := &syncProducer{producer: broker}
+
+	err := producer.SendMessage(context.Background(), "orders.events", "order-1", []byte(`{}`), nil)
+	if !errors.Is(err, ErrSendOutsideTransaction) {
+		t.Errorf("SendMessage() error = %v, want ErrSendOutsideTransaction", err)
+	}
+	if len(broker.pending) != 0 || len(broker.visible) != 0 {
+		t.Errorf("messages sent outside a transaction: pending %d, visible %d", len(broker.pending), len(broker.visible))
+	}
+}
+
+// cancellingBroker cancels the caller's context while the transaction is open
+type cancellingBroker struct {
+	*txnBroker
+	cancel context.CancelFunc
+}
+
+func (b *cancellingBroker) AddMessageToTxn(msg *sarama.ConsumerMessage, groupID string, metadata *string) error {
+	b.cancel()
+	return b.txnBroker.AddMessageToTxn(msg, groupID, metadata)
+}
+
+func TestTransact_RequiresTransactionalProducer(t *testing.T) {
+	producer := &syncProducer{producer: mocks.NewSyncProducer(t, nil)}
+
+	err := producer.Transact(context.Background(), func(tx Transaction) error { return nil })
+	if err == nil {
+		t.Error("Transact() on a non-transactional producer error = nil, want error")
+	}
+}
+
+func TestNewTransactionalProducer_RequiresID(t *testing.T) {
+	if _, err := NewTransactionalProducer(ProducerConfig{Brokers: []string{"localhost:9092"}}); err == nil {
+		t.Error("NewTransactionalProducer() without TransactionalID error = nil, want error")
+	}
+}
+
+func TestTransactionalConfig(t *testing.T) {
+	config := sarama.NewConfig()
+	transactionalConfig(config, "outbox-relay-0")
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("transactional config is invalid: %v", err)
+	}
+	if !config.Producer.Idempotent || config.Net.MaxOpenRequests != 1 || config.Producer.RequiredAcks != sarama.WaitForAll {
+		t.Errorf("config = idempotent %v, max open %d, acks %v; want idempotent, 1, WaitForAll",
+			config.Producer.Idempotent, config.Net.MaxOpenRequests, config.Producer.RequiredAcks)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -23,17 +25,32 @@ type CachedClient interface {
 	// SetUserIntegration stores a user's integration secret
 	SetUserIntegration(ctx context.Context, userID string, integrationType IntegrationType, value string, expiresAt *time.Time) error
 
+	// SetUserIntegrations stores several integrations, rolling back on failure
+	SetUserIntegrations(ctx context.Context, userID string, values map[IntegrationType]IntegrationValue) (*BulkIntegrationResult, error)
+
 	// DeleteUserIntegration removes a user's integration secret
 	DeleteUserIntegration(ctx context.Context, userID string, integrationType IntegrationType) error
 
 	// ListUserIntegrations returns all integrations for a user
 	ListUserIntegrations(ctx context.Context, userID string) ([]UserIntegration, error)
 
+	// ListUserIntegrationsPage returns a user's integrations filtered, sorted and paged by opts
+	ListUserIntegrationsPage(ctx context.Context, userID string, opts ListIntegrationsOptions) (*IntegrationPage, error)
+
 	// GetCacheStats returns cache performance metrics
 	GetCacheStats() *CacheStats
 
 	// InvalidateCache invalidates a specific cache entry
 	InvalidateCache(ctx context.Context, key string) error
+
+	// WarmCache caches every secret whose name starts with prefix, returning
+	// how many were cached
+	WarmCache(ctx context.Context, prefix string) (int, error)
+
+	// OnSecretChanged registers fn to be called after a secret matching name
+	// is written. A "*" segment in name matches any one ":"-separated segment,
+	// so "user:*:weather" matches every user's weather key.
+	OnSecretChanged(name string, fn func(old, new *Secret))
 }
 
 // cachedClient implements CachedClient with Redis cache-aside
@@ -44,10 +61,18 @@ type cachedClient struct {
 	cacheTTL    time.Duration
 	cachePrefix string
 
+	// How long not-found secrets are cached; 0 disables negative caching
+	negativeCacheTTL time.Duration
+
+	// Secret change callbacks registered with OnSecretChanged
+	hooksMu sync.RWMutex
+	hooks   []secretHook
+
 	// Cache statistics
-	cacheHits   int64
-	cacheMisses int64
-	lastSync    time.Time
+	cacheHits         int64
+	cacheMisses       int64
+	negativeCacheHits int64
+	lastSync          time.Time
 }
 
 // NewCachedClient creates a new KeyVault client with Redis caching
@@ -88,19 +113,28 @@ func NewCachedClient(cfg CachedClientConfig, log *logger.Logger) (CachedClient,
 		cachePrefix = "keyvault:"
 	}
 
+	negativeCacheTTL := cfg.NegativeCacheTTL
+	if negativeCacheTTL == 0 {
+		negativeCacheTTL = DefaultNegativeCacheTTL
+	} else if negativeCacheTTL < 0 {
+		negativeCacheTTL = 0 // Disabled
+	}
+
 	componentLogger.Info("Cached KeyVault client initialized",
 		zap.Duration("cache_ttl", cfg.CacheTTL),
+		zap.Duration("negative_cache_ttl", negativeCacheTTL),
 		zap.String("cache_prefix", cachePrefix),
 		zap.String("redis_host", cfg.Redis.Host),
 		zap.Int("redis_port", cfg.Redis.Port))
 
 	return &cachedClient{
-		kvClient:    kvClient,
-		redisClient: redisClient,
-		logger:      componentLogger,
-		cacheTTL:    cfg.CacheTTL,
-		cachePrefix: cachePrefix,
-		lastSync:    time.Now(),
+		kvClient:         kvClient,
+		redisClient:      redisClient,
+		logger:           componentLogger,
+		cacheTTL:         cfg.CacheTTL,
+		cachePrefix:      cachePrefix,
+		negativeCacheTTL: negativeCacheTTL,
+		lastSync:         time.Now(),
 	}, nil
 }
 
@@ -109,6 +143,30 @@ func (c *cachedClient) cacheKey(name string) string {
 	return c.cachePrefix + name
 }
 
+// versionCacheKey generates the cache key for a specific secret version
+func (c *cachedClient) versionCacheKey(name, version string) string {
+	return c.cachePrefix + name + "/versions/" + version
+}
+
+// Tags written by SetUserIntegration, used when the vault doesn't report
+// CreatedOn/ExpiresOn (e.g. the emulator) so every integration reports both
+const (
+	tagConfiguredAt = "configured_at"
+	tagExpiresAt    = "expires_at"
+)
+
+// integrationCatalogue lists all integration types in their default display order
+var integrationCatalogue = []IntegrationType{
+	IntegrationWeather,
+	IntegrationGoogleHome,
+	IntegrationAlexa,
+	IntegrationIFTTT,
+	IntegrationEnergy,
+	IntegrationSMS,
+	IntegrationMQTT,
+	IntegrationSmartThings,
+}
+
 // userIntegrationKey generates a secret name for user integrations
 func userIntegrationKey(userID string, integrationType IntegrationType) string {
 	return fmt.Sprintf("user:%s:%s", userID, integrationType)
@@ -116,76 +174,228 @@ func userIntegrationKey(userID string, integrationType IntegrationType) string {
 
 // GetSecret retrieves a secret with cache-aside pattern
 func (c *cachedClient) GetSecret(ctx context.Context, name string) (*Secret, error) {
+	return c.getCached(ctx, c.cacheKey(name), name, func() (*Secret, error) {
+		return c.kvClient.GetSecret(ctx, name)
+	})
+}
+
+// GetSecretVersion retrieves a specific secret version with cache-aside
+// pattern. Versions are cached separately from the latest value, so a pinned
+// version is never replaced by a GetSecret fetch.
+func (c *cachedClient) GetSecretVersion(ctx context.Context, name, version string) (*Secret, error) {
+	if version == "" {
+		return nil, fmt.Errorf("secret version cannot be empty")
+	}
+	return c.getCached(ctx, c.versionCacheKey(name, version), name, func() (*Secret, error) {
+		return c.kvClient.GetSecretVersion(ctx, name, version)
+	})
+}
+
+// ListSecretVersions returns a secret's versions, newest first
+func (c *cachedClient) ListSecretVersions(ctx context.Context, name string) ([]SecretVersionInfo, error) {
+	// List operations bypass cache - go directly to KeyVault
+	return c.kvClient.ListSecretVersions(ctx, name)
+}
+
+// getCached returns the secret cached at cacheKey, calling fetch and caching
+// the result on a miss
+func (c *cachedClient) getCached(ctx context.Context, cacheKey, name string, fetch func() (*Secret, error)) (*Secret, error) {
 	start := time.Now()
-	cacheKey := c.cacheKey(name)
 
 	// Try cache first
+	if secret, hit := c.readCache(ctx, cacheKey, name); hit {
+		c.logger.Debug("Cache hit",
+			zap.String("secret_name", name),
+			zap.Bool("not_found", secret == nil),
+			zap.Duration("duration", time.Since(start)))
+		return secret, nil
+	}
+
+	// Cache miss - fetch from KeyVault
+	secret, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.writeCache(ctx, cacheKey, name, secret)
+	if secret == nil {
+		return nil, nil // Not found
+	}
+
+	c.logger.Debug("Cache miss - fetched from KeyVault",
+		zap.String("secret_name", name),
+		zap.Duration("duration", time.Since(start)))
+
+	return secret, nil
+}
+
+// GetSecrets retrieves several secrets, reading each from cache first and
+// fetching only the misses from KeyVault in one concurrent batch
+func (c *cachedClient) GetSecrets(ctx context.Context, names []string) (map[string]*Secret, error) {
+	start := time.Now()
+
+	secrets := make(map[string]*Secret, len(names))
+	seen := make(map[string]bool, len(names))
+	var misses []string
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if secret, hit := c.readCache(ctx, c.cacheKey(name), name); hit {
+			if secret != nil {
+				secrets[name] = secret
+			}
+			continue
+		}
+		misses = append(misses, name)
+	}
+
+	var err error
+	if len(misses) > 0 {
+		var fetched map[string]*Secret
+		fetched, err = c.kvClient.GetSecrets(ctx, misses)
+		for name, secret := range fetched {
+			c.writeCache(ctx, c.cacheKey(name), name, secret)
+			secrets[name] = secret
+		}
+		// On success, a miss that wasn't fetched doesn't exist. After a
+		// partial failure we can't tell which names were missing.
+		if err == nil {
+			for _, name := range misses {
+				if fetched[name] == nil {
+					c.writeCache(ctx, c.cacheKey(name), name, nil)
+				}
+			}
+		}
+	}
+
+	c.logger.Debug("Batch secret fetch",
+		zap.Int("requested", len(names)),
+		zap.Int("cache_misses", len(misses)),
+		zap.Duration("duration", time.Since(start)))
+
+	return secrets, err
+}
+
+// WarmCache lists the secrets matching prefix and caches each of them, so the
+// first reads after startup don't all go to KeyVault. Secrets are fetched
+// concurrently (ClientConfig.BatchConcurrency). Secrets fetched before a
+// failure or cancellation stay cached and are included in the count.
+func (c *cachedClient) WarmCache(ctx context.Context, prefix string) (int, error) {
+	start := time.Now()
+
+	names, err := c.kvClient.ListSecrets(ctx, prefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list secrets to warm: %w", err)
+	}
+
+	fetched, err := c.kvClient.GetSecrets(ctx, names)
+
+	// Keep what was fetched even if ctx was cancelled mid-batch
+	writeCtx := context.WithoutCancel(ctx)
+	warmed := 0
+	for name, secret := range fetched {
+		c.writeCache(writeCtx, c.cacheKey(name), name, secret)
+		warmed++
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err = ctxErr
+	}
+	if err != nil {
+		c.logger.Warn("Cache warming incomplete",
+			zap.Error(err),
+			zap.String("prefix", prefix),
+			zap.Int("secrets", len(names)),
+			zap.Int("warmed", warmed))
+		return warmed, fmt.Errorf("failed to warm cache: %w", err)
+	}
+
+	c.logger.Info("Cache warmed",
+		zap.String("prefix", prefix),
+		zap.Int("warmed", warmed),
+		zap.Duration("duration", time.Since(start)))
+	return warmed, nil
+}
+
+// notFoundMarker is cached in place of a secret that doesn't exist. Secrets
+// are cached as JSON objects, so it can't collide with one.
+const notFoundMarker = "__keyvault_not_found__"
+
+// readCache looks up cacheKey. hit reports whether the cache answered; a hit
+// with a nil secret means the secret is cached as not found. Cache errors are
+// logged and treated as misses so callers fall back to KeyVault.
+func (c *cachedClient) readCache(ctx context.Context, cacheKey, name string) (secret *Secret, hit bool) {
 	cached, err := c.redisClient.Get(ctx, cacheKey)
 	if err != nil {
 		c.logger.Warn("Cache read failed, falling back to KeyVault",
 			zap.Error(err),
 			zap.String("secret_name", name),
 			zap.String("error_code", ErrCodeCacheReadFailed))
-	} else if cached != "" {
-		// Cache hit
+	} else if cached == notFoundMarker {
 		atomic.AddInt64(&c.cacheHits, 1)
-
+		atomic.AddInt64(&c.negativeCacheHits, 1)
+		return nil, true
+	} else if cached != "" {
 		var secret Secret
 		if err := json.Unmarshal([]byte(cached), &secret); err != nil {
 			c.logger.Warn("Failed to unmarshal cached secret",
 				zap.Error(err),
 				zap.String("secret_name", name))
 		} else {
-			c.logger.Debug("Cache hit",
-				zap.String("secret_name", name),
-				zap.Duration("duration", time.Since(start)))
-			return &secret, nil
+			atomic.AddInt64(&c.cacheHits, 1)
+			return &secret, true
 		}
 	}
 
-	// Cache miss - fetch from KeyVault
 	atomic.AddInt64(&c.cacheMisses, 1)
+	return nil, false
+}
 
-	secret, err := c.kvClient.GetSecret(ctx, name)
-	if err != nil {
-		return nil, err
-	}
-
+// writeCache stores a secret at cacheKey for the cache TTL. A nil secret is
+// cached as not found for the negative cache TTL, unless negative caching is
+// disabled. Failures are logged; the secret will be fetched from KeyVault
+// again next time.
+func (c *cachedClient) writeCache(ctx context.Context, cacheKey, name string, secret *Secret) {
+	value, ttl := notFoundMarker, c.negativeCacheTTL
 	if secret == nil {
-		return nil, nil // Not found
-	}
-
-	// Store in cache
-	secretJSON, err := json.Marshal(secret)
-	if err != nil {
-		c.logger.Warn("Failed to marshal secret for caching",
-			zap.Error(err),
-			zap.String("secret_name", name))
+		if ttl <= 0 {
+			return
+		}
 	} else {
-		if err := c.redisClient.Set(ctx, cacheKey, string(secretJSON)); err != nil {
-			c.logger.Warn("Failed to cache secret",
+		secretJSON, err := json.Marshal(secret)
+		if err != nil {
+			c.logger.Warn("Failed to marshal secret for caching",
 				zap.Error(err),
-				zap.String("secret_name", name),
-				zap.String("error_code", ErrCodeCacheWriteFailed))
-		} else {
-			// Set TTL
-			if err := c.redisClient.Expire(ctx, cacheKey, c.cacheTTL); err != nil {
-				c.logger.Warn("Failed to set cache TTL",
-					zap.Error(err),
-					zap.String("secret_name", name))
-			}
+				zap.String("secret_name", name))
+			return
 		}
+		value, ttl = string(secretJSON), c.cacheTTL
 	}
 
-	c.logger.Debug("Cache miss - fetched from KeyVault",
-		zap.String("secret_name", name),
-		zap.Duration("duration", time.Since(start)))
-
-	return secret, nil
+	if err := c.redisClient.SetWithTTL(ctx, cacheKey, value, ttl); err != nil {
+		c.logger.Warn("Failed to cache secret",
+			zap.Error(err),
+			zap.String("secret_name", name),
+			zap.String("error_code", ErrCodeCacheWriteFailed))
+	}
 }
 
-// SetSecret stores a secret and invalidates cache
+// SetSecret stores a secret, invalidates cache and notifies change callbacks
 func (c *cachedClient) SetSecret(ctx context.Context, name string, value string, tags map[string]string) error {
+	// Callbacks receive the previous secret, so read it before writing
+	hooks := c.matchingHooks(name)
+	var old *Secret
+	if len(hooks) > 0 {
+		var err error
+		if old, err = c.kvClient.GetSecret(ctx, name); err != nil {
+			c.logger.Warn("Failed to read previous secret, change callbacks get nil",
+				zap.Error(err),
+				zap.String("secret_name", name))
+		}
+	}
+
 	// Write to KeyVault first
 	if err := c.kvClient.SetSecret(ctx, name, value, tags); err != nil {
 		return err
@@ -201,19 +411,98 @@ func (c *cachedClient) SetSecret(ctx context.Context, name string, value string,
 	}
 
 	c.lastSync = time.Now()
+
+	if len(hooks) > 0 {
+		updatedOn := c.lastSync
+		updated := &Secret{Name: name, Value: value, Enabled: true, Tags: tags, UpdatedOn: &updatedOn}
+		c.notifySecretChanged(hooks, old, updated)
+	}
 	return nil
 }
 
+// secretHook is a change callback and the secret name pattern it watches
+type secretHook struct {
+	pattern []string
+	fn      func(old, new *Secret)
+}
+
+// OnSecretChanged registers a callback for writes to secrets matching name
+func (c *cachedClient) OnSecretChanged(name string, fn func(old, new *Secret)) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.hooks = append(c.hooks, secretHook{pattern: strings.Split(name, ":"), fn: fn})
+}
+
+// matchingHooks returns the callbacks whose pattern matches a secret name
+func (c *cachedClient) matchingHooks(name string) []secretHook {
+	c.hooksMu.RLock()
+	defer c.hooksMu.RUnlock()
+	if len(c.hooks) == 0 {
+		return nil
+	}
+
+	segments := strings.Split(name, ":")
+	var matched []secretHook
+	for _, hook := range c.hooks {
+		if matchSecretPattern(hook.pattern, segments) {
+			matched = append(matched, hook)
+		}
+	}
+	return matched
+}
+
+// matchSecretPattern reports whether name segments match pattern segments,
+// where a "*" pattern segment matches any single name segment
+func matchSecretPattern(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// notifySecretChanged runs each callback in its own goroutine so a slow or
+// panicking handler can't block or crash the writer
+func (c *cachedClient) notifySecretChanged(hooks []secretHook, old, updated *Secret) {
+	for _, hook := range hooks {
+		go func(fn func(old, new *Secret)) {
+			defer func() {
+				if r := recover(); r != nil {
+					c.logger.Error("Secret change callback panicked",
+						zap.Any("panic", r),
+						zap.String("secret_name", updated.Name))
+				}
+			}()
+			fn(old, updated)
+		}(hook.fn)
+	}
+}
+
 // DeleteSecret removes a secret and invalidates cache
 func (c *cachedClient) DeleteSecret(ctx context.Context, name string) error {
+	// Deleting removes every version, so note which ones may be cached
+	versions, err := c.kvClient.ListSecretVersions(ctx, name)
+	if err != nil {
+		c.logger.Warn("Failed to list secret versions, cached versions will expire by TTL",
+			zap.Error(err),
+			zap.String("secret_name", name))
+	}
+
 	// Delete from KeyVault first
 	if err := c.kvClient.DeleteSecret(ctx, name); err != nil {
 		return err
 	}
 
 	// Invalidate cache
-	cacheKey := c.cacheKey(name)
-	if err := c.redisClient.Del(ctx, cacheKey); err != nil {
+	cacheKeys := []string{c.cacheKey(name)}
+	for _, v := range versions {
+		cacheKeys = append(cacheKeys, c.versionCacheKey(name, v.Version))
+	}
+	if err := c.redisClient.Del(ctx, cacheKeys...); err != nil {
 		c.logger.Warn("Failed to invalidate cache after delete",
 			zap.Error(err),
 			zap.String("secret_name", name),
@@ -251,13 +540,13 @@ func (c *cachedClient) GetUserIntegration(ctx context.Context, userID string, in
 		Type:         integrationType,
 		Status:       StatusConnected,
 		MaskedKey:    maskSecret(secret.Value),
-		ConfiguredAt: secret.CreatedOn,
-		ExpiresAt:    secret.ExpiresOn,
+		ConfiguredAt: tagTime(secret.Tags, tagConfiguredAt, secret.CreatedOn),
+		ExpiresAt:    tagTime(secret.Tags, tagExpiresAt, secret.ExpiresOn),
 		Metadata:     secret.Tags,
 	}
 
 	// Check if expired
-	if secret.ExpiresOn != nil && secret.ExpiresOn.Before(time.Now()) {
+	if integration.ExpiresAt != nil && integration.ExpiresAt.Before(time.Now()) {
 		integration.Status = StatusExpired
 	}
 
@@ -271,6 +560,10 @@ func (c *cachedClient) SetUserIntegration(ctx context.Context, userID string, in
 	tags := map[string]string{
 		"user_id":          userID,
 		"integration_type": string(integrationType),
+		tagConfiguredAt:    time.Now().UTC().Format(time.RFC3339),
+	}
+	if expiresAt != nil {
+		tags[tagExpiresAt] = expiresAt.UTC().Format(time.RFC3339)
 	}
 
 	if err := c.SetSecret(ctx, secretName, value, tags); err != nil {
@@ -285,6 +578,122 @@ func (c *cachedClient) SetUserIntegration(ctx context.Context, userID string, in
 	return nil
 }
 
+// SetUserIntegrations stores several integrations for a user as a unit. Values
+// are validated before anything is written; if a write fails, the integrations
+// already written are restored to their previous state (best effort) and the
+// rest are skipped. The cache is invalidated for every touched key either way.
+func (c *cachedClient) SetUserIntegrations(ctx context.Context, userID string, values map[IntegrationType]IntegrationValue) (*BulkIntegrationResult, error) {
+	result := &BulkIntegrationResult{
+		Failed:         make(map[IntegrationType]error),
+		RollbackFailed: make(map[IntegrationType]error),
+	}
+
+	types := make([]IntegrationType, 0, len(values))
+	for intType, value := range values {
+		if value.Value == "" {
+			return result, fmt.Errorf("integration %s: value cannot be empty", intType)
+		}
+		types = append(types, intType)
+	}
+	sortIntegrationTypes(types)
+
+	type written struct {
+		intType  IntegrationType
+		previous *Secret // nil when the integration was not configured before
+	}
+	var done []written
+	var touched []string
+	var failure error
+
+	for i, intType := range types {
+		secretName := userIntegrationKey(userID, intType)
+
+		// Snapshot from KeyVault, not the cache, so a rollback restores the real state
+		previous, err := c.kvClient.GetSecret(ctx, secretName)
+		if err == nil {
+			touched = append(touched, c.cacheKey(secretName))
+			value := values[intType]
+			err = c.SetUserIntegration(ctx, userID, intType, value.Value, value.ExpiresAt)
+		}
+		if err != nil {
+			result.Failed[intType] = err
+			result.Skipped = append(result.Skipped, types[i+1:]...)
+			failure = fmt.Errorf("failed to set integration %s: %w", intType, err)
+			break
+		}
+
+		result.Succeeded = append(result.Succeeded, intType)
+		done = append(done, written{intType: intType, previous: previous})
+	}
+
+	if failure != nil {
+		// Roll back in reverse order of writing
+		for i := len(done) - 1; i >= 0; i-- {
+			secretName := userIntegrationKey(userID, done[i].intType)
+
+			var err error
+			if done[i].previous == nil {
+				err = c.kvClient.DeleteSecret(ctx, secretName)
+			} else {
+				err = c.kvClient.SetSecret(ctx, secretName, done[i].previous.Value, done[i].previous.Tags)
+			}
+			if err != nil {
+				c.logger.Error("Failed to roll back user integration",
+					zap.Error(err),
+					zap.String("user_id", userID),
+					zap.String("integration_type", string(done[i].intType)),
+					zap.String("error_code", ErrCodeSecretSetFailed))
+				result.RollbackFailed[done[i].intType] = err
+				continue
+			}
+			result.RolledBack = append(result.RolledBack, done[i].intType)
+		}
+	}
+
+	if len(touched) > 0 {
+		if err := c.redisClient.Del(ctx, touched...); err != nil {
+			c.logger.Warn("Failed to invalidate cache after bulk integration update",
+				zap.Error(err),
+				zap.String("user_id", userID),
+				zap.String("error_code", ErrCodeCacheInvalidate))
+		}
+	}
+
+	if failure != nil {
+		c.logger.Warn("Bulk integration update rolled back",
+			zap.Error(failure),
+			zap.String("user_id", userID),
+			zap.Int("rolled_back", len(result.RolledBack)),
+			zap.Int("rollback_failed", len(result.RollbackFailed)))
+		return result, failure
+	}
+
+	c.logger.Info("User integrations configured",
+		zap.String("user_id", userID),
+		zap.Int("count", len(result.Succeeded)))
+
+	return result, nil
+}
+
+// sortIntegrationTypes orders types by catalogue position, unknown types last by name
+func sortIntegrationTypes(types []IntegrationType) {
+	position := make(map[IntegrationType]int, len(integrationCatalogue))
+	for i, intType := range integrationCatalogue {
+		position[intType] = i
+	}
+	sort.Slice(types, func(i, j int) bool {
+		pi, iKnown := position[types[i]]
+		pj, jKnown := position[types[j]]
+		if iKnown != jKnown {
+			return iKnown
+		}
+		if iKnown {
+			return pi < pj
+		}
+		return types[i] < types[j]
+	})
+}
+
 // DeleteUserIntegration removes a user's integration secret
 func (c *cachedClient) DeleteUserIntegration(ctx context.Context, userID string, integrationType IntegrationType) error {
 	secretName := userIntegrationKey(userID, integrationType)
@@ -300,8 +709,30 @@ func (c *cachedClient) DeleteUserIntegration(ctx context.Context, userID string,
 	return nil
 }
 
-// ListUserIntegrations returns all integrations for a user
+// ListUserIntegrations returns all integrations for a user in catalogue order
 func (c *cachedClient) ListUserIntegrations(ctx context.Context, userID string) ([]UserIntegration, error) {
+	page, err := c.ListUserIntegrationsPage(ctx, userID, ListIntegrationsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return page.Integrations, nil
+}
+
+// ListUserIntegrationsPage returns a user's integrations filtered, sorted and paged by opts
+func (c *cachedClient) ListUserIntegrationsPage(ctx context.Context, userID string, opts ListIntegrationsOptions) (*IntegrationPage, error) {
+	if opts.SortBy == "" {
+		opts.SortBy = SortByType
+	}
+	if opts.SortBy != SortByType && opts.SortBy != SortByConfiguredAt {
+		return nil, fmt.Errorf("unsupported integration sort field %q", opts.SortBy)
+	}
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+	if opts.Limit < 0 {
+		opts.Limit = 0
+	}
+
 	prefix := fmt.Sprintf("user:%s:", userID)
 
 	secretNames, err := c.ListSecrets(ctx, prefix)
@@ -309,18 +740,6 @@ func (c *cachedClient) ListUserIntegrations(ctx context.Context, userID string)
 		return nil, err
 	}
 
-	// All possible integration types
-	allTypes := []IntegrationType{
-		IntegrationWeather,
-		IntegrationGoogleHome,
-		IntegrationAlexa,
-		IntegrationIFTTT,
-		IntegrationEnergy,
-		IntegrationSMS,
-		IntegrationMQTT,
-		IntegrationSmartThings,
-	}
-
 	// Build a map of configured integrations
 	configuredMap := make(map[IntegrationType]bool)
 	for _, name := range secretNames {
@@ -332,30 +751,84 @@ func (c *cachedClient) ListUserIntegrations(ctx context.Context, userID string)
 		}
 	}
 
-	// Build full list with status
-	var integrations []UserIntegration
-	for _, intType := range allTypes {
+	wantStatus := make(map[IntegrationStatus]bool, len(opts.Statuses))
+	for _, status := range opts.Statuses {
+		wantStatus[status] = true
+	}
+
+	// Build full list with status, in catalogue order
+	integrations := []UserIntegration{}
+	for _, intType := range integrationCatalogue {
+		integration := UserIntegration{Type: intType, Status: StatusNotConfigured}
+
 		if configuredMap[intType] {
 			// Get full integration details
-			integration, err := c.GetUserIntegration(ctx, userID, intType)
+			details, err := c.GetUserIntegration(ctx, userID, intType)
 			if err != nil {
 				c.logger.Warn("Failed to get integration details",
 					zap.Error(err),
 					zap.String("user_id", userID),
 					zap.String("integration_type", string(intType)))
-				continue
+				integration.Status = StatusError
+			} else {
+				integration = *details
 			}
-			integrations = append(integrations, *integration)
-		} else {
-			// Not configured
-			integrations = append(integrations, UserIntegration{
-				Type:   intType,
-				Status: StatusNotConfigured,
-			})
+		}
+
+		if len(wantStatus) > 0 && !wantStatus[integration.Status] {
+			continue
+		}
+		integrations = append(integrations, integration)
+	}
+
+	if opts.SortBy == SortByConfiguredAt {
+		sortByConfiguredAt(integrations, opts.Descending)
+	} else if opts.Descending {
+		for i, j := 0, len(integrations)-1; i < j; i, j = i+1, j-1 {
+			integrations[i], integrations[j] = integrations[j], integrations[i]
 		}
 	}
 
-	return integrations, nil
+	page := &IntegrationPage{
+		Integrations: []UserIntegration{},
+		Total:        len(integrations),
+		Offset:       opts.Offset,
+		Limit:        opts.Limit,
+	}
+	if opts.Offset < len(integrations) {
+		end := len(integrations)
+		if opts.Limit > 0 && opts.Offset+opts.Limit < end {
+			end = opts.Offset + opts.Limit
+		}
+		page.Integrations = integrations[opts.Offset:end]
+	}
+
+	return page, nil
+}
+
+// sortByConfiguredAt orders integrations by ConfiguredAt; integrations without
+// a configured time always sort last and ties keep catalogue order
+func sortByConfiguredAt(integrations []UserIntegration, descending bool) {
+	sort.SliceStable(integrations, func(i, j int) bool {
+		a, b := integrations[i].ConfiguredAt, integrations[j].ConfiguredAt
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		if descending {
+			return a.After(*b)
+		}
+		return a.Before(*b)
+	})
+}
+
+// tagTime parses an RFC3339 tag, falling back when the tag is absent or invalid
+func tagTime(tags map[string]string, key string, fallback *time.Time) *time.Time {
+	if value, ok := tags[key]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return &t
+		}
+	}
+	return fallback
 }
 
 // GetCacheStats returns cache performance metrics
@@ -370,10 +843,11 @@ func (c *cachedClient) GetCacheStats() *CacheStats {
 	}
 
 	return &CacheStats{
-		Hits:     hits,
-		Misses:   misses,
-		HitRate:  hitRate,
-		LastSync: c.lastSync,
+		Hits:         hits,
+		Misses:       misses,
+		NegativeHits: atomic.LoadInt64(&c.negativeCacheHits),
+		HitRate:      hitRate,
+		LastSync:     c.lastSync,
 	}
 }
 
@@ -2,9 +2,14 @@ package keyvault
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/redis"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
 )
 
 // =============================================================================
@@ -14,6 +19,7 @@ import (
 // MockKeyVaultClient for testing cached client
 type MockKeyVaultClient struct {
 	secrets    map[string]*Secret
+	versions   map[string][]*Secret // Oldest first
 	getCount   int64
 	setCount   int64
 	delCount   int64
@@ -23,7 +29,8 @@ type MockKeyVaultClient struct {
 
 func NewMockKeyVaultClient() *MockKeyVaultClient {
 	return &MockKeyVaultClient{
-		secrets: make(map[string]*Secret),
+		secrets:  make(map[string]*Secret),
+		versions: make(map[string][]*Secret),
 	}
 }
 
@@ -39,6 +46,10 @@ func (m *MockKeyVaultClient) GetSecret(ctx context.Context, name string) (*Secre
 	return secret, nil
 }
 
+func (m *MockKeyVaultClient) GetSecrets(ctx context.Context, names []string) (map[string]*Secret, error) {
+	return fetchSecrets(ctx, names, DefaultBatchConcurrency, m.GetSecret)
+}
+
 func (m *MockKeyVaultClient) SetSecret(ctx context.Context, name string, value string, tags map[string]string) error {
 	atomic.AddInt64(&m.setCount, 1)
 	if m.shouldFail {
@@ -48,20 +59,54 @@ func (m *MockKeyVaultClient) SetSecret(ctx context.Context, name string, value s
 	m.secrets[name] = &Secret{
 		Name:      name,
 		Value:     value,
+		Version:   fmt.Sprintf("v%d", len(m.versions[name])+1),
 		Tags:      tags,
 		Enabled:   true,
 		CreatedOn: &now,
 		UpdatedOn: &now,
 	}
+	m.versions[name] = append(m.versions[name], m.secrets[name])
 	return nil
 }
 
+func (m *MockKeyVaultClient) GetSecretVersion(ctx context.Context, name, version string) (*Secret, error) {
+	atomic.AddInt64(&m.getCount, 1)
+	if m.shouldFail {
+		return nil, context.DeadlineExceeded
+	}
+	for _, secret := range m.versions[name] {
+		if secret.Version == version {
+			return secret, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockKeyVaultClient) ListSecretVersions(ctx context.Context, name string) ([]SecretVersionInfo, error) {
+	atomic.AddInt64(&m.listCount, 1)
+	if m.shouldFail {
+		return nil, context.DeadlineExceeded
+	}
+	var versions []SecretVersionInfo
+	for i := len(m.versions[name]) - 1; i >= 0; i-- {
+		secret := m.versions[name][i]
+		versions = append(versions, SecretVersionInfo{
+			Version:   secret.Version,
+			Enabled:   secret.Enabled,
+			CreatedOn: secret.CreatedOn,
+			UpdatedOn: secret.UpdatedOn,
+		})
+	}
+	return versions, nil
+}
+
 func (m *MockKeyVaultClient) DeleteSecret(ctx context.Context, name string) error {
 	atomic.AddInt64(&m.delCount, 1)
 	if m.shouldFail {
 		return context.DeadlineExceeded
 	}
 	delete(m.secrets, name)
+	delete(m.versions, name)
 	return nil
 }
 
@@ -564,3 +609,733 @@ func TestKeyVaultFailure(t *testing.T) {
 		t.Error("Expected error when KeyVault fails")
 	}
 }
+
+// =============================================================================
+// ListUserIntegrationsPage Tests
+// =============================================================================
+
+// memoryRedis is a minimal in-memory redis.Client for exercising cachedClient
+type memoryRedis struct {
+	values map[string]string
+	ttls   map[string]time.Duration
+}
+
+func (m *memoryRedis) Get(ctx context.Context, key string) (string, error) { return m.values[key], nil }
+func (m *memoryRedis) Set(ctx context.Context, key string, value interface{}) error {
+	m.values[key] = value.(string)
+	return nil
+}
+func (m *memoryRedis) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	m.ttls[key] = ttl
+	return m.Set(ctx, key, value)
+}
+func (m *memoryRedis) SetIfExists(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	if _, ok := m.values[key]; !ok {
+		return false, nil
+	}
+	return true, m.Set(ctx, key, value)
+}
+func (m *memoryRedis) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	if _, ok := m.values[key]; ok {
+		return false, nil
+	}
+	return true, m.Set(ctx, key, value)
+}
+func (m *memoryRedis) Del(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(m.values, key)
+	}
+	return nil
+}
+func (m *memoryRedis) SMembers(ctx context.Context, key string) ([]string, error)         { return nil, nil }
+func (m *memoryRedis) SAdd(ctx context.Context, key string, members ...interface{}) error { return nil }
+func (m *memoryRedis) SRem(ctx context.Context, key string, members ...interface{}) error { return nil }
+func (m *memoryRedis) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	return nil, nil
+}
+func (m *memoryRedis) ZAdd(ctx context.Context, key string, members ...redis.ScoredMember) error {
+	return nil
+}
+func (m *memoryRedis) ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error) {
+	return 0, nil
+}
+func (m *memoryRedis) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.ScoredMember, error) {
+	return nil, nil
+}
+func (m *memoryRedis) ZRevRank(ctx context.Context, key string, member string) (int64, error) {
+	return -1, nil
+}
+func (m *memoryRedis) Expire(ctx context.Context, key string, duration time.Duration) error {
+	return nil
+}
+func (m *memoryRedis) Health(ctx context.Context) error { return nil }
+func (m *memoryRedis) Close(ctx context.Context) error  { return nil }
+
+func newIntegrationsTestClient(t *testing.T) (*cachedClient, *MockKeyVaultClient) {
+	t.Helper()
+	appLogger, err := logger.NewProduction("keyvault-test", "1.0.0")
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	kv := NewMockKeyVaultClient()
+	return &cachedClient{
+		kvClient:         kv,
+		redisClient:      &memoryRedis{values: map[string]string{}, ttls: map[string]time.Duration{}},
+		logger:           appLogger.WithComponent("KeyVaultCachedClient"),
+		cacheTTL:         time.Minute,
+		cachePrefix:      "keyvault:",
+		negativeCacheTTL: DefaultNegativeCacheTTL,
+	}, kv
+}
+
+// configure stores an integration with an explicit configured-at time
+func configure(kv *MockKeyVaultClient, userID string, integrationType IntegrationType, configuredAt time.Time, expiresAt *time.Time) {
+	tags := map[string]string{tagConfiguredAt: configuredAt.Format(time.RFC3339)}
+	if expiresAt != nil {
+		tags[tagExpiresAt] = expiresAt.Format(time.RFC3339)
+	}
+	kv.SetSecret(context.Background(), userIntegrationKey(userID, integrationType), "secret-"+string(integrationType), tags)
+}
+
+func integrationTypes(integrations []UserIntegration) []IntegrationType {
+	types := make([]IntegrationType, len(integrations))
+	for i, integration := range integrations {
+		types[i] = integration.Type
+	}
+	return types
+}
+
+func TestListUserIntegrationsPage_ConnectedOnly(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	expired := now.Add(-time.Hour)
+	expires := now.Add(24 * time.Hour)
+
+	configure(kv, "user-1", IntegrationAlexa, now.Add(-3*time.Hour), &expires)
+	configure(kv, "user-1", IntegrationWeather, now.Add(-2*time.Hour), &expired)
+	configure(kv, "user-1", IntegrationMQTT, now.Add(-1*time.Hour), nil)
+	configure(kv, "user-2", IntegrationIFTTT, now, nil)
+
+	page, err := c.ListUserIntegrationsPage(ctx, "user-1", ListIntegrationsOptions{
+		Statuses: []IntegrationStatus{StatusConnected},
+	})
+	if err != nil {
+		t.Fatalf("ListUserIntegrationsPage() error = %v", err)
+	}
+
+	want := []IntegrationType{IntegrationAlexa, IntegrationMQTT}
+	if got := integrationTypes(page.Integrations); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("integrations = %v, want %v", got, want)
+	}
+	if page.Total != 2 {
+		t.Errorf("Total = %v, want 2", page.Total)
+	}
+
+	for _, integration := range page.Integrations {
+		if integration.Status != StatusConnected {
+			t.Errorf("%s status = %v, want %v", integration.Type, integration.Status, StatusConnected)
+		}
+		if integration.MaskedKey == "" {
+			t.Errorf("%s MaskedKey is empty", integration.Type)
+		}
+		if integration.ConfiguredAt == nil {
+			t.Errorf("%s ConfiguredAt is nil", integration.Type)
+		}
+	}
+	if alexa := page.Integrations[0]; alexa.ExpiresAt == nil || !alexa.ExpiresAt.Equal(expires) {
+		t.Errorf("alexa ExpiresAt = %v, want %v", alexa.ExpiresAt, expires)
+	}
+
+	expiredPage, err := c.ListUserIntegrationsPage(ctx, "user-1", ListIntegrationsOptions{
+		Statuses: []IntegrationStatus{StatusExpired},
+	})
+	if err != nil {
+		t.Fatalf("ListUserIntegrationsPage() error = %v", err)
+	}
+	if got := integrationTypes(expiredPage.Integrations); len(got) != 1 || got[0] != IntegrationWeather {
+		t.Errorf("expired integrations = %v, want [%v]", got, IntegrationWeather)
+	}
+}
+
+func TestListUserIntegrationsPage_SortByConfiguredAt(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	configure(kv, "user-1", IntegrationSMS, now.Add(-1*time.Hour), nil)
+	configure(kv, "user-1", IntegrationGoogleHome, now.Add(-3*time.Hour), nil)
+	configure(kv, "user-1", IntegrationEnergy, now.Add(-2*time.Hour), nil)
+
+	tests := []struct {
+		name string
+		opts ListIntegrationsOptions
+		want []IntegrationType
+	}{
+		{
+			name: "ascending, unconfigured last in catalogue order",
+			opts: ListIntegrationsOptions{SortBy: SortByConfiguredAt},
+			want: []IntegrationType{
+				IntegrationGoogleHome, IntegrationEnergy, IntegrationSMS,
+				IntegrationWeather, IntegrationAlexa, IntegrationIFTTT, IntegrationMQTT, IntegrationSmartThings,
+			},
+		},
+		{
+			name: "descending, paged",
+			opts: ListIntegrationsOptions{SortBy: SortByConfiguredAt, Descending: true, Limit: 2},
+			want: []IntegrationType{IntegrationSMS, IntegrationEnergy},
+		},
+		{
+			name: "second page",
+			opts: ListIntegrationsOptions{SortBy: SortByConfiguredAt, Descending: true, Offset: 2, Limit: 2},
+			want: []IntegrationType{IntegrationGoogleHome, IntegrationWeather},
+		},
+		{
+			name: "offset past end",
+			opts: ListIntegrationsOptions{Offset: 20},
+			want: []IntegrationType{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := c.ListUserIntegrationsPage(ctx, "user-1", tt.opts)
+			if err != nil {
+				t.Fatalf("ListUserIntegrationsPage() error = %v", err)
+			}
+			if got := integrationTypes(page.Integrations); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("integrations = %v, want %v", got, tt.want)
+			}
+			if page.Total != len(integrationCatalogue) {
+				t.Errorf("Total = %v, want %v", page.Total, len(integrationCatalogue))
+			}
+		})
+	}
+
+	if _, err := c.ListUserIntegrationsPage(ctx, "user-1", ListIntegrationsOptions{SortBy: "name"}); err == nil {
+		t.Error("ListUserIntegrationsPage() error = nil, want error for unsupported sort field")
+	}
+}
+
+func TestSetUserIntegration_RecordsExpiry(t *testing.T) {
+	c, _ := newIntegrationsTestClient(t)
+	ctx := context.Background()
+	expires := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Second)
+
+	if err := c.SetUserIntegration(ctx, "user-1", IntegrationIFTTT, "ifttt-webhook-key", &expires); err != nil {
+		t.Fatalf("SetUserIntegration() error = %v", err)
+	}
+
+	integration, err := c.GetUserIntegration(ctx, "user-1", IntegrationIFTTT)
+	if err != nil {
+		t.Fatalf("GetUserIntegration() error = %v", err)
+	}
+	if integration.ExpiresAt == nil || !integration.ExpiresAt.Equal(expires) {
+		t.Errorf("ExpiresAt = %v, want %v", integration.ExpiresAt, expires)
+	}
+	if integration.MaskedKey != "***key" {
+		t.Errorf("MaskedKey = %v, want ***key", integration.MaskedKey)
+	}
+}
+
+// =============================================================================
+// SetUserIntegrations Tests
+// =============================================================================
+
+// failingSetKeyVault fails SetSecret for the named secrets
+type failingSetKeyVault struct {
+	*MockKeyVaultClient
+	failSet map[string]bool
+}
+
+func (f *failingSetKeyVault) SetSecret(ctx context.Context, name string, value string, tags map[string]string) error {
+	if f.failSet[name] {
+		return fmt.Errorf("keyvault returned status 503")
+	}
+	return f.MockKeyVaultClient.SetSecret(ctx, name, value, tags)
+}
+
+func TestSetUserIntegrations_AllSucceed(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	ctx := context.Background()
+	cache := c.redisClient.(*memoryRedis)
+
+	// Stale cache entry for a key about to be overwritten
+	cache.values[c.cacheKey(userIntegrationKey("user-1", IntegrationWeather))] = `{"name":"stale","value":"old"}`
+
+	expires := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	result, err := c.SetUserIntegrations(ctx, "user-1", map[IntegrationType]IntegrationValue{
+		IntegrationMQTT:    {Value: "mqtt-password"},
+		IntegrationWeather: {Value: "weather-api-key", ExpiresAt: &expires},
+		IntegrationAlexa:   {Value: "alexa-token"},
+	})
+	if err != nil {
+		t.Fatalf("SetUserIntegrations() error = %v", err)
+	}
+
+	want := []IntegrationType{IntegrationWeather, IntegrationAlexa, IntegrationMQTT}
+	if fmt.Sprint(result.Succeeded) != fmt.Sprint(want) {
+		t.Errorf("Succeeded = %v, want %v", result.Succeeded, want)
+	}
+	if len(result.Failed) != 0 || len(result.Skipped) != 0 || len(result.RolledBack) != 0 {
+		t.Errorf("result = %+v, want only successes", result)
+	}
+
+	for _, intType := range want {
+		if _, ok := kv.secrets[userIntegrationKey("user-1", intType)]; !ok {
+			t.Errorf("%s was not written", intType)
+		}
+	}
+	if len(cache.values) != 0 {
+		t.Errorf("cache = %v, want touched keys invalidated", cache.values)
+	}
+
+	weather, err := c.GetUserIntegration(ctx, "user-1", IntegrationWeather)
+	if err != nil {
+		t.Fatalf("GetUserIntegration() error = %v", err)
+	}
+	if weather.MaskedKey != "***key" || weather.ExpiresAt == nil || !weather.ExpiresAt.Equal(expires) {
+		t.Errorf("weather = %+v, want new value with expiry %v", weather, expires)
+	}
+}
+
+func TestSetUserIntegrations_PartialFailureRollsBack(t *testing.T) {
+	c, mock := newIntegrationsTestClient(t)
+	ctx := context.Background()
+	cache := c.redisClient.(*memoryRedis)
+
+	// Alexa was configured before; weather and SMS are new
+	mock.SetSecret(ctx, userIntegrationKey("user-1", IntegrationAlexa), "old-alexa-token", map[string]string{"user_id": "user-1"})
+
+	kv := &failingSetKeyVault{
+		MockKeyVaultClient: mock,
+		failSet:            map[string]bool{userIntegrationKey("user-1", IntegrationIFTTT): true},
+	}
+	c.kvClient = kv
+
+	result, err := c.SetUserIntegrations(ctx, "user-1", map[IntegrationType]IntegrationValue{
+		IntegrationWeather: {Value: "weather-api-key"},
+		IntegrationAlexa:   {Value: "new-alexa-token"},
+		IntegrationIFTTT:   {Value: "ifttt-key"},
+		IntegrationSMS:     {Value: "sms-key"},
+	})
+	if err == nil {
+		t.Fatal("SetUserIntegrations() error = nil, want failure")
+	}
+
+	if fmt.Sprint(result.Succeeded) != fmt.Sprint([]IntegrationType{IntegrationWeather, IntegrationAlexa}) {
+		t.Errorf("Succeeded = %v, want [weather alexa]", result.Succeeded)
+	}
+	if _, ok := result.Failed[IntegrationIFTTT]; !ok || len(result.Failed) != 1 {
+		t.Errorf("Failed = %v, want only ifttt", result.Failed)
+	}
+	if fmt.Sprint(result.Skipped) != fmt.Sprint([]IntegrationType{IntegrationSMS}) {
+		t.Errorf("Skipped = %v, want [sms_notification]", result.Skipped)
+	}
+	if fmt.Sprint(result.RolledBack) != fmt.Sprint([]IntegrationType{IntegrationAlexa, IntegrationWeather}) {
+		t.Errorf("RolledBack = %v, want [alexa weather]", result.RolledBack)
+	}
+	if len(result.RollbackFailed) != 0 {
+		t.Errorf("RollbackFailed = %v, want none", result.RollbackFailed)
+	}
+
+	if _, ok := mock.secrets[userIntegrationKey("user-1", IntegrationWeather)]; ok {
+		t.Error("new weather integration was not removed on rollback")
+	}
+	if alexa := mock.secrets[userIntegrationKey("user-1", IntegrationAlexa)]; alexa == nil || alexa.Value != "old-alexa-token" {
+		t.Errorf("alexa = %+v, want previous token restored", alexa)
+	}
+	for _, intType := range []IntegrationType{IntegrationIFTTT, IntegrationSMS} {
+		if _, ok := mock.secrets[userIntegrationKey("user-1", intType)]; ok {
+			t.Errorf("%s should not have been written", intType)
+		}
+	}
+
+	// A cached read after the rollback must see the restored value, not the new one
+	alexa, err := c.GetUserIntegration(ctx, "user-1", IntegrationAlexa)
+	if err != nil {
+		t.Fatalf("GetUserIntegration() error = %v", err)
+	}
+	if alexa.MaskedKey != "***ken" {
+		t.Errorf("alexa MaskedKey = %v, want ***ken", alexa.MaskedKey)
+	}
+	if _, cached := cache.values[c.cacheKey(userIntegrationKey("user-1", IntegrationWeather))]; cached {
+		t.Error("rolled back weather integration is still cached")
+	}
+}
+
+func TestSetUserIntegrations_RejectsEmptyValue(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+
+	_, err := c.SetUserIntegrations(context.Background(), "user-1", map[IntegrationType]IntegrationValue{
+		IntegrationWeather: {Value: "weather-api-key"},
+		IntegrationAlexa:   {Value: ""},
+	})
+	if err == nil {
+		t.Fatal("SetUserIntegrations() error = nil, want validation error")
+	}
+	if len(kv.secrets) != 0 {
+		t.Errorf("secrets = %v, want nothing written", kv.secrets)
+	}
+}
+
+// =============================================================================
+// Secret Version Tests
+// =============================================================================
+
+func TestGetSecretVersion_CachedSeparatelyFromLatest(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	ctx := context.Background()
+
+	kv.SetSecret(ctx, "api-key", "old", nil)
+	pinned, err := c.GetSecretVersion(ctx, "api-key", "v1")
+	if err != nil || pinned == nil || pinned.Value != "old" {
+		t.Fatalf("GetSecretVersion(v1) = %v, %v; want old", pinned, err)
+	}
+
+	// Rotating the secret and reading the latest must not clobber the pinned version
+	if err := c.SetSecret(ctx, "api-key", "new", nil); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+	latest, err := c.GetSecret(ctx, "api-key")
+	if err != nil || latest.Value != "new" || latest.Version != "v2" {
+		t.Fatalf("GetSecret() = %+v, %v; want new at v2", latest, err)
+	}
+
+	misses := c.GetCacheStats().Misses
+	pinned, err = c.GetSecretVersion(ctx, "api-key", "v1")
+	if err != nil || pinned.Value != "old" {
+		t.Errorf("GetSecretVersion(v1) after rotation = %+v, %v; want old", pinned, err)
+	}
+	if got := c.GetCacheStats().Misses; got != misses {
+		t.Errorf("GetSecretVersion(v1) after rotation missed the cache")
+	}
+}
+
+func TestDeleteSecret_InvalidatesCachedVersions(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	ctx := context.Background()
+	cache := c.redisClient.(*memoryRedis)
+
+	kv.SetSecret(ctx, "api-key", "old", nil)
+	kv.SetSecret(ctx, "api-key", "new", nil)
+	c.GetSecret(ctx, "api-key")
+	c.GetSecretVersion(ctx, "api-key", "v1")
+	if _, ok := cache.values[c.versionCacheKey("api-key", "v1")]; !ok {
+		t.Fatal("version should be cached before delete")
+	}
+
+	if err := c.DeleteSecret(ctx, "api-key"); err != nil {
+		t.Fatalf("DeleteSecret() error = %v", err)
+	}
+	if len(cache.values) != 0 {
+		t.Errorf("cache after delete = %v, want empty", cache.values)
+	}
+}
+
+// =============================================================================
+// Batch Get Tests
+// =============================================================================
+
+func TestGetSecrets_FetchesOnlyCacheMisses(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		kv.SetSecret(ctx, name, name+"-value", nil)
+	}
+	c.GetSecret(ctx, "a")
+	c.GetSecret(ctx, "b")
+	fetchesBefore := atomic.LoadInt64(&kv.getCount)
+
+	secrets, err := c.GetSecrets(ctx, []string{"a", "b", "c", "d", "missing"})
+	if err != nil {
+		t.Fatalf("GetSecrets() error = %v", err)
+	}
+	if len(secrets) != 4 {
+		t.Errorf("GetSecrets() returned %d secrets, want 4", len(secrets))
+	}
+	for name, secret := range secrets {
+		if secret.Value != name+"-value" {
+			t.Errorf("GetSecrets()[%s] = %q, want %q", name, secret.Value, name+"-value")
+		}
+	}
+	if fetched := atomic.LoadInt64(&kv.getCount) - fetchesBefore; fetched != 3 {
+		t.Errorf("GetSecrets() fetched %d secrets from KeyVault, want 3 (c, d, missing)", fetched)
+	}
+
+	// Fetched secrets are cached for the next call
+	if _, ok := c.redisClient.(*memoryRedis).values[c.cacheKey("c")]; !ok {
+		t.Error("GetSecrets() should cache secrets fetched from KeyVault")
+	}
+}
+
+func TestGetSecrets_KeyVaultFailureReturnsCachedSecrets(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	ctx := context.Background()
+
+	kv.SetSecret(ctx, "cached", "value", nil)
+	kv.SetSecret(ctx, "uncached", "value", nil)
+	c.GetSecret(ctx, "cached")
+	kv.shouldFail = true
+
+	secrets, err := c.GetSecrets(ctx, []string{"cached", "uncached"})
+	if err == nil {
+		t.Fatal("GetSecrets() error = nil, want the KeyVault failure")
+	}
+	if len(secrets) != 1 || secrets["cached"] == nil {
+		t.Errorf("GetSecrets() = %v, want the cached secret", secrets)
+	}
+}
+
+// =============================================================================
+// Negative Caching Tests
+// =============================================================================
+
+func TestNegativeCache_ServesNotFoundFromCache(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	ctx := context.Background()
+	cache := c.redisClient.(*memoryRedis)
+
+	for i := 0; i < 3; i++ {
+		secret, err := c.GetSecret(ctx, "unconfigured")
+		if err != nil || secret != nil {
+			t.Fatalf("GetSecret(unconfigured) = %v, %v; want nil, nil", secret, err)
+		}
+	}
+
+	if got := atomic.LoadInt64(&kv.getCount); got != 1 {
+		t.Errorf("KeyVault lookups = %d, want 1", got)
+	}
+	stats := c.GetCacheStats()
+	if stats.NegativeHits != 2 {
+		t.Errorf("NegativeHits = %d, want 2", stats.NegativeHits)
+	}
+	if ttl := cache.ttls[c.cacheKey("unconfigured")]; ttl != DefaultNegativeCacheTTL {
+		t.Errorf("tombstone TTL = %v, want %v", ttl, DefaultNegativeCacheTTL)
+	}
+}
+
+func TestNegativeCache_SetSecretClearsTombstone(t *testing.T) {
+	c, _ := newIntegrationsTestClient(t)
+	ctx := context.Background()
+
+	if secret, _ := c.GetSecret(ctx, "api-key"); secret != nil {
+		t.Fatalf("GetSecret() before set = %v, want nil", secret)
+	}
+	if err := c.SetSecret(ctx, "api-key", "value", nil); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+
+	secret, err := c.GetSecret(ctx, "api-key")
+	if err != nil || secret == nil || secret.Value != "value" {
+		t.Errorf("GetSecret() after set = %v, %v; want the new secret", secret, err)
+	}
+}
+
+func TestNegativeCache_Disabled(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	c.negativeCacheTTL = 0
+	ctx := context.Background()
+
+	c.GetSecret(ctx, "unconfigured")
+	c.GetSecret(ctx, "unconfigured")
+
+	if got := atomic.LoadInt64(&kv.getCount); got != 2 {
+		t.Errorf("KeyVault lookups = %d, want 2 with negative caching disabled", got)
+	}
+	if _, ok := c.redisClient.(*memoryRedis).values[c.cacheKey("unconfigured")]; ok {
+		t.Error("no tombstone should be cached with negative caching disabled")
+	}
+}
+
+func TestNegativeCache_GetSecrets(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	ctx := context.Background()
+	kv.SetSecret(ctx, "present", "value", nil)
+
+	c.GetSecrets(ctx, []string{"present", "absent"})
+	fetchesBefore := atomic.LoadInt64(&kv.getCount)
+
+	secrets, err := c.GetSecrets(ctx, []string{"present", "absent"})
+	if err != nil {
+		t.Fatalf("GetSecrets() error = %v", err)
+	}
+	if _, ok := secrets["absent"]; ok || secrets["present"] == nil {
+		t.Errorf("GetSecrets() = %v, want only the present secret", secrets)
+	}
+	if fetched := atomic.LoadInt64(&kv.getCount) - fetchesBefore; fetched != 0 {
+		t.Errorf("second GetSecrets() fetched %d secrets from KeyVault, want 0", fetched)
+	}
+}
+
+// waitForChange returns the next secret change delivered to ch
+func waitForChange(t *testing.T, ch <-chan [2]*Secret) [2]*Secret {
+	t.Helper()
+	select {
+	case change := <-ch:
+		return change
+	case <-time.After(time.Second):
+		t.Fatal("secret change callback not called")
+		return [2]*Secret{}
+	}
+}
+
+func TestOnSecretChanged_ReceivesOldAndNew(t *testing.T) {
+	c, _ := newIntegrationsTestClient(t)
+	ctx := context.Background()
+
+	changes := make(chan [2]*Secret, 4)
+	c.OnSecretChanged("api-key", func(old, new *Secret) { changes <- [2]*Secret{old, new} })
+
+	if err := c.SetSecret(ctx, "api-key", "first", nil); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+	change := waitForChange(t, changes)
+	if change[0] != nil || change[1] == nil || change[1].Value != "first" {
+		t.Errorf("first change = (%v, %v), want (nil, first)", change[0], change[1])
+	}
+
+	if err := c.SetSecret(ctx, "api-key", "second", nil); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+	change = waitForChange(t, changes)
+	if change[0] == nil || change[0].Value != "first" || change[1].Value != "second" {
+		t.Errorf("second change = (%v, %v), want (first, second)", change[0], change[1])
+	}
+
+	// Other secrets don't trigger the callback
+	c.SetSecret(ctx, "other-key", "value", nil)
+	select {
+	case change := <-changes:
+		t.Errorf("unexpected change for %s", change[1].Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestOnSecretChanged_WildcardMatchesUserIntegrations(t *testing.T) {
+	c, _ := newIntegrationsTestClient(t)
+	ctx := context.Background()
+
+	changes := make(chan [2]*Secret, 4)
+	c.OnSecretChanged("user:*:weather", func(old, new *Secret) { changes <- [2]*Secret{old, new} })
+
+	if err := c.SetUserIntegration(ctx, "123", IntegrationAlexa, "alexa-key", nil); err != nil {
+		t.Fatalf("SetUserIntegration() error = %v", err)
+	}
+	if err := c.SetUserIntegration(ctx, "456", IntegrationWeather, "weather-key", nil); err != nil {
+		t.Fatalf("SetUserIntegration() error = %v", err)
+	}
+
+	change := waitForChange(t, changes)
+	if change[1].Name != "user:456:weather" || change[1].Value != "weather-key" {
+		t.Errorf("change = %+v, want user:456:weather", change[1])
+	}
+	if change[1].Tags["user_id"] != "456" {
+		t.Errorf("change tags = %v, want user_id 456", change[1].Tags)
+	}
+	select {
+	case change := <-changes:
+		t.Errorf("unexpected change for %s", change[1].Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestOnSecretChanged_RecoversFromPanic(t *testing.T) {
+	c, _ := newIntegrationsTestClient(t)
+	ctx := context.Background()
+
+	changes := make(chan [2]*Secret, 1)
+	c.OnSecretChanged("api-key", func(old, new *Secret) { panic("bad handler") })
+	c.OnSecretChanged("api-key", func(old, new *Secret) { changes <- [2]*Secret{old, new} })
+
+	if err := c.SetSecret(ctx, "api-key", "value", nil); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+	if change := waitForChange(t, changes); change[1].Value != "value" {
+		t.Errorf("change = %+v, want value", change[1])
+	}
+}
+
+func TestOnSecretChanged_NotCalledWhenWriteFails(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	kv.shouldFail = true
+
+	called := make(chan struct{}, 1)
+	c.OnSecretChanged("api-key", func(old, new *Secret) { called <- struct{}{} })
+
+	if err := c.SetSecret(context.Background(), "api-key", "value", nil); err == nil {
+		t.Fatal("SetSecret() error = nil, want failure")
+	}
+	select {
+	case <-called:
+		t.Error("callback called for a failed write")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWarmCache_CachesMatchingSecrets(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	ctx := context.Background()
+	kv.SetSecret(ctx, "user:123:weather", "w-123", nil)
+	kv.SetSecret(ctx, "user:456:alexa", "a-456", nil)
+	kv.SetSecret(ctx, "db-password", "secret", nil)
+
+	warmed, err := c.WarmCache(ctx, "user:")
+	if err != nil {
+		t.Fatalf("WarmCache() error = %v", err)
+	}
+	if warmed != 2 {
+		t.Errorf("WarmCache() = %d, want 2", warmed)
+	}
+
+	values := c.redisClient.(*memoryRedis).values
+	if _, ok := values[c.cacheKey("db-password")]; ok {
+		t.Error("secret outside the prefix should not be warmed")
+	}
+	if ttl := c.redisClient.(*memoryRedis).ttls[c.cacheKey("user:123:weather")]; ttl != c.cacheTTL {
+		t.Errorf("warmed TTL = %v, want %v", ttl, c.cacheTTL)
+	}
+
+	// Warmed secrets are served without going to KeyVault
+	fetchesBefore := atomic.LoadInt64(&kv.getCount)
+	secret, err := c.GetSecret(ctx, "user:456:alexa")
+	if err != nil || secret == nil || secret.Value != "a-456" {
+		t.Fatalf("GetSecret() = %v, %v, want a-456", secret, err)
+	}
+	if fetched := atomic.LoadInt64(&kv.getCount) - fetchesBefore; fetched != 0 {
+		t.Errorf("GetSecret() after warming fetched %d from KeyVault, want 0", fetched)
+	}
+}
+
+func TestWarmCache_ListFailure(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	kv.shouldFail = true
+
+	if warmed, err := c.WarmCache(context.Background(), "user:"); err == nil || warmed != 0 {
+		t.Errorf("WarmCache() = %d, %v, want 0 and an error", warmed, err)
+	}
+}
+
+func TestWarmCache_RespectsCancellation(t *testing.T) {
+	c, kv := newIntegrationsTestClient(t)
+	for i := 0; i < 20; i++ {
+		kv.SetSecret(context.Background(), fmt.Sprintf("user:%d:weather", i), "value", nil)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fetchesBefore := atomic.LoadInt64(&kv.getCount)
+
+	warmed, err := c.WarmCache(ctx, "user:")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WarmCache() error = %v, want context.Canceled", err)
+	}
+	if warmed != 0 {
+		t.Errorf("WarmCache() = %d, want 0 after cancellation", warmed)
+	}
+	if fetched := atomic.LoadInt64(&kv.getCount) - fetchesBefore; fetched != 0 {
+		t.Errorf("fetched %d secrets after cancellation, want 0", fetched)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,9 +19,21 @@ import (
 
 // Client interface for KeyVault operations
 type Client interface {
-	// GetSecret retrieves a secret by name
+	// GetSecret retrieves the latest version of a secret by name
 	GetSecret(ctx context.Context, name string) (*Secret, error)
 
+	// GetSecrets retrieves the latest version of several secrets concurrently.
+	// Secrets that don't exist are omitted from the result. If some requests
+	// fail, the secrets that were fetched are returned along with an error
+	// joining every failure.
+	GetSecrets(ctx context.Context, names []string) (map[string]*Secret, error)
+
+	// GetSecretVersion retrieves a specific version of a secret
+	GetSecretVersion(ctx context.Context, name, version string) (*Secret, error)
+
+	// ListSecretVersions returns a secret's versions, newest first
+	ListSecretVersions(ctx context.Context, name string) ([]SecretVersionInfo, error)
+
 	// SetSecret stores or updates a secret
 	SetSecret(ctx context.Context, name string, value string, tags map[string]string) error
 
@@ -43,6 +57,9 @@ type client struct {
 	logger     *logger.ContextLogger
 	timeout    time.Duration
 
+	// Maximum concurrent requests in GetSecrets
+	batchConcurrency int
+
 	// Authentication
 	token       string
 	tokenExpiry time.Time
@@ -96,11 +113,17 @@ func NewClient(cfg ClientConfig, log *logger.Logger) (Client, error) {
 		zap.Duration("timeout", cfg.Timeout),
 		zap.Bool("tls_skip_verify", cfg.InsecureSkipVerify))
 
+	batchConcurrency := cfg.BatchConcurrency
+	if batchConcurrency == 0 {
+		batchConcurrency = DefaultBatchConcurrency
+	}
+
 	c := &client{
-		httpClient: httpClient,
-		vaultURL:   strings.TrimSuffix(cfg.VaultURL, "/"),
-		logger:     componentLogger,
-		timeout:    cfg.Timeout,
+		httpClient:       httpClient,
+		vaultURL:         strings.TrimSuffix(cfg.VaultURL, "/"),
+		logger:           componentLogger,
+		timeout:          cfg.Timeout,
+		batchConcurrency: batchConcurrency,
 	}
 
 	// Fetch initial authentication token
@@ -193,8 +216,79 @@ func (c *client) getToken(ctx context.Context) (string, error) {
 	return c.token, nil
 }
 
-// GetSecret retrieves a secret by name from KeyVault
+// GetSecret retrieves the latest version of a secret by name from KeyVault
 func (c *client) GetSecret(ctx context.Context, name string) (*Secret, error) {
+	return c.getSecret(ctx, name, "")
+}
+
+// GetSecrets retrieves several secrets concurrently, at most batchConcurrency
+// requests at a time
+func (c *client) GetSecrets(ctx context.Context, names []string) (map[string]*Secret, error) {
+	return fetchSecrets(ctx, names, c.batchConcurrency, c.GetSecret)
+}
+
+// fetchSecrets calls fetch for each distinct name using at most concurrency
+// workers. Missing secrets (nil, nil) are left out of the result; failures
+// are joined in input order.
+func fetchSecrets(ctx context.Context, names []string, concurrency int, fetch func(context.Context, string) (*Secret, error)) (map[string]*Secret, error) {
+	unique := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+
+	secrets := make([]*Secret, len(unique))
+	errs := make([]error, len(unique))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(unique)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					errs[i] = fmt.Errorf("secret %s: %w", unique[i], err)
+					continue
+				}
+				secret, err := fetch(ctx, unique[i])
+				if err != nil {
+					errs[i] = fmt.Errorf("secret %s: %w", unique[i], err)
+					continue
+				}
+				secrets[i] = secret
+			}
+		}()
+	}
+	for i := range unique {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	result := make(map[string]*Secret, len(unique))
+	for i, secret := range secrets {
+		if secret != nil {
+			result[unique[i]] = secret
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// GetSecretVersion retrieves a specific version of a secret from KeyVault.
+// Like GetSecret, it returns nil, nil if the secret or version doesn't exist.
+func (c *client) GetSecretVersion(ctx context.Context, name, version string) (*Secret, error) {
+	if version == "" {
+		return nil, fmt.Errorf("secret version cannot be empty")
+	}
+	return c.getSecret(ctx, name, version)
+}
+
+// getSecret fetches a secret version, or the latest version if version is empty
+func (c *client) getSecret(ctx context.Context, name, version string) (*Secret, error) {
 	start := time.Now()
 
 	// Get authentication token
@@ -207,8 +301,12 @@ func (c *client) GetSecret(ctx context.Context, name string) (*Secret, error) {
 		return nil, fmt.Errorf("authentication failed: %w", err)
 	}
 
-	// Azure KeyVault API: GET {vaultUri}/secrets/{secret-name}?api-version=7.4
+	// Azure KeyVault API: GET {vaultUri}/secrets/{secret-name}/{secret-version}?api-version=7.4
+	// An empty version selects the latest
 	url := fmt.Sprintf("%s/secrets/%s?api-version=7.4", c.vaultURL, name)
+	if version != "" {
+		url = fmt.Sprintf("%s/secrets/%s/%s?api-version=7.4", c.vaultURL, name, version)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -234,6 +332,7 @@ func (c *client) GetSecret(ctx context.Context, name string) (*Secret, error) {
 	if resp.StatusCode == http.StatusNotFound {
 		c.logger.Debug("Secret not found",
 			zap.String("secret_name", name),
+			zap.String("secret_version", version),
 			zap.String("error_code", ErrCodeSecretNotFound),
 			zap.Duration("duration", time.Since(start)))
 		return nil, nil // Return nil, nil for not found (not an error)
@@ -274,6 +373,7 @@ func (c *client) GetSecret(ctx context.Context, name string) (*Secret, error) {
 	secret := &Secret{
 		Name:    name,
 		Value:   kvResponse.Value,
+		Version: secretVersion(kvResponse.ID),
 		Enabled: kvResponse.Attributes.Enabled,
 		Tags:    kvResponse.Tags,
 	}
@@ -298,12 +398,134 @@ func (c *client) GetSecret(ctx context.Context, name string) (*Secret, error) {
 
 	c.logger.Debug("Secret retrieved successfully",
 		zap.String("secret_name", name),
+		zap.String("secret_version", secret.Version),
 		zap.Bool("enabled", secret.Enabled),
 		zap.Duration("duration", time.Since(start)))
 
 	return secret, nil
 }
 
+// secretVersion extracts the version from a secret ID of the form
+// {vaultUri}/secrets/{name}/{version}; IDs without a version yield ""
+func secretVersion(id string) string {
+	parts := strings.Split(id, "/secrets/")
+	if len(parts) != 2 {
+		return ""
+	}
+	if i := strings.LastIndex(parts[1], "/"); i >= 0 {
+		return parts[1][i+1:]
+	}
+	return ""
+}
+
+// ListSecretVersions returns a secret's versions, newest first. Values are not
+// included; fetch one with GetSecretVersion. A missing secret has no versions.
+func (c *client) ListSecretVersions(ctx context.Context, name string) ([]SecretVersionInfo, error) {
+	start := time.Now()
+
+	// Get authentication token
+	token, err := c.getToken(ctx)
+	if err != nil {
+		c.logger.Error("Failed to get authentication token",
+			zap.Error(err),
+			zap.String("secret_name", name),
+			zap.String("error_code", ErrCodeSecretListFailed))
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	// Azure KeyVault API: GET {vaultUri}/secrets/{secret-name}/versions?api-version=7.4
+	// Results are paged; follow nextLink until it's empty
+	url := fmt.Sprintf("%s/secrets/%s/versions?api-version=7.4", c.vaultURL, name)
+
+	var versions []SecretVersionInfo
+	for url != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			c.logger.Error("Failed to create request",
+				zap.Error(err),
+				zap.String("secret_name", name),
+				zap.String("error_code", ErrCodeSecretListFailed))
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			c.logger.Error("Failed to execute request",
+				zap.Error(err),
+				zap.String("secret_name", name),
+				zap.String("error_code", ErrCodeSecretListFailed),
+				zap.Duration("duration", time.Since(start)))
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return nil, nil
+		}
+		if resp.StatusCode != http.StatusOK {
+			respBody, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			c.logger.Error("KeyVault returned error",
+				zap.Int("status_code", resp.StatusCode),
+				zap.String("secret_name", name),
+				zap.String("response", string(respBody)),
+				zap.String("error_code", ErrCodeSecretListFailed))
+			return nil, fmt.Errorf("keyvault returned status %d: %s", resp.StatusCode, string(respBody))
+		}
+
+		var page struct {
+			Value []struct {
+				ID         string `json:"id"`
+				Attributes struct {
+					Enabled bool  `json:"enabled"`
+					Created int64 `json:"created"`
+					Updated int64 `json:"updated"`
+				} `json:"attributes"`
+			} `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			c.logger.Error("Failed to decode response",
+				zap.Error(err),
+				zap.String("secret_name", name),
+				zap.String("error_code", ErrCodeSecretListFailed))
+			return nil, err
+		}
+
+		for _, item := range page.Value {
+			info := SecretVersionInfo{
+				Version: secretVersion(item.ID),
+				Enabled: item.Attributes.Enabled,
+			}
+			if item.Attributes.Created > 0 {
+				t := time.Unix(item.Attributes.Created, 0)
+				info.CreatedOn = &t
+			}
+			if item.Attributes.Updated > 0 {
+				t := time.Unix(item.Attributes.Updated, 0)
+				info.UpdatedOn = &t
+			}
+			versions = append(versions, info)
+		}
+		url = page.NextLink
+	}
+
+	// Newest first; KeyVault doesn't guarantee an order
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].createdUnix() > versions[j].createdUnix()
+	})
+
+	c.logger.Debug("Secret versions listed successfully",
+		zap.String("secret_name", name),
+		zap.Int("count", len(versions)),
+		zap.Duration("duration", time.Since(start)))
+
+	return versions, nil
+}
+
 // SetSecret stores or updates a secret in KeyVault
 func (c *client) SetSecret(ctx context.Context, name string, value string, tags map[string]string) error {
 	start := time.Now()
@@ -3,9 +3,12 @@ package keyvault
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,14 +20,16 @@ import (
 // =============================================================================
 
 type mockKeyVaultServer struct {
-	secrets map[string]*Secret
-	token   string
+	secrets  map[string]*Secret
+	versions map[string][]*Secret // Oldest first
+	token    string
 }
 
 func newMockKeyVaultServer() *mockKeyVaultServer {
 	return &mockKeyVaultServer{
-		secrets: make(map[string]*Secret),
-		token:   "mock-jwt-token-for-testing",
+		secrets:  make(map[string]*Secret),
+		versions: make(map[string][]*Secret),
+		token:    "mock-jwt-token-for-testing",
 	}
 }
 
@@ -66,6 +71,8 @@ func (m *mockKeyVaultServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Route handling
 	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/secrets/") && strings.HasSuffix(r.URL.Path, "/versions"):
+		m.handleListSecretVersions(w, r)
 	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/secrets/"):
 		m.handleGetSecret(w, r)
 	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/secrets/"):
@@ -89,7 +96,17 @@ func (m *mockKeyVaultServer) handleGetSecret(w http.ResponseWriter, r *http.Requ
 	name := strings.TrimPrefix(r.URL.Path, "/secrets/")
 	name = strings.Split(name, "?")[0] // Remove query params
 
+	// /secrets/{name}/{version} selects a version; /secrets/{name} the latest
+	name, version, _ := strings.Cut(name, "/")
 	secret, exists := m.secrets[name]
+	if version != "" {
+		secret, exists = nil, false
+		for _, v := range m.versions[name] {
+			if v.Version == version {
+				secret, exists = v, true
+			}
+		}
+	}
 	if !exists {
 		w.WriteHeader(http.StatusNotFound)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -103,11 +120,11 @@ func (m *mockKeyVaultServer) handleGetSecret(w http.ResponseWriter, r *http.Requ
 
 	response := map[string]interface{}{
 		"value": secret.Value,
-		"id":    "https://localhost:4997/secrets/" + name,
+		"id":    "https://localhost:4997/secrets/" + name + "/" + secret.Version,
 		"attributes": map[string]interface{}{
 			"enabled": secret.Enabled,
-			"created": time.Now().Unix(),
-			"updated": time.Now().Unix(),
+			"created": secret.CreatedOn.Unix(),
+			"updated": secret.UpdatedOn.Unix(),
 		},
 		"tags": secret.Tags,
 	}
@@ -136,19 +153,23 @@ func (m *mockKeyVaultServer) handleSetSecret(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	now := time.Now()
+	// Each write creates a new version; versions are created a second apart
+	// so they order by creation time like real vault versions
+	now := time.Unix(1700000000+int64(len(m.versions[name])), 0)
 	m.secrets[name] = &Secret{
 		Name:      name,
 		Value:     payload.Value,
+		Version:   fmt.Sprintf("%032x", len(m.versions[name])+1),
 		Enabled:   payload.Attributes["enabled"],
 		Tags:      payload.Tags,
 		CreatedOn: &now,
 		UpdatedOn: &now,
 	}
+	m.versions[name] = append(m.versions[name], m.secrets[name])
 
 	response := map[string]interface{}{
 		"value": payload.Value,
-		"id":    "https://localhost:4997/secrets/" + name,
+		"id":    "https://localhost:4997/secrets/" + name + "/" + m.secrets[name].Version,
 		"attributes": map[string]interface{}{
 			"enabled": payload.Attributes["enabled"],
 			"created": now.Unix(),
@@ -176,9 +197,47 @@ func (m *mockKeyVaultServer) handleDeleteSecret(w http.ResponseWriter, r *http.R
 	}
 
 	delete(m.secrets, name)
+	delete(m.versions, name)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleListSecretVersions serves GET /secrets/{name}/versions one version per
+// page, oldest first, so clients must follow nextLink and sort
+func (m *mockKeyVaultServer) handleListSecretVersions(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/secrets/"), "/versions")
+
+	versions, exists := m.versions[name]
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]string{
+				"code":    "SecretNotFound",
+				"message": "Secret not found: " + name,
+			},
+		})
+		return
+	}
+
+	page := 0
+	fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+	secret := versions[page]
+	response := map[string]interface{}{
+		"value": []map[string]interface{}{{
+			"id": "https://localhost:4997/secrets/" + name + "/" + secret.Version,
+			"attributes": map[string]interface{}{
+				"enabled": secret.Enabled,
+				"created": secret.CreatedOn.Unix(),
+				"updated": secret.UpdatedOn.Unix(),
+			},
+		}},
+	}
+	if page+1 < len(versions) {
+		response["nextLink"] = fmt.Sprintf("https://%s%s?api-version=7.4&page=%d", r.Host, r.URL.Path, page+1)
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
 func (m *mockKeyVaultServer) handleListSecrets(w http.ResponseWriter, r *http.Request) {
 	var value []map[string]interface{}
 	for name := range m.secrets {
@@ -242,6 +301,16 @@ func TestClientConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "negative batch concurrency",
+			config: ClientConfig{
+				VaultURL:         "https://localhost:4997",
+				Timeout:          30 * time.Second,
+				BatchConcurrency: -1,
+			},
+			wantErr: true,
+			errMsg:  "BatchConcurrency cannot be negative",
+		},
 	}
 
 	for _, tt := range tests {
@@ -344,6 +413,23 @@ func TestCachedClientConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "CacheTTL must be at least 60 seconds",
 		},
+		{
+			name: "negative cache TTL longer than cache TTL",
+			config: CachedClientConfig{
+				KeyVault: ClientConfig{
+					VaultURL: "https://localhost:4997",
+					Timeout:  30 * time.Second,
+				},
+				Redis: RedisConfig{
+					Host: "localhost",
+					Port: 6379,
+				},
+				CacheTTL:         5 * time.Minute,
+				NegativeCacheTTL: 10 * time.Minute,
+			},
+			wantErr: true,
+			errMsg:  "NegativeCacheTTL must not exceed CacheTTL",
+		},
 	}
 
 	for _, tt := range tests {
@@ -512,6 +598,152 @@ func TestClient_ListSecrets(t *testing.T) {
 	}
 }
 
+func TestClient_SecretVersions(t *testing.T) {
+	client, _, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for _, value := range []string{"first", "second", "third"} {
+		if err := client.SetSecret(ctx, "rotated", value, nil); err != nil {
+			t.Fatalf("SetSecret(%s) error = %v", value, err)
+		}
+	}
+
+	versions, err := client.ListSecretVersions(ctx, "rotated")
+	if err != nil {
+		t.Fatalf("ListSecretVersions() error = %v", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("ListSecretVersions() returned %d versions, want 3", len(versions))
+	}
+	for i := 1; i < len(versions); i++ {
+		if !versions[i-1].CreatedOn.After(*versions[i].CreatedOn) {
+			t.Errorf("ListSecretVersions() not newest first: %v before %v", versions[i-1].CreatedOn, versions[i].CreatedOn)
+		}
+	}
+	if !versions[0].Enabled || versions[0].UpdatedOn == nil {
+		t.Errorf("ListSecretVersions()[0] = %+v, want enabled with timestamps", versions[0])
+	}
+
+	// The latest secret reports the newest version
+	latest, err := client.GetSecret(ctx, "rotated")
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+	if latest.Value != "third" || latest.Version != versions[0].Version {
+		t.Errorf("GetSecret() = %q version %q, want %q version %q", latest.Value, latest.Version, "third", versions[0].Version)
+	}
+
+	// An older version is still retrievable
+	oldest, err := client.GetSecretVersion(ctx, "rotated", versions[2].Version)
+	if err != nil {
+		t.Fatalf("GetSecretVersion() error = %v", err)
+	}
+	if oldest == nil || oldest.Value != "first" || oldest.Version != versions[2].Version {
+		t.Errorf("GetSecretVersion() = %+v, want the first value", oldest)
+	}
+
+	// Unknown versions and secrets are not errors
+	missing, err := client.GetSecretVersion(ctx, "rotated", "does-not-exist")
+	if err != nil || missing != nil {
+		t.Errorf("GetSecretVersion(unknown) = %v, %v; want nil, nil", missing, err)
+	}
+	none, err := client.ListSecretVersions(ctx, "non-existent")
+	if err != nil || len(none) != 0 {
+		t.Errorf("ListSecretVersions(non-existent) = %v, %v; want empty", none, err)
+	}
+
+	if _, err := client.GetSecretVersion(ctx, "rotated", ""); err == nil {
+		t.Error("GetSecretVersion() with empty version should return error")
+	}
+}
+
+func TestClient_GetSecrets(t *testing.T) {
+	client, _, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	names := []string{"db-password", "api-key", "mqtt-token"}
+	for _, name := range names {
+		if err := client.SetSecret(ctx, name, name+"-value", nil); err != nil {
+			t.Fatalf("SetSecret(%s) error = %v", name, err)
+		}
+	}
+
+	secrets, err := client.GetSecrets(ctx, append(names, "non-existent", "api-key"))
+	if err != nil {
+		t.Fatalf("GetSecrets() error = %v", err)
+	}
+	if len(secrets) != len(names) {
+		t.Errorf("GetSecrets() returned %d secrets, want %d", len(secrets), len(names))
+	}
+	for _, name := range names {
+		if secrets[name] == nil || secrets[name].Value != name+"-value" {
+			t.Errorf("GetSecrets()[%s] = %v, want %s-value", name, secrets[name], name)
+		}
+	}
+	if _, ok := secrets["non-existent"]; ok {
+		t.Error("GetSecrets() should omit missing secrets")
+	}
+}
+
+func TestFetchSecrets_BoundedConcurrency(t *testing.T) {
+	var inFlight, peak int64
+	fetch := func(ctx context.Context, name string) (*Secret, error) {
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			p := atomic.LoadInt64(&peak)
+			if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return &Secret{Name: name}, nil
+	}
+
+	names := make([]string, 40)
+	for i := range names {
+		names[i] = fmt.Sprintf("secret-%d", i)
+	}
+
+	secrets, err := fetchSecrets(context.Background(), names, 3, fetch)
+	if err != nil {
+		t.Fatalf("fetchSecrets() error = %v", err)
+	}
+	if len(secrets) != len(names) {
+		t.Errorf("fetchSecrets() returned %d secrets, want %d", len(secrets), len(names))
+	}
+	if peak > 3 {
+		t.Errorf("fetchSecrets() ran %d requests at once, want at most 3", peak)
+	}
+}
+
+func TestFetchSecrets_PartialFailure(t *testing.T) {
+	errBoom := errors.New("boom")
+	fetch := func(ctx context.Context, name string) (*Secret, error) {
+		if strings.HasPrefix(name, "bad") {
+			return nil, errBoom
+		}
+		return &Secret{Name: name}, nil
+	}
+
+	secrets, err := fetchSecrets(context.Background(), []string{"good-1", "bad-1", "good-2", "bad-2"}, 2, fetch)
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("fetchSecrets() error = %v, want it to wrap the fetch error", err)
+	}
+	for _, name := range []string{"bad-1", "bad-2"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("fetchSecrets() error %q should name %s", err, name)
+		}
+	}
+	if len(secrets) != 2 || secrets["good-1"] == nil || secrets["good-2"] == nil {
+		t.Errorf("fetchSecrets() = %v, want the two good secrets", secrets)
+	}
+}
+
 func TestClient_Health(t *testing.T) {
 	client, _, cleanup := setupTestClient(t)
 	defer cleanup()
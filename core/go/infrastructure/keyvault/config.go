@@ -18,8 +18,16 @@ type ClientConfig struct {
 
 	// Optional: Skip TLS verification (ONLY for local development)
 	InsecureSkipVerify bool
+
+	// BatchConcurrency is the maximum number of concurrent requests made by
+	// GetSecrets (default: 8)
+	BatchConcurrency int
 }
 
+// DefaultBatchConcurrency is the GetSecrets worker count when
+// ClientConfig.BatchConcurrency is not set
+const DefaultBatchConcurrency = 8
+
 // TLSConfig for KeyVault connection
 type TLSConfig struct {
 	// CertPath is the path to the TLS certificate file
@@ -45,8 +53,17 @@ type CachedClientConfig struct {
 
 	// CachePrefix is the prefix for all cache keys (default: "keyvault:")
 	CachePrefix string
+
+	// NegativeCacheTTL is how long a secret that doesn't exist is cached as
+	// not found, so repeated lookups don't reach KeyVault (default: 30s,
+	// negative disables). Must not exceed CacheTTL.
+	NegativeCacheTTL time.Duration
 }
 
+// DefaultNegativeCacheTTL is used when CachedClientConfig.NegativeCacheTTL is
+// not set
+const DefaultNegativeCacheTTL = 30 * time.Second
+
 // RedisConfig for cache-aside pattern
 type RedisConfig struct {
 	// Host is the Redis host
@@ -66,6 +83,10 @@ func (c *ClientConfig) Validate() error {
 		return fmt.Errorf("Timeout must be at least 10 seconds, got %v", c.Timeout)
 	}
 
+	if c.BatchConcurrency < 0 {
+		return fmt.Errorf("BatchConcurrency cannot be negative, got %d", c.BatchConcurrency)
+	}
+
 	return nil
 }
 
@@ -87,6 +108,10 @@ func (c *CachedClientConfig) Validate() error {
 		return fmt.Errorf("CacheTTL must be at least 60 seconds, got %v", c.CacheTTL)
 	}
 
+	if c.NegativeCacheTTL > c.CacheTTL {
+		return fmt.Errorf("NegativeCacheTTL must not exceed CacheTTL (%v), got %v", c.CacheTTL, c.NegativeCacheTTL)
+	}
+
 	return nil
 }
 
@@ -135,12 +160,52 @@ const (
 type UserIntegration struct {
 	Type         IntegrationType   `json:"type"`
 	Status       IntegrationStatus `json:"status"`
-	MaskedKey    string            `json:"masked_key,omitempty"` // Last 4 characters only
-	ExpiresAt    *time.Time        `json:"expires_at,omitempty"`
+	MaskedKey    string            `json:"masked_key"` // Last 3 characters only; empty when not configured
+	ExpiresAt    *time.Time        `json:"expires_at"` // Null when the integration never expires
 	ConfiguredAt *time.Time        `json:"configured_at,omitempty"`
 	Metadata     map[string]string `json:"metadata,omitempty"`
 }
 
+// IntegrationValue is the secret and optional expiry for one integration
+type IntegrationValue struct {
+	Value     string
+	ExpiresAt *time.Time
+}
+
+// BulkIntegrationResult reports the outcome of SetUserIntegrations per integration
+type BulkIntegrationResult struct {
+	Succeeded      []IntegrationType         // Written (and still in place unless RolledBack)
+	Failed         map[IntegrationType]error // Write failed
+	Skipped        []IntegrationType         // Not attempted after an earlier failure
+	RolledBack     []IntegrationType         // Written, then restored to the previous state
+	RollbackFailed map[IntegrationType]error // Written, but could not be restored
+}
+
+// IntegrationSortField selects the sort key for ListUserIntegrationsPage
+type IntegrationSortField string
+
+const (
+	SortByType         IntegrationSortField = "type"          // Catalogue order (default)
+	SortByConfiguredAt IntegrationSortField = "configured_at" // Unconfigured integrations sort last
+)
+
+// ListIntegrationsOptions filters, sorts and pages a user's integrations
+type ListIntegrationsOptions struct {
+	Statuses   []IntegrationStatus  // Only include these statuses (empty = all)
+	SortBy     IntegrationSortField // Default SortByType
+	Descending bool
+	Offset     int
+	Limit      int // 0 = no limit
+}
+
+// IntegrationPage is one page of a user's integrations
+type IntegrationPage struct {
+	Integrations []UserIntegration `json:"integrations"`
+	Total        int               `json:"total"` // Matching integrations before paging
+	Offset       int               `json:"offset"`
+	Limit        int               `json:"limit"`
+}
+
 // Secret represents a KeyVault secret
 type Secret struct {
 	Name      string            `json:"name"`
@@ -154,11 +219,28 @@ type Secret struct {
 	UpdatedOn *time.Time        `json:"updated_on,omitempty"`
 }
 
+// SecretVersionInfo describes one version of a secret, without its value
+type SecretVersionInfo struct {
+	Version   string     `json:"version"`
+	Enabled   bool       `json:"enabled"`
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+	UpdatedOn *time.Time `json:"updated_on,omitempty"`
+}
+
+// createdUnix returns the creation time for ordering; unknown sorts last
+func (v SecretVersionInfo) createdUnix() int64 {
+	if v.CreatedOn == nil {
+		return 0
+	}
+	return v.CreatedOn.UnixNano()
+}
+
 // CacheStats provides cache performance metrics
 type CacheStats struct {
-	Hits       int64         `json:"hits"`
-	Misses     int64         `json:"misses"`
-	HitRate    float64       `json:"hit_rate"`
-	LastSync   time.Time     `json:"last_sync"`
-	AvgLatency time.Duration `json:"avg_latency"`
+	Hits         int64         `json:"hits"`
+	Misses       int64         `json:"misses"`
+	NegativeHits int64         `json:"negative_hits"` // Hits that found a secret cached as not found (included in Hits)
+	HitRate      float64       `json:"hit_rate"`
+	LastSync     time.Time     `json:"last_sync"`
+	AvgLatency   time.Duration `json:"avg_latency"`
 }
@@ -24,16 +24,29 @@ type ClientConfig struct {
 type Client interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value interface{}) error
+	SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	SetIfExists(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
 	Del(ctx context.Context, keys ...string) error
 	SMembers(ctx context.Context, key string) ([]string, error)
 	SAdd(ctx context.Context, key string, members ...interface{}) error
 	SRem(ctx context.Context, key string, members ...interface{}) error
 	LRange(ctx context.Context, key string, start, stop int64) ([]string, error)
+	ZAdd(ctx context.Context, key string, members ...ScoredMember) error
+	ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error)
+	ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]ScoredMember, error)
+	ZRevRank(ctx context.Context, key string, member string) (int64, error)
 	Expire(ctx context.Context, key string, duration time.Duration) error
 	Health(ctx context.Context) error
 	Close(ctx context.Context) error
 }
 
+// ScoredMember is a sorted set member and its score
+type ScoredMember struct {
+	Member string
+	Score  float64
+}
+
 // redisClient implements the Client interface
 type redisClient struct {
 	client *redis.Client
@@ -121,23 +134,19 @@ func (r *redisClient) Get(ctx context.Context, key string) (string, error) {
 
 // Set stores a value in Redis
 func (r *redisClient) Set(ctx context.Context, key string, value interface{}) error {
-	var strValue string
-	switch v := value.(type) {
-	case string:
-		strValue = v
-	default:
-		// Marshal to JSON for complex types
-		jsonBytes, err := json.Marshal(v)
-		if err != nil {
-			if r.logger != nil {
-				r.logger.Error("redis_set_marshal_failed", zap.String("key", key), zap.Error(err))
-			}
-			return err
-		}
-		strValue = string(jsonBytes)
-	}
-
-	if err := r.client.Set(ctx, key, strValue, 0).Err(); err != nil {
+	return r.SetWithTTL(ctx, key, value, 0)
+}
+
+// SetWithTTL stores a value and its expiration in one command (SET EX), so
+// the key is never left without a TTL. A zero ttl stores the value without
+// expiration, like Set.
+func (r *redisClient) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	strValue, err := r.encode(key, value)
+	if err != nil {
+		return err
+	}
+
+	if err := r.client.Set(ctx, key, strValue, ttl).Err(); err != nil {
 		if r.logger != nil {
 			r.logger.Error("redis_set_failed", zap.String("key", key), zap.Error(err))
 		}
@@ -146,6 +155,61 @@ func (r *redisClient) Set(ctx context.Context, key string, value interface{}) er
 	return nil
 }
 
+// SetIfExists overwrites a value and its expiration in one command
+// (SET XX EX). It reports false without writing if the key doesn't exist,
+// so a key deleted concurrently is never recreated.
+func (r *redisClient) SetIfExists(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	strValue, err := r.encode(key, value)
+	if err != nil {
+		return false, err
+	}
+
+	ok, err := r.client.SetXX(ctx, key, strValue, ttl).Result()
+	if err != nil {
+		if r.logger != nil {
+			r.logger.Error("redis_setxx_failed", zap.String("key", key), zap.Error(err))
+		}
+		return false, err
+	}
+	return ok, nil
+}
+
+// SetNX stores a value and its expiration only if the key doesn't exist, in
+// one command (SET NX EX). It reports false without writing if the key is
+// already set, so of several concurrent callers exactly one succeeds.
+func (r *redisClient) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	strValue, err := r.encode(key, value)
+	if err != nil {
+		return false, err
+	}
+
+	ok, err := r.client.SetNX(ctx, key, strValue, ttl).Result()
+	if err != nil {
+		if r.logger != nil {
+			r.logger.Error("redis_setnx_failed", zap.String("key", key), zap.Error(err))
+		}
+		return false, err
+	}
+	return ok, nil
+}
+
+// encode converts a value to its stored string form
+func (r *redisClient) encode(key string, value interface{}) (string, error) {
+	if v, ok := value.(string); ok {
+		return v, nil
+	}
+
+	// Marshal to JSON for complex types
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		if r.logger != nil {
+			r.logger.Error("redis_set_marshal_failed", zap.String("key", key), zap.Error(err))
+		}
+		return "", err
+	}
+	return string(jsonBytes), nil
+}
+
 // Del deletes keys from Redis
 func (r *redisClient) Del(ctx context.Context, keys ...string) error {
 	if len(keys) == 0 {
@@ -212,6 +276,67 @@ func (r *redisClient) LRange(ctx context.Context, key string, start, stop int64)
 	return vals, nil
 }
 
+// ZAdd adds members to a sorted set, overwriting the scores of existing members
+func (r *redisClient) ZAdd(ctx context.Context, key string, members ...ScoredMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+	zs := make([]redis.Z, len(members))
+	for i, m := range members {
+		zs[i] = redis.Z{Score: m.Score, Member: m.Member}
+	}
+	if err := r.client.ZAdd(ctx, key, zs...).Err(); err != nil {
+		if r.logger != nil {
+			r.logger.Error("redis_zadd_failed", zap.String("key", key), zap.Error(err))
+		}
+		return err
+	}
+	return nil
+}
+
+// ZIncrBy atomically adds increment to member's score and returns the new score
+func (r *redisClient) ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error) {
+	score, err := r.client.ZIncrBy(ctx, key, increment, member).Result()
+	if err != nil {
+		if r.logger != nil {
+			r.logger.Error("redis_zincrby_failed", zap.String("key", key), zap.String("member", member), zap.Error(err))
+		}
+		return 0, err
+	}
+	return score, nil
+}
+
+// ZRevRangeWithScores returns sorted set members by descending score
+func (r *redisClient) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]ScoredMember, error) {
+	zs, err := r.client.ZRevRangeWithScores(ctx, key, start, stop).Result()
+	if err != nil {
+		if r.logger != nil {
+			r.logger.Error("redis_zrevrange_failed", zap.String("key", key), zap.Int64("start", start), zap.Int64("stop", stop), zap.Error(err))
+		}
+		return nil, err
+	}
+	members := make([]ScoredMember, len(zs))
+	for i, z := range zs {
+		members[i] = ScoredMember{Member: fmt.Sprint(z.Member), Score: z.Score}
+	}
+	return members, nil
+}
+
+// ZRevRank returns member's 0-based rank by descending score, or -1 if it is not in the set
+func (r *redisClient) ZRevRank(ctx context.Context, key string, member string) (int64, error) {
+	rank, err := r.client.ZRevRank(ctx, key, member).Result()
+	if err == redis.Nil {
+		return -1, nil // Member doesn't exist
+	}
+	if err != nil {
+		if r.logger != nil {
+			r.logger.Error("redis_zrevrank_failed", zap.String("key", key), zap.String("member", member), zap.Error(err))
+		}
+		return 0, err
+	}
+	return rank, nil
+}
+
 // Expire sets expiration time on a key
 func (r *redisClient) Expire(ctx context.Context, key string, duration time.Duration) error {
 	if err := r.client.Expire(ctx, key, duration).Err(); err != nil {
@@ -0,0 +1,137 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ConnectFunc establishes a new Redis client
+type ConnectFunc func() (Client, error)
+
+// ReconnectingClient is a Client whose underlying connection can be replaced
+// at runtime (see health.Supervisor). Callers keep a single Client reference
+// while Reconnect swaps in a fresh connection after a drop.
+type ReconnectingClient struct {
+	mu      sync.RWMutex
+	current Client
+	connect ConnectFunc
+}
+
+// NewReconnectingClient wraps an established client; connect is used to re-establish it
+func NewReconnectingClient(initial Client, connect ConnectFunc) *ReconnectingClient {
+	return &ReconnectingClient{
+		current: initial,
+		connect: connect,
+	}
+}
+
+// Reconnect establishes a new connection and closes the previous one.
+// The previous connection stays in use if the new one cannot be established.
+func (c *ReconnectingClient) Reconnect(ctx context.Context) error {
+	next, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	previous := c.current
+	c.current = next
+	c.mu.Unlock()
+
+	if previous != nil {
+		previous.Close(ctx)
+	}
+	return nil
+}
+
+func (c *ReconnectingClient) client() Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current
+}
+
+// Get retrieves a value from Redis
+func (c *ReconnectingClient) Get(ctx context.Context, key string) (string, error) {
+	return c.client().Get(ctx, key)
+}
+
+// Set stores a value in Redis
+func (c *ReconnectingClient) Set(ctx context.Context, key string, value interface{}) error {
+	return c.client().Set(ctx, key, value)
+}
+
+// SetWithTTL stores a value with an expiration
+func (c *ReconnectingClient) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return c.client().SetWithTTL(ctx, key, value, ttl)
+}
+
+// SetIfExists overwrites an existing value with an expiration
+func (c *ReconnectingClient) SetIfExists(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	return c.client().SetIfExists(ctx, key, value, ttl)
+}
+
+// SetNX stores a value with an expiration if the key doesn't exist
+func (c *ReconnectingClient) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	return c.client().SetNX(ctx, key, value, ttl)
+}
+
+// Del deletes keys from Redis
+func (c *ReconnectingClient) Del(ctx context.Context, keys ...string) error {
+	return c.client().Del(ctx, keys...)
+}
+
+// SMembers returns all members of a set
+func (c *ReconnectingClient) SMembers(ctx context.Context, key string) ([]string, error) {
+	return c.client().SMembers(ctx, key)
+}
+
+// SAdd adds members to a set
+func (c *ReconnectingClient) SAdd(ctx context.Context, key string, members ...interface{}) error {
+	return c.client().SAdd(ctx, key, members...)
+}
+
+// SRem removes members from a set
+func (c *ReconnectingClient) SRem(ctx context.Context, key string, members ...interface{}) error {
+	return c.client().SRem(ctx, key, members...)
+}
+
+// LRange returns a range of list elements
+func (c *ReconnectingClient) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	return c.client().LRange(ctx, key, start, stop)
+}
+
+// ZAdd adds members to a sorted set
+func (c *ReconnectingClient) ZAdd(ctx context.Context, key string, members ...ScoredMember) error {
+	return c.client().ZAdd(ctx, key, members...)
+}
+
+// ZIncrBy atomically increments a sorted set member's score
+func (c *ReconnectingClient) ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error) {
+	return c.client().ZIncrBy(ctx, key, increment, member)
+}
+
+// ZRevRangeWithScores returns sorted set members by descending score
+func (c *ReconnectingClient) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]ScoredMember, error) {
+	return c.client().ZRevRangeWithScores(ctx, key, start, stop)
+}
+
+// ZRevRank returns a member's rank by descending score, or -1 if absent
+func (c *ReconnectingClient) ZRevRank(ctx context.Context, key string, member string) (int64, error) {
+	return c.client().ZRevRank(ctx, key, member)
+}
+
+// Expire sets a key's time to live
+func (c *ReconnectingClient) Expire(ctx context.Context, key string, duration time.Duration) error {
+	return c.client().Expire(ctx, key, duration)
+}
+
+// Health checks the current connection
+func (c *ReconnectingClient) Health(ctx context.Context) error {
+	return c.client().Health(ctx)
+}
+
+// Close closes the current connection
+func (c *ReconnectingClient) Close(ctx context.Context) error {
+	return c.client().Close(ctx)
+}
@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// stubClient implements Client for the methods exercised by ReconnectingClient tests
+type stubClient struct {
+	Client
+	name   string
+	health error
+	closed bool
+}
+
+func (s *stubClient) Get(ctx context.Context, key string) (string, error) { return s.name, nil }
+func (s *stubClient) Health(ctx context.Context) error                    { return s.health }
+func (s *stubClient) Close(ctx context.Context) error {
+	s.closed = true
+	return nil
+}
+
+func TestReconnectingClient_SwapsConnection(t *testing.T) {
+	ctx := context.Background()
+	dropped := &stubClient{name: "first", health: errors.New("connection reset")}
+	fresh := &stubClient{name: "second"}
+
+	client := NewReconnectingClient(dropped, func() (Client, error) { return fresh, nil })
+
+	if err := client.Health(ctx); err == nil {
+		t.Fatal("Health() error = nil, want error from dropped connection")
+	}
+
+	if err := client.Reconnect(ctx); err != nil {
+		t.Fatalf("Reconnect() error = %v", err)
+	}
+
+	if got, _ := client.Get(ctx, "key"); got != "second" {
+		t.Errorf("Get() served by %v, want second", got)
+	}
+	if err := client.Health(ctx); err != nil {
+		t.Errorf("Health() error = %v after reconnect", err)
+	}
+	if !dropped.closed {
+		t.Error("previous connection should be closed after reconnect")
+	}
+}
+
+func TestReconnectingClient_KeepsConnectionWhenReconnectFails(t *testing.T) {
+	ctx := context.Background()
+	current := &stubClient{name: "first"}
+
+	client := NewReconnectingClient(current, func() (Client, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	if err := client.Reconnect(ctx); err == nil {
+		t.Fatal("Reconnect() error = nil, want error")
+	}
+	if got, _ := client.Get(ctx, "key"); got != "first" {
+		t.Errorf("Get() served by %v, want first", got)
+	}
+	if current.closed {
+		t.Error("current connection should not be closed when reconnect fails")
+	}
+}
@@ -0,0 +1,81 @@
+package scylladb
+
+import (
+	"context"
+	"sync"
+)
+
+// ConnectFunc establishes a new ScyllaDB session
+type ConnectFunc func() (Session, error)
+
+// ReconnectingSession is a Session whose underlying connection can be replaced
+// at runtime (see health.Supervisor). Callers keep a single Session reference
+// while Reconnect swaps in a fresh session after a drop.
+type ReconnectingSession struct {
+	mu      sync.RWMutex
+	current Session
+	connect ConnectFunc
+}
+
+// NewReconnectingSession wraps an established session; connect is used to re-establish it
+func NewReconnectingSession(initial Session, connect ConnectFunc) *ReconnectingSession {
+	return &ReconnectingSession{
+		current: initial,
+		connect: connect,
+	}
+}
+
+// Reconnect establishes a new session and closes the previous one.
+// The previous session stays in use if the new one cannot be established.
+func (s *ReconnectingSession) Reconnect(ctx context.Context) error {
+	next, err := s.connect()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	previous := s.current
+	s.current = next
+	s.mu.Unlock()
+
+	if previous != nil {
+		previous.Close(ctx)
+	}
+	return nil
+}
+
+func (s *ReconnectingSession) session() Session {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// QueryContext executes a query without returning rows
+func (s *ReconnectingSession) QueryContext(ctx context.Context, query string, args ...interface{}) error {
+	return s.session().QueryContext(ctx, query, args...)
+}
+
+// ExecContext executes a statement
+func (s *ReconnectingSession) ExecContext(ctx context.Context, query string, args ...interface{}) error {
+	return s.session().ExecContext(ctx, query, args...)
+}
+
+// QueryRow executes a query returning a single row
+func (s *ReconnectingSession) QueryRow(ctx context.Context, query string, args ...interface{}) Row {
+	return s.session().QueryRow(ctx, query, args...)
+}
+
+// QueryIter executes a query returning an iterator
+func (s *ReconnectingSession) QueryIter(ctx context.Context, query string, args ...interface{}) Iterator {
+	return s.session().QueryIter(ctx, query, args...)
+}
+
+// Health checks the current session
+func (s *ReconnectingSession) Health(ctx context.Context) error {
+	return s.session().Health(ctx)
+}
+
+// Close closes the current session
+func (s *ReconnectingSession) Close(ctx context.Context) error {
+	return s.session().Close(ctx)
+}
@@ -3,6 +3,7 @@ package logger
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"go.uber.org/zap"
@@ -19,8 +20,73 @@ type Config struct {
 	// Advanced options
 	EnableCaller     bool // Include caller information (file:line)
 	EnableStacktrace bool // Include stacktrace for errors
+
+	// Optional: output encoding and field key names
+	EncoderConfig EncoderConfig
+
+	// Optional: sampling of repetitive entries. Nil keeps zap's defaults
+	// (100 then every 100th per second in production, none in development).
+	Sampling *SamplingConfig
+
+	// Optional: zap sink URLs or file paths for entries and for the logger's
+	// own errors. Default: stderr.
+	OutputPaths      []string
+	ErrorOutputPaths []string
+
+	// Optional: write entries and logger errors to Writer instead of the
+	// output paths, e.g. a bytes.Buffer in tests
+	Writer io.Writer
+}
+
+// SamplingConfig caps identical entries (same level and message) per second:
+// the first Initial are logged, then every Thereafter-th. Thereafter 0 drops
+// the rest until the next second.
+type SamplingConfig struct {
+	Initial    int
+	Thereafter int
+}
+
+// Encoding selects the log output format
+type Encoding string
+
+const (
+	EncodingJSON    Encoding = "json"
+	EncodingConsole Encoding = "console"
+	// EncodingECS is JSON with Elastic Common Schema keys (@timestamp,
+	// log.level, trace.id, service.component, error.code, ...)
+	EncodingECS Encoding = "ecs"
+)
+
+// EncoderConfig controls how log entries are encoded
+type EncoderConfig struct {
+	// Default: console in development, JSON otherwise
+	Encoding Encoding
+
+	// FieldKeys renames keys in the output, e.g. "correlation_id": "trace.id".
+	// Applies to the standard keys (timestamp, level, message, caller,
+	// stacktrace) and to every field, so call sites don't change. Overrides
+	// the ECS names when Encoding is EncodingECS.
+	FieldKeys map[string]string
+}
+
+// ecsFieldKeys maps this package's keys to their Elastic Common Schema names
+var ecsFieldKeys = map[string]string{
+	"timestamp":      "@timestamp",
+	"level":          "log.level",
+	"caller":         "log.origin.file.name",
+	"stacktrace":     "error.stack_trace",
+	"service":        "service.name",
+	"environment":    "service.environment",
+	"version":        "service.version",
+	"correlation_id": "trace.id",
+	"component":      "service.component",
+	"error_code":     "error.code",
+	"error":          "error.message",
 }
 
+// ecsVersion is the ECS version the ECS encoding follows
+const ecsVersion = "8.11.0"
+
 // Logger wraps zap.Logger with additional SRE functionality
 type Logger struct {
 	*zap.Logger
@@ -34,17 +100,47 @@ type ContextLogger struct {
 	component     string
 }
 
-// ContextKey type for context values
+// contextKey is unexported so only this package can set or read the values;
+// use the ContextWith* setters and *FromContext getters
 type contextKey string
 
 const (
-	// CorrelationIDKey is the context key for correlation IDs
-	CorrelationIDKey contextKey = "correlation_id"
-
-	// ComponentKey is the context key for component names
-	ComponentKey contextKey = "component"
+	correlationIDKey contextKey = "correlation_id"
+	componentKey     contextKey = "component"
 )
 
+// ContextWithCorrelationID returns a copy of ctx carrying correlationID,
+// which WithContext picks up
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+// ContextWithComponent returns a copy of ctx carrying the component name,
+// which WithContext picks up
+func ContextWithComponent(ctx context.Context, component string) context.Context {
+	return context.WithValue(ctx, componentKey, component)
+}
+
+// CorrelationIDFromContext returns the correlation ID set by
+// ContextWithCorrelationID, or "" if ctx has none
+func CorrelationIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	correlationID, _ := ctx.Value(correlationIDKey).(string)
+	return correlationID
+}
+
+// ComponentFromContext returns the component name set by
+// ContextWithComponent, or "" if ctx has none
+func ComponentFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	component, _ := ctx.Value(componentKey).(string)
+	return component
+}
+
 // New creates a new structured logger with consistent configuration
 func New(cfg Config) (*Logger, error) {
 	var config zap.Config
@@ -64,6 +160,23 @@ func New(cfg Config) (*Logger, error) {
 	config.EncoderConfig.CallerKey = "caller"
 	config.EncoderConfig.StacktraceKey = "stacktrace"
 
+	fieldKeys := applyEncoding(&config, cfg.EncoderConfig)
+
+	// Sampling is applied below, outside the renaming core, rather than by
+	// config.Build, which would put it inside where renamingCore.Check skips it
+	sampling := cfg.Sampling
+	if sampling == nil && config.Sampling != nil {
+		sampling = &SamplingConfig{Initial: config.Sampling.Initial, Thereafter: config.Sampling.Thereafter}
+	}
+	config.Sampling = nil
+
+	if len(cfg.OutputPaths) > 0 {
+		config.OutputPaths = cfg.OutputPaths
+	}
+	if len(cfg.ErrorOutputPaths) > 0 {
+		config.ErrorOutputPaths = cfg.ErrorOutputPaths
+	}
+
 	// Set log level if specified
 	if cfg.LogLevel != "" {
 		var level zapcore.Level
@@ -74,6 +187,25 @@ func New(cfg Config) (*Logger, error) {
 
 	// Configure caller and stacktrace
 	options := []zap.Option{}
+	if cfg.Writer != nil {
+		// Replace the core built from the output paths; must come first so
+		// the wraps below sit on top of it
+		sink := zapcore.AddSync(cfg.Writer)
+		options = append(options, zap.WrapCore(func(zapcore.Core) zapcore.Core {
+			return zapcore.NewCore(newEncoder(config), sink, config.Level)
+		}), zap.ErrorOutput(sink))
+	}
+	if len(fieldKeys) > 0 {
+		// Wrap before adding the service fields below so they're renamed too
+		options = append(options, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &renamingCore{Core: core, keys: fieldKeys}
+		}))
+	}
+	if sampling != nil {
+		options = append(options, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return newSampler(core, sampling)
+		}))
+	}
 	if cfg.EnableCaller {
 		options = append(options, zap.AddCaller())
 	}
@@ -90,6 +222,9 @@ func New(cfg Config) (*Logger, error) {
 	if cfg.Version != "" {
 		fields = append(fields, zap.String("version", cfg.Version))
 	}
+	if cfg.EncoderConfig.Encoding == EncodingECS {
+		fields = append(fields, zap.String("ecs.version", ecsVersion))
+	}
 
 	options = append(options, zap.Fields(fields...))
 
@@ -104,6 +239,90 @@ func New(cfg Config) (*Logger, error) {
 	}, nil
 }
 
+// applyEncoding sets the encoding and standard keys on config and returns the
+// remaining field keys to rename
+func applyEncoding(config *zap.Config, enc EncoderConfig) map[string]string {
+	keys := map[string]string{}
+	switch enc.Encoding {
+	case EncodingECS:
+		for k, v := range ecsFieldKeys {
+			keys[k] = v
+		}
+		config.Encoding = "json"
+	case EncodingJSON, EncodingConsole:
+		config.Encoding = string(enc.Encoding)
+	}
+	if config.Encoding == "json" {
+		// Color codes only make sense on a terminal
+		config.EncoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+	}
+	for k, v := range enc.FieldKeys {
+		keys[k] = v
+	}
+
+	// The standard keys are set on the encoder; the rest are fields
+	standard := map[string]*string{
+		"timestamp":  &config.EncoderConfig.TimeKey,
+		"level":      &config.EncoderConfig.LevelKey,
+		"message":    &config.EncoderConfig.MessageKey,
+		"caller":     &config.EncoderConfig.CallerKey,
+		"stacktrace": &config.EncoderConfig.StacktraceKey,
+	}
+	for k, key := range standard {
+		if v, ok := keys[k]; ok {
+			*key = v
+			delete(keys, k)
+		}
+	}
+	return keys
+}
+
+// newEncoder builds the encoder config.Build would use
+func newEncoder(config zap.Config) zapcore.Encoder {
+	if config.Encoding == "console" {
+		return zapcore.NewConsoleEncoder(config.EncoderConfig)
+	}
+	return zapcore.NewJSONEncoder(config.EncoderConfig)
+}
+
+// newSampler wraps core to log the first Initial identical entries each
+// second, then every Thereafter-th
+func newSampler(core zapcore.Core, sampling *SamplingConfig) zapcore.Core {
+	return zapcore.NewSamplerWithOptions(core, time.Second, sampling.Initial, sampling.Thereafter)
+}
+
+// renamingCore renames field keys before they reach the encoder
+type renamingCore struct {
+	zapcore.Core
+	keys map[string]string
+}
+
+func (c *renamingCore) rename(fields []zapcore.Field) []zapcore.Field {
+	renamed := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		if key, ok := c.keys[f.Key]; ok {
+			f.Key = key
+		}
+		renamed[i] = f
+	}
+	return renamed
+}
+
+func (c *renamingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &renamingCore{Core: c.Core.With(c.rename(fields)), keys: c.keys}
+}
+
+func (c *renamingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *renamingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.rename(fields))
+}
+
 // NewProduction creates a production logger with standard settings
 // Enables caller info and stacktraces for errors
 func NewProduction(serviceName, version string) (*Logger, error) {
@@ -132,17 +351,8 @@ func NewDevelopment(serviceName, version string) (*Logger, error) {
 // WithContext creates a context-aware logger from the context
 // Extracts correlation_id and component from context if available
 func (l *Logger) WithContext(ctx context.Context) *ContextLogger {
-	correlationID := ""
-	component := ""
-
-	if ctx != nil {
-		if corrID, ok := ctx.Value(CorrelationIDKey).(string); ok {
-			correlationID = corrID
-		}
-		if comp, ok := ctx.Value(ComponentKey).(string); ok {
-			component = comp
-		}
-	}
+	correlationID := CorrelationIDFromContext(ctx)
+	component := ComponentFromContext(ctx)
 
 	fields := []zap.Field{}
 	if correlationID != "" {
@@ -217,6 +427,23 @@ func (cl *ContextLogger) WithCorrelation(correlationID string) *ContextLogger {
 	}
 }
 
+// WithFields creates a logger carrying fields on every entry, e.g. an
+// order_id bound once per request
+func (l *Logger) WithFields(fields ...zap.Field) *ContextLogger {
+	return &ContextLogger{
+		Logger: l.Logger.With(fields...),
+	}
+}
+
+// WithFields adds fields to existing context logger
+func (cl *ContextLogger) WithFields(fields ...zap.Field) *ContextLogger {
+	return &ContextLogger{
+		Logger:        cl.Logger.With(fields...),
+		correlationID: cl.correlationID,
+		component:     cl.component,
+	}
+}
+
 // GetCorrelationID returns the correlation ID from the context logger
 func (cl *ContextLogger) GetCorrelationID() string {
 	return cl.correlationID
@@ -1,7 +1,11 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -116,18 +120,25 @@ func TestWithContext(t *testing.T) {
 	}{
 		{
 			name:              "context with correlation ID and component",
-			ctx:               context.WithValue(context.WithValue(context.Background(), CorrelationIDKey, "test-corr-123"), ComponentKey, "TestComponent"),
+			ctx:               ContextWithComponent(ContextWithCorrelationID(context.Background(), "test-corr-123"), "TestComponent"),
 			message:           "test message",
 			wantCorrelationID: "test-corr-123",
 			wantComponent:     "TestComponent",
 		},
 		{
 			name:              "context with correlation ID only",
-			ctx:               context.WithValue(context.Background(), CorrelationIDKey, "test-corr-456"),
+			ctx:               ContextWithCorrelationID(context.Background(), "test-corr-456"),
 			message:           "test message",
 			wantCorrelationID: "test-corr-456",
 			wantComponent:     "",
 		},
+		{
+			name:              "context with component only",
+			ctx:               ContextWithComponent(context.Background(), "Worker"),
+			message:           "test message",
+			wantCorrelationID: "",
+			wantComponent:     "Worker",
+		},
 		{
 			name:              "empty context",
 			ctx:               context.Background(),
@@ -332,6 +343,49 @@ func TestContextLoggerChaining(t *testing.T) {
 	}
 }
 
+func TestContextLoggerChaining_WithFields(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Logger{
+		Logger:      zap.New(core),
+		serviceName: "test-service",
+	}
+
+	// Bind order_id once, then keep chaining
+	contextLogger := logger.
+		WithFields(zap.String("order_id", "order-42")).
+		WithCorrelation("test-corr-777").
+		WithFields(zap.String("user_id", "user-7"), zap.Int("attempt", 2)).
+		WithComponent("OrderService")
+
+	contextLogger.Info("first")
+	contextLogger.Info("second")
+
+	entries := recorded.All()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+
+	for _, entry := range entries {
+		fields := entry.ContextMap()
+		if fields["order_id"] != "order-42" {
+			t.Errorf("%s: order_id = %v, want order-42", entry.Message, fields["order_id"])
+		}
+		if fields["user_id"] != "user-7" {
+			t.Errorf("%s: user_id = %v, want user-7", entry.Message, fields["user_id"])
+		}
+		if fields["attempt"] != int64(2) {
+			t.Errorf("%s: attempt = %v, want 2", entry.Message, fields["attempt"])
+		}
+		if fields["correlation_id"] != "test-corr-777" || fields["component"] != "OrderService" {
+			t.Errorf("%s: correlation_id = %v, component = %v", entry.Message, fields["correlation_id"], fields["component"])
+		}
+	}
+
+	if contextLogger.GetCorrelationID() != "test-corr-777" || contextLogger.GetComponent() != "OrderService" {
+		t.Errorf("GetCorrelationID() = %q, GetComponent() = %q", contextLogger.GetCorrelationID(), contextLogger.GetComponent())
+	}
+}
+
 func TestGenerateCorrelationID(t *testing.T) {
 	serviceName := "test-service"
 	corrID1 := GenerateCorrelationID(serviceName)
@@ -358,3 +412,255 @@ func TestGenerateCorrelationID(t *testing.T) {
 		t.Errorf("correlation ID too short: %v", corrID1)
 	}
 }
+
+func TestContextGetters(t *testing.T) {
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		wantCorrID    string
+		wantComponent string
+	}{
+		{
+			name:          "values set",
+			ctx:           ContextWithComponent(ContextWithCorrelationID(context.Background(), "corr-123"), "OrderService"),
+			wantCorrID:    "corr-123",
+			wantComponent: "OrderService",
+		},
+		{
+			name: "empty context",
+			ctx:  context.Background(),
+		},
+		{
+			name: "nil context",
+			ctx:  nil,
+		},
+		{
+			name: "wrong type in context",
+			ctx:  context.WithValue(context.WithValue(context.Background(), correlationIDKey, 123), componentKey, 456),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CorrelationIDFromContext(tt.ctx); got != tt.wantCorrID {
+				t.Errorf("CorrelationIDFromContext() = %q, want %q", got, tt.wantCorrID)
+			}
+			if got := ComponentFromContext(tt.ctx); got != tt.wantComponent {
+				t.Errorf("ComponentFromContext() = %q, want %q", got, tt.wantComponent)
+			}
+		})
+	}
+}
+
+// captureStderr runs fn with os.Stderr redirected and returns what was written
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "stderr")
+	if err != nil {
+		t.Fatalf("CreateTemp() error = %v", err)
+	}
+	defer f.Close()
+
+	orig := os.Stderr
+	os.Stderr = f
+	defer func() { os.Stderr = orig }()
+	fn()
+
+	out, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	return string(out)
+}
+
+// logOnce builds a logger from cfg and logs one error with the usual fields
+func logOnce(t *testing.T, cfg Config) map[string]interface{} {
+	t.Helper()
+	out := captureStderr(t, func() {
+		log, err := New(cfg)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		ctx := ContextWithCorrelationID(context.Background(), "corr-123")
+		log.WithContext(ctx).WithComponent("OrderService").WithError("ORD-001", "high").Error("order failed")
+		log.Sync()
+	})
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &entry); err != nil {
+		t.Fatalf("output is not one JSON entry: %v\n%s", err, out)
+	}
+	return entry
+}
+
+func TestNew_FieldKeysRemapped(t *testing.T) {
+	entry := logOnce(t, Config{
+		ServiceName: "test-service",
+		Environment: "production",
+		EncoderConfig: EncoderConfig{
+			FieldKeys: map[string]string{
+				"correlation_id": "trace.id",
+				"message":        "msg",
+			},
+		},
+	})
+
+	if entry["trace.id"] != "corr-123" {
+		t.Errorf("trace.id = %v, want corr-123", entry["trace.id"])
+	}
+	if _, ok := entry["correlation_id"]; ok {
+		t.Error("correlation_id still present under its old key")
+	}
+	if entry["msg"] != "order failed" {
+		t.Errorf("msg = %v, want order failed", entry["msg"])
+	}
+	// Keys not in the map are unchanged
+	if entry["component"] != "OrderService" || entry["error_code"] != "ORD-001" {
+		t.Errorf("component = %v, error_code = %v; want them unchanged", entry["component"], entry["error_code"])
+	}
+}
+
+func TestNew_ECSEncoding(t *testing.T) {
+	entry := logOnce(t, Config{
+		ServiceName:   "test-service",
+		Environment:   "development",
+		Version:       "v1.0.0",
+		EncoderConfig: EncoderConfig{Encoding: EncodingECS},
+	})
+
+	want := map[string]string{
+		"trace.id":          "corr-123",
+		"service.component": "OrderService",
+		"service.name":      "test-service",
+		"service.version":   "v1.0.0",
+		"error.code":        "ORD-001",
+		"log.level":         "error",
+		"message":           "order failed",
+		"ecs.version":       ecsVersion,
+	}
+	for key, value := range want {
+		if entry[key] != value {
+			t.Errorf("%s = %v, want %v", key, entry[key], value)
+		}
+	}
+	if _, ok := entry["@timestamp"]; !ok {
+		t.Error("@timestamp missing")
+	}
+}
+
+func TestNewSampler_CapsIdenticalEntries(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(newSampler(core, &SamplingConfig{Initial: 3, Thereafter: 0}))
+
+	for i := 0; i < 50; i++ {
+		log.Error("database unavailable", zap.String("error_code", "PAT-INFRA-001"))
+	}
+	log.Error("a different message")
+
+	if got := logs.FilterMessage("database unavailable").Len(); got != 3 {
+		t.Errorf("logged %d identical entries, want the first 3", got)
+	}
+	if got := logs.FilterMessage("a different message").Len(); got != 1 {
+		t.Errorf("logged %d distinct entries, want 1", got)
+	}
+}
+
+func TestNewSampler_Thereafter(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(newSampler(core, &SamplingConfig{Initial: 2, Thereafter: 10}))
+
+	for i := 0; i < 42; i++ {
+		log.Warn("retrying")
+	}
+
+	// Entries 1-2, then 12, 22, 32, 42
+	if got := logs.Len(); got != 6 {
+		t.Errorf("logged %d entries, want 6", got)
+	}
+}
+
+func TestNew_SamplingWithFieldKeys(t *testing.T) {
+	out := captureStderr(t, func() {
+		log, err := New(Config{
+			ServiceName:   "test",
+			Environment:   "test",
+			Sampling:      &SamplingConfig{Initial: 2},
+			EncoderConfig: EncoderConfig{FieldKeys: map[string]string{"error_code": "error.code"}},
+		})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		for i := 0; i < 10; i++ {
+			log.Error("order failed", zap.String("error_code", "ORD-001"))
+		}
+		log.Sync()
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2 (renaming must not bypass sampling)\n%s", len(lines), out)
+	}
+	if !strings.Contains(lines[0], `"error.code":"ORD-001"`) {
+		t.Errorf("entry = %s, want error_code renamed", lines[0])
+	}
+}
+
+func TestNew_Writer(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(Config{
+		ServiceName:   "test",
+		Environment:   "test",
+		LogLevel:      "info",
+		Writer:        &buf,
+		EncoderConfig: EncoderConfig{FieldKeys: map[string]string{"correlation_id": "trace.id"}},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Debug("below level")
+	log.WithCorrelation("corr-1").Info("order created", zap.String("order_id", "order-42"))
+	log.Sync()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("wrote %d lines, want 1\n%s", len(lines), buf.String())
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("entry is not JSON: %v\n%s", err, lines[0])
+	}
+	for key, want := range map[string]string{
+		"message":  "order created",
+		"service":  "test",
+		"trace.id": "corr-1",
+		"order_id": "order-42",
+	} {
+		if entry[key] != want {
+			t.Errorf("%s = %v, want %s", key, entry[key], want)
+		}
+	}
+}
+
+func TestNew_OutputPaths(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.log")
+	log, err := New(Config{
+		ServiceName: "test",
+		Environment: "test",
+		OutputPaths: []string{path},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Info("written to file")
+	log.Sync()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"message":"written to file"`) {
+		t.Errorf("log file = %s, want the entry", data)
+	}
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxExemplarRunes is the OpenMetrics limit on an exemplar's label names and values
+const maxExemplarRunes = 128
+
+// endpointHistogram is a histogram vec whose buckets can be overridden per
+// endpoint label. Every series shares one metric name; only their le bounds
+// differ, which the exposition format allows.
+type endpointHistogram struct {
+	defaults  *prometheus.HistogramVec
+	overrides map[string]*prometheus.HistogramVec
+}
+
+// newEndpointHistogram creates an unregistered histogram using opts.Buckets
+// except for the endpoints in endpointBuckets
+func newEndpointHistogram(opts prometheus.HistogramOpts, labels []string, endpointBuckets map[string][]float64) *endpointHistogram {
+	h := &endpointHistogram{
+		defaults:  prometheus.NewHistogramVec(opts, labels),
+		overrides: make(map[string]*prometheus.HistogramVec, len(endpointBuckets)),
+	}
+	for endpoint, buckets := range endpointBuckets {
+		endpointOpts := opts
+		endpointOpts.Buckets = buckets
+		h.overrides[endpoint] = prometheus.NewHistogramVec(endpointOpts, labels)
+	}
+	return h
+}
+
+// With returns the observer for labels, using the endpoint's buckets if overridden
+func (h *endpointHistogram) With(labels prometheus.Labels) prometheus.Observer {
+	if vec, ok := h.overrides[labels["endpoint"]]; ok {
+		return vec.With(labels)
+	}
+	return h.defaults.With(labels)
+}
+
+// Describe implements prometheus.Collector. The overrides share the default
+// descriptor, so describing it once covers them.
+func (h *endpointHistogram) Describe(ch chan<- *prometheus.Desc) {
+	h.defaults.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (h *endpointHistogram) Collect(ch chan<- prometheus.Metric) {
+	h.defaults.Collect(ch)
+	for _, vec := range h.overrides {
+		vec.Collect(ch)
+	}
+}
+
+// validExemplar reports whether exemplar is non-empty and within the
+// OpenMetrics size limit; ObserveWithExemplar panics otherwise
+func validExemplar(exemplar prometheus.Labels) bool {
+	if len(exemplar) == 0 {
+		return false
+	}
+	runes := 0
+	for name, value := range exemplar {
+		if !utf8.ValidString(value) {
+			return false
+		}
+		runes += utf8.RuneCountInString(name) + utf8.RuneCountInString(value)
+	}
+	return runes <= maxExemplarRunes
+}
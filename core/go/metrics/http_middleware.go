@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+)
+
+// RouteFunc returns the route template a request matched, e.g. "/orders/{id}",
+// so metrics are labelled per route rather than per URL
+type RouteFunc func(r *http.Request) string
+
+// HTTPMiddleware records latency, traffic, response size and active requests
+// for every request, labelled by method, route and status code. A correlation
+// ID in the request context (logger.CorrelationIDFromContext) is attached to the
+// latency sample as an exemplar.
+// The route is the pattern matched by a net/http ServeMux inside the wrapped
+// handler; requests with no pattern are labelled "unmatched". Use
+// HTTPMiddlewareWithRoute for other routers.
+func HTTPMiddleware(m *ServiceMetrics) func(http.Handler) http.Handler {
+	return HTTPMiddlewareWithRoute(m, nil)
+}
+
+// HTTPMiddlewareWithRoute is HTTPMiddleware with route templates from route,
+// e.g. gorilla/mux's mux.CurrentRoute(r).GetPathTemplate()
+func HTTPMiddlewareWithRoute(m *ServiceMetrics, route RouteFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			m.IncActiveRequests()
+			defer m.DecActiveRequests()
+
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(recorder, r)
+
+			endpoint := ""
+			if route != nil {
+				endpoint = route(r)
+			} else {
+				// ServeMux sets Pattern on the request it's given, which is r
+				endpoint = r.Pattern
+			}
+			if endpoint == "" {
+				endpoint = "unmatched"
+			}
+
+			var exemplar prometheus.Labels
+			if id := logger.CorrelationIDFromContext(r.Context()); id != "" {
+				exemplar = prometheus.Labels{"correlation_id": id}
+			}
+
+			m.RecordRequestWithExemplar(r.Method, endpoint, strconv.Itoa(recorder.status), time.Since(start), exemplar)
+			m.RecordResponseSize(r.Method, endpoint, recorder.bytes)
+		})
+	}
+}
+
+// statusRecorder captures the status code and body size written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (rw *statusRecorder) WriteHeader(code int) {
+	if !rw.wroteHeader {
+		rw.status = code
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *statusRecorder) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (rw *statusRecorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
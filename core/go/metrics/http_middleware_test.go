@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestHTTPMiddleware_ServeMuxPattern(t *testing.T) {
+	metrics := NewServiceMetrics(Config{
+		ServiceName: "test-http",
+		Namespace:   "test_http",
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("order not found"))
+	})
+	handler := HTTPMiddleware(metrics)(mux)
+
+	for _, path := range []string{"/orders/1", "/orders/2", "/unknown"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	orders := testutil.ToFloat64(metrics.requestTotal.With(prometheus.Labels{
+		"service":  "test-http",
+		"method":   "GET",
+		"endpoint": "GET /orders/{id}",
+		"status":   "404",
+	}))
+	if orders != 2 {
+		t.Errorf("requests for GET /orders/{id} = %v, want 2", orders)
+	}
+
+	unmatched := testutil.ToFloat64(metrics.requestTotal.With(prometheus.Labels{
+		"service":  "test-http",
+		"method":   "GET",
+		"endpoint": "unmatched",
+		"status":   "404",
+	}))
+	if unmatched != 1 {
+		t.Errorf("unmatched requests = %v, want 1", unmatched)
+	}
+
+	if active := testutil.ToFloat64(metrics.activeRequests); active != 0 {
+		t.Errorf("active requests = %v, want 0", active)
+	}
+}
+
+func TestHTTPMiddlewareWithRoute(t *testing.T) {
+	metrics := NewServiceMetrics(Config{
+		ServiceName: "test-http-route",
+		Namespace:   "test_http_route",
+	})
+
+	route := func(r *http.Request) string { return "/telemetry/{deviceId}" }
+	handler := HTTPMiddlewareWithRoute(metrics, route)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// No WriteHeader: the status defaults to 200
+		w.Write(make([]byte, 2048))
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/telemetry/device-42", nil))
+
+	requests := testutil.ToFloat64(metrics.requestTotal.With(prometheus.Labels{
+		"service":  "test-http-route",
+		"method":   "GET",
+		"endpoint": "/telemetry/{deviceId}",
+		"status":   "200",
+	}))
+	if requests != 1 {
+		t.Errorf("requests = %v, want 1", requests)
+	}
+
+	expected := `
+# HELP test_http_route_response_size_bytes Response body size in bytes (Golden Signal: Traffic)
+# TYPE test_http_route_response_size_bytes histogram
+test_http_route_response_size_bytes_bucket{endpoint="/telemetry/{deviceId}",method="GET",service="test-http-route",le="100"} 0
+test_http_route_response_size_bytes_bucket{endpoint="/telemetry/{deviceId}",method="GET",service="test-http-route",le="1000"} 0
+test_http_route_response_size_bytes_bucket{endpoint="/telemetry/{deviceId}",method="GET",service="test-http-route",le="10000"} 1
+test_http_route_response_size_bytes_bucket{endpoint="/telemetry/{deviceId}",method="GET",service="test-http-route",le="100000"} 1
+test_http_route_response_size_bytes_bucket{endpoint="/telemetry/{deviceId}",method="GET",service="test-http-route",le="1e+06"} 1
+test_http_route_response_size_bytes_bucket{endpoint="/telemetry/{deviceId}",method="GET",service="test-http-route",le="1e+07"} 1
+test_http_route_response_size_bytes_bucket{endpoint="/telemetry/{deviceId}",method="GET",service="test-http-route",le="1e+08"} 1
+test_http_route_response_size_bytes_bucket{endpoint="/telemetry/{deviceId}",method="GET",service="test-http-route",le="+Inf"} 1
+test_http_route_response_size_bytes_sum{endpoint="/telemetry/{deviceId}",method="GET",service="test-http-route"} 2048
+test_http_route_response_size_bytes_count{endpoint="/telemetry/{deviceId}",method="GET",service="test-http-route"} 1
+`
+	if err := testutil.CollectAndCompare(metrics.responseSize, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
@@ -3,6 +3,7 @@ package metrics
 import (
 	"time"
 
+	"github.com/your-github-org/ai-scaffolder/core/go/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -16,10 +17,11 @@ type ServiceMetrics struct {
 	serviceName string
 
 	// Latency (Golden Signal #1)
-	requestDuration *prometheus.HistogramVec
+	requestDuration *endpointHistogram
 
 	// Traffic (Golden Signal #2)
 	requestTotal *prometheus.CounterVec
+	responseSize *prometheus.HistogramVec
 
 	// Errors (Golden Signal #3)
 	errorTotal *prometheus.CounterVec
@@ -28,6 +30,9 @@ type ServiceMetrics struct {
 	resourceUtilization *prometheus.GaugeVec
 	activeRequests      prometheus.Gauge
 	queueDepth          prometheus.Gauge
+
+	// Graceful shutdown, by phase (for tuning termination grace periods)
+	shutdownPhaseDuration *prometheus.HistogramVec
 }
 
 // Config holds configuration for metrics
@@ -36,7 +41,17 @@ type Config struct {
 	Namespace         string // Prometheus namespace (default: "iot_homeguard")
 	Subsystem         string // Prometheus subsystem (optional)
 	LatencyBuckets    []float64
-	EnableGoProfiling bool // Enable Go runtime metrics
+	SizeBuckets       []float64 // Response size buckets in bytes
+	EnableGoProfiling bool      // Enable Go runtime metrics
+
+	// EndpointBuckets overrides LatencyBuckets for the given endpoint labels,
+	// e.g. seconds-scale buckets for a slow analytics route
+	EndpointBuckets map[string][]float64
+
+	// Registerer the collectors are registered with (default:
+	// prometheus.DefaultRegisterer). Tests can pass prometheus.NewRegistry()
+	// so instances don't collide.
+	Registerer prometheus.Registerer
 }
 
 // NewServiceMetrics creates a new metrics instance for a service
@@ -45,17 +60,27 @@ func NewServiceMetrics(config Config) *ServiceMetrics {
 		config.Namespace = "iot_homeguard"
 	}
 
+	if config.Registerer == nil {
+		config.Registerer = prometheus.DefaultRegisterer
+	}
+	factory := promauto.With(config.Registerer)
+
 	if config.LatencyBuckets == nil {
 		// Default buckets: 10ms, 50ms, 100ms, 250ms, 500ms, 1s, 2.5s, 5s, 10s
 		config.LatencyBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0}
 	}
 
+	if config.SizeBuckets == nil {
+		// Default buckets: 100B, 1KB, 10KB, 100KB, 1MB, 10MB, 100MB
+		config.SizeBuckets = prometheus.ExponentialBuckets(100, 10, 7)
+	}
+
 	m := &ServiceMetrics{
 		serviceName: config.ServiceName,
 	}
 
-	// Latency: Request duration histogram
-	m.requestDuration = promauto.NewHistogramVec(
+	// Latency: Request duration histogram, with per-endpoint bucket overrides
+	m.requestDuration = newEndpointHistogram(
 		prometheus.HistogramOpts{
 			Namespace: config.Namespace,
 			Subsystem: config.Subsystem,
@@ -64,10 +89,12 @@ func NewServiceMetrics(config Config) *ServiceMetrics {
 			Buckets:   config.LatencyBuckets,
 		},
 		[]string{"service", "method", "endpoint", "status"},
+		config.EndpointBuckets,
 	)
+	config.Registerer.MustRegister(m.requestDuration)
 
 	// Traffic: Total requests counter
-	m.requestTotal = promauto.NewCounterVec(
+	m.requestTotal = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: config.Namespace,
 			Subsystem: config.Subsystem,
@@ -77,8 +104,20 @@ func NewServiceMetrics(config Config) *ServiceMetrics {
 		[]string{"service", "method", "endpoint", "status"},
 	)
 
+	// Traffic: Response payload size histogram
+	m.responseSize = factory.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: config.Namespace,
+			Subsystem: config.Subsystem,
+			Name:      "response_size_bytes",
+			Help:      "Response body size in bytes (Golden Signal: Traffic)",
+			Buckets:   config.SizeBuckets,
+		},
+		[]string{"service", "method", "endpoint"},
+	)
+
 	// Errors: Error counter
-	m.errorTotal = promauto.NewCounterVec(
+	m.errorTotal = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: config.Namespace,
 			Subsystem: config.Subsystem,
@@ -89,7 +128,7 @@ func NewServiceMetrics(config Config) *ServiceMetrics {
 	)
 
 	// Saturation: Resource utilization gauge
-	m.resourceUtilization = promauto.NewGaugeVec(
+	m.resourceUtilization = factory.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: config.Namespace,
 			Subsystem: config.Subsystem,
@@ -100,7 +139,7 @@ func NewServiceMetrics(config Config) *ServiceMetrics {
 	)
 
 	// Saturation: Active requests gauge
-	m.activeRequests = promauto.NewGauge(
+	m.activeRequests = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: config.Namespace,
 			Subsystem: config.Subsystem,
@@ -113,7 +152,7 @@ func NewServiceMetrics(config Config) *ServiceMetrics {
 	)
 
 	// Saturation: Queue depth gauge
-	m.queueDepth = promauto.NewGauge(
+	m.queueDepth = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: config.Namespace,
 			Subsystem: config.Subsystem,
@@ -125,12 +164,41 @@ func NewServiceMetrics(config Config) *ServiceMetrics {
 		},
 	)
 
+	// Shutdown: duration of each graceful shutdown phase
+	m.shutdownPhaseDuration = factory.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: config.Namespace,
+			Subsystem: config.Subsystem,
+			Name:      "shutdown_phase_duration_seconds",
+			Help:      "Duration of each graceful shutdown phase in seconds",
+			Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 20, 30, 60},
+		},
+		[]string{"service", "phase"},
+	)
+
 	return m
 }
 
+// RecordShutdownPhase records how long a graceful shutdown phase took (e.g.
+// "drain", "http_server", "clients", "total")
+func (m *ServiceMetrics) RecordShutdownPhase(phase string, duration time.Duration) {
+	m.shutdownPhaseDuration.With(prometheus.Labels{
+		"service": m.serviceName,
+		"phase":   phase,
+	}).Observe(duration.Seconds())
+}
+
 // RecordRequest records a completed request with latency and status
 // Golden Signals: Latency + Traffic
 func (m *ServiceMetrics) RecordRequest(method, endpoint, status string, duration time.Duration) {
+	m.RecordRequestWithExemplar(method, endpoint, status, duration, nil)
+}
+
+// RecordRequestWithExemplar records a completed request, attaching exemplar
+// (e.g. {"correlation_id": id}) to the latency sample so a slow bucket links
+// to a request. Exemplars are only exposed in the OpenMetrics format.
+// Golden Signals: Latency + Traffic
+func (m *ServiceMetrics) RecordRequestWithExemplar(method, endpoint, status string, duration time.Duration, exemplar prometheus.Labels) {
 	labels := prometheus.Labels{
 		"service":  m.serviceName,
 		"method":   method,
@@ -138,10 +206,25 @@ func (m *ServiceMetrics) RecordRequest(method, endpoint, status string, duration
 		"status":   status,
 	}
 
-	m.requestDuration.With(labels).Observe(duration.Seconds())
+	observer := m.requestDuration.With(labels)
+	if eo, ok := observer.(prometheus.ExemplarObserver); ok && validExemplar(exemplar) {
+		eo.ObserveWithExemplar(duration.Seconds(), exemplar)
+	} else {
+		observer.Observe(duration.Seconds())
+	}
 	m.requestTotal.With(labels).Inc()
 }
 
+// RecordResponseSize records the size of a response body
+// Golden Signal: Traffic
+func (m *ServiceMetrics) RecordResponseSize(method, endpoint string, bytes int) {
+	m.responseSize.With(prometheus.Labels{
+		"service":  m.serviceName,
+		"method":   method,
+		"endpoint": endpoint,
+	}).Observe(float64(bytes))
+}
+
 // RecordError records an error occurrence
 // Golden Signal: Errors
 func (m *ServiceMetrics) RecordError(errorCode, severity, component string) {
@@ -155,6 +238,21 @@ func (m *ServiceMetrics) RecordError(errorCode, severity, component string) {
 	m.errorTotal.With(labels).Inc()
 }
 
+// TrackServiceErrors records every ServiceError created in the process
+// (via errors.New, errors.Wrap, or an ErrorRegistry) into the error counter,
+// labelled with the error's code, severity, and component.
+// Returns a function that stops tracking.
+// Golden Signal: Errors
+func (m *ServiceMetrics) TrackServiceErrors() func() {
+	return errors.RegisterHook(func(err *errors.ServiceError) {
+		component := err.Component()
+		if component == "" {
+			component = "unknown"
+		}
+		m.RecordError(err.Code, err.Severity, component)
+	})
+}
+
 // UpdateResourceUtilization updates resource utilization percentage (0-100)
 // Golden Signal: Saturation
 func (m *ServiceMetrics) UpdateResourceUtilization(resourceType string, percentage float64) {
@@ -190,6 +288,7 @@ type RequestTimer struct {
 	method   string
 	endpoint string
 	start    time.Time
+	size     int // Response size in bytes; -1 until SetResponseSize
 }
 
 // NewRequestTimer creates a timer for tracking request duration
@@ -200,13 +299,20 @@ func (m *ServiceMetrics) NewRequestTimer(method, endpoint string) *RequestTimer
 		method:   method,
 		endpoint: endpoint,
 		start:    time.Now(),
+		size:     -1,
 	}
 }
 
+// SetResponseSize sets the response body size that Done or DoneWithError records
+func (t *RequestTimer) SetResponseSize(bytes int) {
+	t.size = bytes
+}
+
 // Done completes the request timer and records metrics
 func (t *RequestTimer) Done(status string) {
 	duration := time.Since(t.start)
 	t.metrics.RecordRequest(t.method, t.endpoint, status, duration)
+	t.recordSize()
 	t.metrics.DecActiveRequests()
 }
 
@@ -215,5 +321,12 @@ func (t *RequestTimer) DoneWithError(status, errorCode, severity, component stri
 	duration := time.Since(t.start)
 	t.metrics.RecordRequest(t.method, t.endpoint, status, duration)
 	t.metrics.RecordError(errorCode, severity, component)
+	t.recordSize()
 	t.metrics.DecActiveRequests()
 }
+
+func (t *RequestTimer) recordSize() {
+	if t.size >= 0 {
+		t.metrics.RecordResponseSize(t.method, t.endpoint, t.size)
+	}
+}
@@ -1,9 +1,12 @@
 package metrics
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/your-github-org/ai-scaffolder/core/go/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
@@ -38,11 +41,12 @@ func TestNewServiceMetrics(t *testing.T) {
 }
 
 func TestNewServiceMetrics_DefaultNamespace(t *testing.T) {
-	// Create with unregistered metrics by using a unique registry
+	// Register with a private registry so the default namespace can't collide
 	registry := prometheus.NewRegistry()
 
 	config := Config{
 		ServiceName: "test-service-2",
+		Registerer:  registry,
 	}
 
 	metrics := NewServiceMetrics(config)
@@ -51,13 +55,28 @@ func TestNewServiceMetrics_DefaultNamespace(t *testing.T) {
 		t.Fatal("NewServiceMetrics returned nil")
 	}
 
-	// Verify default namespace is used
-	// We can't easily check the namespace directly, but we can verify metrics were created
-	if metrics.requestDuration == nil {
-		t.Error("requestDuration should use default namespace")
+	metrics.RecordRequest("GET", "/health", "200", time.Millisecond)
+	count, err := testutil.GatherAndCount(registry, "iot_homeguard_requests_total")
+	if err != nil || count != 1 {
+		t.Errorf("iot_homeguard_requests_total series = %d (err %v), want 1", count, err)
 	}
+}
+
+func TestNewServiceMetrics_Registerer(t *testing.T) {
+	// The same namespace twice would panic on the default registry
+	first := NewServiceMetrics(Config{ServiceName: "test-registerer", Namespace: "test_registerer", Registerer: prometheus.NewRegistry()})
+	second := NewServiceMetrics(Config{ServiceName: "test-registerer", Namespace: "test_registerer", Registerer: prometheus.NewRegistry()})
 
-	_ = registry // Suppress unused warning
+	first.RecordRequest("GET", "/orders", "200", time.Millisecond)
+	second.RecordRequest("GET", "/orders", "200", time.Millisecond)
+	second.RecordRequest("GET", "/orders", "200", time.Millisecond)
+
+	if got := testutil.ToFloat64(first.requestTotal); got != 1 {
+		t.Errorf("first requests_total = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(second.requestTotal); got != 2 {
+		t.Errorf("second requests_total = %v, want 2", got)
+	}
 }
 
 func TestNewServiceMetrics_CustomBuckets(t *testing.T) {
@@ -287,6 +306,23 @@ func TestSetQueueDepth(t *testing.T) {
 	}
 }
 
+func TestRecordShutdownPhase(t *testing.T) {
+	config := Config{
+		ServiceName: "test-shutdown",
+		Namespace:   "test_shutdown",
+	}
+
+	metrics := NewServiceMetrics(config)
+
+	metrics.RecordShutdownPhase("drain", 5*time.Second)
+	metrics.RecordShutdownPhase("http_server", 200*time.Millisecond)
+	metrics.RecordShutdownPhase("http_server", 300*time.Millisecond)
+
+	if count := testutil.CollectAndCount(metrics.shutdownPhaseDuration); count != 2 {
+		t.Errorf("shutdown phase series = %d, want 2", count)
+	}
+}
+
 func TestNewRequestTimer(t *testing.T) {
 	config := Config{
 		ServiceName: "test-timer",
@@ -347,6 +383,59 @@ func TestRequestTimer_Done(t *testing.T) {
 	}
 }
 
+func TestRecordResponseSize(t *testing.T) {
+	config := Config{
+		ServiceName: "test-size",
+		Namespace:   "test_size",
+	}
+
+	metrics := NewServiceMetrics(config)
+
+	metrics.RecordResponseSize("GET", "/api/telemetry", 512)
+	metrics.RecordResponseSize("GET", "/api/telemetry", 4*1024*1024)
+
+	expected := `
+# HELP test_size_response_size_bytes Response body size in bytes (Golden Signal: Traffic)
+# TYPE test_size_response_size_bytes histogram
+test_size_response_size_bytes_bucket{endpoint="/api/telemetry",method="GET",service="test-size",le="100"} 0
+test_size_response_size_bytes_bucket{endpoint="/api/telemetry",method="GET",service="test-size",le="1000"} 1
+test_size_response_size_bytes_bucket{endpoint="/api/telemetry",method="GET",service="test-size",le="10000"} 1
+test_size_response_size_bytes_bucket{endpoint="/api/telemetry",method="GET",service="test-size",le="100000"} 1
+test_size_response_size_bytes_bucket{endpoint="/api/telemetry",method="GET",service="test-size",le="1e+06"} 1
+test_size_response_size_bytes_bucket{endpoint="/api/telemetry",method="GET",service="test-size",le="1e+07"} 2
+test_size_response_size_bytes_bucket{endpoint="/api/telemetry",method="GET",service="test-size",le="1e+08"} 2
+test_size_response_size_bytes_bucket{endpoint="/api/telemetry",method="GET",service="test-size",le="+Inf"} 2
+test_size_response_size_bytes_sum{endpoint="/api/telemetry",method="GET",service="test-size"} 4.194816e+06
+test_size_response_size_bytes_count{endpoint="/api/telemetry",method="GET",service="test-size"} 2
+`
+	if err := testutil.CollectAndCompare(metrics.responseSize, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRequestTimer_ResponseSize(t *testing.T) {
+	config := Config{
+		ServiceName: "test-timer-size",
+		Namespace:   "test_timer_size",
+	}
+
+	metrics := NewServiceMetrics(config)
+
+	// Without SetResponseSize nothing is recorded
+	metrics.NewRequestTimer("GET", "/health").Done("200")
+	if count := testutil.CollectAndCount(metrics.responseSize); count != 0 {
+		t.Errorf("response size series = %d, want 0", count)
+	}
+
+	timer := metrics.NewRequestTimer("GET", "/api/analytics")
+	timer.SetResponseSize(2048)
+	timer.Done("200")
+
+	if count := testutil.CollectAndCount(metrics.responseSize); count != 1 {
+		t.Errorf("response size series = %d, want 1", count)
+	}
+}
+
 func TestRequestTimer_DoneWithError(t *testing.T) {
 	config := Config{
 		ServiceName: "test-timer-error",
@@ -426,3 +515,141 @@ func TestMultipleTimers(t *testing.T) {
 		t.Errorf("active requests after all done = %v, want 0", active)
 	}
 }
+
+func TestTrackServiceErrors(t *testing.T) {
+	config := Config{
+		ServiceName: "test-error-hook",
+		Namespace:   "test_error_hook",
+	}
+
+	metrics := NewServiceMetrics(config)
+	stop := metrics.TrackServiceErrors()
+	defer stop()
+
+	registry := errors.NewErrorRegistry().WithComponent("OrderService")
+	registry.Register(&errors.ErrorDefinition{
+		Code:        "ORD-001",
+		Severity:    errors.SeverityHigh,
+		Description: "Order not found: %v",
+	})
+
+	// Registry-created errors carry the registry component
+	_ = registry.CreateError("ORD-001", "order-123")
+
+	counter := testutil.ToFloat64(metrics.errorTotal.With(prometheus.Labels{
+		"service":    "test-error-hook",
+		"error_code": "ORD-001",
+		"severity":   errors.SeverityHigh,
+		"component":  "OrderService",
+	}))
+	if counter != 1 {
+		t.Errorf("error counter = %v, want 1", counter)
+	}
+
+	// Directly constructed errors fall back to the "unknown" component
+	_ = errors.Wrap(fmt.Errorf("connection refused"), "DB-001", errors.SeverityCritical, "Database unavailable")
+
+	counter = testutil.ToFloat64(metrics.errorTotal.With(prometheus.Labels{
+		"service":    "test-error-hook",
+		"error_code": "DB-001",
+		"severity":   errors.SeverityCritical,
+		"component":  "unknown",
+	}))
+	if counter != 1 {
+		t.Errorf("error counter = %v, want 1", counter)
+	}
+
+	// After stopping, new errors are no longer counted
+	stop()
+	_ = registry.CreateError("ORD-001", "order-456")
+
+	counter = testutil.ToFloat64(metrics.errorTotal.With(prometheus.Labels{
+		"service":    "test-error-hook",
+		"error_code": "ORD-001",
+		"severity":   errors.SeverityHigh,
+		"component":  "OrderService",
+	}))
+	if counter != 1 {
+		t.Errorf("error counter after stop = %v, want 1", counter)
+	}
+}
+
+func TestEndpointBuckets(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	config := Config{
+		ServiceName:     "test-endpoint-buckets",
+		Namespace:       "test_endpoint_buckets",
+		LatencyBuckets:  []float64{0.001, 0.01},
+		EndpointBuckets: map[string][]float64{"/analytics": {1, 5}},
+		Registerer:      registry,
+	}
+
+	metrics := NewServiceMetrics(config)
+
+	metrics.RecordRequest("GET", "/health", "200", 500*time.Microsecond)
+	metrics.RecordRequest("GET", "/analytics", "200", 3*time.Second)
+
+	expected := `
+# HELP test_endpoint_buckets_request_duration_seconds Request latency in seconds (Golden Signal: Latency)
+# TYPE test_endpoint_buckets_request_duration_seconds histogram
+test_endpoint_buckets_request_duration_seconds_bucket{endpoint="/analytics",method="GET",service="test-endpoint-buckets",status="200",le="1"} 0
+test_endpoint_buckets_request_duration_seconds_bucket{endpoint="/analytics",method="GET",service="test-endpoint-buckets",status="200",le="5"} 1
+test_endpoint_buckets_request_duration_seconds_bucket{endpoint="/analytics",method="GET",service="test-endpoint-buckets",status="200",le="+Inf"} 1
+test_endpoint_buckets_request_duration_seconds_sum{endpoint="/analytics",method="GET",service="test-endpoint-buckets",status="200"} 3
+test_endpoint_buckets_request_duration_seconds_count{endpoint="/analytics",method="GET",service="test-endpoint-buckets",status="200"} 1
+test_endpoint_buckets_request_duration_seconds_bucket{endpoint="/health",method="GET",service="test-endpoint-buckets",status="200",le="0.001"} 1
+test_endpoint_buckets_request_duration_seconds_bucket{endpoint="/health",method="GET",service="test-endpoint-buckets",status="200",le="0.01"} 1
+test_endpoint_buckets_request_duration_seconds_bucket{endpoint="/health",method="GET",service="test-endpoint-buckets",status="200",le="+Inf"} 1
+test_endpoint_buckets_request_duration_seconds_sum{endpoint="/health",method="GET",service="test-endpoint-buckets",status="200"} 0.0005
+test_endpoint_buckets_request_duration_seconds_count{endpoint="/health",method="GET",service="test-endpoint-buckets",status="200"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"test_endpoint_buckets_request_duration_seconds"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecordRequestWithExemplar(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	config := Config{
+		ServiceName: "test-exemplar",
+		Namespace:   "test_exemplar",
+		Registerer:  registry,
+	}
+
+	metrics := NewServiceMetrics(config)
+
+	metrics.RecordRequestWithExemplar("GET", "/analytics", "200", 2*time.Second,
+		prometheus.Labels{"correlation_id": "patterns-abc123"})
+	// Oversized exemplars are dropped rather than panicking
+	metrics.RecordRequestWithExemplar("GET", "/analytics", "200", 2*time.Second,
+		prometheus.Labels{"correlation_id": strings.Repeat("x", 200)})
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+
+	var exemplars []string
+	for _, family := range families {
+		if family.GetName() != "test_exemplar_request_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metric.GetHistogram().GetSampleCount() != 2 {
+				t.Errorf("sample count = %d, want 2", metric.GetHistogram().GetSampleCount())
+			}
+			for _, bucket := range metric.GetHistogram().GetBucket() {
+				if exemplar := bucket.GetExemplar(); exemplar != nil {
+					for _, label := range exemplar.GetLabel() {
+						exemplars = append(exemplars, label.GetName()+"="+label.GetValue())
+					}
+				}
+			}
+		}
+	}
+
+	if len(exemplars) != 1 || exemplars[0] != "correlation_id=patterns-abc123" {
+		t.Errorf("exemplars = %v, want [correlation_id=patterns-abc123]", exemplars)
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"strings"
+)
+
+// HeaderCarrier adapts a Kafka client's message headers so the correlation
+// and trace helpers don't depend on one header representation. For example,
+// segmentio/kafka-go headers can be wrapped as:
+//
+//	type segmentioCarrier struct{ msg *kafka.Message }
+//
+//	func (c segmentioCarrier) Get(key string) []byte { ... }
+//	func (c segmentioCarrier) Set(key string, value []byte) { ... }
+type HeaderCarrier interface {
+	// Get returns the value of the header, or nil if it isn't set
+	Get(key string) []byte
+
+	// Set adds the header, replacing any existing value
+	Set(key string, value []byte)
+}
+
+// SliceCarrier is a HeaderCarrier over a []KafkaHeader. Keys are matched
+// case-insensitively.
+type SliceCarrier []KafkaHeader
+
+// Get implements HeaderCarrier
+func (c *SliceCarrier) Get(key string) []byte {
+	for _, header := range *c {
+		if strings.EqualFold(header.Key, key) {
+			return header.Value
+		}
+	}
+	return nil
+}
+
+// Set implements HeaderCarrier
+func (c *SliceCarrier) Set(key string, value []byte) {
+	for i, header := range *c {
+		if strings.EqualFold(header.Key, key) {
+			(*c)[i].Value = value
+			return
+		}
+	}
+	*c = append(*c, KafkaHeader{Key: key, Value: value})
+}
+
+// MapCarrier is a HeaderCarrier over the map[string]string headers taken by
+// kafka.Producer.SendMessage. Keys are matched exactly.
+type MapCarrier map[string]string
+
+// Get implements HeaderCarrier
+func (c MapCarrier) Get(key string) []byte {
+	if value, ok := c[key]; ok {
+		return []byte(value)
+	}
+	return nil
+}
+
+// Set implements HeaderCarrier
+func (c MapCarrier) Set(key string, value []byte) {
+	c[key] = string(value)
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+)
+
+func TestSliceCarrier(t *testing.T) {
+	carrier := SliceCarrier{{Key: "X-Correlation-ID", Value: []byte("corr-1")}}
+
+	if got := string(carrier.Get("x-correlation-id")); got != "corr-1" {
+		t.Errorf("Get() = %q, want corr-1 (case-insensitive)", got)
+	}
+	if got := carrier.Get("missing"); got != nil {
+		t.Errorf("Get(missing) = %q, want nil", got)
+	}
+
+	carrier.Set("x-correlation-id", []byte("corr-2"))
+	carrier.Set("traceparent", []byte(testTraceParent))
+	if len(carrier) != 2 {
+		t.Fatalf("len = %d, want 2 (existing key replaced, new key appended)", len(carrier))
+	}
+	if carrier[0].Key != "X-Correlation-ID" || string(carrier[0].Value) != "corr-2" {
+		t.Errorf("carrier[0] = %+v, want value replaced under original key", carrier[0])
+	}
+}
+
+func TestMapCarrier(t *testing.T) {
+	carrier := MapCarrier{}
+
+	if got := carrier.Get(CorrelationIDHeader); got != nil {
+		t.Errorf("Get() on empty carrier = %q, want nil", got)
+	}
+	carrier.Set(CorrelationIDHeader, []byte("corr-1"))
+	if carrier[CorrelationIDHeader] != "corr-1" {
+		t.Errorf("map = %v, want %s set", carrier, CorrelationIDHeader)
+	}
+}
+
+func TestCarrierRoundTrip(t *testing.T) {
+	helper := NewKafkaCorrelationIDHelper("test-service")
+
+	// Producer side: headers as passed to kafka.Producer.SendMessage
+	ctx := AddTraceContextToContext(
+		logger.ContextWithCorrelationID(context.Background(), "http-corr-1"),
+		testTraceParent, testTraceState,
+	)
+	headers := map[string]string{"content-type": "application/json"}
+	helper.PrepareCarrierForPublish(ctx, MapCarrier(headers))
+
+	if headers[CorrelationIDHeader] != "http-corr-1" || headers[TraceParentHeader] != testTraceParent || headers[TraceStateHeader] != testTraceState {
+		t.Fatalf("published headers = %v, want correlation ID and trace context", headers)
+	}
+
+	// Consumer side: the same headers as a KafkaHeader slice
+	var received SliceCarrier
+	for key, value := range headers {
+		received = append(received, KafkaHeader{Key: key, Value: []byte(value)})
+	}
+	consumerCtx := helper.CreateContextFromCarrier(context.Background(), &received)
+
+	if got := ExtractCorrelationID(consumerCtx); got != "http-corr-1" {
+		t.Errorf("consumer correlation ID = %v, want http-corr-1", got)
+	}
+	if traceParent, traceState := ExtractTraceContext(consumerCtx); traceParent != testTraceParent || traceState != testTraceState {
+		t.Errorf("consumer trace context = (%q, %q), want (%q, %q)", traceParent, traceState, testTraceParent, testTraceState)
+	}
+}
+
+func TestAddToCarrier_GeneratesID(t *testing.T) {
+	helper := NewKafkaCorrelationIDHelper("test-service")
+	carrier := MapCarrier{}
+
+	correlationID := helper.AddToCarrier(carrier, "")
+
+	if correlationID == "" || carrier[CorrelationIDHeader] != correlationID {
+		t.Errorf("AddToCarrier() = %q, header = %q, want generated ID set", correlationID, carrier[CorrelationIDHeader])
+	}
+	if id, generated := helper.ExtractOrGenerateFromCarrier(carrier); id != correlationID || generated {
+		t.Errorf("ExtractOrGenerateFromCarrier() = (%q, %v), want (%q, false)", id, generated, correlationID)
+	}
+}
@@ -37,7 +37,7 @@ func (m *CorrelationIDMiddleware) Handler(next http.Handler) http.Handler {
 		}
 
 		// Add correlation ID to request context
-		ctx := context.WithValue(r.Context(), logger.CorrelationIDKey, correlationID)
+		ctx := logger.ContextWithCorrelationID(r.Context(), correlationID)
 
 		// Add correlation ID to response header
 		w.Header().Set(CorrelationIDHeader, correlationID)
@@ -57,25 +57,19 @@ func (m *CorrelationIDMiddleware) HandlerFunc(next http.HandlerFunc) http.Handle
 // ExtractCorrelationID extracts the correlation ID from a context
 // Returns empty string if not found
 func ExtractCorrelationID(ctx context.Context) string {
-	if ctx == nil {
-		return ""
-	}
-	if correlationID, ok := ctx.Value(logger.CorrelationIDKey).(string); ok {
-		return correlationID
-	}
-	return ""
+	return logger.CorrelationIDFromContext(ctx)
 }
 
 // AddCorrelationIDToContext adds a correlation ID to a context
 // Useful for background jobs, scheduled tasks, or Kafka consumers
 func AddCorrelationIDToContext(ctx context.Context, correlationID string) context.Context {
-	return context.WithValue(ctx, logger.CorrelationIDKey, correlationID)
+	return logger.ContextWithCorrelationID(ctx, correlationID)
 }
 
 // AddComponentToContext adds a component name to a context
 // Useful for tracking which component is processing a request
 func AddComponentToContext(ctx context.Context, component string) context.Context {
-	return context.WithValue(ctx, logger.ComponentKey, component)
+	return logger.ContextWithComponent(ctx, component)
 }
 
 // GenerateCorrelationIDForContext creates a new correlation ID and adds it to a context
@@ -84,3 +78,29 @@ func GenerateCorrelationIDForContext(ctx context.Context, serviceName string) (c
 	correlationID := logger.GenerateCorrelationID(serviceName)
 	return AddCorrelationIDToContext(ctx, correlationID), correlationID
 }
+
+// traceContextKey is the context key for the W3C trace context
+type traceContextKey struct{}
+
+type traceContext struct {
+	traceParent string
+	traceState  string
+}
+
+// AddTraceContextToContext adds a W3C traceparent and tracestate to a context
+// Useful for Kafka consumers continuing a trace started by the producer
+func AddTraceContextToContext(ctx context.Context, traceParent, traceState string) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, traceContext{traceParent: traceParent, traceState: traceState})
+}
+
+// ExtractTraceContext extracts the W3C traceparent and tracestate from a context
+// Returns empty strings if not found
+func ExtractTraceContext(ctx context.Context) (traceParent, traceState string) {
+	if ctx == nil {
+		return "", ""
+	}
+	if tc, ok := ctx.Value(traceContextKey{}).(traceContext); ok {
+		return tc.traceParent, tc.traceState
+	}
+	return "", ""
+}
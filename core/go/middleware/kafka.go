@@ -2,10 +2,22 @@ package middleware
 
 import (
 	"context"
+	"regexp"
 
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
 )
 
+const (
+	// TraceParentHeader is the W3C Trace Context header carrying trace and span IDs
+	TraceParentHeader = "traceparent"
+
+	// TraceStateHeader is the W3C Trace Context header carrying vendor state
+	TraceStateHeader = "tracestate"
+)
+
+// traceParentPattern matches a W3C traceparent: version-traceid-parentid-flags
+var traceParentPattern = regexp.MustCompile(`^[0-9a-f]{2}-[0-9a-f]{32}-[0-9a-f]{16}-[0-9a-f]{2}$`)
+
 // KafkaHeader represents a Kafka message header
 type KafkaHeader struct {
 	Key   string
@@ -27,44 +39,47 @@ func NewKafkaCorrelationIDHelper(serviceName string) *KafkaCorrelationIDHelper {
 // ExtractFromHeaders extracts correlation ID from Kafka message headers
 // Returns empty string if not found
 func (h *KafkaCorrelationIDHelper) ExtractFromHeaders(headers []KafkaHeader) string {
-	for _, header := range headers {
-		if header.Key == CorrelationIDHeader {
-			return string(header.Value)
-		}
-	}
-	return ""
+	carrier := SliceCarrier(headers)
+	return h.ExtractFromCarrier(&carrier)
+}
+
+// ExtractFromCarrier extracts correlation ID from message headers
+// Returns empty string if not found
+func (h *KafkaCorrelationIDHelper) ExtractFromCarrier(carrier HeaderCarrier) string {
+	return string(carrier.Get(CorrelationIDHeader))
 }
 
 // AddToHeaders adds correlation ID to Kafka message headers
 // If correlation ID is empty, generates a new one
 // Returns the updated headers slice and the correlation ID used
 func (h *KafkaCorrelationIDHelper) AddToHeaders(headers []KafkaHeader, correlationID string) ([]KafkaHeader, string) {
-	// Generate correlation ID if not provided
+	carrier := SliceCarrier(headers)
+	correlationID = h.AddToCarrier(&carrier, correlationID)
+	return carrier, correlationID
+}
+
+// AddToCarrier sets the correlation ID header, replacing any existing one
+// If correlation ID is empty, generates a new one
+// Returns the correlation ID used
+func (h *KafkaCorrelationIDHelper) AddToCarrier(carrier HeaderCarrier, correlationID string) string {
 	if correlationID == "" {
 		correlationID = logger.GenerateCorrelationID(h.serviceName)
 	}
-
-	// Check if correlation ID already exists in headers
-	for i, header := range headers {
-		if header.Key == CorrelationIDHeader {
-			headers[i].Value = []byte(correlationID)
-			return headers, correlationID
-		}
-	}
-
-	// Add new header
-	headers = append(headers, KafkaHeader{
-		Key:   CorrelationIDHeader,
-		Value: []byte(correlationID),
-	})
-
-	return headers, correlationID
+	carrier.Set(CorrelationIDHeader, []byte(correlationID))
+	return correlationID
 }
 
 // ExtractOrGenerateFromHeaders extracts correlation ID from headers or generates a new one
 // Returns the correlation ID and whether it was newly generated
 func (h *KafkaCorrelationIDHelper) ExtractOrGenerateFromHeaders(headers []KafkaHeader) (string, bool) {
-	correlationID := h.ExtractFromHeaders(headers)
+	carrier := SliceCarrier(headers)
+	return h.ExtractOrGenerateFromCarrier(&carrier)
+}
+
+// ExtractOrGenerateFromCarrier extracts correlation ID from headers or generates a new one
+// Returns the correlation ID and whether it was newly generated
+func (h *KafkaCorrelationIDHelper) ExtractOrGenerateFromCarrier(carrier HeaderCarrier) (string, bool) {
+	correlationID := h.ExtractFromCarrier(carrier)
 	if correlationID != "" {
 		return correlationID, false
 	}
@@ -74,16 +89,79 @@ func (h *KafkaCorrelationIDHelper) ExtractOrGenerateFromHeaders(headers []KafkaH
 
 // CreateContextFromHeaders creates a context with correlation ID from Kafka headers
 // Extracts correlation ID from headers or generates a new one, then adds to context
+// along with the W3C trace context if the headers carry a valid one
 func (h *KafkaCorrelationIDHelper) CreateContextFromHeaders(ctx context.Context, headers []KafkaHeader) context.Context {
-	correlationID, _ := h.ExtractOrGenerateFromHeaders(headers)
-	return AddCorrelationIDToContext(ctx, correlationID)
+	carrier := SliceCarrier(headers)
+	return h.CreateContextFromCarrier(ctx, &carrier)
+}
+
+// CreateContextFromCarrier creates a context with correlation ID and W3C trace
+// context from message headers, as CreateContextFromHeaders does
+func (h *KafkaCorrelationIDHelper) CreateContextFromCarrier(ctx context.Context, carrier HeaderCarrier) context.Context {
+	correlationID, _ := h.ExtractOrGenerateFromCarrier(carrier)
+	ctx = AddCorrelationIDToContext(ctx, correlationID)
+
+	if traceParent, traceState := h.ExtractTraceContextFromCarrier(carrier); traceParent != "" {
+		ctx = AddTraceContextToContext(ctx, traceParent, traceState)
+	}
+	return ctx
 }
 
 // PrepareHeadersForPublish prepares Kafka headers with correlation ID from context
-// Extracts correlation ID from context if available, or generates a new one
+// Extracts correlation ID from context if available, or generates a new one,
+// and adds the context's W3C trace context if present
 // Returns the updated headers slice
 func (h *KafkaCorrelationIDHelper) PrepareHeadersForPublish(ctx context.Context, headers []KafkaHeader) []KafkaHeader {
-	correlationID := ExtractCorrelationID(ctx)
-	updatedHeaders, _ := h.AddToHeaders(headers, correlationID)
-	return updatedHeaders
+	carrier := SliceCarrier(headers)
+	h.PrepareCarrierForPublish(ctx, &carrier)
+	return carrier
+}
+
+// PrepareCarrierForPublish sets the correlation ID and W3C trace context from
+// ctx on message headers, as PrepareHeadersForPublish does
+func (h *KafkaCorrelationIDHelper) PrepareCarrierForPublish(ctx context.Context, carrier HeaderCarrier) {
+	h.AddToCarrier(carrier, ExtractCorrelationID(ctx))
+	h.InjectTraceContextIntoCarrier(ctx, carrier)
+}
+
+// ExtractTraceContext extracts the W3C traceparent and tracestate from Kafka
+// message headers. Header names are matched case-insensitively. Returns empty
+// strings if traceparent is missing or malformed, since tracestate is
+// meaningless without it.
+func (h *KafkaCorrelationIDHelper) ExtractTraceContext(headers []KafkaHeader) (traceParent, traceState string) {
+	carrier := SliceCarrier(headers)
+	return h.ExtractTraceContextFromCarrier(&carrier)
+}
+
+// ExtractTraceContextFromCarrier extracts the W3C traceparent and tracestate
+// from message headers, as ExtractTraceContext does
+func (h *KafkaCorrelationIDHelper) ExtractTraceContextFromCarrier(carrier HeaderCarrier) (traceParent, traceState string) {
+	traceParent = string(carrier.Get(TraceParentHeader))
+	if !traceParentPattern.MatchString(traceParent) {
+		return "", ""
+	}
+	return traceParent, string(carrier.Get(TraceStateHeader))
+}
+
+// InjectTraceContext adds the W3C trace context from ctx to Kafka message
+// headers, replacing existing trace headers. Headers are returned unchanged
+// if ctx has no trace context.
+func (h *KafkaCorrelationIDHelper) InjectTraceContext(ctx context.Context, headers []KafkaHeader) []KafkaHeader {
+	carrier := SliceCarrier(headers)
+	h.InjectTraceContextIntoCarrier(ctx, &carrier)
+	return carrier
+}
+
+// InjectTraceContextIntoCarrier sets the W3C trace context from ctx on message
+// headers, as InjectTraceContext does
+func (h *KafkaCorrelationIDHelper) InjectTraceContextIntoCarrier(ctx context.Context, carrier HeaderCarrier) {
+	traceParent, traceState := ExtractTraceContext(ctx)
+	if traceParent == "" {
+		return
+	}
+
+	carrier.Set(TraceParentHeader, []byte(traceParent))
+	if traceState != "" {
+		carrier.Set(TraceStateHeader, []byte(traceState))
+	}
 }
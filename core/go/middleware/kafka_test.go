@@ -222,7 +222,7 @@ func TestPrepareHeadersForPublish(t *testing.T) {
 	helper := NewKafkaCorrelationIDHelper("test-service")
 
 	t.Run("from context", func(t *testing.T) {
-		ctx := context.WithValue(context.Background(), logger.CorrelationIDKey, "ctx-corr-456")
+		ctx := logger.ContextWithCorrelationID(context.Background(), "ctx-corr-456")
 		headers := []KafkaHeader{}
 
 		updatedHeaders := helper.PrepareHeadersForPublish(ctx, headers)
@@ -264,7 +264,7 @@ func TestPrepareHeadersForPublish(t *testing.T) {
 	})
 
 	t.Run("preserve other headers", func(t *testing.T) {
-		ctx := context.WithValue(context.Background(), logger.CorrelationIDKey, "ctx-corr-789")
+		ctx := logger.ContextWithCorrelationID(context.Background(), "ctx-corr-789")
 		headers := []KafkaHeader{
 			{Key: "other-header-1", Value: []byte("value-1")},
 			{Key: "other-header-2", Value: []byte("value-2")},
@@ -288,3 +288,140 @@ func TestPrepareHeadersForPublish(t *testing.T) {
 		}
 	})
 }
+
+const (
+	testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testTraceState  = "congo=t61rcWkgMzE"
+)
+
+func TestExtractTraceContext(t *testing.T) {
+	helper := NewKafkaCorrelationIDHelper("test-service")
+
+	tests := []struct {
+		name            string
+		headers         []KafkaHeader
+		wantTraceParent string
+		wantTraceState  string
+	}{
+		{
+			name: "with traceparent and tracestate",
+			headers: []KafkaHeader{
+				{Key: CorrelationIDHeader, Value: []byte("kafka-corr-123")},
+				{Key: TraceParentHeader, Value: []byte(testTraceParent)},
+				{Key: TraceStateHeader, Value: []byte(testTraceState)},
+			},
+			wantTraceParent: testTraceParent,
+			wantTraceState:  testTraceState,
+		},
+		{
+			name: "traceparent only",
+			headers: []KafkaHeader{
+				{Key: TraceParentHeader, Value: []byte(testTraceParent)},
+			},
+			wantTraceParent: testTraceParent,
+		},
+		{
+			name: "header names are case-insensitive",
+			headers: []KafkaHeader{
+				{Key: "Traceparent", Value: []byte(testTraceParent)},
+				{Key: "TraceState", Value: []byte(testTraceState)},
+			},
+			wantTraceParent: testTraceParent,
+			wantTraceState:  testTraceState,
+		},
+		{
+			name: "malformed traceparent drops tracestate",
+			headers: []KafkaHeader{
+				{Key: TraceParentHeader, Value: []byte("00-not-a-trace-01")},
+				{Key: TraceStateHeader, Value: []byte(testTraceState)},
+			},
+		},
+		{
+			name: "tracestate without traceparent",
+			headers: []KafkaHeader{
+				{Key: TraceStateHeader, Value: []byte(testTraceState)},
+			},
+		},
+		{
+			name:    "nil headers",
+			headers: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traceParent, traceState := helper.ExtractTraceContext(tt.headers)
+			if traceParent != tt.wantTraceParent || traceState != tt.wantTraceState {
+				t.Errorf("ExtractTraceContext() = (%q, %q), want (%q, %q)", traceParent, traceState, tt.wantTraceParent, tt.wantTraceState)
+			}
+		})
+	}
+}
+
+func TestInjectTraceContext(t *testing.T) {
+	helper := NewKafkaCorrelationIDHelper("test-service")
+
+	t.Run("from context", func(t *testing.T) {
+		ctx := AddTraceContextToContext(context.Background(), testTraceParent, testTraceState)
+		headers := []KafkaHeader{{Key: "other-header", Value: []byte("other-value")}}
+
+		updatedHeaders := helper.InjectTraceContext(ctx, headers)
+
+		if len(updatedHeaders) != 3 {
+			t.Fatalf("expected 3 headers, got %d", len(updatedHeaders))
+		}
+		traceParent, traceState := helper.ExtractTraceContext(updatedHeaders)
+		if traceParent != testTraceParent || traceState != testTraceState {
+			t.Errorf("injected trace context = (%q, %q), want (%q, %q)", traceParent, traceState, testTraceParent, testTraceState)
+		}
+	})
+
+	t.Run("replaces existing", func(t *testing.T) {
+		ctx := AddTraceContextToContext(context.Background(), testTraceParent, "")
+		headers := []KafkaHeader{{Key: TraceParentHeader, Value: []byte("00-00000000000000000000000000000001-0000000000000001-00")}}
+
+		updatedHeaders := helper.InjectTraceContext(ctx, headers)
+
+		if len(updatedHeaders) != 1 || string(updatedHeaders[0].Value) != testTraceParent {
+			t.Errorf("headers = %v, want traceparent replaced in place", updatedHeaders)
+		}
+	})
+
+	t.Run("no trace context", func(t *testing.T) {
+		headers := []KafkaHeader{{Key: "other-header", Value: []byte("other-value")}}
+
+		updatedHeaders := helper.InjectTraceContext(context.Background(), headers)
+
+		if len(updatedHeaders) != 1 {
+			t.Errorf("expected headers unchanged, got %v", updatedHeaders)
+		}
+	})
+}
+
+func TestTraceContextRoundTrip(t *testing.T) {
+	helper := NewKafkaCorrelationIDHelper("test-service")
+
+	// Producer side: request context carries the trace started by the HTTP call
+	producerCtx := AddTraceContextToContext(
+		logger.ContextWithCorrelationID(context.Background(), "http-corr-1"),
+		testTraceParent, testTraceState,
+	)
+	headers := helper.PrepareHeadersForPublish(producerCtx, nil)
+
+	// Consumer side
+	consumerCtx := helper.CreateContextFromHeaders(context.Background(), headers)
+
+	if got := ExtractCorrelationID(consumerCtx); got != "http-corr-1" {
+		t.Errorf("consumer correlation ID = %v, want http-corr-1", got)
+	}
+	traceParent, traceState := ExtractTraceContext(consumerCtx)
+	if traceParent != testTraceParent || traceState != testTraceState {
+		t.Errorf("consumer trace context = (%q, %q), want (%q, %q)", traceParent, traceState, testTraceParent, testTraceState)
+	}
+
+	// No trace headers means no trace context
+	plainCtx := helper.CreateContextFromHeaders(context.Background(), []KafkaHeader{{Key: CorrelationIDHeader, Value: []byte("c")}})
+	if traceParent, _ := ExtractTraceContext(plainCtx); traceParent != "" {
+		t.Errorf("trace context = %q, want none", traceParent)
+	}
+}
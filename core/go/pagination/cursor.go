@@ -0,0 +1,122 @@
+package pagination
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/keyvault"
+)
+
+// Cursor errors
+var (
+	ErrMalformedCursor   = errors.New("malformed pagination cursor")
+	ErrTamperedCursor    = errors.New("pagination cursor signature mismatch")
+	ErrUnencodableCursor = errors.New("pagination cursor fields are not JSON-encodable")
+)
+
+// DefaultSigningKeySecret is the KeyVault secret holding the cursor signing key
+const DefaultSigningKeySecret = "pagination-cursor-key"
+
+var (
+	keyMu      sync.RWMutex
+	signingKey = randomKey()
+)
+
+// randomKey generates a per-process key so cursors are unforgeable even before
+// LoadSigningKey is called. Cursors signed with it don't survive restarts or
+// work across replicas, so services should load the shared key from KeyVault.
+func randomKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("pagination: failed to generate signing key: %v", err))
+	}
+	return key
+}
+
+// SetSigningKey sets the HMAC key used to sign and verify cursors
+func SetSigningKey(key []byte) {
+	keyMu.Lock()
+	defer keyMu.Unlock()
+	signingKey = append([]byte(nil), key...)
+}
+
+// LoadSigningKey loads the cursor signing key from a KeyVault secret.
+// All replicas must share the key so cursors issued by one are accepted by the others.
+func LoadSigningKey(ctx context.Context, kv keyvault.Client, secretName string) error {
+	secret, err := kv.GetSecret(ctx, secretName)
+	if err != nil {
+		return fmt.Errorf("failed to load cursor signing key: %w", err)
+	}
+	if secret == nil || secret.Value == "" {
+		return fmt.Errorf("cursor signing key secret %q not found", secretName)
+	}
+	SetSigningKey([]byte(secret.Value))
+	return nil
+}
+
+// EncodeCursor encodes fields as an opaque cursor: base64 compact JSON plus an
+// HMAC-SHA256 signature, so clients can't forge cursors (e.g. into other tenants).
+// Fields JSON can't represent, such as NaN, return ErrUnencodableCursor.
+func EncodeCursor(fields map[string]any) (string, error) {
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnencodableCursor, err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(sign(payload)), nil
+}
+
+// DecodeCursor verifies the cursor signature and returns its fields.
+// Numbers are decoded as json.Number to preserve int64 precision.
+func DecodeCursor(cursor string) (map[string]any, error) {
+	encodedPayload, encodedSig, ok := strings.Cut(cursor, ".")
+	if !ok || encodedPayload == "" || encodedSig == "" {
+		return nil, ErrMalformedCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedCursor, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedCursor, err)
+	}
+
+	if !hmac.Equal(sig, sign(payload)) {
+		return nil, ErrTamperedCursor
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedCursor, err)
+	}
+	if fields == nil {
+		return nil, ErrMalformedCursor
+	}
+
+	return fields, nil
+}
+
+// sign computes the HMAC-SHA256 of payload with the current signing key
+func sign(payload []byte) []byte {
+	keyMu.RLock()
+	defer keyMu.RUnlock()
+
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,174 @@
+package pagination
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/keyvault"
+)
+
+// mustEncode encodes fields or fails the test
+func mustEncode(t *testing.T, fields map[string]any) string {
+	t.Helper()
+	cursor, err := EncodeCursor(fields)
+	if err != nil {
+		t.Fatalf("EncodeCursor() error = %v", err)
+	}
+	return cursor
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	SetSigningKey([]byte("test-key"))
+
+	fields := map[string]any{
+		"tenant_id":  "tenant-a",
+		"last_id":    "9b2f6c1e-1d1c-4c4c-8f1e-0b7a6d3e2f10",
+		"created_at": int64(1735689600123456789),
+		"offset":     50,
+	}
+
+	cursor := mustEncode(t, fields)
+	if strings.ContainsAny(cursor, "+/=") {
+		t.Errorf("cursor %q is not URL-safe", cursor)
+	}
+
+	got, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+
+	if got["tenant_id"] != "tenant-a" {
+		t.Errorf("tenant_id = %v, want tenant-a", got["tenant_id"])
+	}
+	if got["last_id"] != fields["last_id"] {
+		t.Errorf("last_id = %v, want %v", got["last_id"], fields["last_id"])
+	}
+	createdAt, err := got["created_at"].(json.Number).Int64()
+	if err != nil || createdAt != 1735689600123456789 {
+		t.Errorf("created_at = %v, want 1735689600123456789 without precision loss", got["created_at"])
+	}
+	if got["offset"] != json.Number("50") {
+		t.Errorf("offset = %v, want 50", got["offset"])
+	}
+}
+
+func TestCursor_UnencodableFields(t *testing.T) {
+	for name, value := range map[string]any{"NaN": math.NaN(), "infinity": math.Inf(1), "channel": make(chan int)} {
+		cursor, err := EncodeCursor(map[string]any{"score": value})
+		if !errors.Is(err, ErrUnencodableCursor) || cursor != "" {
+			t.Errorf("EncodeCursor(%s) = %q, %v; want ErrUnencodableCursor", name, cursor, err)
+		}
+	}
+}
+
+func TestCursor_TamperingDetected(t *testing.T) {
+	SetSigningKey([]byte("test-key"))
+
+	cursor := mustEncode(t, map[string]any{"tenant_id": "tenant-a", "offset": 10})
+	payload, sig, _ := strings.Cut(cursor, ".")
+
+	forgedPayload := base64.RawURLEncoding.EncodeToString([]byte(`{"offset":10,"tenant_id":"tenant-b"}`))
+
+	flipped := []byte(sig)
+	if flipped[0] == 'A' {
+		flipped[0] = 'B'
+	} else {
+		flipped[0] = 'A'
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"payload swapped to another tenant", forgedPayload + "." + sig},
+		{"signature altered", payload + "." + string(flipped)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor); !errors.Is(err, ErrTamperedCursor) {
+				t.Errorf("DecodeCursor() error = %v, want %v", err, ErrTamperedCursor)
+			}
+		})
+	}
+
+	t.Run("signed with different key", func(t *testing.T) {
+		SetSigningKey([]byte("other-key"))
+		defer SetSigningKey([]byte("test-key"))
+
+		if _, err := DecodeCursor(cursor); !errors.Is(err, ErrTamperedCursor) {
+			t.Errorf("DecodeCursor() error = %v, want %v", err, ErrTamperedCursor)
+		}
+	})
+}
+
+func TestCursor_MalformedInput(t *testing.T) {
+	SetSigningKey([]byte("test-key"))
+
+	signed := func(payload string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+			base64.RawURLEncoding.EncodeToString(sign([]byte(payload)))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"empty", ""},
+		{"no separator", "abcdef"},
+		{"empty signature", "abcdef."},
+		{"empty payload", ".abcdef"},
+		{"invalid base64 payload", "!!!." + base64.RawURLEncoding.EncodeToString([]byte("sig"))},
+		{"invalid base64 signature", base64.RawURLEncoding.EncodeToString([]byte("{}")) + ".!!!"},
+		{"signed non-JSON payload", signed("not json")},
+		{"signed JSON null", signed("null")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor); !errors.Is(err, ErrMalformedCursor) {
+				t.Errorf("DecodeCursor(%q) error = %v, want %v", tt.cursor, err, ErrMalformedCursor)
+			}
+		})
+	}
+}
+
+// stubKeyVault returns a fixed secret from GetSecret
+type stubKeyVault struct {
+	keyvault.Client
+	secret *keyvault.Secret
+	err    error
+}
+
+func (s *stubKeyVault) GetSecret(ctx context.Context, name string) (*keyvault.Secret, error) {
+	return s.secret, s.err
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	SetSigningKey([]byte("before"))
+	cursor := mustEncode(t, map[string]any{"offset": 1})
+
+	kv := &stubKeyVault{secret: &keyvault.Secret{Name: DefaultSigningKeySecret, Value: "from-keyvault"}}
+	if err := LoadSigningKey(context.Background(), kv, DefaultSigningKeySecret); err != nil {
+		t.Fatalf("LoadSigningKey() error = %v", err)
+	}
+
+	if _, err := DecodeCursor(cursor); !errors.Is(err, ErrTamperedCursor) {
+		t.Errorf("cursor signed with previous key: error = %v, want %v", err, ErrTamperedCursor)
+	}
+	if _, err := DecodeCursor(mustEncode(t, map[string]any{"offset": 1})); err != nil {
+		t.Errorf("cursor signed with loaded key: error = %v", err)
+	}
+
+	if err := LoadSigningKey(context.Background(), &stubKeyVault{}, DefaultSigningKeySecret); err == nil {
+		t.Error("LoadSigningKey() error = nil, want error for missing secret")
+	}
+	if err := LoadSigningKey(context.Background(), &stubKeyVault{err: errors.New("unavailable")}, DefaultSigningKeySecret); err == nil {
+		t.Error("LoadSigningKey() error = nil, want error when KeyVault fails")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -32,7 +33,21 @@ func (s CircuitState) String() string {
 	}
 }
 
-// CircuitBreaker prevents cascading failures by opening after threshold failures
+// ErrCircuitOpen is returned when the breaker rejects a call, either because
+// it is open or because it is half-open and all probe slots are taken
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerConfig configures a circuit breaker
+type CircuitBreakerConfig struct {
+	Name             string
+	FailureThreshold uint32        // Consecutive failures that open the circuit (default 5)
+	ResetTimeout     time.Duration // Time open before probing (default 30s)
+	HalfOpenProbes   uint32        // Probes allowed while half-open; all must succeed to close (default 1)
+}
+
+// CircuitBreaker prevents cascading failures by opening after threshold failures.
+// After the reset timeout it goes half-open and lets a limited number of probe
+// requests through: if they all succeed it closes, and any failure re-opens it.
 type CircuitBreaker struct {
 	name             string
 	maxFailures      uint32
@@ -43,7 +58,16 @@ type CircuitBreaker struct {
 	state            CircuitState
 	failures         uint32
 	lastFailTime     time.Time
-	halfOpenAttempts uint32
+	halfOpenAttempts uint32 // Successful probes
+	halfOpenAdmitted uint32 // Probes let through
+	listeners        []func(from, to CircuitState)
+
+	// Counters for Metrics, updated without holding mu
+	calls         atomic.Uint64
+	successes     atomic.Uint64
+	failuresTotal atomic.Uint64
+	rejections    atomic.Uint64
+	cancellations atomic.Uint64
 
 	// Metrics
 	stateGauge        prometheus.Gauge
@@ -86,15 +110,46 @@ var (
 	)
 )
 
-// NewCircuitBreaker creates a new circuit breaker
+// CircuitBreakerMetrics is a snapshot of a breaker's counters since creation
+type CircuitBreakerMetrics struct {
+	Name          string
+	State         CircuitState
+	Calls         uint64 // Every Execute call, including rejected and cancelled ones
+	Successes     uint64
+	Failures      uint64
+	Rejections    uint64 // Fast-failed with ErrCircuitOpen
+	Cancellations uint64 // Context done before or during the call
+}
+
+// NewCircuitBreaker creates a new circuit breaker that allows 3 probes when half-open
 func NewCircuitBreaker(name string, maxFailures uint32, timeout time.Duration) *CircuitBreaker {
+	return NewCircuitBreakerWithConfig(CircuitBreakerConfig{
+		Name:             name,
+		FailureThreshold: maxFailures,
+		ResetTimeout:     timeout,
+		HalfOpenProbes:   3,
+	})
+}
+
+// NewCircuitBreakerWithConfig creates a new circuit breaker from config
+func NewCircuitBreakerWithConfig(config CircuitBreakerConfig) *CircuitBreaker {
+	if config.FailureThreshold == 0 {
+		config.FailureThreshold = 5
+	}
+	if config.ResetTimeout == 0 {
+		config.ResetTimeout = 30 * time.Second
+	}
+	if config.HalfOpenProbes == 0 {
+		config.HalfOpenProbes = 1
+	}
+
 	cb := &CircuitBreaker{
-		name:              name,
-		maxFailures:       maxFailures,
-		timeout:           timeout,
-		halfOpenRequests:  3, // Allow 3 requests in half-open state
+		name:              config.Name,
+		maxFailures:       config.FailureThreshold,
+		timeout:           config.ResetTimeout,
+		halfOpenRequests:  config.HalfOpenProbes,
 		state:             StateClosed,
-		stateGauge:        circuitBreakerState.WithLabelValues(name),
+		stateGauge:        circuitBreakerState.WithLabelValues(config.Name),
 		requestsTotal:     circuitBreakerRequests,
 		errorsTotal:       circuitBreakerErrors,
 		stateChangesTotal: circuitBreakerStateChanges,
@@ -105,19 +160,51 @@ func NewCircuitBreaker(name string, maxFailures uint32, timeout time.Duration) *
 
 // Execute runs the given function with circuit breaker protection
 func (cb *CircuitBreaker) Execute(fn func() error) error {
+	return cb.execute(context.Background(), fn)
+}
+
+// ExecuteWithContext runs the function with circuit breaker protection unless
+// ctx is already done. Errors while ctx is done are the caller giving up, not
+// the dependency failing, so they don't count towards opening the circuit.
+func (cb *CircuitBreaker) ExecuteWithContext(ctx context.Context, fn func(context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		cb.record(cb.GetState(), "cancelled")
+		return err
+	}
+	return cb.execute(ctx, func() error {
+		return fn(ctx)
+	})
+}
+
+// ExecuteWithFallback runs fn with circuit breaker protection, running
+// fallback instead when the circuit is open or fn fails. It returns
+// fallback's error, so a fallback that degrades gracefully returns nil.
+func (cb *CircuitBreaker) ExecuteWithFallback(fn func() error, fallback func() error) error {
+	if err := cb.Execute(fn); err != nil {
+		return fallback()
+	}
+	return nil
+}
+
+func (cb *CircuitBreaker) execute(ctx context.Context, fn func() error) error {
 	cb.mu.Lock()
 
 	// Check if circuit should transition from open to half-open
 	if cb.state == StateOpen && time.Since(cb.lastFailTime) > cb.timeout {
 		cb.setState(StateHalfOpen)
 		cb.halfOpenAttempts = 0
+		cb.halfOpenAdmitted = 0
 	}
 
-	// Reject if circuit is open
-	if cb.state == StateOpen {
+	// Reject if circuit is open, or half-open with every probe slot taken
+	if cb.state == StateOpen || (cb.state == StateHalfOpen && cb.halfOpenAdmitted >= cb.halfOpenRequests) {
+		state := cb.state
 		cb.mu.Unlock()
-		cb.requestsTotal.WithLabelValues(cb.name, "open", "rejected").Inc()
-		return errors.New("circuit breaker is open")
+		cb.record(state, "rejected")
+		return ErrCircuitOpen
+	}
+	if cb.state == StateHalfOpen {
+		cb.halfOpenAdmitted++
 	}
 
 	currentState := cb.state
@@ -129,25 +216,62 @@ func (cb *CircuitBreaker) Execute(fn func() error) error {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
+	if err != nil && ctx.Err() != nil {
+		// Free the probe slot so another caller can test the dependency
+		if currentState == StateHalfOpen && cb.state == StateHalfOpen {
+			cb.halfOpenAdmitted--
+		}
+		cb.record(currentState, "cancelled")
+		return err
+	}
+
 	if err != nil {
 		cb.onError(currentState)
-		cb.requestsTotal.WithLabelValues(cb.name, currentState.String(), "error").Inc()
+		cb.record(currentState, "error")
 		return err
 	}
 
 	cb.onSuccess(currentState)
-	cb.requestsTotal.WithLabelValues(cb.name, currentState.String(), "success").Inc()
+	cb.record(currentState, "success")
 	return nil
 }
 
-// ExecuteWithContext runs the function with context support
-func (cb *CircuitBreaker) ExecuteWithContext(ctx context.Context, fn func(context.Context) error) error {
-	return cb.Execute(func() error {
-		return fn(ctx)
-	})
+// record counts a call's result in Prometheus and the Metrics counters
+func (cb *CircuitBreaker) record(state CircuitState, result string) {
+	cb.requestsTotal.WithLabelValues(cb.name, state.String(), result).Inc()
+	cb.calls.Add(1)
+	switch result {
+	case "success":
+		cb.successes.Add(1)
+	case "error":
+		cb.failuresTotal.Add(1)
+	case "rejected":
+		cb.rejections.Add(1)
+	case "cancelled":
+		cb.cancellations.Add(1)
+	}
+}
+
+// Metrics returns the breaker's call counters and current state.
+// Don't call it from an OnStateChange listener.
+func (cb *CircuitBreaker) Metrics() CircuitBreakerMetrics {
+	return CircuitBreakerMetrics{
+		Name:          cb.name,
+		State:         cb.GetState(),
+		Calls:         cb.calls.Load(),
+		Successes:     cb.successes.Load(),
+		Failures:      cb.failuresTotal.Load(),
+		Rejections:    cb.rejections.Load(),
+		Cancellations: cb.cancellations.Load(),
+	}
 }
 
 func (cb *CircuitBreaker) onSuccess(state CircuitState) {
+	// A probe finishing after another probe re-opened the circuit doesn't count
+	if state != cb.state {
+		return
+	}
+
 	if state == StateHalfOpen {
 		cb.halfOpenAttempts++
 		if cb.halfOpenAttempts >= cb.halfOpenRequests {
@@ -164,6 +288,10 @@ func (cb *CircuitBreaker) onError(state CircuitState) {
 	cb.lastFailTime = time.Now()
 	cb.errorsTotal.WithLabelValues(cb.name).Inc()
 
+	if state != cb.state {
+		return
+	}
+
 	if state == StateHalfOpen {
 		cb.setState(StateOpen)
 	} else if cb.failures >= cb.maxFailures {
@@ -176,6 +304,17 @@ func (cb *CircuitBreaker) setState(newState CircuitState) {
 	cb.state = newState
 	cb.stateGauge.Set(float64(newState))
 	cb.stateChangesTotal.WithLabelValues(cb.name, oldState.String(), newState.String()).Inc()
+	for _, fn := range cb.listeners {
+		fn(oldState, newState)
+	}
+}
+
+// OnStateChange registers fn to be called on every state transition.
+// fn runs with the breaker locked, so it must not call back into it.
+func (cb *CircuitBreaker) OnStateChange(fn func(from, to CircuitState)) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.listeners = append(cb.listeners, fn)
 }
 
 // GetState returns the current circuit breaker state
@@ -192,4 +331,5 @@ func (cb *CircuitBreaker) Reset() {
 	cb.setState(StateClosed)
 	cb.failures = 0
 	cb.halfOpenAttempts = 0
+	cb.halfOpenAdmitted = 0
 }
@@ -0,0 +1,281 @@
+package reliability
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var errDependency = errors.New("dependency unavailable")
+
+func failing() error { return errDependency }
+
+func succeeding() error { return nil }
+
+// openBreaker returns a breaker that has just opened
+func openBreaker(t *testing.T, name string, probes uint32) *CircuitBreaker {
+	t.Helper()
+	cb := NewCircuitBreakerWithConfig(CircuitBreakerConfig{
+		Name:             name,
+		FailureThreshold: 2,
+		ResetTimeout:     20 * time.Millisecond,
+		HalfOpenProbes:   probes,
+	})
+	for i := 0; i < 2; i++ {
+		cb.Execute(failing)
+	}
+	if cb.GetState() != StateOpen {
+		t.Fatalf("state = %s after threshold failures, want open", cb.GetState())
+	}
+	return cb
+}
+
+func TestCircuitBreaker_ClosedToOpen(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig(CircuitBreakerConfig{Name: "test-closed-open", FailureThreshold: 3, ResetTimeout: time.Minute})
+
+	cb.Execute(failing)
+	cb.Execute(failing)
+	cb.Execute(succeeding) // Resets the consecutive failure count
+	cb.Execute(failing)
+	cb.Execute(failing)
+	if cb.GetState() != StateClosed {
+		t.Fatalf("state = %s, want closed below the threshold", cb.GetState())
+	}
+
+	cb.Execute(failing)
+	if cb.GetState() != StateOpen {
+		t.Fatalf("state = %s, want open", cb.GetState())
+	}
+
+	called := false
+	err := cb.Execute(func() error { called = true; return nil })
+	if !errors.Is(err, ErrCircuitOpen) || called {
+		t.Errorf("Execute() = %v, called = %v; want ErrCircuitOpen without calling fn", err, called)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenCloses(t *testing.T) {
+	cb := openBreaker(t, "test-half-open-close", 2)
+	time.Sleep(30 * time.Millisecond)
+
+	if err := cb.Execute(succeeding); err != nil {
+		t.Fatalf("first probe error = %v", err)
+	}
+	if cb.GetState() != StateHalfOpen {
+		t.Fatalf("state = %s after one of two probes, want half_open", cb.GetState())
+	}
+
+	if err := cb.Execute(succeeding); err != nil {
+		t.Fatalf("second probe error = %v", err)
+	}
+	if cb.GetState() != StateClosed {
+		t.Errorf("state = %s after all probes succeeded, want closed", cb.GetState())
+	}
+}
+
+func TestCircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	cb := openBreaker(t, "test-half-open-reopen", 3)
+	time.Sleep(30 * time.Millisecond)
+
+	cb.Execute(succeeding)
+	if err := cb.Execute(failing); !errors.Is(err, errDependency) {
+		t.Fatalf("probe error = %v, want %v", err, errDependency)
+	}
+	if cb.GetState() != StateOpen {
+		t.Fatalf("state = %s after a failed probe, want open", cb.GetState())
+	}
+	if err := cb.Execute(succeeding); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Execute() = %v after re-opening, want ErrCircuitOpen", err)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenLimitsProbes(t *testing.T) {
+	cb := openBreaker(t, "test-half-open-limit", 2)
+	time.Sleep(30 * time.Millisecond)
+
+	// Hold the probes in flight so concurrent callers see the slots taken
+	release := make(chan struct{})
+	var started, done sync.WaitGroup
+	started.Add(2)
+	done.Add(2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			defer done.Done()
+			cb.Execute(func() error {
+				started.Done()
+				<-release
+				return nil
+			})
+		}()
+	}
+	started.Wait()
+
+	if err := cb.Execute(succeeding); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Execute() = %v with all probes in flight, want ErrCircuitOpen", err)
+	}
+
+	close(release)
+	done.Wait()
+	if cb.GetState() != StateClosed {
+		t.Errorf("state = %s after probes succeeded, want closed", cb.GetState())
+	}
+}
+
+func TestCircuitBreakerWithConfig_Defaults(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig(CircuitBreakerConfig{Name: "test-defaults"})
+
+	if cb.maxFailures != 5 || cb.timeout != 30*time.Second || cb.halfOpenRequests != 1 {
+		t.Errorf("defaults = %d failures, %s timeout, %d probes; want 5, 30s, 1",
+			cb.maxFailures, cb.timeout, cb.halfOpenRequests)
+	}
+}
+
+func TestExecuteWithContext_CancelledShortCircuits(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig(CircuitBreakerConfig{Name: "test-ctx-cancelled", FailureThreshold: 1, ResetTimeout: time.Minute})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	err := cb.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) || called {
+		t.Errorf("ExecuteWithContext() = %v, called = %v; want context.Canceled without calling fn", err, called)
+	}
+	if cb.GetState() != StateClosed {
+		t.Errorf("state = %s, want closed", cb.GetState())
+	}
+}
+
+func TestExecuteWithContext_CancellationNotCounted(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig(CircuitBreakerConfig{Name: "test-ctx-not-counted", FailureThreshold: 2, ResetTimeout: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := cb.ExecuteWithContext(ctx, func(ctx context.Context) error {
+			cancel()
+			return ctx.Err()
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("ExecuteWithContext() = %v, want context.Canceled", err)
+		}
+	}
+
+	cb.mu.RLock()
+	failures := cb.failures
+	cb.mu.RUnlock()
+	if failures != 0 || cb.GetState() != StateClosed {
+		t.Errorf("failures = %d, state = %s after cancellations; want 0, closed", failures, cb.GetState())
+	}
+
+	// Real failures still open the circuit
+	for i := 0; i < 2; i++ {
+		cb.ExecuteWithContext(context.Background(), func(ctx context.Context) error { return errDependency })
+	}
+	if cb.GetState() != StateOpen {
+		t.Errorf("state = %s after failures, want open", cb.GetState())
+	}
+}
+
+func TestExecuteWithContext_CancelledProbeFreesSlot(t *testing.T) {
+	cb := openBreaker(t, "test-ctx-probe", 1)
+	time.Sleep(30 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cb.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		cancel()
+		return ctx.Err()
+	})
+	if cb.GetState() != StateHalfOpen {
+		t.Fatalf("state = %s after a cancelled probe, want half_open", cb.GetState())
+	}
+
+	if err := cb.Execute(succeeding); err != nil {
+		t.Fatalf("Execute() = %v, want the probe slot freed", err)
+	}
+	if cb.GetState() != StateClosed {
+		t.Errorf("state = %s, want closed", cb.GetState())
+	}
+}
+
+func TestCircuitBreaker_MetricsConcurrent(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig(CircuitBreakerConfig{Name: "test-metrics", FailureThreshold: 1000, ResetTimeout: time.Minute})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%4 == 0 {
+				cb.Execute(failing)
+			} else {
+				cb.Execute(succeeding)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	got := cb.Metrics()
+	want := CircuitBreakerMetrics{Name: "test-metrics", State: StateClosed, Calls: 100, Successes: 75, Failures: 25}
+	if got != want {
+		t.Errorf("Metrics() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCircuitBreaker_MetricsRejectionsAndStateChanges(t *testing.T) {
+	opens := circuitBreakerStateChanges.WithLabelValues("test-metrics-open", "closed", "open")
+	before := testutil.ToFloat64(opens)
+
+	cb := openBreaker(t, "test-metrics-open", 1)
+	cb.Execute(succeeding)
+	cb.Execute(succeeding)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cb.ExecuteWithContext(ctx, func(ctx context.Context) error { return nil })
+
+	got := cb.Metrics()
+	want := CircuitBreakerMetrics{Name: "test-metrics-open", State: StateOpen, Calls: 5, Failures: 2, Rejections: 2, Cancellations: 1}
+	if got != want {
+		t.Errorf("Metrics() = %+v, want %+v", got, want)
+	}
+
+	if opened := testutil.ToFloat64(opens) - before; opened != 1 {
+		t.Errorf("circuit_breaker_state_changes_total{from=closed,to=open} rose by %v, want 1", opened)
+	}
+	if state := testutil.ToFloat64(circuitBreakerState.WithLabelValues("test-metrics-open")); state != float64(StateOpen) {
+		t.Errorf("circuit_breaker_state = %v, want %d", state, StateOpen)
+	}
+}
+
+func TestExecuteWithFallback(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig(CircuitBreakerConfig{Name: "test-fallback", FailureThreshold: 1, ResetTimeout: time.Minute})
+	errFallback := errors.New("fallback failed")
+	var primaryCalls, fallbackCalls int
+	primary := func(err error) func() error {
+		return func() error { primaryCalls++; return err }
+	}
+	fallback := func() error { fallbackCalls++; return nil }
+
+	// Primary succeeds: no fallback
+	if err := cb.ExecuteWithFallback(primary(nil), fallback); err != nil || fallbackCalls != 0 {
+		t.Fatalf("success: error = %v, fallback calls = %d; want nil, 0", err, fallbackCalls)
+	}
+
+	// Primary fails: fallback's result is returned
+	if err := cb.ExecuteWithFallback(primary(errDependency), fallback); err != nil || fallbackCalls != 1 {
+		t.Fatalf("failure: error = %v, fallback calls = %d; want nil, 1", err, fallbackCalls)
+	}
+
+	// Circuit open: fallback runs without calling primary
+	err := cb.ExecuteWithFallback(primary(nil), func() error { fallbackCalls++; return errFallback })
+	if !errors.Is(err, errFallback) || fallbackCalls != 2 || primaryCalls != 2 {
+		t.Errorf("open: error = %v, fallback calls = %d, primary calls = %d; want %v, 2, 2",
+			err, fallbackCalls, primaryCalls, errFallback)
+	}
+}
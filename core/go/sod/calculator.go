@@ -3,6 +3,7 @@ package sod
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
@@ -14,6 +15,10 @@ type calculator struct {
 	mu               sync.RWMutex
 	configLoader     ConfigLoader
 	metricsCollector MetricsCollector
+	reloadMu         sync.Mutex // serializes Reload so an older load can't win
+
+	historyMu sync.Mutex
+	history   map[string]*occurrenceHistory
 }
 
 // NewCalculator creates a new SOD calculator with dependency injection
@@ -27,6 +32,7 @@ func NewCalculator(loader ConfigLoader, metrics MetricsCollector) (Calculator, e
 		config:           config,
 		configLoader:     loader,
 		metricsCollector: metrics,
+		history:          make(map[string]*occurrenceHistory),
 		runtimeFactors: RuntimeFactors{
 			LastUpdated: time.Now(),
 		},
@@ -40,6 +46,41 @@ func (c *calculator) CalculateScore(ctx context.Context, errorCode string, error
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	return c.calculateScore(errorCode, errorContext)
+}
+
+// CalculateScores computes scores for a batch under a single read lock, so
+// the whole batch sees one config
+func (c *calculator) CalculateScores(ctx context.Context, requests []ScoreRequest) ([]Score, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	scores := make([]Score, len(requests))
+	var failed map[int]error
+	for i, req := range requests {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		score, err := c.calculateScore(req.ErrorCode, req.Context)
+		if err != nil {
+			if failed == nil {
+				failed = make(map[int]error)
+			}
+			failed[i] = err
+			continue
+		}
+		scores[i] = score
+	}
+
+	if failed != nil {
+		return scores, &BatchError{Errors: failed}
+	}
+	return scores, nil
+}
+
+// calculateScore computes one score; callers hold c.mu
+func (c *calculator) calculateScore(errorCode string, errorContext ErrorContext) (Score, error) {
 	errorConfig, ok := c.config.Errors[errorCode]
 	if !ok {
 		return Score{}, fmt.Errorf("error code %s not found in SOD configuration", errorCode)
@@ -47,7 +88,7 @@ func (c *calculator) CalculateScore(ctx context.Context, errorCode string, error
 
 	// Calculate base scores
 	severity := c.calculateSeverity(errorConfig, errorContext)
-	occurrence := c.calculateOccurrence(errorConfig, errorContext)
+	occurrence := c.calculateOccurrence(errorCode, errorConfig, errorContext)
 	detect := c.calculateDetect(errorConfig, errorContext)
 
 	// Calculate total SOD score
@@ -75,7 +116,7 @@ func (c *calculator) CalculateScore(ctx context.Context, errorCode string, error
 		AdjustedScore:    adjustedScore,
 		AdjustmentFactor: adjustmentFactor,
 		SeverityReason:   c.getSeverityReason(errorConfig, errorContext),
-		OccurrenceReason: c.getOccurrenceReason(errorConfig, errorContext),
+		OccurrenceReason: c.getOccurrenceReason(errorCode, errorConfig, errorContext),
 		DetectReason:     c.getDetectReason(errorConfig, errorContext),
 	}
 
@@ -115,25 +156,13 @@ func (c *calculator) calculateSeverity(cfg ErrorConfig, ctx ErrorContext) int {
 }
 
 // calculateOccurrence computes occurrence score based on patterns
-func (c *calculator) calculateOccurrence(cfg ErrorConfig, ctx ErrorContext) int {
+func (c *calculator) calculateOccurrence(errorCode string, cfg ErrorConfig, ctx ErrorContext) int {
 	occurrence := cfg.BaseOccurrence
 
 	// Check occurrence patterns
 	for _, pattern := range cfg.OccurrencePatterns {
-		switch pattern.Type {
-		case "rate":
-			if ctx.RecentErrorRate >= pattern.Threshold {
-				occurrence = pattern.Score
-			}
-		case "burst":
-			// Burst detection would require time-series data
-			// For now, use error rate as proxy
-			if ctx.RecentErrorRate > pattern.Threshold*2 {
-				occurrence = pattern.Score
-			}
-		case "trend":
-			// Trend analysis would require historical data
-			// Placeholder for future implementation
+		if _, matched := c.evaluatePattern(errorCode, pattern, ctx); matched {
+			occurrence = pattern.Score
 		}
 	}
 
@@ -148,6 +177,50 @@ func (c *calculator) calculateOccurrence(cfg ErrorConfig, ctx ErrorContext) int
 	return occurrence
 }
 
+// evaluatePattern returns the pattern's measured value (rate or ratio) and
+// whether it reaches the threshold
+func (c *calculator) evaluatePattern(errorCode string, pattern OccurrencePattern, ctx ErrorContext) (float64, bool) {
+	switch pattern.Type {
+	case "rate":
+		return ctx.RecentErrorRate, ctx.RecentErrorRate >= pattern.Threshold
+	case "burst", "trend":
+		now := ctx.Timestamp
+		if now.IsZero() {
+			now = time.Now()
+		}
+
+		c.historyMu.Lock()
+		defer c.historyMu.Unlock()
+		h, ok := c.history[errorCode]
+		if !ok {
+			return 0, false
+		}
+
+		var value float64
+		if pattern.Type == "burst" {
+			value = h.burstRate(now, pattern.Window)
+		} else {
+			value = h.trendRatio(now, pattern.Window, pattern.Baseline)
+		}
+		return value, value >= pattern.Threshold
+	default:
+		return 0, false
+	}
+}
+
+// RecordOccurrence records that errorCode occurred at t
+func (c *calculator) RecordOccurrence(errorCode string, t time.Time) {
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+
+	h, ok := c.history[errorCode]
+	if !ok {
+		h = newOccurrenceHistory()
+		c.history[errorCode] = h
+	}
+	h.add(t)
+}
+
 // calculateDetect computes detectability score
 func (c *calculator) calculateDetect(cfg ErrorConfig, ctx ErrorContext) int {
 	detect := cfg.BaseDetect
@@ -217,8 +290,55 @@ func (c *calculator) calculateAdjustmentFactor(ctx ErrorContext) float64 {
 	return factor
 }
 
-// evaluateCondition checks if a condition is met
+// evaluateCondition checks if a condition is met. Conditions with operators
+// are parsed as expressions (see condition.go); invalid ones are never met.
 func (c *calculator) evaluateCondition(condition string, ctx ErrorContext) bool {
+	if !isConditionExpression(condition) {
+		return c.evaluateNamedCondition(strings.TrimSpace(condition), ctx)
+	}
+
+	node, err := parseCondition(condition)
+	if err != nil {
+		return false
+	}
+	return node.eval(conditionEnv{
+		named: func(name string) bool { return c.evaluateNamedCondition(name, ctx) },
+		field: func(name string) (interface{}, bool) { return contextField(name, ctx) },
+	})
+}
+
+// contextField returns the ErrorContext value an expression identifier
+// refers to
+func contextField(name string, ctx ErrorContext) (interface{}, bool) {
+	if key, ok := strings.CutPrefix(name, "tag."); ok {
+		v, ok := ctx.CustomTags[key]
+		return v, ok
+	}
+	switch name {
+	case "error_rate":
+		return ctx.RecentErrorRate, true
+	case "system_load":
+		return ctx.SystemLoad, true
+	case "hour":
+		return ctx.TimeOfDay, true
+	case "business_hours":
+		return ctx.IsBusinessHours, true
+	case "environment":
+		return ctx.Environment, true
+	case "service":
+		return ctx.ServiceName, true
+	case "request_path":
+		return ctx.RequestPath, true
+	case "user_id":
+		return ctx.UserID, true
+	case "device_id":
+		return ctx.DeviceID, true
+	}
+	return nil, false
+}
+
+// evaluateNamedCondition checks one of the built-in named conditions
+func (c *calculator) evaluateNamedCondition(condition string, ctx ErrorContext) bool {
 	switch condition {
 	case "business_hours":
 		return ctx.IsBusinessHours
@@ -254,6 +374,46 @@ func (c *calculator) GetErrorConfig(errorCode string) (*ErrorConfig, error) {
 	return &cfg, nil
 }
 
+// defaultWatchInterval matches the file loader's polling interval
+const defaultWatchInterval = 30 * time.Second
+
+// Reload loads the config from the loader and swaps it in
+func (c *calculator) Reload() error {
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	// Load outside the write lock so scoring isn't blocked on I/O
+	config, err := c.configLoader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to reload SOD config: %w", err)
+	}
+
+	c.mu.Lock()
+	c.config = config
+	c.mu.Unlock()
+	return nil
+}
+
+// Watch reloads the config every interval until ctx is cancelled
+func (c *calculator) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Reload(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
 // UpdateRuntimeFactors updates dynamic runtime factors
 func (c *calculator) UpdateRuntimeFactors(factors RuntimeFactors) {
 	c.mu.Lock()
@@ -280,12 +440,21 @@ func (c *calculator) getSeverityReason(cfg ErrorConfig, ctx ErrorContext) string
 	return reason
 }
 
-func (c *calculator) getOccurrenceReason(cfg ErrorConfig, ctx ErrorContext) string {
+func (c *calculator) getOccurrenceReason(errorCode string, cfg ErrorConfig, ctx ErrorContext) string {
 	reason := fmt.Sprintf("Base occurrence: %d", cfg.BaseOccurrence)
 
 	for _, pattern := range cfg.OccurrencePatterns {
-		if pattern.Type == "rate" && ctx.RecentErrorRate >= pattern.Threshold {
-			reason += fmt.Sprintf("; Rate %.2f/s exceeds %.2f threshold", ctx.RecentErrorRate, pattern.Threshold)
+		value, matched := c.evaluatePattern(errorCode, pattern, ctx)
+		if !matched {
+			continue
+		}
+		switch pattern.Type {
+		case "rate":
+			reason += fmt.Sprintf("; Rate %.2f/s exceeds %.2f threshold", value, pattern.Threshold)
+		case "burst":
+			reason += fmt.Sprintf("; Burst %.2f/s exceeds %.2f threshold", value, pattern.Threshold)
+		case "trend":
+			reason += fmt.Sprintf("; Trend %.1fx baseline exceeds %.1fx threshold", value, pattern.Threshold)
 		}
 	}
 
@@ -0,0 +1,320 @@
+package sod
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// staticLoader is a ConfigLoader returning a fixed config
+type staticLoader struct {
+	config *Config
+}
+
+func (l *staticLoader) Load() (*Config, error) { return l.config, nil }
+
+func (l *staticLoader) Reload() error { return nil }
+
+func (l *staticLoader) Watch(ctx context.Context, callback func(*Config)) error { return nil }
+
+// sequenceLoader returns the next config (or error) on each Load
+type sequenceLoader struct {
+	mu      sync.Mutex
+	configs []*Config
+	errs    []error
+	calls   int
+}
+
+func (l *sequenceLoader) Load() (*Config, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	i := min(l.calls, len(l.configs)-1)
+	l.calls++
+	return l.configs[i], l.errs[i]
+}
+
+func (l *sequenceLoader) Reload() error { return nil }
+
+func (l *sequenceLoader) Watch(ctx context.Context, callback func(*Config)) error { return nil }
+
+func singleErrorConfig(severity int) *Config {
+	return &Config{Errors: map[string]ErrorConfig{
+		"ERR-001": {
+			BaseSeverity:    severity,
+			BaseOccurrence:  2,
+			BaseDetect:      5,
+			DetectionConfig: DetectionConfig{MonitoringEnabled: true, AlertingEnabled: true},
+		},
+	}}
+}
+
+func newTestCalculator(t *testing.T, patterns ...OccurrencePattern) Calculator {
+	t.Helper()
+	calc, err := NewCalculator(&staticLoader{config: &Config{
+		Errors: map[string]ErrorConfig{
+			"ERR-001": {
+				Code:               "ERR-001",
+				BaseSeverity:       5,
+				BaseOccurrence:     2,
+				BaseDetect:         4,
+				OccurrencePatterns: patterns,
+				DetectionConfig:    DetectionConfig{MonitoringEnabled: true, AlertingEnabled: true},
+			},
+		},
+	}}, nil)
+	if err != nil {
+		t.Fatalf("NewCalculator() error = %v", err)
+	}
+	return calc
+}
+
+func occurrenceAt(t *testing.T, calc Calculator, now time.Time) Score {
+	t.Helper()
+	score, err := calc.CalculateScore(context.Background(), "ERR-001", ErrorContext{Timestamp: now})
+	if err != nil {
+		t.Fatalf("CalculateScore() error = %v", err)
+	}
+	return score
+}
+
+func TestCalculateScore_BurstPattern(t *testing.T) {
+	calc := newTestCalculator(t, OccurrencePattern{Type: "burst", Threshold: 1, Score: 8, Window: 10 * time.Second})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// A steady trickle over the past hour isn't a burst
+	for i := 1; i <= 6; i++ {
+		calc.RecordOccurrence("ERR-001", now.Add(-time.Duration(i)*10*time.Minute))
+	}
+	if score := occurrenceAt(t, calc, now); score.Occurrence != 2 {
+		t.Errorf("occurrence before burst = %d, want base 2", score.Occurrence)
+	}
+
+	// 20 events in two seconds is 2/s over the 10s window
+	for i := 0; i < 20; i++ {
+		calc.RecordOccurrence("ERR-001", now.Add(-time.Duration(i)*100*time.Millisecond))
+	}
+	score := occurrenceAt(t, calc, now)
+	if score.Occurrence != 8 {
+		t.Errorf("occurrence during burst = %d, want pattern score 8", score.Occurrence)
+	}
+	if !strings.Contains(score.OccurrenceReason, "Burst") {
+		t.Errorf("OccurrenceReason = %q, want burst explanation", score.OccurrenceReason)
+	}
+
+	// Once the window has passed the burst no longer counts
+	if score := occurrenceAt(t, calc, now.Add(time.Minute)); score.Occurrence != 2 {
+		t.Errorf("occurrence after burst = %d, want base 2", score.Occurrence)
+	}
+}
+
+func TestCalculateScore_TrendPattern(t *testing.T) {
+	calc := newTestCalculator(t, OccurrencePattern{Type: "trend", Threshold: 5, Score: 9, Window: time.Minute, Baseline: 10 * time.Minute})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Baseline: one event a minute for the ten minutes before the window
+	for i := 2; i <= 11; i++ {
+		calc.RecordOccurrence("ERR-001", now.Add(-time.Duration(i)*time.Minute+time.Second))
+	}
+	calc.RecordOccurrence("ERR-001", now.Add(-30*time.Second))
+	if score := occurrenceAt(t, calc, now); score.Occurrence != 2 {
+		t.Errorf("occurrence at baseline rate = %d, want base 2", score.Occurrence)
+	}
+
+	// 20 events in the current minute is 20x the baseline
+	for i := 0; i < 19; i++ {
+		calc.RecordOccurrence("ERR-001", now.Add(-time.Duration(i)*time.Second))
+	}
+	score := occurrenceAt(t, calc, now)
+	if score.Occurrence != 9 {
+		t.Errorf("occurrence on rising trend = %d, want pattern score 9", score.Occurrence)
+	}
+	if !strings.Contains(score.OccurrenceReason, "Trend") {
+		t.Errorf("OccurrenceReason = %q, want trend explanation", score.OccurrenceReason)
+	}
+}
+
+func TestCalculateScore_PatternsIgnoreOtherCodes(t *testing.T) {
+	calc := newTestCalculator(t, OccurrencePattern{Type: "burst", Threshold: 1, Score: 8})
+	now := time.Now()
+
+	for i := 0; i < 50; i++ {
+		calc.RecordOccurrence("ERR-999", now)
+	}
+	if score := occurrenceAt(t, calc, now); score.Occurrence != 2 {
+		t.Errorf("occurrence = %d, want base 2 when only other codes burst", score.Occurrence)
+	}
+}
+
+func TestOccurrenceHistory_Wraps(t *testing.T) {
+	h := newOccurrenceHistory()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < occurrenceHistorySize+10; i++ {
+		h.add(start.Add(time.Duration(i) * time.Millisecond))
+	}
+
+	end := start.Add(time.Hour)
+	if got := h.count(start, end); got != occurrenceHistorySize {
+		t.Errorf("count() = %d, want %d (capped at history size)", got, occurrenceHistorySize)
+	}
+	// The oldest ten were overwritten
+	if got := h.count(start, start.Add(10*time.Millisecond)); got != 0 {
+		t.Errorf("count() of overwritten entries = %d, want 0", got)
+	}
+}
+
+func TestCalculator_Reload(t *testing.T) {
+	loader := &sequenceLoader{
+		configs: []*Config{singleErrorConfig(4), singleErrorConfig(8), singleErrorConfig(8)},
+		errs:    []error{nil, nil, errors.New("config unreadable")},
+	}
+	calc, err := NewCalculator(loader, nil)
+	if err != nil {
+		t.Fatalf("NewCalculator() error = %v", err)
+	}
+	calc.UpdateRuntimeFactors(RuntimeFactors{MaintenanceMode: true})
+
+	score := occurrenceAt(t, calc, time.Now())
+	if score.Severity != 4 || score.AdjustmentFactor != 0.7 {
+		t.Fatalf("initial severity = %d, factor = %v, want 4 and 0.7", score.Severity, score.AdjustmentFactor)
+	}
+
+	if err := calc.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	score = occurrenceAt(t, calc, time.Now())
+	if score.Severity != 8 {
+		t.Errorf("severity after Reload() = %d, want 8 from the new config", score.Severity)
+	}
+	if score.AdjustmentFactor != 0.7 {
+		t.Errorf("adjustment factor after Reload() = %v, want runtime factors kept (0.7)", score.AdjustmentFactor)
+	}
+
+	// A failed load keeps the current config
+	if err := calc.Reload(); err == nil {
+		t.Error("Reload() error = nil, want load error")
+	}
+	if score := occurrenceAt(t, calc, time.Now()); score.Severity != 8 {
+		t.Errorf("severity after failed Reload() = %d, want 8", score.Severity)
+	}
+}
+
+func TestCalculator_Watch(t *testing.T) {
+	loader := &sequenceLoader{
+		configs: []*Config{singleErrorConfig(4), singleErrorConfig(8)},
+		errs:    []error{nil, nil},
+	}
+	calc, err := NewCalculator(loader, nil)
+	if err != nil {
+		t.Fatalf("NewCalculator() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		calc.Watch(ctx, time.Millisecond, func(err error) { t.Errorf("reload error = %v", err) })
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for occurrenceAt(t, calc, time.Now()).Severity != 8 {
+		if time.Now().After(deadline) {
+			t.Fatal("Watch() did not reload the config")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Watch() did not return after cancel")
+	}
+}
+
+func TestCalculateScores(t *testing.T) {
+	calc := newTestCalculator(t)
+	now := time.Now()
+
+	requests := []ScoreRequest{
+		{ErrorCode: "ERR-001", Context: ErrorContext{Timestamp: now, Environment: "production"}},
+		{ErrorCode: "ERR-404", Context: ErrorContext{Timestamp: now}},
+		{ErrorCode: "ERR-001", Context: ErrorContext{Timestamp: now, Environment: "dev"}},
+	}
+	scores, err := calc.CalculateScores(context.Background(), requests)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("CalculateScores() error = %v, want *BatchError", err)
+	}
+	if len(batchErr.Errors) != 1 || batchErr.Errors[1] == nil {
+		t.Errorf("BatchError.Errors = %v, want only index 1", batchErr.Errors)
+	}
+	if len(scores) != len(requests) {
+		t.Fatalf("CalculateScores() returned %d scores, want %d", len(scores), len(requests))
+	}
+	if scores[1].Total != 0 {
+		t.Errorf("failed request score = %+v, want zero Score", scores[1])
+	}
+
+	for _, i := range []int{0, 2} {
+		want, err := calc.CalculateScore(context.Background(), requests[i].ErrorCode, requests[i].Context)
+		if err != nil {
+			t.Fatalf("CalculateScore() error = %v", err)
+		}
+		if scores[i] != want {
+			t.Errorf("scores[%d] = %+v, want %+v as from CalculateScore", i, scores[i], want)
+		}
+	}
+}
+
+func TestCalculateScores_Cancelled(t *testing.T) {
+	calc := newTestCalculator(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := calc.CalculateScores(ctx, []ScoreRequest{{ErrorCode: "ERR-001"}}); !errors.Is(err, context.Canceled) {
+		t.Errorf("CalculateScores() error = %v, want context.Canceled", err)
+	}
+}
+
+func benchmarkRequests(n int) []ScoreRequest {
+	requests := make([]ScoreRequest, n)
+	for i := range requests {
+		requests[i] = ScoreRequest{
+			ErrorCode: "ERR-001",
+			Context:   ErrorContext{Environment: "production", SystemLoad: 0.6, IsBusinessHours: i%2 == 0},
+		}
+	}
+	return requests
+}
+
+func BenchmarkCalculateScore_Loop(b *testing.B) {
+	calc, _ := NewCalculator(&staticLoader{config: singleErrorConfig(5)}, nil)
+	requests := benchmarkRequests(1000)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, req := range requests {
+			if _, err := calc.CalculateScore(ctx, req.ErrorCode, req.Context); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkCalculateScores_Batch(b *testing.B) {
+	calc, _ := NewCalculator(&staticLoader{config: singleErrorConfig(5)}, nil)
+	requests := benchmarkRequests(1000)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc.CalculateScores(ctx, requests); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package sod
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Condition expressions combine named conditions and comparisons against
+// ErrorContext fields, e.g.
+//
+//	production && high_load
+//	error_rate > 8 || (environment == "staging" && !business_hours)
+//	tag.region == "eu-west"
+//
+// Precedence is ! over comparisons over && over ||. A bare identifier is a
+// named condition ("production", "high_load", ...).
+
+// conditionOperators marks a condition as an expression rather than a name
+const conditionOperators = "&|<>=!()\""
+
+// isConditionExpression reports whether condition needs the expression
+// parser rather than the named-condition lookup
+func isConditionExpression(condition string) bool {
+	return strings.ContainsAny(condition, conditionOperators)
+}
+
+// conditionEnv resolves identifiers while evaluating an expression
+type conditionEnv struct {
+	named func(name string) bool
+	field func(name string) (interface{}, bool)
+}
+
+// conditionNode is a parsed condition expression
+type conditionNode interface {
+	eval(env conditionEnv) bool
+}
+
+type andNode struct{ left, right conditionNode }
+
+func (n andNode) eval(env conditionEnv) bool { return n.left.eval(env) && n.right.eval(env) }
+
+type orNode struct{ left, right conditionNode }
+
+func (n orNode) eval(env conditionEnv) bool { return n.left.eval(env) || n.right.eval(env) }
+
+type notNode struct{ operand conditionNode }
+
+func (n notNode) eval(env conditionEnv) bool { return !n.operand.eval(env) }
+
+type namedNode struct{ name string }
+
+func (n namedNode) eval(env conditionEnv) bool { return env.named(n.name) }
+
+// operand is a literal or an ErrorContext field in a comparison
+type operand struct {
+	field   string
+	literal interface{} // float64 or string
+}
+
+func (o operand) value(env conditionEnv) (interface{}, bool) {
+	if o.field == "" {
+		return o.literal, true
+	}
+	return env.field(o.field)
+}
+
+type compareNode struct {
+	op          string
+	left, right operand
+}
+
+// eval compares numbers numerically and anything else as strings; unknown
+// fields make the comparison false
+func (n compareNode) eval(env conditionEnv) bool {
+	left, ok := n.left.value(env)
+	if !ok {
+		return false
+	}
+	right, ok := n.right.value(env)
+	if !ok {
+		return false
+	}
+
+	lf, lnum := toFloat(left)
+	rf, rnum := toFloat(right)
+	if lnum && rnum {
+		switch n.op {
+		case ">":
+			return lf > rf
+		case ">=":
+			return lf >= rf
+		case "<":
+			return lf < rf
+		case "<=":
+			return lf <= rf
+		case "==":
+			return lf == rf
+		case "!=":
+			return lf != rf
+		}
+		return false
+	}
+
+	ls, rs := fmt.Sprint(left), fmt.Sprint(right)
+	switch n.op {
+	case "==":
+		return ls == rs
+	case "!=":
+		return ls != rs
+	}
+	return false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// parseCondition parses a condition expression
+func parseCondition(expr string) (conditionNode, error) {
+	tokens, err := tokenizeCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &conditionParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in condition %q", p.tokens[p.pos].text, expr)
+	}
+	return node, nil
+}
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenOp
+)
+
+type conditionToken struct {
+	kind tokenKind
+	text string
+}
+
+func tokenizeCondition(expr string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	for i := 0; i < len(expr); {
+		ch := rune(expr[i])
+		switch {
+		case unicode.IsSpace(ch):
+			i++
+		case strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"),
+			strings.HasPrefix(expr[i:], ">="), strings.HasPrefix(expr[i:], "<="),
+			strings.HasPrefix(expr[i:], "=="), strings.HasPrefix(expr[i:], "!="):
+			tokens = append(tokens, conditionToken{tokenOp, expr[i : i+2]})
+			i += 2
+		case strings.ContainsRune("<>!()", ch):
+			tokens = append(tokens, conditionToken{tokenOp, string(ch)})
+			i++
+		case ch == '"' || ch == '\'':
+			end := strings.IndexRune(expr[i+1:], ch)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string in condition %q", expr)
+			}
+			tokens = append(tokens, conditionToken{tokenString, expr[i+1 : i+1+end]})
+			i += end + 2
+		case unicode.IsDigit(ch) || ch == '.' || ch == '-':
+			start := i
+			for i++; i < len(expr) && (unicode.IsDigit(rune(expr[i])) || expr[i] == '.'); i++ {
+			}
+			tokens = append(tokens, conditionToken{tokenNumber, expr[start:i]})
+		case unicode.IsLetter(ch) || ch == '_':
+			start := i
+			for i++; i < len(expr) && isIdentRune(rune(expr[i])); i++ {
+			}
+			tokens = append(tokens, conditionToken{tokenIdent, expr[start:i]})
+		default:
+			return nil, fmt.Errorf("unexpected %q in condition %q", ch, expr)
+		}
+	}
+	return tokens, nil
+}
+
+// isIdentRune allows dots for tag.<key> identifiers
+func isIdentRune(ch rune) bool {
+	return unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '_' || ch == '.' || ch == '-'
+}
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func (p *conditionParser) peekOp(ops ...string) (string, bool) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.peekOp("||"); !ok {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.peekOp("&&"); !ok {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *conditionParser) parseUnary() (conditionNode, error) {
+	if _, ok := p.peekOp("!"); ok {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	}
+	if _, ok := p.peekOp("("); ok {
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.peekOp(")"); !ok {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.peekOp(">", ">=", "<", "<=", "==", "!=")
+	if !ok {
+		if left.field == "" {
+			return nil, fmt.Errorf("literal %v must be compared", left.literal)
+		}
+		return namedNode{left.field}, nil
+	}
+	p.pos++
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return compareNode{op: op, left: left, right: right}, nil
+}
+
+func (p *conditionParser) parseOperand() (operand, error) {
+	if p.pos >= len(p.tokens) {
+		return operand{}, fmt.Errorf("unexpected end of condition")
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch tok.kind {
+	case tokenIdent:
+		return operand{field: tok.text}, nil
+	case tokenString:
+		return operand{literal: tok.text}, nil
+	case tokenNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return operand{}, fmt.Errorf("invalid number %q", tok.text)
+		}
+		return operand{literal: f}, nil
+	}
+	return operand{}, fmt.Errorf("unexpected %q", tok.text)
+}
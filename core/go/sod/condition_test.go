@@ -0,0 +1,106 @@
+package sod
+
+import (
+	"testing"
+)
+
+func TestEvaluateCondition_Expressions(t *testing.T) {
+	c := &calculator{config: &Config{}}
+	c.config.RuntimeSettings.LoadThresholds.Medium = 0.5
+	c.config.RuntimeSettings.LoadThresholds.High = 0.75
+
+	ctx := ErrorContext{
+		Environment:     "production",
+		SystemLoad:      0.9,
+		RecentErrorRate: 9.5,
+		TimeOfDay:       14,
+		IsBusinessHours: true,
+		CustomTags:      map[string]string{"region": "eu-west"},
+	}
+
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		// Named conditions keep working
+		{"production", true},
+		{"staging", false},
+		{"unknown_condition", false},
+
+		// Compound
+		{"production && high_load", true},
+		{"production && staging", false},
+		{"staging || high_load", true},
+		{"staging || dev", false},
+		{"!staging && business_hours", true},
+		{"staging || production && high_load", true},
+		{"(staging || production) && !business_hours", false},
+
+		// Comparisons
+		{"error_rate > 8", true},
+		{"error_rate >= 9.5", true},
+		{"error_rate < 9.5", false},
+		{"system_load <= 0.9 && hour != 3", true},
+		{`environment == "production"`, true},
+		{"environment != 'production'", false},
+		{`tag.region == "eu-west"`, true},
+		{`tag.missing == "x"`, false},
+		{"unknown_field > 1", false},
+		{"business_hours == 1", true},
+		{`error_rate > 8 && environment == "staging" || tag.region == "eu-west"`, true},
+
+		// Invalid expressions are never met
+		{"production &&", false},
+		{"(production", false},
+		{`environment == "production`, false},
+		{"error_rate >", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			if got := c.evaluateCondition(tt.condition, ctx); got != tt.want {
+				t.Errorf("evaluateCondition(%q) = %v, want %v", tt.condition, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCondition_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"production &&",
+		"(production",
+		"production)",
+		"error_rate > ",
+		"5",
+		`environment == "production`,
+		"error_rate # 5",
+	} {
+		if _, err := parseCondition(expr); err == nil {
+			t.Errorf("parseCondition(%q) error = nil, want error", expr)
+		}
+	}
+}
+
+func TestValidateConfig_RejectsInvalidConditions(t *testing.T) {
+	config := &Config{
+		ServiceName: "test",
+		Environment: "test",
+		Errors: map[string]ErrorConfig{
+			"ERR-001": {
+				BaseSeverity:   5,
+				BaseOccurrence: 5,
+				BaseDetect:     5,
+				SeverityRules:  []SeverityRule{{Condition: "production && (high_load", Multiplier: 1.5}},
+			},
+		},
+	}
+
+	if err := (&fileConfigLoader{}).validateConfig(config); err == nil {
+		t.Error("validateConfig() error = nil, want invalid condition error")
+	}
+
+	config.Errors["ERR-001"].SeverityRules[0].Condition = "production && high_load"
+	if err := (&fileConfigLoader{}).validateConfig(config); err != nil {
+		t.Errorf("validateConfig() error = %v, want nil", err)
+	}
+}
@@ -126,6 +126,14 @@ func (l *fileConfigLoader) validateConfig(config *Config) error {
 		if errCfg.BaseDetect < 1 || errCfg.BaseDetect > 10 {
 			return fmt.Errorf("error %s: base_detect must be 1-10", code)
 		}
+		for _, rule := range errCfg.SeverityRules {
+			if !isConditionExpression(rule.Condition) {
+				continue
+			}
+			if _, err := parseCondition(rule.Condition); err != nil {
+				return fmt.Errorf("error %s: invalid condition %q: %w", code, rule.Condition, err)
+			}
+		}
 	}
 
 	return nil
@@ -2,6 +2,7 @@ package sod
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -10,11 +11,28 @@ type Calculator interface {
 	// CalculateScore computes the SOD score based on error context and configuration
 	CalculateScore(ctx context.Context, errorCode string, errorContext ErrorContext) (Score, error)
 
+	// CalculateScores computes a score per request against one config. Requests
+	// that fail leave a zero Score and are reported in a *BatchError; the rest
+	// are still scored.
+	CalculateScores(ctx context.Context, requests []ScoreRequest) ([]Score, error)
+
 	// GetErrorConfig retrieves the SOD configuration for a specific error code
 	GetErrorConfig(errorCode string) (*ErrorConfig, error)
 
 	// UpdateRuntimeFactors updates dynamic runtime factors (load, error rate, etc.)
 	UpdateRuntimeFactors(factors RuntimeFactors)
+
+	// RecordOccurrence records that errorCode occurred at t, feeding the
+	// "burst" and "trend" occurrence patterns
+	RecordOccurrence(errorCode string, t time.Time)
+
+	// Reload loads the config again and swaps it in, keeping runtime factors
+	// and recorded occurrences. On error the current config stays in use.
+	Reload() error
+
+	// Watch calls Reload every interval (default 30s) until ctx is cancelled,
+	// passing reload errors to onError if it's non-nil
+	Watch(ctx context.Context, interval time.Duration, onError func(error))
 }
 
 // ConfigLoader defines the interface for loading SOD configuration
@@ -46,7 +64,7 @@ type MetricsCollector interface {
 
 // ErrorContext contains runtime context for SOD calculation
 type ErrorContext struct {
-	Timestamp       time.Time
+	Timestamp       time.Time // evaluation time for burst/trend patterns (default: now)
 	ServiceName     string
 	Environment     string // dev, staging, production
 	UserID          string
@@ -59,6 +77,27 @@ type ErrorContext struct {
 	CustomTags      map[string]string
 }
 
+// ScoreRequest is one entry in a CalculateScores batch
+type ScoreRequest struct {
+	ErrorCode string
+	Context   ErrorContext
+}
+
+// BatchError reports the CalculateScores requests that failed, by index
+type BatchError struct {
+	Errors map[int]error
+}
+
+func (e *BatchError) Error() string {
+	first := -1
+	for i := range e.Errors {
+		if first < 0 || i < first {
+			first = i
+		}
+	}
+	return fmt.Sprintf("%d of the batch's SOD scores failed, first at index %d: %v", len(e.Errors), first, e.Errors[first])
+}
+
 // RuntimeFactors contains dynamic factors affecting SOD scores
 type RuntimeFactors struct {
 	CurrentLoad     float64 // 0-1
@@ -118,11 +157,19 @@ type SeverityRule struct {
 	Override   *int    // optional: override severity completely
 }
 
-// OccurrencePattern defines how to calculate occurrence score
+// OccurrencePattern defines how to calculate occurrence score.
+//
+// "rate" compares ErrorContext.RecentErrorRate to Threshold. "burst" and
+// "trend" use the occurrences fed to Calculator.RecordOccurrence: burst
+// compares the rate over Window (default 10s) to Threshold in errors/second;
+// trend compares the rate over Window (default 1m) to the rate over the
+// Baseline before it (default 10 windows), with Threshold as the ratio.
 type OccurrencePattern struct {
-	Type      string  // "rate", "burst", "trend"
-	Threshold float64 // threshold for this pattern
-	Score     int     // occurrence score if threshold exceeded
+	Type      string        // "rate", "burst", "trend"
+	Threshold float64       // threshold for this pattern
+	Score     int           // occurrence score if threshold exceeded
+	Window    time.Duration // burst/trend: current window
+	Baseline  time.Duration // trend: baseline period before the window
 }
 
 // DetectionConfig defines how quickly errors are detected
@@ -0,0 +1,86 @@
+package sod
+
+import (
+	"time"
+)
+
+const (
+	// occurrenceHistorySize caps the timestamps kept per error code. Counts
+	// over a window saturate at this size, so very high rates read low.
+	occurrenceHistorySize = 4096
+
+	// defaultBurstWindow is the burst pattern window when Window is unset
+	defaultBurstWindow = 10 * time.Second
+
+	// defaultTrendWindow is the trend pattern's current window when Window is
+	// unset; the baseline defaults to the ten windows before it
+	defaultTrendWindow     = time.Minute
+	defaultBaselineWindows = 10
+)
+
+// occurrenceHistory is a ring buffer of the most recent occurrence times of
+// one error code
+type occurrenceHistory struct {
+	times []time.Time
+	next  int
+	full  bool
+}
+
+func newOccurrenceHistory() *occurrenceHistory {
+	return &occurrenceHistory{times: make([]time.Time, occurrenceHistorySize)}
+}
+
+// add records an occurrence, overwriting the oldest once full
+func (h *occurrenceHistory) add(t time.Time) {
+	h.times[h.next] = t
+	h.next = (h.next + 1) % len(h.times)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// count returns the occurrences in [from, to)
+func (h *occurrenceHistory) count(from, to time.Time) int {
+	n := h.next
+	if h.full {
+		n = len(h.times)
+	}
+	count := 0
+	for _, t := range h.times[:n] {
+		if !t.Before(from) && t.Before(to) {
+			count++
+		}
+	}
+	return count
+}
+
+// burstRate returns occurrences per second over the window ending at now
+func (h *occurrenceHistory) burstRate(now time.Time, window time.Duration) float64 {
+	if window <= 0 {
+		window = defaultBurstWindow
+	}
+	// Include events stamped at now
+	end := now.Add(time.Nanosecond)
+	return float64(h.count(end.Add(-window), end)) / window.Seconds()
+}
+
+// trendRatio returns the rate over the window ending at now divided by the
+// rate over the baseline period before it. An empty baseline counts as one
+// occurrence so a first spike gives a large but finite ratio.
+func (h *occurrenceHistory) trendRatio(now time.Time, window, baseline time.Duration) float64 {
+	if window <= 0 {
+		window = defaultTrendWindow
+	}
+	if baseline <= 0 {
+		baseline = window * defaultBaselineWindows
+	}
+	end := now.Add(time.Nanosecond)
+	start := end.Add(-window)
+
+	current := float64(h.count(start, end)) / window.Seconds()
+	previous := h.count(start.Add(-baseline), start)
+	if previous == 0 {
+		previous = 1
+	}
+	return current / (float64(previous) / baseline.Seconds())
+}
@@ -0,0 +1,139 @@
+// Command leaderboard-rebuild reconstructs Redis leaderboards by replaying the
+// leaderboard topic from the oldest retained offset. Score events are applied
+// idempotently, so it is safe to run against a live or partially-populated
+// Redis, and safe to run more than once.
+//
+// Usage:
+//
+//	CONFIG_PATH=config/config.yaml go run ./cmd/leaderboard-rebuild -idle 30s
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/kafka"
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/redis"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/config"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/services"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/eventbus"
+	"go.uber.org/zap"
+)
+
+func main() {
+	idle := flag.Duration("idle", 30*time.Second, "stop after no events arrive for this long (caught up)")
+	flag.Parse()
+
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config/config.yaml"
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cfg = config.LoadFromEnv()
+	}
+
+	log, err := logger.NewProduction(cfg.Service.Name+"-leaderboard-rebuild", cfg.Service.Version)
+	if err != nil {
+		fmt.Printf("Failed to create logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer log.Sync()
+
+	eventbus.ConfigureTopics(eventbus.Topics(cfg.Kafka.Topics))
+	topic, err := eventbus.TopicFor[*models.LeaderboardEvent]()
+	if err != nil {
+		log.Error("Failed to resolve leaderboard topic", zap.Error(err))
+		os.Exit(1)
+	}
+
+	redisClient, err := redis.NewClient(redis.ClientConfig{
+		Host:        cfg.Redis.Host,
+		Port:        cfg.Redis.Port,
+		Logger:      log,
+		PingTimeout: cfg.Redis.PingTimeout,
+	})
+	if err != nil {
+		log.Error("Failed to connect to Redis", zap.Error(err))
+		os.Exit(1)
+	}
+	defer redisClient.Close(context.Background())
+
+	// A fresh group ID per run starts at the oldest offset instead of resuming
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers:       cfg.Kafka.Brokers,
+		Topic:         topic,
+		GroupID:       fmt.Sprintf("leaderboard-rebuild-%d", time.Now().UnixNano()),
+		Logger:        log,
+		FromOldest:    true,
+		ReadCommitted: true,
+	})
+	if err != nil {
+		log.Error("Failed to create Kafka consumer", zap.Error(err))
+		os.Exit(1)
+	}
+	defer consumer.Close(context.Background())
+
+	svc := services.NewPatternsService(nil, nil, "", nil, redisClient, nil, log, nil)
+
+	var applied atomic.Int64
+	subscriber := eventbus.NewSubscriber(log)
+	if err := eventbus.Subscribe(subscriber, func(ctx context.Context, event *models.LeaderboardEvent) error {
+		if err := svc.ApplyLeaderboardEvent(ctx, event); err != nil {
+			return err
+		}
+		applied.Add(1)
+		return nil
+	}); err != nil {
+		log.Error("Failed to subscribe to leaderboard events", zap.Error(err))
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// Stop once the topic has been idle for the configured period
+	activity := make(chan struct{}, 1)
+	go func() {
+		timer := time.NewTimer(*idle)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-activity:
+				timer.Reset(*idle)
+			case <-timer.C:
+				cancel()
+				return
+			}
+		}
+	}()
+
+	log.Info("Rebuilding leaderboards from Kafka",
+		zap.String("topic", topic),
+		zap.Duration("idle_timeout", *idle))
+
+	err = consumer.Start(ctx, func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		select {
+		case activity <- struct{}{}:
+		default:
+		}
+		return subscriber.Handle(ctx, msg)
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Error("Leaderboard rebuild failed", zap.Error(err), zap.Int64("applied", applied.Load()))
+		os.Exit(1)
+	}
+
+	log.Info("Leaderboard rebuild complete", zap.Int64("applied", applied.Load()))
+}
@@ -11,23 +11,39 @@ import (
 	"time"
 
 	// Core packages
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/health"
 	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/kafka"
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/keyvault"
 	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/mongodb"
 	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/redis"
 	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/scylladb"
 	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/sqlserver"
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
 	"github.com/your-github-org/ai-scaffolder/core/go/metrics"
+	"github.com/your-github-org/ai-scaffolder/core/go/pagination"
 
 	// Patterns packages
 	"github.com/your-github-org/ai-scaffolder/patterns/go/config"
 	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/api"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
 	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/services"
 	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/sli"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/eventbus"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/safego"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/selftest"
+
+	"github.com/prometheus/client_golang/prometheus"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
+// Build details, set via -ldflags "-X main.Commit=... -X main.BuildTime=..."
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
 func main() {
 	// ========================================
 	// 1. LOAD CONFIGURATION
@@ -62,6 +78,8 @@ func main() {
 
 	log.Info("Starting AI Patterns service - demonstrating Core package usage",
 		zap.String("version", cfg.Service.Version),
+		zap.String("build_version", Version),
+		zap.String("commit", Commit),
 		zap.String("environment", cfg.Service.Environment),
 		zap.Int("port", cfg.Service.Port))
 
@@ -72,8 +90,16 @@ func main() {
 		ServiceName: cfg.Service.Name,
 		Namespace:   "iot_homeguard",
 		Subsystem:   "patterns",
+		// Analytics fans out to every store and runs for seconds, not milliseconds
+		EndpointBuckets: map[string][]float64{
+			"/api/v1/patterns/analytics": {0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30},
+		},
 	})
 
+	// Every ServiceError created (New/Wrap/registry) is counted in errors_total
+	stopErrorTracking := serviceMetrics.TrackServiceErrors()
+	defer stopErrorTracking()
+
 	log.Info("Core.Metrics initialized",
 		zap.String("namespace", "iot_homeguard"),
 		zap.String("subsystem", "patterns"))
@@ -131,14 +157,16 @@ func main() {
 
 	// --- Core.Infrastructure.ScyllaDB ---
 	var scyllaSession scylladb.Session
+	var scyllaReconnecting *scylladb.ReconnectingSession
 	if len(cfg.ScyllaDB.Hosts) > 0 {
-		scyllaSession, err = scylladb.NewSession(scylladb.SessionConfig{
+		scyllaConfig := scylladb.SessionConfig{
 			Hosts:          cfg.ScyllaDB.Hosts,
 			Keyspace:       cfg.ScyllaDB.Keyspace,
 			Logger:         log,
 			Timeout:        60 * time.Second,
 			ConnectTimeout: 60 * time.Second,
-		})
+		}
+		scyllaSession, err = scylladb.NewSession(scyllaConfig)
 		if err != nil {
 			log.Warn("Failed to connect to ScyllaDB - continuing without it",
 				zap.Error(err),
@@ -147,18 +175,62 @@ func main() {
 			log.Info("Core.Infrastructure.ScyllaDB connected",
 				zap.Strings("hosts", cfg.ScyllaDB.Hosts),
 				zap.String("keyspace", cfg.ScyllaDB.Keyspace))
+
+			// Wrap so a dropped session can be re-established without restarting
+			scyllaReconnecting = scylladb.NewReconnectingSession(scyllaSession, func() (scylladb.Session, error) {
+				return scylladb.NewSession(scyllaConfig)
+			})
+			scyllaSession = scyllaReconnecting
+		}
+	}
+
+	// Extra telemetry shards; the primary session is always shard "primary"
+	var telemetryShards *services.DeviceShardResolver
+	var shardSessions []scylladb.Session
+	if scyllaSession != nil && len(cfg.ScyllaDB.Shards) > 0 {
+		shards := map[string]scylladb.Session{"primary": scyllaSession}
+		for _, shard := range cfg.ScyllaDB.Shards {
+			if _, exists := shards[shard.Name]; exists || shard.Name == "" {
+				log.Error("ScyllaDB shard names must be unique and non-empty", zap.String("shard", shard.Name))
+				os.Exit(1)
+			}
+			session, err := scylladb.NewSession(scylladb.SessionConfig{
+				Hosts:          shard.Hosts,
+				Keyspace:       shard.Keyspace,
+				Logger:         log,
+				Timeout:        60 * time.Second,
+				ConnectTimeout: 60 * time.Second,
+			})
+			if err != nil {
+				// Routing around a missing shard would write its devices to the wrong place
+				log.Error("Failed to connect to ScyllaDB telemetry shard - exiting",
+					zap.String("shard", shard.Name),
+					zap.Error(err))
+				os.Exit(1)
+			}
+			shards[shard.Name] = session
+			shardSessions = append(shardSessions, session)
 		}
+
+		telemetryShards, err = services.NewDeviceShardResolver(shards, cfg.ScyllaDB.VirtualNodes)
+		if err != nil {
+			log.Error("Invalid ScyllaDB shard configuration", zap.Error(err))
+			os.Exit(1)
+		}
+		log.Info("Telemetry sharding enabled", zap.Int("shards", len(shards)))
 	}
 
 	// --- Core.Infrastructure.Redis ---
 	var redisClient redis.Client
+	var redisReconnecting *redis.ReconnectingClient
 	if cfg.Redis.Host != "" {
-		redisClient, err = redis.NewClient(redis.ClientConfig{
+		redisConfig := redis.ClientConfig{
 			Host:        cfg.Redis.Host,
 			Port:        cfg.Redis.Port,
 			Logger:      log,
 			PingTimeout: 60 * time.Second,
-		})
+		}
+		redisClient, err = redis.NewClient(redisConfig)
 		if err != nil {
 			log.Warn("Failed to connect to Redis - continuing without it",
 				zap.Error(err),
@@ -167,10 +239,17 @@ func main() {
 			log.Info("Core.Infrastructure.Redis connected",
 				zap.String("host", cfg.Redis.Host),
 				zap.Int("port", cfg.Redis.Port))
+
+			// Wrap so a dropped connection can be re-established without restarting
+			redisReconnecting = redis.NewReconnectingClient(redisClient, func() (redis.Client, error) {
+				return redis.NewClient(redisConfig)
+			})
+			redisClient = redisReconnecting
 		}
 	}
 
 	// --- Core.Infrastructure.Kafka ---
+	eventbus.ConfigureTopics(eventbus.Topics(cfg.Kafka.Topics))
 	var kafkaProducer kafka.Producer
 	if len(cfg.Kafka.Brokers) > 0 {
 		kafkaProducer, err = kafka.NewProducer(kafka.ProducerConfig{
@@ -187,15 +266,108 @@ func main() {
 		}
 	}
 
+	// Critical/high error logs also go to the ops event stream from here on
+	var systemEvents *eventbus.SystemEventHook
+	if kafkaProducer != nil && cfg.Kafka.LogSystemEvents {
+		systemEvents = eventbus.NewSystemEventHook(kafkaProducer, cfg.Service.Name, 0)
+		log = systemEvents.Attach(log)
+		log.Info("Publishing critical error logs as system events",
+			zap.String("topic", cfg.Kafka.Topics.System))
+	}
+
+	// --- Core.Infrastructure.KeyVault (optional) ---
+	var keyVaultClient keyvault.CachedClient
+	if cfg.KeyVault.VaultURL != "" {
+		keyVaultClient, err = keyvault.NewCachedClient(keyvault.CachedClientConfig{
+			KeyVault: keyvault.ClientConfig{
+				VaultURL:           cfg.KeyVault.VaultURL,
+				Timeout:            cfg.KeyVault.Timeout,
+				InsecureSkipVerify: cfg.KeyVault.InsecureSkipVerify,
+			},
+			Redis: keyvault.RedisConfig{
+				Host: cfg.Redis.Host,
+				Port: cfg.Redis.Port,
+			},
+			CacheTTL: cfg.KeyVault.CacheTTL,
+		}, log)
+		if err != nil {
+			log.Warn("Failed to connect to KeyVault - continuing without it",
+				zap.Error(err),
+				zap.String("vault_url", cfg.KeyVault.VaultURL))
+		} else {
+			log.Info("Core.Infrastructure.KeyVault connected",
+				zap.String("vault_url", cfg.KeyVault.VaultURL))
+
+			// Warm the cache before serving so the first reads after a
+			// deploy don't all go to KeyVault
+			for _, prefix := range cfg.KeyVault.WarmPrefixes {
+				warmCtx, cancel := context.WithTimeout(context.Background(), cfg.KeyVault.Timeout)
+				if _, err := keyVaultClient.WarmCache(warmCtx, prefix); err != nil {
+					log.Warn("KeyVault cache warming incomplete - continuing",
+						zap.Error(err),
+						zap.String("prefix", prefix))
+				}
+				cancel()
+			}
+
+			// Replicas must sign pagination cursors with the shared key, or a
+			// cursor from one is rejected by the others and after restarts
+			keyCtx, cancel := context.WithTimeout(context.Background(), cfg.KeyVault.Timeout)
+			if err := pagination.LoadSigningKey(keyCtx, keyVaultClient, pagination.DefaultSigningKeySecret); err != nil {
+				log.Warn("Failed to load pagination cursor signing key - cursors are valid on this instance only",
+					zap.Error(err),
+					zap.String("secret", pagination.DefaultSigningKeySecret))
+			}
+			cancel()
+		}
+	}
+
 	log.Info("Core.Infrastructure initialization complete",
 		zap.Bool("sqlserver", sqlDB != nil),
 		zap.Bool("mongodb", mongoClient != nil),
 		zap.Bool("scylladb", scyllaSession != nil),
 		zap.Bool("redis", redisClient != nil),
-		zap.Bool("kafka", kafkaProducer != nil))
+		zap.Bool("kafka", kafkaProducer != nil),
+		zap.Bool("keyvault", keyVaultClient != nil))
 
 	// ========================================
-	// 6. SERVICE LAYER SETUP
+	// 6. STARTUP SELF-TEST (schemas exist)
+	// ========================================
+	if cfg.SelfTest.Mode != selftest.ModeOff {
+		selfTestCfg := selftest.Config{
+			SQLDB:          sqlDB,
+			ScyllaSession:  scyllaSession,
+			ScyllaKeyspace: cfg.ScyllaDB.Keyspace,
+			Requirements:   selftest.DefaultRequirements(),
+		}
+		if mongoClient != nil {
+			selfTestCfg.MongoDatabase = mongoClient.Database(cfg.MongoDB.Database)
+		}
+
+		selfTestCtx, cancelSelfTest := context.WithTimeout(context.Background(), cfg.SelfTest.Timeout)
+		report := selftest.Run(selfTestCtx, selfTestCfg)
+		cancelSelfTest()
+
+		for _, failure := range report.Failures() {
+			log.Warn("Startup self-test failed",
+				zap.String("backend", failure.Backend),
+				zap.String("resource", failure.Resource),
+				zap.Error(failure.Err),
+				zap.String("fix", failure.Hint))
+		}
+
+		if !report.OK() && cfg.SelfTest.Mode == selftest.ModeStrict {
+			log.Error("Startup self-test failed in strict mode - exiting",
+				zap.Int("failures", len(report.Failures())))
+			os.Exit(1)
+		}
+		log.Info("Startup self-test complete",
+			zap.Int("checks", len(report.Results)),
+			zap.Int("failures", len(report.Failures())))
+	}
+
+	// ========================================
+	// 7. SERVICE LAYER SETUP
 	// ========================================
 	patternsService := services.NewPatternsService(
 		sqlDB,
@@ -207,17 +379,125 @@ func main() {
 		log,
 		sliTracker,
 	)
+	if err := patternsService.DeadLetters().RegisterMetrics(prometheus.DefaultRegisterer); err != nil {
+		log.Warn("Failed to register dead-letter metrics", zap.Error(err))
+	}
+	patternsService.SetAnalyticsTimeouts(cfg.Analytics.StoreTimeout, cfg.Analytics.Deadline)
+	patternsService.SetExchangeRates(services.StaticExchangeRates{
+		Base:  cfg.Analytics.ReportingCurrency,
+		Rates: cfg.Analytics.ExchangeRates,
+	}, cfg.Analytics.ReportingCurrency)
+	if telemetryShards != nil {
+		patternsService.SetTelemetryShards(telemetryShards)
+	}
+	patternsService.SetTelemetryRetention(services.TelemetryRetention{
+		Default: cfg.Telemetry.Retention,
+		Metrics: cfg.Telemetry.MetricRetention,
+	})
+	patternsService.SetTelemetryTimestampPolicy(services.TelemetryTimestampPolicy{
+		MaxFutureSkew:   cfg.Telemetry.MaxClockSkew,
+		MaxAge:          cfg.Telemetry.MaxReadingAge,
+		StoreIngestTime: cfg.Telemetry.StoreIngestTime,
+	})
+	if keyVaultClient != nil {
+		patternsService.SetKeyVault(keyVaultClient)
+	}
+	leaderboardWindows := make([]models.LeaderboardWindow, 0, len(cfg.Leaderboards.Windows))
+	for _, name := range cfg.Leaderboards.Windows {
+		window := models.LeaderboardWindow(name)
+		if !window.IsValid() {
+			log.Warn("Ignoring unknown leaderboard window", zap.String("window", name))
+			continue
+		}
+		leaderboardWindows = append(leaderboardWindows, window)
+	}
+	patternsService.SetLeaderboardWindows(leaderboardWindows...)
+	if cfg.Sessions.MaxLifetime < cfg.Sessions.IdleTimeout {
+		log.Warn("Session max lifetime is shorter than the idle timeout; sessions will not slide",
+			zap.Duration("idle_timeout", cfg.Sessions.IdleTimeout),
+			zap.Duration("max_lifetime", cfg.Sessions.MaxLifetime))
+	}
+	patternsService.SetSessionExpiry(cfg.Sessions.IdleTimeout, cfg.Sessions.MaxLifetime)
+	patternsService.SetMaxSessionsPerUser(cfg.Sessions.MaxPerUser)
 
 	log.Info("PatternsService created with Core infrastructure clients")
 
+	// Reconnection supervisors: detect dropped connections via periodic health
+	// checks, reconnect with backoff, and reflect status in capabilities
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	onBackendStatus := func(name string, status health.Status) {
+		patternsService.SetBackendHealthy(name, status == health.StatusHealthy)
+	}
+	if scyllaReconnecting != nil {
+		health.NewSupervisor(health.SupervisorConfig{
+			Name:           "scylladb",
+			Check:          scyllaReconnecting.Health,
+			Reconnect:      scyllaReconnecting.Reconnect,
+			Logger:         log,
+			Interval:       cfg.Reconnect.Interval,
+			MaxBackoff:     cfg.Reconnect.MaxBackoff,
+			OnStatusChange: onBackendStatus,
+		}).Start(backgroundCtx)
+	}
+	if redisReconnecting != nil {
+		health.NewSupervisor(health.SupervisorConfig{
+			Name:           "redis",
+			Check:          redisReconnecting.Health,
+			Reconnect:      redisReconnecting.Reconnect,
+			Logger:         log,
+			Interval:       cfg.Reconnect.Interval,
+			MaxBackoff:     cfg.Reconnect.MaxBackoff,
+			OnStatusChange: onBackendStatus,
+		}).Start(backgroundCtx)
+	}
+
+	if systemEvents != nil {
+		safego.Go(backgroundCtx, log, systemEvents.Run)
+	}
+
+	// Hard-delete soft-deleted users once their retention window has passed
+	if mongoClient != nil {
+		safego.Go(backgroundCtx, log, func(ctx context.Context) {
+			patternsService.RunUserPurge(ctx, cfg.Users.PurgeInterval, cfg.Users.DeletedRetention)
+		})
+	}
+
+	// Remove telemetry recorded before TTLs were enabled; newer rows expire on their own
+	if scyllaSession != nil && cfg.Telemetry.PurgeInterval > 0 {
+		safego.Go(backgroundCtx, log, func(ctx context.Context) {
+			patternsService.RunTelemetryPurge(ctx, cfg.Telemetry.PurgeInterval)
+		})
+	}
+
+	// Republish order events buffered while Kafka was unavailable
+	if kafkaProducer != nil {
+		safego.Go(backgroundCtx, log, func(ctx context.Context) {
+			patternsService.RunDeadLetterReplay(ctx, cfg.Kafka.DeadLetterReplayInterval)
+		})
+	}
+
+	// Aggregate raw telemetry into hourly/daily rollups for long-range stats
+	if scyllaSession != nil && cfg.Telemetry.RollupInterval > 0 {
+		safego.Go(backgroundCtx, log, func(ctx context.Context) {
+			patternsService.RunTelemetryRollups(ctx, cfg.Telemetry.RollupInterval)
+		})
+	}
+
 	// ========================================
-	// 7. HTTP HANDLER & SERVER SETUP
+	// 8. HTTP HANDLER & SERVER SETUP
 	// ========================================
 	handler := api.NewPatternsHandler(patternsService, log, serviceMetrics)
+	handler.SetBuildInfo(api.BuildInfo{
+		Version:   cfg.Service.Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+	})
 	server := api.NewServer(fmt.Sprintf("%d", cfg.Service.Port), handler, log, serviceMetrics)
 
 	// ========================================
-	// 8. GRACEFUL SHUTDOWN SETUP
+	// 9. GRACEFUL SHUTDOWN SETUP
 	// ========================================
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
@@ -243,8 +523,23 @@ func main() {
 	log.Info("Received shutdown signal")
 
 	// ========================================
-	// 9. GRACEFUL SHUTDOWN WITH CORE CLIENTS
+	// 10. GRACEFUL SHUTDOWN WITH CORE CLIENTS
 	// ========================================
+	shutdownStart := time.Now()
+	phaseStart := shutdownStart
+	endPhase := func(phase string) {
+		duration := time.Since(phaseStart)
+		serviceMetrics.RecordShutdownPhase(phase, duration)
+		log.Info("Shutdown phase complete", zap.String("phase", phase), zap.Duration("duration", duration))
+		phaseStart = time.Now()
+	}
+
+	// Fail readiness first so load balancers stop sending new requests
+	handler.BeginShutdown()
+	log.Info("Draining traffic...", zap.Duration("drain", cfg.Service.ShutdownDrain))
+	time.Sleep(cfg.Service.ShutdownDrain)
+	endPhase("drain")
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -252,6 +547,7 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Error("Server shutdown failed", zap.Error(err))
 	}
+	endPhase("http_server")
 
 	// Close Core.Infrastructure clients
 	log.Info("Closing Core.Infrastructure clients...")
@@ -262,6 +558,12 @@ func main() {
 		}
 	}
 
+	if keyVaultClient != nil {
+		if err := keyVaultClient.Close(ctx); err != nil {
+			log.Error("Failed to close KeyVault client", zap.Error(err))
+		}
+	}
+
 	if redisClient != nil {
 		if err := redisClient.Close(ctx); err != nil {
 			log.Error("Failed to close Redis client", zap.Error(err))
@@ -273,6 +575,11 @@ func main() {
 			log.Error("Failed to close ScyllaDB session", zap.Error(err))
 		}
 	}
+	for _, session := range shardSessions {
+		if err := session.Close(ctx); err != nil {
+			log.Error("Failed to close ScyllaDB shard session", zap.Error(err))
+		}
+	}
 
 	if mongoClient != nil {
 		if err := mongoClient.Disconnect(ctx); err != nil {
@@ -285,6 +592,8 @@ func main() {
 			log.Error("Failed to close SQL Server connection", zap.Error(err))
 		}
 	}
+	endPhase("clients")
 
+	serviceMetrics.RecordShutdownPhase("total", time.Since(shutdownStart))
 	log.Info("AI Patterns service stopped - all Core.Infrastructure clients closed")
 }
@@ -17,7 +17,16 @@ type Config struct {
 	ScyllaDB  ScyllaDBConfig  `yaml:"scylladb"`
 	Redis     RedisConfig     `yaml:"redis"`
 	Kafka     KafkaConfig     `yaml:"kafka"`
+	KeyVault  KeyVaultConfig  `yaml:"keyvault"`
 	SLI       SLIConfig       `yaml:"sli"`
+	Analytics AnalyticsConfig `yaml:"analytics"`
+	SelfTest  SelfTestConfig  `yaml:"selftest"`
+	Reconnect ReconnectConfig `yaml:"reconnect"`
+	Users     UsersConfig     `yaml:"users"`
+	Sessions  SessionsConfig  `yaml:"sessions"`
+	Telemetry TelemetryConfig `yaml:"telemetry"`
+
+	Leaderboards LeaderboardsConfig `yaml:"leaderboards"`
 }
 
 // ServiceConfig holds service-level configuration
@@ -26,6 +35,9 @@ type ServiceConfig struct {
 	Version     string `yaml:"version"`
 	Port        int    `yaml:"port"`
 	Environment string `yaml:"environment"`
+	// How long readiness fails before the HTTP server stops, so load
+	// balancers stop routing new requests first
+	ShutdownDrain time.Duration `yaml:"shutdown_drain"`
 }
 
 // LoggingConfig holds logging configuration
@@ -57,6 +69,18 @@ type ScyllaDBConfig struct {
 	Keyspace       string        `yaml:"keyspace"`
 	Timeout        time.Duration `yaml:"timeout"`
 	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+
+	// Optional extra telemetry shards. Devices are spread over the primary
+	// session ("primary") and these shards by consistent hashing.
+	Shards       []ScyllaShardConfig `yaml:"shards"`
+	VirtualNodes int                 `yaml:"virtual_nodes"` // Ring points per shard (default 128)
+}
+
+// ScyllaShardConfig holds the connection for one telemetry shard
+type ScyllaShardConfig struct {
+	Name     string   `yaml:"name"`
+	Hosts    []string `yaml:"hosts"`
+	Keyspace string   `yaml:"keyspace"`
 }
 
 // RedisConfig holds Redis connection configuration
@@ -69,6 +93,32 @@ type RedisConfig struct {
 // KafkaConfig holds Kafka connection configuration
 type KafkaConfig struct {
 	Brokers []string `yaml:"brokers"`
+	// Publish CRITICAL/HIGH error logs as SystemEvents (opt-in: the topic's
+	// consumers must not log back into it at that severity)
+	LogSystemEvents bool `yaml:"log_system_events"`
+	// How often order events buffered while Kafka was unavailable are republished
+	DeadLetterReplayInterval time.Duration `yaml:"dead_letter_replay_interval"`
+	// Topic names per event type
+	Topics KafkaTopicsConfig `yaml:"topics"`
+}
+
+// KafkaTopicsConfig names the Kafka topic for each event type
+type KafkaTopicsConfig struct {
+	Orders       string `yaml:"orders"`
+	Users        string `yaml:"users"`
+	Telemetry    string `yaml:"telemetry"`
+	Leaderboards string `yaml:"leaderboards"`
+	System       string `yaml:"system"`
+}
+
+// KeyVaultConfig holds KeyVault connection configuration (user integration secrets).
+// KeyVault is optional; leave VaultURL empty to run without it.
+type KeyVaultConfig struct {
+	VaultURL           string        `yaml:"vault_url"`
+	Timeout            time.Duration `yaml:"timeout"`
+	CacheTTL           time.Duration `yaml:"cache_ttl"`
+	InsecureSkipVerify bool          `yaml:"insecure_skip_verify"`
+	WarmPrefixes       []string      `yaml:"warm_prefixes"` // Secret name prefixes cached at startup, e.g. "user:"
 }
 
 // SLIConfig holds SLI/error budget configuration
@@ -79,6 +129,63 @@ type SLIConfig struct {
 	ErrorRateTargetPercent float64 `yaml:"error_rate_target_percent"`
 }
 
+// AnalyticsConfig holds cross-platform analytics query configuration
+type AnalyticsConfig struct {
+	StoreTimeout time.Duration `yaml:"store_timeout"` // Per-store query timeout
+	Deadline     time.Duration `yaml:"deadline"`      // Overall deadline; stores still running are reported as timed out
+
+	// Revenue is reported in ReportingCurrency. ExchangeRates maps other
+	// currencies to their value in ReportingCurrency (EUR: 1.08).
+	ReportingCurrency string             `yaml:"reporting_currency"`
+	ExchangeRates     map[string]float64 `yaml:"exchange_rates"`
+}
+
+// SelfTestConfig holds startup self-test configuration
+type SelfTestConfig struct {
+	Mode    string        `yaml:"mode"`    // "off", "warn" (log and continue) or "strict" (exit on failure)
+	Timeout time.Duration `yaml:"timeout"` // Overall timeout for all schema checks
+}
+
+// ReconnectConfig holds reconnection supervisor configuration for Redis and ScyllaDB
+type ReconnectConfig struct {
+	Interval   time.Duration `yaml:"interval"`    // Time between health checks
+	MaxBackoff time.Duration `yaml:"max_backoff"` // Cap for reconnect retry delay
+}
+
+// UsersConfig holds user profile lifecycle configuration
+type UsersConfig struct {
+	DeletedRetention time.Duration `yaml:"deleted_retention"` // How long soft-deleted users can be restored before purge
+	PurgeInterval    time.Duration `yaml:"purge_interval"`    // Time between purge job runs
+}
+
+// SessionsConfig holds user session expiry configuration
+type SessionsConfig struct {
+	IdleTimeout time.Duration `yaml:"idle_timeout"` // Sessions expire this long after their last access
+	MaxLifetime time.Duration `yaml:"max_lifetime"` // Absolute cap from creation, however often the session is used
+	MaxPerUser  int           `yaml:"max_per_user"` // Concurrent sessions per user; the oldest is revoked on overflow. Negative disables the cap
+}
+
+// TelemetryConfig holds ScyllaDB telemetry retention configuration
+type TelemetryConfig struct {
+	Retention       time.Duration            `yaml:"retention"`        // Default TTL for telemetry rows; 0 keeps rows forever
+	MetricRetention map[string]time.Duration `yaml:"metric_retention"` // Per-metric TTL overrides
+	PurgeInterval   time.Duration            `yaml:"purge_interval"`   // Time between purges of pre-TTL rows; 0 disables the job
+	RollupInterval  time.Duration            `yaml:"rollup_interval"`  // How often to check for hours/days to roll up; 0 disables the job
+
+	// Device-reported reading timestamps
+	MaxClockSkew    time.Duration `yaml:"max_clock_skew"`    // How far ahead of server time a reading may be
+	MaxReadingAge   time.Duration `yaml:"max_reading_age"`   // Older readings are rejected; negative accepts any age
+	StoreIngestTime bool          `yaml:"store_ingest_time"` // Also store ingest time (needs the ingest_time column)
+
+	// Deprecated: use StoreIngestTime. Setting it enables StoreIngestTime.
+	StoreReceivedAt bool `yaml:"store_received_at"`
+}
+
+// LeaderboardsConfig holds Redis leaderboard configuration
+type LeaderboardsConfig struct {
+	Windows []string `yaml:"windows"` // Periodic boards kept alongside all-time: daily, weekly, monthly
+}
+
 // Load reads configuration from a YAML file
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
@@ -101,10 +208,11 @@ func Load(path string) (*Config, error) {
 func LoadFromEnv() *Config {
 	cfg := &Config{
 		Service: ServiceConfig{
-			Name:        getEnv("SERVICE_NAME", "ai-patterns"),
-			Version:     getEnv("SERVICE_VERSION", "1.0.0"),
-			Port:        getEnvInt("SERVICE_PORT", 8080),
-			Environment: getEnv("ENVIRONMENT", "development"),
+			Name:          getEnv("SERVICE_NAME", "ai-patterns"),
+			Version:       getEnv("SERVICE_VERSION", "1.0.0"),
+			Port:          getEnvInt("SERVICE_PORT", 8080),
+			Environment:   getEnv("ENVIRONMENT", "development"),
+			ShutdownDrain: getEnvDuration("SERVICE_SHUTDOWN_DRAIN", 5*time.Second),
 		},
 		Logging: LoggingConfig{
 			Level:            getEnv("LOG_LEVEL", "info"),
@@ -135,7 +243,24 @@ func LoadFromEnv() *Config {
 			PingTimeout: getEnvDuration("REDIS_PING_TIMEOUT", 60*time.Second),
 		},
 		Kafka: KafkaConfig{
-			Brokers: getEnvSlice("KAFKA_BROKERS", []string{"localhost:9092"}),
+			Brokers:         getEnvSlice("KAFKA_BROKERS", []string{"localhost:9092"}),
+			LogSystemEvents: getEnvBool("KAFKA_LOG_SYSTEM_EVENTS", false),
+
+			DeadLetterReplayInterval: getEnvDuration("KAFKA_DEAD_LETTER_REPLAY_INTERVAL", 30*time.Second),
+			Topics: KafkaTopicsConfig{
+				Orders:       getEnv("KAFKA_TOPIC_ORDERS", "orders.events"),
+				Users:        getEnv("KAFKA_TOPIC_USERS", "users.events"),
+				Telemetry:    getEnv("KAFKA_TOPIC_TELEMETRY", "telemetry.events"),
+				Leaderboards: getEnv("KAFKA_TOPIC_LEADERBOARDS", "leaderboard.events"),
+				System:       getEnv("KAFKA_TOPIC_SYSTEM", "system.events"),
+			},
+		},
+		KeyVault: KeyVaultConfig{
+			VaultURL:           getEnv("KEYVAULT_URL", ""),
+			Timeout:            getEnvDuration("KEYVAULT_TIMEOUT", 30*time.Second),
+			CacheTTL:           getEnvDuration("KEYVAULT_CACHE_TTL", 5*time.Minute),
+			InsecureSkipVerify: getEnvBool("KEYVAULT_INSECURE_SKIP_VERIFY", false),
+			WarmPrefixes:       getEnvSlice("KEYVAULT_WARM_PREFIXES", nil),
 		},
 		SLI: SLIConfig{
 			AvailabilityTarget:     getEnvFloat("SLI_AVAILABILITY_TARGET", 99.9),
@@ -143,6 +268,41 @@ func LoadFromEnv() *Config {
 			LatencyP99TargetMs:     getEnvInt("SLI_LATENCY_P99_TARGET_MS", 500),
 			ErrorRateTargetPercent: getEnvFloat("SLI_ERROR_RATE_TARGET", 0.1),
 		},
+		Analytics: AnalyticsConfig{
+			StoreTimeout: getEnvDuration("ANALYTICS_STORE_TIMEOUT", 5*time.Second),
+			Deadline:     getEnvDuration("ANALYTICS_DEADLINE", 10*time.Second),
+
+			ReportingCurrency: getEnv("ANALYTICS_REPORTING_CURRENCY", "USD"),
+		},
+		SelfTest: SelfTestConfig{
+			Mode:    getEnv("SELFTEST_MODE", "warn"),
+			Timeout: getEnvDuration("SELFTEST_TIMEOUT", 10*time.Second),
+		},
+		Reconnect: ReconnectConfig{
+			Interval:   getEnvDuration("RECONNECT_INTERVAL", 15*time.Second),
+			MaxBackoff: getEnvDuration("RECONNECT_MAX_BACKOFF", 30*time.Second),
+		},
+		Users: UsersConfig{
+			DeletedRetention: getEnvDuration("USERS_DELETED_RETENTION", 30*24*time.Hour),
+			PurgeInterval:    getEnvDuration("USERS_PURGE_INTERVAL", time.Hour),
+		},
+		Sessions: SessionsConfig{
+			IdleTimeout: getEnvDuration("SESSIONS_IDLE_TIMEOUT", 24*time.Hour),
+			MaxLifetime: getEnvDuration("SESSIONS_MAX_LIFETIME", 7*24*time.Hour),
+			MaxPerUser:  getEnvInt("SESSIONS_MAX_PER_USER", 10),
+		},
+		Telemetry: TelemetryConfig{
+			Retention:      getEnvDuration("TELEMETRY_RETENTION", 90*24*time.Hour),
+			PurgeInterval:  getEnvDuration("TELEMETRY_PURGE_INTERVAL", 0),
+			RollupInterval: getEnvDuration("TELEMETRY_ROLLUP_INTERVAL", 5*time.Minute),
+
+			MaxClockSkew:    getEnvDuration("TELEMETRY_MAX_CLOCK_SKEW", 5*time.Minute),
+			MaxReadingAge:   getEnvDuration("TELEMETRY_MAX_READING_AGE", 24*time.Hour),
+			StoreIngestTime: getEnvBool("TELEMETRY_STORE_INGEST_TIME", getEnvBool("TELEMETRY_STORE_RECEIVED_AT", false)),
+		},
+		Leaderboards: LeaderboardsConfig{
+			Windows: getEnvSlice("LEADERBOARD_WINDOWS", []string{"daily", "weekly", "monthly"}),
+		},
 	}
 
 	return cfg
@@ -162,6 +322,9 @@ func applyDefaults(cfg *Config) {
 	if cfg.Service.Environment == "" {
 		cfg.Service.Environment = "development"
 	}
+	if cfg.Service.ShutdownDrain == 0 {
+		cfg.Service.ShutdownDrain = 5 * time.Second
+	}
 	if cfg.Logging.Level == "" {
 		cfg.Logging.Level = "info"
 	}
@@ -180,6 +343,79 @@ func applyDefaults(cfg *Config) {
 	if cfg.Redis.PingTimeout == 0 {
 		cfg.Redis.PingTimeout = 60 * time.Second
 	}
+	if cfg.Analytics.StoreTimeout == 0 {
+		cfg.Analytics.StoreTimeout = 5 * time.Second
+	}
+	if cfg.Analytics.Deadline == 0 {
+		cfg.Analytics.Deadline = 10 * time.Second
+	}
+	if cfg.Analytics.ReportingCurrency == "" {
+		cfg.Analytics.ReportingCurrency = "USD"
+	}
+	if cfg.SelfTest.Mode == "" {
+		cfg.SelfTest.Mode = "warn"
+	}
+	if cfg.SelfTest.Timeout == 0 {
+		cfg.SelfTest.Timeout = 10 * time.Second
+	}
+	if cfg.Reconnect.Interval == 0 {
+		cfg.Reconnect.Interval = 15 * time.Second
+	}
+	if cfg.Reconnect.MaxBackoff == 0 {
+		cfg.Reconnect.MaxBackoff = 30 * time.Second
+	}
+	if cfg.KeyVault.Timeout == 0 {
+		cfg.KeyVault.Timeout = 30 * time.Second
+	}
+	if cfg.KeyVault.CacheTTL == 0 {
+		cfg.KeyVault.CacheTTL = 5 * time.Minute
+	}
+	if cfg.Users.DeletedRetention == 0 {
+		cfg.Users.DeletedRetention = 30 * 24 * time.Hour
+	}
+	if cfg.Users.PurgeInterval == 0 {
+		cfg.Users.PurgeInterval = time.Hour
+	}
+	if cfg.Telemetry.MaxClockSkew == 0 {
+		cfg.Telemetry.MaxClockSkew = 5 * time.Minute
+	}
+	if cfg.Telemetry.MaxReadingAge == 0 {
+		cfg.Telemetry.MaxReadingAge = 24 * time.Hour
+	}
+	if cfg.Telemetry.StoreReceivedAt {
+		cfg.Telemetry.StoreIngestTime = true
+	}
+	if cfg.Sessions.IdleTimeout == 0 {
+		cfg.Sessions.IdleTimeout = 24 * time.Hour
+	}
+	if cfg.Sessions.MaxLifetime == 0 {
+		cfg.Sessions.MaxLifetime = 7 * 24 * time.Hour
+	}
+	if cfg.Sessions.MaxPerUser == 0 {
+		cfg.Sessions.MaxPerUser = 10
+	}
+	// An explicit empty list disables periodic leaderboards
+	if cfg.Leaderboards.Windows == nil {
+		cfg.Leaderboards.Windows = []string{"daily", "weekly", "monthly"}
+	}
+	if cfg.Kafka.DeadLetterReplayInterval == 0 {
+		cfg.Kafka.DeadLetterReplayInterval = 30 * time.Second
+	}
+	if cfg.Kafka.Topics.Orders == "" {
+		cfg.Kafka.Topics.Orders = "orders.events"
+	}
+	if cfg.Kafka.Topics.Users == "" {
+		cfg.Kafka.Topics.Users = "users.events"
+	}
+	if cfg.Kafka.Topics.Telemetry == "" {
+		cfg.Kafka.Topics.Telemetry = "telemetry.events"
+	}
+	if cfg.Kafka.Topics.Leaderboards == "" {
+		cfg.Kafka.Topics.Leaderboards = "leaderboard.events"
+	}
+	if cfg.Kafka.Topics.System == "" {
+		cfg.Kafka.Topics.System = "system.events"
+	}
 }
 
 // Helper functions for environment variables
@@ -2,11 +2,19 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync/atomic"
 	"time"
 
+	coreerrors "github.com/your-github-org/ai-scaffolder/core/go/errors"
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
 	"github.com/your-github-org/ai-scaffolder/core/go/metrics"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
 	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
 	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/services"
 	"github.com/google/uuid"
@@ -19,6 +27,17 @@ type PatternsHandler struct {
 	service *services.PatternsService
 	logger  *logger.Logger
 	metrics *metrics.ServiceMetrics
+	build   BuildInfo
+
+	// Set once shutdown begins; readiness then fails so traffic drains
+	shuttingDown atomic.Bool
+}
+
+// BuildInfo identifies the running build (version from config, commit/time via ldflags)
+type BuildInfo struct {
+	Version   string
+	Commit    string
+	BuildTime string
 }
 
 // NewPatternsHandler creates a new patterns handler
@@ -52,33 +71,68 @@ func (h *PatternsHandler) Health(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(health)
 }
 
+// SetBuildInfo sets the build details reported by GET /version
+func (h *PatternsHandler) SetBuildInfo(info BuildInfo) {
+	h.build = info
+}
+
+// Version handles GET /version
+// Unauthenticated and cheap: reports build details and configured backends without touching them
+func (h *PatternsHandler) Version(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, http.StatusOK, models.VersionInfo{
+		Version:      h.build.Version,
+		Commit:       h.build.Commit,
+		BuildTime:    h.build.BuildTime,
+		GoVersion:    runtime.Version(),
+		Capabilities: h.service.Capabilities(),
+	})
+}
+
 // LivenessProbe handles GET /health/live
 func (h *PatternsHandler) LivenessProbe(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("OK"))
 }
 
+// BeginShutdown marks the service as shutting down; readiness fails from now on
+func (h *PatternsHandler) BeginShutdown() {
+	h.shuttingDown.Store(true)
+}
+
 // ReadinessProbe handles GET /health/ready
+// Reports why the service isn't ready: shutting_down or dependency_unhealthy
 func (h *PatternsHandler) ReadinessProbe(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	health := h.service.HealthCheck(ctx)
+	if h.shuttingDown.Load() {
+		h.respondJSON(w, http.StatusServiceUnavailable, models.ReadinessStatus{Reason: models.ReadinessShuttingDown})
+		return
+	}
 
-	for _, v := range health {
+	var unhealthy []string
+	for name, v := range h.service.HealthCheck(r.Context()) {
 		if v != "healthy" {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			w.Write([]byte("Not Ready"))
-			return
+			unhealthy = append(unhealthy, name)
 		}
 	}
+	if len(unhealthy) > 0 {
+		sort.Strings(unhealthy)
+		h.respondJSON(w, http.StatusServiceUnavailable, models.ReadinessStatus{
+			Reason:    models.ReadinessDependencyUnhealthy,
+			Unhealthy: unhealthy,
+		})
+		return
+	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("Ready"))
+	h.respondJSON(w, http.StatusOK, models.ReadinessStatus{Ready: true, Reason: models.ReadinessReady})
 }
 
 // =============================================================================
 // Order Endpoints (SQL Server)
 // =============================================================================
 
+// IdempotencyKeyHeader lets clients retry CreateOrder without creating a
+// duplicate order; a repeated key returns the order created the first time.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 // CreateOrder handles POST /api/v1/patterns/orders
 func (h *PatternsHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -90,11 +144,11 @@ func (h *PatternsHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		h.respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
+	req.IdempotencyKey = r.Header.Get(IdempotencyKeyHeader)
 
 	order, err := h.service.CreateOrder(ctx, &req)
 	if err != nil {
-		log.Error("Failed to create order", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, err.Error())
+		h.respondServiceError(w, r, "Failed to create order", err)
 		return
 	}
 
@@ -116,14 +170,94 @@ func (h *PatternsHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 
 	order, err := h.service.GetOrder(ctx, id)
 	if err != nil {
-		log.Error("Failed to get order", zap.Error(err))
-		h.respondError(w, http.StatusNotFound, err.Error())
+		h.respondServiceError(w, r, "Failed to get order", err)
 		return
 	}
 
 	h.respondJSON(w, http.StatusOK, order)
 }
 
+// ListOrders handles GET /api/v1/patterns/orders?customerId=&status=&createdAfter=&createdBefore=&offset=&limit=
+func (h *PatternsHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.OrderFilter{Status: models.OrderStatus(query.Get("status"))}
+	if customerID := query.Get("customerId"); customerID != "" {
+		id, err := uuid.Parse(customerID)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid customerId")
+			return
+		}
+		filter.CustomerID = id
+	}
+	if after := query.Get("createdAfter"); after != "" {
+		t, err := time.Parse(time.RFC3339, after)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid createdAfter")
+			return
+		}
+		filter.CreatedAfter = t
+	}
+	if before := query.Get("createdBefore"); before != "" {
+		t, err := time.Parse(time.RFC3339, before)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid createdBefore")
+			return
+		}
+		filter.CreatedBefore = t
+	}
+	if offset := query.Get("offset"); offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			h.respondError(w, http.StatusBadRequest, "Invalid offset")
+			return
+		}
+		filter.Offset = n
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			h.respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		filter.Limit = n
+	}
+	filter = filter.WithDefaults()
+
+	orders, total, err := h.service.ListOrders(r.Context(), filter)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to list orders", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.OrderPage{
+		Orders: orders,
+		Total:  total,
+		Offset: filter.Offset,
+		Limit:  filter.Limit,
+	})
+}
+
+// BatchGetOrders handles POST /api/v1/patterns/orders/batch-get
+func (h *PatternsHandler) BatchGetOrders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.WithContext(ctx)
+
+	var req models.BatchGetOrdersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn("Invalid request body", zap.Error(err))
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	result, err := h.service.BatchGetOrders(ctx, req.CustomerID, req.IDs)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to batch get orders", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
 // UpdateOrderStatus handles PATCH /api/v1/patterns/orders/{id}/status
 func (h *PatternsHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -137,24 +271,176 @@ func (h *PatternsHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var req struct {
-		Status string `json:"status"`
-	}
+	var req models.UpdateOrderStatusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	order, err := h.service.UpdateOrderStatus(ctx, id, models.OrderStatus(req.Status))
+	order, err := h.service.UpdateOrderStatus(ctx, id, req.Status, req.Actor, req.Reason)
 	if err != nil {
-		log.Error("Failed to update order status", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, err.Error())
+		h.respondServiceError(w, r, "Failed to update order status", err)
 		return
 	}
 
 	h.respondJSON(w, http.StatusOK, order)
 }
 
+// GetOrderHistory handles GET /api/v1/patterns/orders/{id}/history
+func (h *PatternsHandler) GetOrderHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.WithContext(ctx)
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Warn("Invalid order ID", zap.String("id", vars["id"]))
+		h.respondError(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	history, err := h.service.GetOrderHistory(ctx, id)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to get order history", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, history)
+}
+
+// =============================================================================
+// Product Endpoints (SQL Server)
+// =============================================================================
+
+// CreateProduct handles POST /api/v1/patterns/products
+func (h *PatternsHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.WithContext(ctx)
+
+	var req models.CreateProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn("Invalid request body", zap.Error(err))
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	product, err := h.service.CreateProduct(ctx, &req)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to create product", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, product)
+}
+
+// GetProduct handles GET /api/v1/patterns/products/{id}
+func (h *PatternsHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.productID(w, r)
+	if !ok {
+		return
+	}
+
+	product, err := h.service.GetProduct(r.Context(), id)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to get product", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, product)
+}
+
+// UpdateProduct handles PATCH /api/v1/patterns/products/{id}
+func (h *PatternsHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.productID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	product, err := h.service.UpdateProduct(r.Context(), id, &req)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to update product", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, product)
+}
+
+// DeleteProduct handles DELETE /api/v1/patterns/products/{id}
+func (h *PatternsHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.productID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteProduct(r.Context(), id); err != nil {
+		h.respondServiceError(w, r, "Failed to delete product", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListProducts handles GET /api/v1/patterns/products?category=&status=&offset=&limit=
+func (h *PatternsHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := models.ListProductsOptions{
+		Category: query.Get("category"),
+		Status:   models.ProductStatus(query.Get("status")),
+	}
+	if offset := query.Get("offset"); offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			h.respondError(w, http.StatusBadRequest, "Invalid offset")
+			return
+		}
+		opts.Offset = n
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			h.respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		opts.Limit = n
+	}
+
+	page, err := h.service.ListProducts(r.Context(), opts)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to list products", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, page)
+}
+
+// productID parses the {id} route variable, responding 400 if it is not a UUID
+func (h *PatternsHandler) productID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warn("Invalid product ID", zap.String("id", vars["id"]))
+		h.respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondServiceError responds with the status of err's ServiceError code
+// (see ProductErrors) or domain sentinel, logging msg for server-side failures
+func (h *PatternsHandler) respondServiceError(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	status := errors.HTTPStatus(err)
+	if status >= http.StatusInternalServerError {
+		h.logger.WithContext(r.Context()).Error(msg, zap.Error(err))
+	}
+	coreerrors.WriteHTTPErrorStatus(w, err, status)
+}
+
 // =============================================================================
 // User Endpoints (MongoDB)
 // =============================================================================
@@ -173,8 +459,7 @@ func (h *PatternsHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.service.CreateUser(ctx, &req)
 	if err != nil {
-		log.Error("Failed to create user", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, err.Error())
+		h.respondServiceError(w, r, "Failed to create user", err)
 		return
 	}
 
@@ -196,14 +481,105 @@ func (h *PatternsHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.service.GetUser(ctx, id)
 	if err != nil {
-		log.Error("Failed to get user", zap.Error(err))
-		h.respondError(w, http.StatusNotFound, err.Error())
+		h.respondServiceError(w, r, "Failed to get user", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, user)
+}
+
+// DeleteUser handles DELETE /api/v1/patterns/users/{id} (soft delete)
+func (h *PatternsHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.WithContext(ctx)
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Warn("Invalid user ID", zap.String("id", vars["id"]))
+		h.respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.service.SoftDeleteUser(ctx, id); err != nil {
+		h.respondServiceError(w, r, "Failed to delete user", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreUser handles POST /api/v1/patterns/users/{id}/restore
+func (h *PatternsHandler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.WithContext(ctx)
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Warn("Invalid user ID", zap.String("id", vars["id"]))
+		h.respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	user, err := h.service.RestoreUser(ctx, id)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to restore user", err)
 		return
 	}
 
 	h.respondJSON(w, http.StatusOK, user)
 }
 
+// ExportUserData handles GET /api/v1/patterns/users/{id}/export (GDPR data export)
+func (h *PatternsHandler) ExportUserData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.WithContext(ctx)
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Warn("Invalid user ID", zap.String("id", vars["id"]))
+		h.respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	// The bundle is streamed; headers are sent with the first write
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%s-export.json"`, id))
+
+	if err := h.service.ExportUserData(ctx, id, w); err != nil {
+		// Lookup errors occur before streaming starts; write errors mean the client
+		// has gone away, so the error response below is best effort
+		w.Header().Del("Content-Disposition")
+		h.respondServiceError(w, r, "Failed to export user data", err)
+	}
+}
+
+// EraseUser handles POST /api/v1/patterns/users/{id}/erase (GDPR erasure).
+// Safe to retry: a failed erasure resumes and a completed one is a no-op.
+func (h *PatternsHandler) EraseUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.WithContext(ctx)
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Warn("Invalid user ID", zap.String("id", vars["id"]))
+		h.respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	record, err := h.service.EraseUser(ctx, id)
+	if err != nil {
+		log.Error("Failed to erase user", zap.Error(err))
+		h.respondJSON(w, http.StatusInternalServerError, record)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, record)
+}
+
 // UpdateUserPreferences handles PUT /api/v1/patterns/users/{id}/preferences
 func (h *PatternsHandler) UpdateUserPreferences(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -224,8 +600,7 @@ func (h *PatternsHandler) UpdateUserPreferences(w http.ResponseWriter, r *http.R
 	}
 
 	if err := h.service.UpdateUserPreferences(ctx, id, prefs); err != nil {
-		log.Error("Failed to update user preferences", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, err.Error())
+		h.respondServiceError(w, r, "Failed to update user preferences", err)
 		return
 	}
 
@@ -250,18 +625,43 @@ func (h *PatternsHandler) RecordTelemetry(w http.ResponseWriter, r *http.Request
 
 	telemetry, err := h.service.RecordTelemetry(ctx, &req)
 	if err != nil {
-		log.Error("Failed to record telemetry", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, err.Error())
+		h.respondServiceError(w, r, "Failed to record telemetry", err)
 		return
 	}
 
 	h.respondJSON(w, http.StatusCreated, telemetry)
 }
 
+// RecordTelemetryBatch handles POST /api/v1/patterns/telemetry/batch. It
+// responds 201 when every reading was stored and 207 with per-reading
+// results otherwise.
+func (h *PatternsHandler) RecordTelemetryBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.WithContext(ctx)
+
+	var req models.RecordTelemetryBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn("Invalid request body", zap.Error(err))
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	result, err := h.service.RecordTelemetryBatch(ctx, req.Readings)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to record telemetry batch", err)
+		return
+	}
+
+	status := http.StatusCreated
+	if result.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	h.respondJSON(w, status, result)
+}
+
 // GetTelemetryHistory handles GET /api/v1/patterns/telemetry/{deviceId}
 func (h *PatternsHandler) GetTelemetryHistory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	log := h.logger.WithContext(ctx)
 
 	vars := mux.Vars(r)
 	deviceID := vars["deviceId"]
@@ -283,19 +683,59 @@ func (h *PatternsHandler) GetTelemetryHistory(w http.ResponseWriter, r *http.Req
 
 	telemetry, err := h.service.GetTelemetryHistory(ctx, deviceID, startTime, endTime)
 	if err != nil {
-		log.Error("Failed to get telemetry history", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, err.Error())
+		h.respondServiceError(w, r, "Failed to get telemetry history", err)
 		return
 	}
 
 	h.respondJSON(w, http.StatusOK, telemetry)
 }
 
+// GetTelemetryStats handles GET /api/v1/patterns/telemetry/{deviceId}/stats
+func (h *PatternsHandler) GetTelemetryStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	deviceID := mux.Vars(r)["deviceId"]
+
+	// Parse time range from query params (default to last 7 days)
+	endTime := time.Now()
+	startTime := endTime.Add(-7 * 24 * time.Hour)
+
+	if start := r.URL.Query().Get("start"); start != "" {
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid start time")
+			return
+		}
+		startTime = t
+	}
+	if end := r.URL.Query().Get("end"); end != "" {
+		t, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid end time")
+			return
+		}
+		endTime = t
+	}
+	if !endTime.After(startTime) {
+		h.respondError(w, http.StatusBadRequest, "end must be after start")
+		return
+	}
+
+	stats, err := h.service.GetTelemetryStats(ctx, deviceID, startTime, endTime)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to get telemetry stats", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, stats)
+}
+
 // =============================================================================
 // Leaderboard Endpoints (Redis)
 // =============================================================================
 
-// UpdateLeaderboard handles POST /api/v1/patterns/leaderboards/{category}/scores
+// UpdateLeaderboard handles POST /api/v1/patterns/leaderboards/{category}/scores.
+// The score replaces the user's current score (last write wins).
 func (h *PatternsHandler) UpdateLeaderboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := h.logger.WithContext(ctx)
@@ -311,35 +751,88 @@ func (h *PatternsHandler) UpdateLeaderboard(w http.ResponseWriter, r *http.Reque
 	}
 
 	if err := h.service.UpdateLeaderboard(ctx, category, req.UserID, req.Score); err != nil {
-		log.Error("Failed to update leaderboard", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, err.Error())
+		h.respondServiceError(w, r, "Failed to update leaderboard", err)
 		return
 	}
 
 	h.respondJSON(w, http.StatusOK, map[string]string{"message": "Leaderboard updated"})
 }
 
-// GetLeaderboard handles GET /api/v1/patterns/leaderboards/{category}
-func (h *PatternsHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+// IncrementLeaderboard handles POST /api/v1/patterns/leaderboards/{category}/increments.
+// The delta is added to the user's current score atomically.
+func (h *PatternsHandler) IncrementLeaderboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := h.logger.WithContext(ctx)
 
 	vars := mux.Vars(r)
 	category := vars["category"]
 
-	// Default to top 10
+	var req models.IncrementLeaderboardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn("Invalid request body", zap.Error(err))
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	score, err := h.service.IncrementLeaderboard(ctx, category, req.UserID, req.Delta)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to increment leaderboard", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.IncrementLeaderboardResponse{UserID: req.UserID, Score: score})
+}
+
+// GetLeaderboard handles GET /api/v1/patterns/leaderboards/{category}?window=daily
+func (h *PatternsHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	category := vars["category"]
+
+	// Default to top 10 of the all-time board
 	top := 10
+	window := models.LeaderboardWindow(r.URL.Query().Get("window"))
 
-	entries, err := h.service.GetLeaderboard(ctx, category, top)
+	entries, err := h.service.GetLeaderboard(ctx, category, window, top)
 	if err != nil {
-		log.Error("Failed to get leaderboard", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, err.Error())
+		h.respondServiceError(w, r, "Failed to get leaderboard", err)
 		return
 	}
 
 	h.respondJSON(w, http.StatusOK, entries)
 }
 
+// maxLeaderboardRadius caps the entries returned either side of a user
+const maxLeaderboardRadius = 50
+
+// GetLeaderboardAround handles GET /api/v1/patterns/leaderboards/{category}/around/{userId}?radius=5
+func (h *PatternsHandler) GetLeaderboardAround(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	category := vars["category"]
+	userID := vars["userId"]
+
+	radius := 5
+	if value := r.URL.Query().Get("radius"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > maxLeaderboardRadius {
+			h.respondError(w, http.StatusBadRequest, fmt.Sprintf("radius must be between 0 and %d", maxLeaderboardRadius))
+			return
+		}
+		radius = n
+	}
+
+	around, err := h.service.GetLeaderboardAround(ctx, category, userID, radius)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to get leaderboard around user", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, around)
+}
+
 // =============================================================================
 // Session Endpoints (Redis + Kafka)
 // =============================================================================
@@ -356,16 +849,84 @@ func (h *PatternsHandler) CreateSession(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Default the device metadata to the caller's own
+	if req.Device == "" {
+		req.Device = r.UserAgent()
+	}
+	if req.IPAddress == "" {
+		req.IPAddress = r.RemoteAddr
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			req.IPAddress = host
+		}
+	}
+
 	session, err := h.service.CreateSession(ctx, &req)
 	if err != nil {
-		log.Error("Failed to create session", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, err.Error())
+		h.respondServiceError(w, r, "Failed to create session", err)
 		return
 	}
 
 	h.respondJSON(w, http.StatusCreated, session)
 }
 
+// ListUserSessions handles GET /api/v1/patterns/users/{id}/sessions
+func (h *PatternsHandler) ListUserSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	sessions, err := h.service.ListSessions(ctx, userID)
+	if err != nil {
+		h.respondServiceError(w, r, "Failed to list sessions", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, sessions)
+}
+
+// RevokeUserSession handles DELETE /api/v1/patterns/users/{id}/sessions/{sessionId}
+func (h *PatternsHandler) RevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.service.RevokeSession(ctx, userID, vars["sessionId"]); err != nil {
+		h.respondServiceError(w, r, "Failed to revoke session", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAllUserSessions handles DELETE /api/v1/patterns/users/{id}/sessions
+func (h *PatternsHandler) RevokeAllUserSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.service.RevokeAllSessions(ctx, userID); err != nil {
+		h.respondServiceError(w, r, "Failed to revoke sessions", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // =============================================================================
 // Analytics Endpoints (Cross-Platform)
 // =============================================================================
@@ -373,7 +934,6 @@ func (h *PatternsHandler) CreateSession(w http.ResponseWriter, r *http.Request)
 // GetAnalytics handles GET /api/v1/patterns/analytics
 func (h *PatternsHandler) GetAnalytics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	log := h.logger.WithContext(ctx)
 
 	// Parse time range from query params (default to last 30 days)
 	endDate := time.Now()
@@ -392,8 +952,7 @@ func (h *PatternsHandler) GetAnalytics(w http.ResponseWriter, r *http.Request) {
 
 	analytics, err := h.service.GetAnalytics(ctx, startDate, endDate)
 	if err != nil {
-		log.Error("Failed to get analytics", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, err.Error())
+		h.respondServiceError(w, r, "Failed to get analytics", err)
 		return
 	}
 
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/config"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/services"
+	"go.uber.org/zap"
+)
+
+func TestVersion(t *testing.T) {
+	t.Setenv("SERVICE_VERSION", "2.3.4")
+	cfg := config.LoadFromEnv()
+
+	log := &logger.Logger{Logger: zap.NewNop()}
+	svc := services.NewPatternsService(nil, nil, "", nil, nil, nil, log, nil)
+	handler := NewPatternsHandler(svc, log, nil)
+	handler.SetBuildInfo(BuildInfo{
+		Version:   cfg.Service.Version,
+		Commit:    "abc1234",
+		BuildTime: "2026-01-02T03:04:05Z",
+	})
+
+	rec := httptest.NewRecorder()
+	handler.Version(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	for _, field := range []string{"version", "commit", "buildTime", "goVersion", "capabilities"} {
+		if _, ok := raw[field]; !ok {
+			t.Errorf("response missing field %q", field)
+		}
+	}
+
+	var info models.VersionInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil {
+		t.Fatalf("invalid VersionInfo: %v", err)
+	}
+	if info.Version != "2.3.4" {
+		t.Errorf("version = %v, want %v", info.Version, "2.3.4")
+	}
+	if info.Commit != "abc1234" {
+		t.Errorf("commit = %v, want abc1234", info.Commit)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("goVersion = %v, want %v", info.GoVersion, runtime.Version())
+	}
+	if len(info.Capabilities.APIVersions) == 0 || info.Capabilities.APIVersions[0] != "v1" {
+		t.Errorf("apiVersions = %v, want [v1]", info.Capabilities.APIVersions)
+	}
+	for _, backend := range []string{"sqlserver", "mongodb", "scylladb", "redis", "kafka"} {
+		enabled, ok := info.Capabilities.Backends[backend]
+		if !ok {
+			t.Errorf("backends missing %q", backend)
+		}
+		if enabled {
+			t.Errorf("backends[%q] = true, want false when not configured", backend)
+		}
+	}
+}
+
+// fakeProducer is a kafka.Producer whose health check returns healthErr
+type fakeProducer struct {
+	healthErr error
+}
+
+func (p *fakeProducer) SendMessage(ctx context.Context, topic, key string, value []byte, headers map[string]string) error {
+	return nil
+}
+func (p *fakeProducer) Close(ctx context.Context) error  { return nil }
+func (p *fakeProducer) Health(ctx context.Context) error { return p.healthErr }
+
+func readiness(t *testing.T, handler *PatternsHandler) (int, models.ReadinessStatus) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ReadinessProbe(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	var status models.ReadinessStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatalf("invalid ReadinessStatus: %v", err)
+	}
+	return rec.Code, status
+}
+
+func TestReadinessProbe_ReasonDuringShutdown(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	svc := services.NewPatternsService(nil, nil, "", nil, nil, &fakeProducer{}, log, nil)
+	handler := NewPatternsHandler(svc, log, nil)
+
+	code, status := readiness(t, handler)
+	if code != http.StatusOK || !status.Ready || status.Reason != models.ReadinessReady {
+		t.Fatalf("before shutdown = %d %+v, want 200 ready", code, status)
+	}
+
+	handler.BeginShutdown()
+
+	code, status = readiness(t, handler)
+	if code != http.StatusServiceUnavailable || status.Ready || status.Reason != models.ReadinessShuttingDown {
+		t.Errorf("after BeginShutdown = %d %+v, want 503 shutting_down", code, status)
+	}
+}
+
+func TestReadinessProbe_DependencyUnhealthy(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	producer := &fakeProducer{healthErr: errors.New("no brokers available")}
+	svc := services.NewPatternsService(nil, nil, "", nil, nil, producer, log, nil)
+	handler := NewPatternsHandler(svc, log, nil)
+
+	code, status := readiness(t, handler)
+	if code != http.StatusServiceUnavailable || status.Reason != models.ReadinessDependencyUnhealthy {
+		t.Fatalf("readiness = %d %+v, want 503 dependency_unhealthy", code, status)
+	}
+	if len(status.Unhealthy) != 1 || status.Unhealthy[0] != "kafka" {
+		t.Errorf("unhealthy = %v, want [kafka]", status.Unhealthy)
+	}
+
+	// Shutting down takes precedence over dependency health
+	handler.BeginShutdown()
+	if _, status := readiness(t, handler); status.Reason != models.ReadinessShuttingDown {
+		t.Errorf("reason = %q, want %q", status.Reason, models.ReadinessShuttingDown)
+	}
+}
@@ -3,8 +3,10 @@ package api
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
 	"github.com/your-github-org/ai-scaffolder/core/go/metrics"
 	"go.uber.org/zap"
@@ -33,7 +35,7 @@ func CorrelationMiddleware(log *logger.Logger) func(http.Handler) http.Handler {
 
 			// Add to context using Core.Logger's context support
 			ctx := context.WithValue(r.Context(), correlationIDKey, correlationID)
-			ctx = context.WithValue(ctx, logger.CorrelationIDKey, correlationID)
+			ctx = logger.ContextWithCorrelationID(ctx, correlationID)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -73,23 +75,15 @@ func RequestLoggingMiddleware(log *logger.Logger) func(http.Handler) http.Handle
 	}
 }
 
-// MetricsMiddleware records metrics using Core.Metrics
-func MetricsMiddleware(met *metrics.ServiceMetrics) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			start := time.Now()
-
-			// Create wrapped response writer to capture status
-			wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
-
-			next.ServeHTTP(wrapped, r)
-
-			// Record metrics using Core.Metrics
-			duration := time.Since(start)
-			status := http.StatusText(wrapped.statusCode)
-			met.RecordRequest(r.Method, r.URL.Path, status, duration)
-		})
+// routeTemplate returns the mux path template the request matched, e.g.
+// /api/v1/patterns/orders/{id}, so metrics aren't labelled per order ID
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
 	}
+	return ""
 }
 
 // RecoveryMiddleware recovers from panics using Core.Logger
@@ -115,10 +109,31 @@ func RecoveryMiddleware(log *logger.Logger, met *metrics.ServiceMetrics) func(ht
 	}
 }
 
-// TimeoutMiddleware adds request timeout
-func TimeoutMiddleware(timeout time.Duration) func(http.Handler) http.Handler {
+// RequestTimeoutHeader lets clients cap how long the server works on their request.
+// Accepts a Go duration ("500ms", "2s") or a bare number of milliseconds.
+const RequestTimeoutHeader = "X-Request-Timeout"
+
+// RequestTimeoutMiddleware sets the request context deadline from the client's
+// X-Request-Timeout header, bounded to maxTimeout. Missing or invalid headers use
+// defaultTimeout. Service calls receive r.Context(), so store queries are cancelled
+// when the client's deadline passes.
+func RequestTimeoutMiddleware(log *logger.Logger, defaultTimeout, maxTimeout time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := defaultTimeout
+			if header := r.Header.Get(RequestTimeoutHeader); header != "" {
+				if requested, ok := parseRequestTimeout(header); ok {
+					timeout = requested
+				} else {
+					log.WithContext(r.Context()).Debug("Ignoring invalid request timeout header",
+						zap.String("header", RequestTimeoutHeader),
+						zap.String("value", header))
+				}
+			}
+			if timeout > maxTimeout {
+				timeout = maxTimeout
+			}
+
 			ctx, cancel := context.WithTimeout(r.Context(), timeout)
 			defer cancel()
 
@@ -127,13 +142,22 @@ func TimeoutMiddleware(timeout time.Duration) func(http.Handler) http.Handler {
 	}
 }
 
+// parseRequestTimeout parses a duration string or a bare millisecond count
+func parseRequestTimeout(value string) (time.Duration, bool) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(ms) * time.Millisecond, ms > 0
+	}
+	d, err := time.ParseDuration(value)
+	return d, err == nil && d > 0
+}
+
 // CORSMiddleware adds CORS headers
 func CORSMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Correlation-ID")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Correlation-ID, X-Request-Timeout")
 
 			if r.Method == "OPTIONS" {
 				w.WriteHeader(http.StatusOK)
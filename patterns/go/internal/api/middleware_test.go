@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"go.uber.org/zap"
+)
+
+func TestRequestTimeoutMiddleware_ClientTimeoutExceeded(t *testing.T) {
+	mw := RequestTimeoutMiddleware(&logger.Logger{Logger: zap.NewNop()}, 30*time.Second, time.Minute)
+
+	var ctxErr error
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Simulates a downstream store call that honors the request context
+		select {
+		case <-r.Context().Done():
+			ctxErr = r.Context().Err()
+		case <-time.After(5 * time.Second):
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/patterns/analytics", nil)
+	req.Header.Set(RequestTimeoutHeader, "50ms")
+
+	start := time.Now()
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !errors.Is(ctxErr, context.DeadlineExceeded) {
+		t.Errorf("context error = %v, want %v", ctxErr, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %v, want it cut short by the client timeout", elapsed)
+	}
+}
+
+func TestRequestTimeoutMiddleware_Deadline(t *testing.T) {
+	const (
+		defaultTimeout = 30 * time.Second
+		maxTimeout     = time.Minute
+	)
+
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"missing header uses default", "", defaultTimeout},
+		{"duration string", "2s", 2 * time.Second},
+		{"bare milliseconds", "1500", 1500 * time.Millisecond},
+		{"bounded to max", "10m", maxTimeout},
+		{"invalid uses default", "soon", defaultTimeout},
+		{"non-positive uses default", "0", defaultTimeout},
+	}
+
+	mw := RequestTimeoutMiddleware(&logger.Logger{Logger: zap.NewNop()}, defaultTimeout, maxTimeout)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var remaining time.Duration
+			handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, ok := r.Context().Deadline()
+				if !ok {
+					t.Fatal("request context has no deadline")
+				}
+				remaining = time.Until(deadline)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestTimeoutHeader, tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if remaining > tt.want || remaining < tt.want-time.Second {
+				t.Errorf("deadline in %v, want ~%v", remaining, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteTemplate(t *testing.T) {
+	var got string
+	router := mux.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = routeTemplate(r)
+			next.ServeHTTP(w, r)
+		})
+	})
+	api := router.PathPrefix("/api/v1/patterns").Subrouter()
+	api.HandleFunc("/orders/{id}", func(w http.ResponseWriter, r *http.Request) {}).Methods("GET")
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/patterns/orders/42", nil))
+
+	if got != "/api/v1/patterns/orders/{id}" {
+		t.Errorf("routeTemplate() = %q, want /api/v1/patterns/orders/{id}", got)
+	}
+	if tmpl := routeTemplate(httptest.NewRequest(http.MethodGet, "/orders/42", nil)); tmpl != "" {
+		t.Errorf("routeTemplate() outside a router = %q, want empty", tmpl)
+	}
+}
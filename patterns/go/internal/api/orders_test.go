@@ -0,0 +1,252 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/services"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+var orderColumns = []string{"Id", "CustomerID", "TotalAmount", "Currency", "Status", "ShippingAddress", "CreatedAt", "UpdatedAt"}
+
+func newBatchGetRequest(t *testing.T, req models.BatchGetOrdersRequest) *http.Request {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return httptest.NewRequest(http.MethodPost, "/api/v1/patterns/orders/batch-get", bytes.NewReader(body))
+}
+
+func TestBatchGetOrders(t *testing.T) {
+	customerID := uuid.New()
+	first, second, missing := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		ids          []uuid.UUID
+		rows         []uuid.UUID // IDs returned by the database
+		wantOrders   []uuid.UUID
+		wantNotFound []uuid.UUID
+	}{
+		{
+			name:         "all found",
+			ids:          []uuid.UUID{first, second},
+			rows:         []uuid.UUID{second, first},
+			wantOrders:   []uuid.UUID{first, second},
+			wantNotFound: []uuid.UUID{},
+		},
+		{
+			name:         "partial",
+			ids:          []uuid.UUID{first, missing, first},
+			rows:         []uuid.UUID{first},
+			wantOrders:   []uuid.UUID{first},
+			wantNotFound: []uuid.UUID{missing},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows(orderColumns)
+			for _, id := range tt.rows {
+				rows.AddRow(id, customerID, 10.5, "USD", "pending", "1 Main St", now, now)
+			}
+			mock.ExpectQuery(`WHERE CustomerID = @p1 AND Id IN \(@p2, @p3\)`).WillReturnRows(rows)
+
+			log := &logger.Logger{Logger: zap.NewNop()}
+			handler := NewPatternsHandler(services.NewPatternsService(db, nil, "", nil, nil, nil, log, nil), log, nil)
+
+			rec := httptest.NewRecorder()
+			handler.BatchGetOrders(rec, newBatchGetRequest(t, models.BatchGetOrdersRequest{CustomerID: customerID, IDs: tt.ids}))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %v, want %v (body %s)", rec.Code, http.StatusOK, rec.Body.String())
+			}
+
+			var resp models.BatchGetOrdersResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid JSON response: %v", err)
+			}
+
+			if len(resp.Orders) != len(tt.wantOrders) {
+				t.Fatalf("len(orders) = %v, want %v", len(resp.Orders), len(tt.wantOrders))
+			}
+			for i, id := range tt.wantOrders {
+				if resp.Orders[i].ID != id {
+					t.Errorf("orders[%d].ID = %v, want %v", i, resp.Orders[i].ID, id)
+				}
+				if resp.Orders[i].CustomerID != customerID {
+					t.Errorf("orders[%d].CustomerID = %v, want %v", i, resp.Orders[i].CustomerID, customerID)
+				}
+			}
+			if len(resp.NotFound) != len(tt.wantNotFound) {
+				t.Fatalf("notFound = %v, want %v", resp.NotFound, tt.wantNotFound)
+			}
+			for i, id := range tt.wantNotFound {
+				if resp.NotFound[i] != id {
+					t.Errorf("notFound[%d] = %v, want %v", i, resp.NotFound[i], id)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestBatchGetOrders_RejectedRequests(t *testing.T) {
+	tooMany := make([]uuid.UUID, models.MaxBatchGetOrderIDs+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+
+	tests := []struct {
+		name string
+		req  models.BatchGetOrdersRequest
+		want int
+	}{
+		{"over limit", models.BatchGetOrdersRequest{CustomerID: uuid.New(), IDs: tooMany}, http.StatusRequestEntityTooLarge},
+		{"missing customer", models.BatchGetOrdersRequest{IDs: []uuid.UUID{uuid.New()}}, http.StatusBadRequest},
+		{"no ids", models.BatchGetOrdersRequest{CustomerID: uuid.New()}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			log := &logger.Logger{Logger: zap.NewNop()}
+			handler := NewPatternsHandler(services.NewPatternsService(db, nil, "", nil, nil, nil, log, nil), log, nil)
+
+			rec := httptest.NewRecorder()
+			handler.BatchGetOrders(rec, newBatchGetRequest(t, tt.req))
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %v, want %v", rec.Code, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("rejected request should not query the database: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetOrder_StatusFromError(t *testing.T) {
+	tests := []struct {
+		name     string
+		queryErr error // nil returns no rows
+		want     int
+	}{
+		{"not found", nil, http.StatusNotFound},
+		{"database failure", goerrors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			query := mock.ExpectQuery(`FROM Orders`)
+			if tt.queryErr != nil {
+				query.WillReturnError(tt.queryErr)
+			} else {
+				query.WillReturnRows(sqlmock.NewRows(orderColumns))
+			}
+
+			log := &logger.Logger{Logger: zap.NewNop()}
+			handler := NewPatternsHandler(services.NewPatternsService(db, nil, "", nil, nil, nil, log, nil), log, nil)
+
+			id := uuid.New()
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/api/v1/patterns/orders/"+id.String(), nil), map[string]string{"id": id.String()})
+			rec := httptest.NewRecorder()
+			handler.GetOrder(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %v, want %v (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestListOrders(t *testing.T) {
+	customerID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCode  int
+		wantTotal int
+		wantLimit int
+	}{
+		{name: "filtered", query: "?customerId=" + customerID.String() + "&status=pending&createdAfter=2026-01-01T00:00:00Z&limit=10", wantCode: http.StatusOK, wantTotal: 3, wantLimit: 10},
+		{name: "defaults", query: "", wantCode: http.StatusOK, wantTotal: 3, wantLimit: models.DefaultOrderPageSize},
+		{name: "invalid customer", query: "?customerId=nope", wantCode: http.StatusBadRequest},
+		{name: "invalid date", query: "?createdBefore=yesterday", wantCode: http.StatusBadRequest},
+		{name: "unknown status", query: "?status=lost", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			if tt.wantCode == http.StatusOK {
+				mock.ExpectQuery(`SELECT COUNT`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.wantTotal))
+				mock.ExpectQuery(`FETCH NEXT @limit ROWS ONLY`).
+					WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(uuid.New(), customerID, 10.5, "USD", "pending", "1 Main St", now, now))
+			}
+
+			log := &logger.Logger{Logger: zap.NewNop()}
+			handler := NewPatternsHandler(services.NewPatternsService(db, nil, "", nil, nil, nil, log, nil), log, nil)
+
+			rec := httptest.NewRecorder()
+			handler.ListOrders(rec, httptest.NewRequest(http.MethodGet, "/api/v1/patterns/orders"+tt.query, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %v, want %v (body %s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode == http.StatusOK {
+				var page models.OrderPage
+				if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+					t.Fatalf("invalid JSON response: %v", err)
+				}
+				if page.Total != tt.wantTotal || page.Limit != tt.wantLimit || len(page.Orders) != 1 {
+					t.Errorf("page = total %d, limit %d, %d orders; want total %d, limit %d, 1 order",
+						page.Total, page.Limit, len(page.Orders), tt.wantTotal, tt.wantLimit)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/services"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+var productColumns = []string{"Id", "Name", "Description", "Price", "Category", "StockQuantity", "Status", "CreatedAt", "UpdatedAt"}
+
+func newProductTestHandler(db *sql.DB) *PatternsHandler {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	return NewPatternsHandler(services.NewPatternsService(db, nil, "", nil, nil, nil, log, nil), log, nil)
+}
+
+func newProductRequest(method, id, body string) *http.Request {
+	req := httptest.NewRequest(method, "/api/v1/patterns/products/"+id, bytes.NewBufferString(body))
+	if id != "" {
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+	}
+	return req
+}
+
+func productRow(id uuid.UUID, status string) *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows(productColumns).AddRow(id, "Widget", "", 9.99, "tools", 5, status, now, now)
+}
+
+func TestCreateProduct_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode string
+	}{
+		{name: "missing name", body: `{"price":9.99,"category":"tools","stockQuantity":1}`, wantCode: "PAT-PRD-002"},
+		{name: "blank name", body: `{"name":"  ","price":9.99,"category":"tools"}`, wantCode: "PAT-PRD-002"},
+		{name: "zero price", body: `{"name":"Widget","price":0,"category":"tools"}`, wantCode: "PAT-PRD-003"},
+		{name: "negative price", body: `{"name":"Widget","price":-1,"category":"tools"}`, wantCode: "PAT-PRD-003"},
+		{name: "missing category", body: `{"name":"Widget","price":9.99}`, wantCode: "PAT-PRD-004"},
+		{name: "negative stock", body: `{"name":"Widget","price":9.99,"category":"tools","stockQuantity":-3}`, wantCode: "PAT-PRD-005"},
+		{name: "unknown status", body: `{"name":"Widget","price":9.99,"category":"tools","status":"archived"}`, wantCode: "PAT-VAL-001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			rec := httptest.NewRecorder()
+			newProductTestHandler(db).CreateProduct(rec, newProductRequest(http.MethodPost, "", tt.body))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %v, want %v", rec.Code, http.StatusBadRequest)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantCode) {
+				t.Errorf("body = %s, want error code %s", rec.Body.String(), tt.wantCode)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("validation failure reached the database: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateProduct_DefaultsToActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	// The price is stored as an exact decimal, like order amounts
+	mock.ExpectExec(`INSERT INTO Products`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sql.Named("p4", "9.99"),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	rec := httptest.NewRecorder()
+	newProductTestHandler(db).CreateProduct(rec, newProductRequest(http.MethodPost, "", `{"name":"Widget","price":9.99,"category":"tools","stockQuantity":5}`))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %v, want %v (body %s)", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"status":"active"`) {
+		t.Errorf("body = %s, want status active", rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"price":9.99`) {
+		t.Errorf("body = %s, want price 9.99", rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateProduct_Validation(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name       string
+		current    string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "negative price", current: "active", body: `{"price":-5}`, wantStatus: http.StatusBadRequest, wantCode: "PAT-PRD-003"},
+		{name: "negative stock", current: "active", body: `{"stockQuantity":-1}`, wantStatus: http.StatusBadRequest, wantCode: "PAT-PRD-005"},
+		{name: "discontinued is final", current: "discontinued", body: `{"status":"active"}`, wantStatus: http.StatusConflict, wantCode: "PAT-PRD-006"},
+		{name: "unknown status", current: "active", body: `{"status":"archived"}`, wantStatus: http.StatusBadRequest, wantCode: "PAT-VAL-001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(`FROM Products WHERE Id = @p1`).WillReturnRows(productRow(id, tt.current))
+
+			rec := httptest.NewRecorder()
+			newProductTestHandler(db).UpdateProduct(rec, newProductRequest(http.MethodPatch, id.String(), tt.body))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantCode) {
+				t.Errorf("body = %s, want error code %s", rec.Body.String(), tt.wantCode)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteProduct(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name       string
+		deleted    int64
+		current    string // status returned by the follow-up lookup; "" = not found
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "inactive product", deleted: 1, wantStatus: http.StatusNoContent},
+		{name: "active product", deleted: 0, current: "active", wantStatus: http.StatusConflict, wantCode: "PAT-PRD-007"},
+		{name: "unknown product", deleted: 0, wantStatus: http.StatusNotFound, wantCode: "PAT-PRD-001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec(`DELETE FROM Products WHERE Id = @p1 AND Status <> @p2`).
+				WithArgs(sql.Named("p1", id), sql.Named("p2", "active")).
+				WillReturnResult(sqlmock.NewResult(0, tt.deleted))
+			if tt.deleted == 0 {
+				rows := sqlmock.NewRows(productColumns)
+				if tt.current != "" {
+					rows = productRow(id, tt.current)
+				}
+				mock.ExpectQuery(`FROM Products WHERE Id = @p1`).WillReturnRows(rows)
+			}
+
+			rec := httptest.NewRecorder()
+			newProductTestHandler(db).DeleteProduct(rec, newProductRequest(http.MethodDelete, id.String(), ""))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v (body %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantCode != "" && !strings.Contains(rec.Body.String(), tt.wantCode) {
+				t.Errorf("body = %s, want error code %s", rec.Body.String(), tt.wantCode)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestListProducts_Filters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`FROM Products WHERE Category = @category AND Status = @status ORDER BY Name, Id OFFSET @offset ROWS FETCH NEXT @limit ROWS ONLY`).
+		WillReturnRows(productRow(uuid.New(), "active"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/patterns/products?category=tools&status=active&limit=10", nil)
+	rec := httptest.NewRecorder()
+	newProductTestHandler(db).ListProducts(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %v, want %v (body %s)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"limit":10`) {
+		t.Errorf("body = %s, want limit 10", rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
 	"github.com/your-github-org/ai-scaffolder/core/go/metrics"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -21,11 +22,11 @@ func SetupRoutes(
 	// Apply global middleware using Core packages
 	router.Use(
 		CORSMiddleware(),
-		CorrelationMiddleware(log),    // Core.Logger correlation
-		RequestLoggingMiddleware(log), // Core.Logger request logging
-		MetricsMiddleware(met),        // Core.Metrics
-		RecoveryMiddleware(log, met),  // Core.Logger + Core.Metrics
-		TimeoutMiddleware(30*time.Second),
+		CorrelationMiddleware(log),                                    // Core.Logger correlation
+		RequestLoggingMiddleware(log),                                 // Core.Logger request logging
+		metrics.HTTPMiddlewareWithRoute(met, routeTemplate),           // Core.Metrics (per route template)
+		RecoveryMiddleware(log, met),                                  // Core.Logger + Core.Metrics
+		RequestTimeoutMiddleware(log, 30*time.Second, 30*time.Second), // Client X-Request-Timeout, capped at server WriteTimeout
 	)
 
 	// ========================================================================
@@ -34,9 +35,12 @@ func SetupRoutes(
 	router.HandleFunc("/health", handler.Health).Methods("GET")
 	router.HandleFunc("/health/live", handler.LivenessProbe).Methods("GET")
 	router.HandleFunc("/health/ready", handler.ReadinessProbe).Methods("GET")
+	router.HandleFunc("/version", handler.Version).Methods("GET")
 
-	// Prometheus metrics endpoint (Core.Metrics)
-	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+	// Prometheus metrics endpoint (Core.Metrics); OpenMetrics exposes latency exemplars
+	router.Handle("/metrics", promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	)).Methods("GET")
 
 	// ========================================================================
 	// API v1 Routes - Demonstrating Core Infrastructure Usage
@@ -48,24 +52,45 @@ func SetupRoutes(
 
 	// SQL Server Patterns - Orders (Core.Infrastructure.SqlServer)
 	apiV1.HandleFunc("/orders", handler.CreateOrder).Methods("POST")
+	apiV1.HandleFunc("/orders", handler.ListOrders).Methods("GET")
+	apiV1.HandleFunc("/orders/batch-get", handler.BatchGetOrders).Methods("POST")
 	apiV1.HandleFunc("/orders/{id}", handler.GetOrder).Methods("GET")
 	apiV1.HandleFunc("/orders/{id}/status", handler.UpdateOrderStatus).Methods("PATCH")
+	apiV1.HandleFunc("/orders/{id}/history", handler.GetOrderHistory).Methods("GET")
+
+	// SQL Server Patterns - Products (Core.Infrastructure.SqlServer)
+	apiV1.HandleFunc("/products", handler.CreateProduct).Methods("POST")
+	apiV1.HandleFunc("/products", handler.ListProducts).Methods("GET")
+	apiV1.HandleFunc("/products/{id}", handler.GetProduct).Methods("GET")
+	apiV1.HandleFunc("/products/{id}", handler.UpdateProduct).Methods("PATCH")
+	apiV1.HandleFunc("/products/{id}", handler.DeleteProduct).Methods("DELETE")
 
 	// MongoDB Patterns - Users (Core.Infrastructure.MongoDB)
 	apiV1.HandleFunc("/users", handler.CreateUser).Methods("POST")
 	apiV1.HandleFunc("/users/{id}", handler.GetUser).Methods("GET")
+	apiV1.HandleFunc("/users/{id}", handler.DeleteUser).Methods("DELETE")
+	apiV1.HandleFunc("/users/{id}/restore", handler.RestoreUser).Methods("POST")
+	apiV1.HandleFunc("/users/{id}/export", handler.ExportUserData).Methods("GET")
+	apiV1.HandleFunc("/users/{id}/erase", handler.EraseUser).Methods("POST")
 	apiV1.HandleFunc("/users/{id}/preferences", handler.UpdateUserPreferences).Methods("PUT")
 
 	// ScyllaDB Patterns - Telemetry (Core.Infrastructure.ScyllaDB)
 	apiV1.HandleFunc("/telemetry", handler.RecordTelemetry).Methods("POST")
+	apiV1.HandleFunc("/telemetry/batch", handler.RecordTelemetryBatch).Methods("POST")
 	apiV1.HandleFunc("/telemetry/{deviceId}", handler.GetTelemetryHistory).Methods("GET")
+	apiV1.HandleFunc("/telemetry/{deviceId}/stats", handler.GetTelemetryStats).Methods("GET")
 
 	// Redis Patterns - Leaderboards (Core.Infrastructure.Redis)
 	apiV1.HandleFunc("/leaderboards/{category}/scores", handler.UpdateLeaderboard).Methods("POST")
+	apiV1.HandleFunc("/leaderboards/{category}/increments", handler.IncrementLeaderboard).Methods("POST")
 	apiV1.HandleFunc("/leaderboards/{category}", handler.GetLeaderboard).Methods("GET")
+	apiV1.HandleFunc("/leaderboards/{category}/around/{userId}", handler.GetLeaderboardAround).Methods("GET")
 
 	// Redis + Kafka Patterns - Sessions
 	apiV1.HandleFunc("/sessions", handler.CreateSession).Methods("POST")
+	apiV1.HandleFunc("/users/{id}/sessions", handler.ListUserSessions).Methods("GET")
+	apiV1.HandleFunc("/users/{id}/sessions", handler.RevokeAllUserSessions).Methods("DELETE")
+	apiV1.HandleFunc("/users/{id}/sessions/{sessionId}", handler.RevokeUserSession).Methods("DELETE")
 
 	// Cross-Platform Analytics (All Core Infrastructure)
 	apiV1.HandleFunc("/analytics", handler.GetAnalytics).Methods("GET")
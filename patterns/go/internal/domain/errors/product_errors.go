@@ -2,6 +2,7 @@ package errors
 
 import (
 	goerrors "errors"
+	"net/http"
 
 	"github.com/your-github-org/ai-scaffolder/core/go/errors"
 )
@@ -15,10 +16,23 @@ var (
 	ErrInvalidEmail      = goerrors.New("valid email address is required")
 	ErrInvalidDeviceID   = goerrors.New("device ID is required")
 	ErrSessionNotFound   = goerrors.New("session not found")
+	ErrEmptyOrderIDs     = goerrors.New("at least one order ID is required")
+	ErrTooManyOrderIDs   = goerrors.New("too many order IDs requested")
+
+	ErrEmptyTelemetryBatch    = goerrors.New("at least one telemetry reading is required")
+	ErrTelemetryBatchTooLarge = goerrors.New("too many telemetry readings in batch")
+
+	ErrSagaCompensated        = goerrors.New("saga step failed and completed steps were compensated")
+	ErrSagaCompensationFailed = goerrors.New("saga compensation failed")
+
+	ErrExchangeRateNotFound = goerrors.New("exchange rate not available")
+
+	ErrInvalidLeaderboardWindow = goerrors.New("leaderboard window must be one of all-time, daily, weekly, monthly")
+	ErrLeaderboardWindowOff     = goerrors.New("leaderboard window is not enabled")
 )
 
 // ProductErrors is the error registry for product/patterns domain
-var ProductErrors = errors.NewErrorRegistry()
+var ProductErrors = errors.NewErrorRegistry().WithComponent("PatternsService")
 
 func init() {
 	// Product entity errors (PRD = Product)
@@ -32,6 +46,7 @@ func init() {
 		Detect_D:    6,
 		Mitigation:  "Verify product ID exists in database before lookup",
 		Example:     "User requests product that was deleted or never existed",
+		HTTPStatus:  http.StatusNotFound,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -44,6 +59,7 @@ func init() {
 		Detect_D:    5,
 		Mitigation:  "Validate product name in request payload",
 		Example:     "API request missing required name field",
+		HTTPStatus:  http.StatusBadRequest,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -56,6 +72,7 @@ func init() {
 		Detect_D:    4,
 		Mitigation:  "Validate price > 0 before persistence",
 		Example:     "API request with negative or zero price",
+		HTTPStatus:  http.StatusBadRequest,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -68,6 +85,7 @@ func init() {
 		Detect_D:    5,
 		Mitigation:  "Validate category in request payload",
 		Example:     "API request missing required category field",
+		HTTPStatus:  http.StatusBadRequest,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -80,6 +98,7 @@ func init() {
 		Detect_D:    4,
 		Mitigation:  "Validate quantity >= 0 before update",
 		Example:     "Inventory update with negative quantity",
+		HTTPStatus:  http.StatusBadRequest,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -92,6 +111,7 @@ func init() {
 		Detect_D:    5,
 		Mitigation:  "Implement state machine validation for status transitions",
 		Example:     "Trying to transition from 'delivered' to 'pending'",
+		HTTPStatus:  http.StatusConflict,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -104,6 +124,7 @@ func init() {
 		Detect_D:    6,
 		Mitigation:  "Check product status before deletion, require deactivation first",
 		Example:     "Delete request for product currently listed in orders",
+		HTTPStatus:  http.StatusConflict,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -116,6 +137,20 @@ func init() {
 		Detect_D:    4,
 		Mitigation:  "Check stock availability before order creation",
 		Example:     "Order for 10 units when only 5 available",
+		HTTPStatus:  http.StatusConflict,
+	})
+
+	ProductErrors.Register(&errors.ErrorDefinition{
+		Code:        "PAT-PRD-009",
+		Severity:    errors.SeverityLow,
+		Description: "Product %v is not available for ordering (status %v)",
+		SODScore:    36, // 3 × 4 × 3
+		Severity_S:  3,
+		Occurrence:  4,
+		Detect_D:    3,
+		Mitigation:  "Only offer active products; remove inactive ones from carts",
+		Example:     "Order for a discontinued product",
+		HTTPStatus:  http.StatusConflict,
 	})
 
 	// Order entity errors (ORD = Order)
@@ -129,6 +164,7 @@ func init() {
 		Detect_D:    5,
 		Mitigation:  "Verify order ID exists before lookup",
 		Example:     "Customer queries non-existent order",
+		HTTPStatus:  http.StatusNotFound,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -141,6 +177,7 @@ func init() {
 		Detect_D:    4,
 		Mitigation:  "Validate items array is not empty",
 		Example:     "Create order request with empty items array",
+		HTTPStatus:  http.StatusBadRequest,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -153,6 +190,20 @@ func init() {
 		Detect_D:    4,
 		Mitigation:  "Check order status before modification",
 		Example:     "Attempt to ship already cancelled order",
+		HTTPStatus:  http.StatusConflict,
+	})
+
+	ProductErrors.Register(&errors.ErrorDefinition{
+		Code:        "PAT-ORD-004",
+		Severity:    errors.SeverityLow,
+		Description: "Order request with idempotency key %v is still in progress",
+		SODScore:    24, // 2 × 4 × 3
+		Severity_S:  2,
+		Occurrence:  4,
+		Detect_D:    3,
+		Mitigation:  "Retry after the original request completes",
+		Example:     "Client retries CreateOrder before the first attempt has committed",
+		HTTPStatus:  http.StatusConflict,
 	})
 
 	// User entity errors (USR = User)
@@ -166,6 +217,7 @@ func init() {
 		Detect_D:    5,
 		Mitigation:  "Verify user ID exists before operations",
 		Example:     "Profile update for non-existent user",
+		HTTPStatus:  http.StatusNotFound,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -178,6 +230,7 @@ func init() {
 		Detect_D:    4,
 		Mitigation:  "Validate email field in request",
 		Example:     "User registration without email",
+		HTTPStatus:  http.StatusBadRequest,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -190,6 +243,7 @@ func init() {
 		Detect_D:    4,
 		Mitigation:  "Check email uniqueness before registration",
 		Example:     "Registration with existing email address",
+		HTTPStatus:  http.StatusConflict,
 	})
 
 	// Infrastructure errors (INFRA)
@@ -253,6 +307,18 @@ func init() {
 		Example:     "ScyllaDB query timeout during telemetry insert",
 	})
 
+	ProductErrors.Register(&errors.ErrorDefinition{
+		Code:        "PAT-INFRA-006",
+		Severity:    errors.SeverityCritical,
+		Description: "Background goroutine panicked: %v",
+		SODScore:    144, // 9 × 2 × 8
+		Severity_S:  9,
+		Occurrence:  2,
+		Detect_D:    8,
+		Mitigation:  "Fix the panic from the logged stack trace; the goroutine's work was abandoned",
+		Example:     "Nil map write in an analytics fan-out query",
+	})
+
 	// Validation errors (VAL)
 	ProductErrors.Register(&errors.ErrorDefinition{
 		Code:        "PAT-VAL-001",
@@ -301,6 +367,7 @@ func init() {
 		Detect_D:    5,
 		Mitigation:  "Verify device ID exists in device registry",
 		Example:     "Telemetry query for unknown device",
+		HTTPStatus:  http.StatusNotFound,
 	})
 
 	ProductErrors.Register(&errors.ErrorDefinition{
@@ -314,6 +381,19 @@ func init() {
 		Mitigation:  "Alert operations team, check device status",
 		Example:     "Temperature reading outside normal range",
 	})
+
+	ProductErrors.Register(&errors.ErrorDefinition{
+		Code:        "PAT-TEL-003",
+		Severity:    errors.SeverityLow,
+		Description: "Telemetry timestamp rejected for device %v: %v",
+		SODScore:    48, // 3 × 4 × 4
+		Severity_S:  3,
+		Occurrence:  4,
+		Detect_D:    4,
+		Mitigation:  "Sync the device clock (NTP) or omit the timestamp to use server time",
+		Example:     "Reading timestamped a day ahead by a device with a reset clock",
+		HTTPStatus:  http.StatusBadRequest,
+	})
 }
 
 // Convenience functions for creating specific errors
@@ -339,6 +419,24 @@ func InvalidStatusTransition(from, to interface{}) *errors.ServiceError {
 	return ProductErrors.CreateError("PAT-PRD-006", from, to)
 }
 
+// EmailAlreadyRegistered creates an error for a registration whose email is
+// already in use
+func EmailAlreadyRegistered(email string) *errors.ServiceError {
+	return ProductErrors.CreateError("PAT-USR-003", email)
+}
+
+// ProductUnavailable creates an error for an order of a product that is not
+// active
+func ProductUnavailable(id interface{}, status string) *errors.ServiceError {
+	return ProductErrors.CreateError("PAT-PRD-009", id, status)
+}
+
+// IdempotencyKeyInProgress creates an error for a retry that arrives while
+// the original request with the same idempotency key is still running
+func IdempotencyKeyInProgress(key string) *errors.ServiceError {
+	return ProductErrors.CreateError("PAT-ORD-004", key)
+}
+
 // InsufficientStock creates an insufficient stock error
 func InsufficientStock(available, requested int) *errors.ServiceError {
 	return ProductErrors.CreateError("PAT-PRD-008", available, requested)
@@ -359,6 +457,11 @@ func ExternalServiceError(serviceName string, err error) *errors.ServiceError {
 	return ProductErrors.WrapError(err, "PAT-INFRA-003", serviceName)
 }
 
+// GoroutinePanic wraps a value recovered from a panicking goroutine
+func GoroutinePanic(recovered interface{}) *errors.ServiceError {
+	return ProductErrors.CreateError("PAT-INFRA-006", recovered)
+}
+
 // ValidationError creates a validation error
 func ValidationError(details string) *errors.ServiceError {
 	return ProductErrors.CreateError("PAT-VAL-001", details)
@@ -379,6 +482,54 @@ func AnomalyDetected(deviceID, anomalyType string) *errors.ServiceError {
 	return ProductErrors.CreateError("PAT-TEL-002", deviceID, anomalyType)
 }
 
+// TelemetryClockSkew creates an error for a reading whose timestamp is too
+// far from server time
+func TelemetryClockSkew(deviceID, reason string) *errors.ServiceError {
+	return ProductErrors.CreateError("PAT-TEL-003", deviceID, reason)
+}
+
+// sentinelStatuses maps the plain sentinel errors above to HTTP statuses.
+// Sentinels not listed (saga, exchange rate) are server-side failures.
+var sentinelStatuses = []struct {
+	err    error
+	status int
+}{
+	{ErrInvalidCustomerID, http.StatusBadRequest},
+	{ErrEmptyOrderItems, http.StatusBadRequest},
+	{ErrInvalidEmail, http.StatusBadRequest},
+	{ErrInvalidDeviceID, http.StatusBadRequest},
+	{ErrEmptyOrderIDs, http.StatusBadRequest},
+	{ErrEmptyTelemetryBatch, http.StatusBadRequest},
+	{ErrInvalidLeaderboardWindow, http.StatusBadRequest},
+	{ErrLeaderboardWindowOff, http.StatusBadRequest},
+	{ErrTooManyOrderIDs, http.StatusRequestEntityTooLarge},
+	{ErrTelemetryBatchTooLarge, http.StatusRequestEntityTooLarge},
+	{ErrOrderNotFound, http.StatusNotFound},
+	{ErrUserNotFound, http.StatusNotFound},
+	{ErrSessionNotFound, http.StatusNotFound},
+}
+
+// HTTPStatus returns the HTTP status for err: its ServiceError's HTTPStatus
+// if it is, or wraps, one; else the status of a wrapped sentinel error; else 500
+func HTTPStatus(err error) int {
+	var serviceErr *errors.ServiceError
+	if goerrors.As(err, &serviceErr) {
+		return serviceErr.HTTPStatus()
+	}
+	for _, sentinel := range sentinelStatuses {
+		if goerrors.Is(err, sentinel.err) {
+			return sentinel.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// HasCode reports whether err is, or wraps, a ServiceError with the given code
+func HasCode(err error, code string) bool {
+	var serviceErr *errors.ServiceError
+	return goerrors.As(err, &serviceErr) && serviceErr.Code == code
+}
+
 // GetAllErrorCodes returns all registered error codes
 func GetAllErrorCodes() []string {
 	codes := []string{}
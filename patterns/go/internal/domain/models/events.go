@@ -27,18 +27,34 @@ func NewBaseEvent(eventType, source string) BaseEvent {
 	}
 }
 
+// EventName returns the event type, sent as the event_type header
+func (e *BaseEvent) EventName() string { return e.EventType }
+
+// EventCorrelationID returns the correlation ID, sent as the correlation_id header
+func (e *BaseEvent) EventCorrelationID() string { return e.CorrelationID }
+
+// SetEventCorrelationID sets the correlation ID if the event doesn't have one
+func (e *BaseEvent) SetEventCorrelationID(id string) {
+	if e.CorrelationID == "" {
+		e.CorrelationID = id
+	}
+}
+
 // OrderEvent represents order-related events for Kafka
 type OrderEvent struct {
 	BaseEvent
 	OrderID         uuid.UUID   `json:"orderId"`
 	CustomerID      uuid.UUID   `json:"customerId"`
 	Status          OrderStatus `json:"status"`
-	TotalAmount     float64     `json:"totalAmount"`
+	TotalAmount     Money       `json:"totalAmount"`
 	ItemCount       int         `json:"itemCount"`
 	ShippingAddress string      `json:"shippingAddress,omitempty"`
 	PreviousStatus  OrderStatus `json:"previousStatus,omitempty"`
 }
 
+// EventKey partitions order events by order
+func (e *OrderEvent) EventKey() string { return e.OrderID.String() }
+
 // NewOrderCreatedEvent creates an order created event
 func NewOrderCreatedEvent(order *Order, source string) *OrderEvent {
 	return &OrderEvent{
@@ -77,6 +93,9 @@ type UserEvent struct {
 	LastName  string    `json:"lastName"`
 }
 
+// EventKey partitions user events by user
+func (e *UserEvent) EventKey() string { return e.UserID.String() }
+
 // NewUserRegisteredEvent creates a user registered event
 func NewUserRegisteredEvent(profile *UserProfile, source string) *UserEvent {
 	return &UserEvent{
@@ -99,6 +118,41 @@ func NewUserProfileUpdatedEvent(profile *UserProfile, source string) *UserEvent
 	}
 }
 
+// NewUserDeletedEvent creates a user soft-deleted event
+func NewUserDeletedEvent(profile *UserProfile, source string) *UserEvent {
+	return &UserEvent{
+		BaseEvent: NewBaseEvent("UserDeleted", source),
+		UserID:    profile.ID,
+		Email:     profile.Email,
+		FirstName: profile.FirstName,
+		LastName:  profile.LastName,
+	}
+}
+
+// NewUserRestoredEvent creates a user restored event
+func NewUserRestoredEvent(profile *UserProfile, source string) *UserEvent {
+	return &UserEvent{
+		BaseEvent: NewBaseEvent("UserRestored", source),
+		UserID:    profile.ID,
+		Email:     profile.Email,
+		FirstName: profile.FirstName,
+		LastName:  profile.LastName,
+	}
+}
+
+// NewUserErasedEvent creates the audit event for a completed GDPR erasure.
+// It carries only the user ID; the erased PII must not be republished.
+func NewUserErasedEvent(record *ErasureRecord, source string) *UserEvent {
+	event := &UserEvent{
+		BaseEvent: NewBaseEvent("UserErased", source),
+		UserID:    record.UserID,
+	}
+	for _, step := range record.Steps {
+		event.Metadata["step."+step.Name] = step.Status
+	}
+	return event
+}
+
 // NewUserLoggedInEvent creates a user logged in event
 func NewUserLoggedInEvent(userID uuid.UUID, email, source string) *UserEvent {
 	return &UserEvent{
@@ -117,8 +171,24 @@ type TelemetryEvent struct {
 	Unit         string  `json:"unit"`
 	AnomalyType  string  `json:"anomalyType,omitempty"`
 	AnomalyScore float64 `json:"anomalyScore,omitempty"`
+
+	// Readings carried by a TelemetryBatchReceived event
+	Readings []TelemetryReading `json:"readings,omitempty"`
+}
+
+// TelemetryReading is one reading in a TelemetryBatchReceived event
+type TelemetryReading struct {
+	DeviceID      string    `json:"deviceId"`
+	Metric        string    `json:"metric"`
+	Value         float64   `json:"value"`
+	Unit          string    `json:"unit"`
+	EventTime     time.Time `json:"eventTime"`
+	CorrelationID string    `json:"correlationId"`
 }
 
+// EventKey partitions telemetry events by device
+func (e *TelemetryEvent) EventKey() string { return e.DeviceID }
+
 // NewTelemetryReceivedEvent creates a telemetry received event
 func NewTelemetryReceivedEvent(telemetry *DeviceTelemetry, source string) *TelemetryEvent {
 	event := &TelemetryEvent{
@@ -132,6 +202,32 @@ func NewTelemetryReceivedEvent(telemetry *DeviceTelemetry, source string) *Telem
 	return event
 }
 
+// NewTelemetryBatchReceivedEvent creates one event for a batch of stored
+// readings. DeviceID, and so the partition key, is set when every reading
+// comes from the same device.
+func NewTelemetryBatchReceivedEvent(telemetry []*DeviceTelemetry, source string) *TelemetryEvent {
+	event := &TelemetryEvent{
+		BaseEvent: NewBaseEvent("TelemetryBatchReceived", source),
+		Readings:  make([]TelemetryReading, len(telemetry)),
+	}
+	for i, t := range telemetry {
+		event.Readings[i] = TelemetryReading{
+			DeviceID:      t.DeviceID,
+			Metric:        t.Metric,
+			Value:         t.Value,
+			Unit:          t.Unit,
+			EventTime:     t.EventTime,
+			CorrelationID: t.CorrelationID.String(),
+		}
+		if i == 0 {
+			event.DeviceID = t.DeviceID
+		} else if t.DeviceID != event.DeviceID {
+			event.DeviceID = ""
+		}
+	}
+	return event
+}
+
 // NewAnomalyDetectedEvent creates an anomaly detected event
 func NewAnomalyDetectedEvent(telemetry *DeviceTelemetry, anomalyType string, anomalyScore float64, source string) *TelemetryEvent {
 	event := &TelemetryEvent{
@@ -147,6 +243,42 @@ func NewAnomalyDetectedEvent(telemetry *DeviceTelemetry, anomalyType string, ano
 	return event
 }
 
+// LeaderboardEvent records a leaderboard score change. The leaderboard topic
+// is the source of truth; Redis can be rebuilt by replaying it.
+type LeaderboardEvent struct {
+	BaseEvent
+	Category string  `json:"category"`
+	UserID   string  `json:"userId"`
+	Score    float64 `json:"score"`           // Absolute score; for increments, the all-time total afterwards
+	Delta    float64 `json:"delta,omitempty"` // Amount added by a ScoreIncremented event
+}
+
+// EventKey partitions leaderboard events by category, keeping each board's updates in order
+func (e *LeaderboardEvent) EventKey() string { return e.Category }
+
+// IsIncrement reports whether the event adds Delta rather than setting Score
+func (e *LeaderboardEvent) IsIncrement() bool { return e.EventType == "ScoreIncremented" }
+
+// NewScoreUpdatedEvent creates a leaderboard score updated event
+func NewScoreUpdatedEvent(category, userID string, score float64, source string) *LeaderboardEvent {
+	return &LeaderboardEvent{
+		BaseEvent: NewBaseEvent("ScoreUpdated", source),
+		Category:  category,
+		UserID:    userID,
+		Score:     score,
+	}
+}
+
+// NewScoreIncrementedEvent creates a leaderboard score incremented event
+func NewScoreIncrementedEvent(category, userID string, delta float64, source string) *LeaderboardEvent {
+	return &LeaderboardEvent{
+		BaseEvent: NewBaseEvent("ScoreIncremented", source),
+		Category:  category,
+		UserID:    userID,
+		Delta:     delta,
+	}
+}
+
 // SystemEvent represents system-level events for Kafka
 type SystemEvent struct {
 	BaseEvent
@@ -156,6 +288,9 @@ type SystemEvent struct {
 	Details   string `json:"details,omitempty"`
 }
 
+// EventKey partitions system events by component
+func (e *SystemEvent) EventKey() string { return e.Component }
+
 // NewSystemEvent creates a system event
 func NewSystemEvent(component, message, level, source string) *SystemEvent {
 	return &SystemEvent{
@@ -194,26 +329,74 @@ type LeaderboardEntry struct {
 	Rank   int     `json:"rank"`
 }
 
-// UpdateLeaderboardRequest represents the request to update a leaderboard
+// LeaderboardAround is a user's position on a leaderboard and the entries
+// ranked just above and below them. OnBoard is false, with no entries, when
+// the user has no score in the category.
+type LeaderboardAround struct {
+	UserID  string             `json:"userId"`
+	OnBoard bool               `json:"onBoard"`
+	Rank    int                `json:"rank,omitempty"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// LeaderboardWindow selects the period a leaderboard covers
+type LeaderboardWindow string
+
+// Leaderboard windows. Periods are calendar UTC days, ISO weeks and months.
+const (
+	LeaderboardAllTime LeaderboardWindow = "all-time"
+	LeaderboardDaily   LeaderboardWindow = "daily"
+	LeaderboardWeekly  LeaderboardWindow = "weekly"
+	LeaderboardMonthly LeaderboardWindow = "monthly"
+)
+
+// IsValid reports whether w is a known window
+func (w LeaderboardWindow) IsValid() bool {
+	switch w {
+	case LeaderboardAllTime, LeaderboardDaily, LeaderboardWeekly, LeaderboardMonthly:
+		return true
+	}
+	return false
+}
+
+// UpdateLeaderboardRequest represents the request to set a user's leaderboard score
 type UpdateLeaderboardRequest struct {
 	UserID string  `json:"userId"`
 	Score  float64 `json:"score"`
 }
 
+// IncrementLeaderboardRequest represents the request to add to a user's leaderboard score
+type IncrementLeaderboardRequest struct {
+	UserID string  `json:"userId"`
+	Delta  float64 `json:"delta"`
+}
+
+// IncrementLeaderboardResponse returns the user's all-time score after an increment
+type IncrementLeaderboardResponse struct {
+	UserID string  `json:"userId"`
+	Score  float64 `json:"score"`
+}
+
 // CreateSessionRequest represents the request to create a session
 type CreateSessionRequest struct {
 	SessionID string    `json:"sessionId"`
 	UserID    uuid.UUID `json:"userId"`
 	UserEmail string    `json:"userEmail"`
+	Device    string    `json:"device,omitempty"`    // Client description, e.g. the User-Agent
+	IPAddress string    `json:"ipAddress,omitempty"` // Client address the session was created from
 }
 
 // Session represents a user session stored in Redis
 type Session struct {
-	SessionID string    `json:"sessionId"`
-	UserID    uuid.UUID `json:"userId"`
-	UserEmail string    `json:"userEmail"`
-	CreatedAt time.Time `json:"createdAt"`
-	ExpiresAt time.Time `json:"expiresAt"`
+	SessionID      string    `json:"sessionId"`
+	UserID         uuid.UUID `json:"userId"`
+	UserEmail      string    `json:"userEmail"`
+	Device         string    `json:"device,omitempty"`
+	IPAddress      string    `json:"ipAddress,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	MaxExpiresAt   time.Time `json:"maxExpiresAt,omitempty"` // Absolute cap on sliding expiry; zero means uncapped
+	LastAccessedAt time.Time `json:"lastAccessedAt,omitempty"`
 }
 
 // PlatformAnalyticsResult represents cross-platform analytics
@@ -225,14 +408,54 @@ type PlatformAnalyticsResult struct {
 	ScyllaDB    *ScyllaDBAnalytics  `json:"scylladb,omitempty"`
 	Redis       *RedisAnalytics     `json:"redis,omitempty"`
 	GeneratedAt time.Time           `json:"generatedAt"`
+
+	// Sources reports per-store status so clients can tell when the result is partial
+	Sources map[string]SourceStatus `json:"sources"`
+}
+
+// Analytics source names used as keys in PlatformAnalyticsResult.Sources
+const (
+	SourceSQLServer = "sqlserver"
+	SourceMongoDB   = "mongodb"
+	SourceScyllaDB  = "scylladb"
+	SourceRedis     = "redis"
+)
+
+// Analytics source status values
+const (
+	SourceStatusOK       = "ok"
+	SourceStatusFailed   = "failed"
+	SourceStatusSkipped  = "skipped"
+	SourceStatusTimedOut = "timed_out"
+)
+
+// SourceStatus represents the outcome of querying a single analytics store
+type SourceStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
 }
 
-// SQLServerAnalytics represents SQL Server specific analytics
+// IsPartial reports whether any store failed or timed out
+func (r *PlatformAnalyticsResult) IsPartial() bool {
+	for _, source := range r.Sources {
+		if source.Status == SourceStatusFailed || source.Status == SourceStatusTimedOut {
+			return true
+		}
+	}
+	return false
+}
+
+// SQLServerAnalytics represents SQL Server specific analytics.
+// TotalRevenue and AverageOrderValue are in ReportingCurrency and cover only
+// orders whose currency could be converted; RevenueByCurrency is unconverted.
 type SQLServerAnalytics struct {
-	TotalOrders       int64            `json:"totalOrders"`
-	TotalRevenue      float64          `json:"totalRevenue"`
-	AverageOrderValue float64          `json:"averageOrderValue"`
-	OrdersByStatus    map[string]int64 `json:"ordersByStatus"`
+	TotalOrders           int64            `json:"totalOrders"`
+	TotalRevenue          Money            `json:"totalRevenue"`
+	AverageOrderValue     Money            `json:"averageOrderValue"`
+	ReportingCurrency     string           `json:"reportingCurrency"`
+	RevenueByCurrency     map[string]Money `json:"revenueByCurrency"`
+	UnconvertedCurrencies []string         `json:"unconvertedCurrencies,omitempty"`
+	OrdersByStatus        map[string]int64 `json:"ordersByStatus"`
 }
 
 // MongoDBAnalytics represents MongoDB specific analytics
@@ -255,3 +478,32 @@ type RedisAnalytics struct {
 	ActiveSessions   int64   `json:"activeSessions"`
 	LeaderboardCount int64   `json:"leaderboardCount"`
 }
+
+// ServiceCapabilities describes which backends are enabled and which API versions are served
+type ServiceCapabilities struct {
+	APIVersions []string        `json:"apiVersions"`
+	Backends    map[string]bool `json:"backends"`
+}
+
+// Readiness reasons reported by GET /health/ready
+const (
+	ReadinessReady               = "ready"
+	ReadinessShuttingDown        = "shutting_down"
+	ReadinessDependencyUnhealthy = "dependency_unhealthy"
+)
+
+// ReadinessStatus represents the response of GET /health/ready
+type ReadinessStatus struct {
+	Ready     bool     `json:"ready"`
+	Reason    string   `json:"reason"`
+	Unhealthy []string `json:"unhealthy,omitempty"` // Dependencies failing their health check
+}
+
+// VersionInfo represents the response of GET /version
+type VersionInfo struct {
+	Version      string              `json:"version"`
+	Commit       string              `json:"commit"`
+	BuildTime    string              `json:"buildTime"`
+	GoVersion    string              `json:"goVersion"`
+	Capabilities ServiceCapabilities `json:"capabilities"`
+}
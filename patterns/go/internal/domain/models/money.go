@@ -0,0 +1,121 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Money is an amount in minor currency units (cents), so sums and products
+// are exact integer arithmetic. All supported currencies use two decimal places.
+//
+// Money encodes to JSON as a decimal number (12.34), decodes from JSON numbers
+// or strings without going through float64, and is stored in SQL Server
+// DECIMAL(18,2) columns as an exact decimal string.
+type Money int64
+
+// moneyScale is the number of minor units per major unit
+const moneyScale = 100
+
+// ParseMoney parses a decimal amount such as "12.34". Amounts with more than
+// two decimal places are rejected rather than silently rounded.
+func ParseMoney(s string) (Money, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("invalid money amount %q", s)
+	}
+	r.Mul(r, big.NewRat(moneyScale, 1))
+	if !r.IsInt() {
+		return 0, fmt.Errorf("money amount %q has more than 2 decimal places", s)
+	}
+	if !r.Num().IsInt64() {
+		return 0, fmt.Errorf("money amount %q is out of range", s)
+	}
+	return Money(r.Num().Int64()), nil
+}
+
+// MoneyFromFloat converts a float amount, rounding to the nearest cent.
+// Prefer ParseMoney for untrusted input.
+func MoneyFromFloat(f float64) Money {
+	return Money(math.Round(f * moneyScale))
+}
+
+// Convert returns the amount multiplied by an exchange rate, rounded to the nearest cent
+func (m Money) Convert(rate float64) Money {
+	return Money(math.Round(float64(m) * rate))
+}
+
+// Times returns the amount multiplied by quantity
+func (m Money) Times(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Float64 returns the amount in major units, for metrics and display only
+func (m Money) Float64() float64 {
+	return float64(m) / moneyScale
+}
+
+// String formats the amount with two decimal places, e.g. "-0.05"
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/moneyScale, cents%moneyScale)
+}
+
+// MarshalJSON encodes the amount as an exact decimal number
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a quoted decimal string
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	return m.scanText(text)
+}
+
+// Value implements driver.Valuer, passing the amount as an exact decimal string
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner for DECIMAL, integer and float columns
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		return m.scanText(string(v))
+	case string:
+		return m.scanText(v)
+	case int64:
+		*m = Money(v * moneyScale)
+		return nil
+	case float64:
+		*m = MoneyFromFloat(v)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+}
+
+func (m *Money) scanText(text string) error {
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
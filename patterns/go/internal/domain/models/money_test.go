@@ -0,0 +1,131 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func mustParseMoney(t *testing.T, s string) Money {
+	t.Helper()
+	m, err := ParseMoney(s)
+	if err != nil {
+		t.Fatalf("ParseMoney(%q) error = %v", s, err)
+	}
+	return m
+}
+
+func TestMoney_ExactSums(t *testing.T) {
+	if got, want := mustParseMoney(t, "0.1")+mustParseMoney(t, "0.2"), mustParseMoney(t, "0.3"); got != want {
+		t.Errorf("0.1 + 0.2 = %v, want %v", got, want)
+	}
+
+	var total Money
+	for i := 0; i < 1000; i++ {
+		total += mustParseMoney(t, "0.01")
+	}
+	if total.String() != "10.00" {
+		t.Errorf("1000 × 0.01 = %v, want 10.00", total)
+	}
+
+	order := NewOrder(uuid.New(), "1 Main St", []OrderItem{
+		NewOrderItem(uuid.New(), "a", 3, mustParseMoney(t, "0.10")),
+		NewOrderItem(uuid.New(), "b", 1, mustParseMoney(t, "0.20")),
+	})
+	if order.TotalAmount.String() != "0.50" {
+		t.Errorf("TotalAmount = %v, want 0.50", order.TotalAmount)
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "12.34", want: "12.34"},
+		{in: "12", want: "12.00"},
+		{in: "-0.05", want: "-0.05"},
+		{in: "1.50", want: "1.50"},
+		{in: "1e2", want: "100.00"},
+		{in: "1.005", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMoney(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMoney(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParseMoney(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_JSONRoundTrip(t *testing.T) {
+	order := NewOrder(uuid.New(), "1 Main St", []OrderItem{
+		NewOrderItem(uuid.New(), "a", 1, mustParseMoney(t, "0.10")),
+		NewOrderItem(uuid.New(), "b", 1, mustParseMoney(t, "0.20")),
+	})
+	event := NewOrderCreatedEvent(order, "test")
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"totalAmount":0.30`) {
+		t.Errorf("event JSON = %s, want totalAmount 0.30", data)
+	}
+
+	var decoded OrderEvent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if decoded.TotalAmount != order.TotalAmount {
+		t.Errorf("decoded TotalAmount = %v, want %v", decoded.TotalAmount, order.TotalAmount)
+	}
+
+	// Clients may send amounts as numbers or strings
+	var input CreateOrderItemInput
+	if err := json.Unmarshal([]byte(`{"unitPrice":"19.99"}`), &input); err != nil || input.UnitPrice.String() != "19.99" {
+		t.Errorf("unitPrice string = %v, %v, want 19.99", input.UnitPrice, err)
+	}
+	if err := json.Unmarshal([]byte(`{"unitPrice":0.005}`), &input); err == nil {
+		t.Errorf("unitPrice 0.005 error = nil, want sub-cent amounts rejected")
+	}
+}
+
+func TestMoney_SQLRoundTrip(t *testing.T) {
+	want := mustParseMoney(t, "1234567.89")
+
+	value, err := want.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{name: "valuer output", src: value},
+		{name: "decimal column bytes", src: []byte("1234567.89")},
+		{name: "float column", src: 1234567.89},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("Scan(%v) error = %v", tt.src, err)
+			}
+			if got != want {
+				t.Errorf("Scan(%v) = %v, want %v", tt.src, got, want)
+			}
+		})
+	}
+}
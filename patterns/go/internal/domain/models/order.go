@@ -17,13 +17,32 @@ const (
 	OrderStatusCancelled  OrderStatus = "cancelled"
 )
 
-// Order entity for SQL Server storage - demonstrates transactional data
+// orderTransitions is the order lifecycle state machine: the statuses each
+// status may move to. Orders can be cancelled until they ship; delivered and
+// cancelled are final.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:    {OrderStatusProcessing, OrderStatusCancelled},
+	OrderStatusProcessing: {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped:    {OrderStatusDelivered},
+	OrderStatusDelivered:  {},
+	OrderStatusCancelled:  {},
+}
+
+// IsValid reports whether s is a known order status
+func (s OrderStatus) IsValid() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+// Order entity for SQL Server storage - demonstrates transactional data.
+// The Orders row is a projection of the order's current state; the full
+// lifecycle is recorded in the append-only order_events table (see OrderHistoryEntry).
 type Order struct {
 	ID              uuid.UUID   `json:"id"`
 	CustomerID      uuid.UUID   `json:"customerId"`
 	OrderDate       time.Time   `json:"orderDate"`
 	Status          OrderStatus `json:"status"`
-	TotalAmount     float64     `json:"totalAmount"`
+	TotalAmount     Money       `json:"totalAmount"`
 	Currency        string      `json:"currency"`
 	ShippingAddress string      `json:"shippingAddress"`
 	Items           []OrderItem `json:"items"`
@@ -37,7 +56,7 @@ type OrderItem struct {
 	ProductID   uuid.UUID `json:"productId"`
 	ProductName string    `json:"productName"`
 	Quantity    int       `json:"quantity"`
-	Price       float64   `json:"price"`
+	Price       Money     `json:"price"`
 }
 
 // NewOrder creates a new order with calculated total
@@ -69,11 +88,11 @@ func NewOrder(customerID uuid.UUID, shippingAddress string, items []OrderItem) *
 	return order
 }
 
-// CalculateTotal calculates the total amount from items
-func (o *Order) CalculateTotal() float64 {
-	var total float64
+// CalculateTotal calculates the total amount from items in exact cents
+func (o *Order) CalculateTotal() Money {
+	var total Money
 	for _, item := range o.Items {
-		total += float64(item.Quantity) * item.Price
+		total += item.GetTotal()
 	}
 	return total
 }
@@ -93,18 +112,12 @@ func (o *Order) UpdateStatus(newStatus OrderStatus) error {
 
 // CanTransitionTo checks if a status transition is valid
 func (o *Order) CanTransitionTo(newStatus OrderStatus) bool {
-	switch o.Status {
-	case OrderStatusPending:
-		return newStatus == OrderStatusProcessing || newStatus == OrderStatusCancelled
-	case OrderStatusProcessing:
-		return newStatus == OrderStatusShipped || newStatus == OrderStatusCancelled
-	case OrderStatusShipped:
-		return newStatus == OrderStatusDelivered
-	case OrderStatusDelivered, OrderStatusCancelled:
-		return false
-	default:
-		return false
+	for _, next := range orderTransitions[o.Status] {
+		if next == newStatus {
+			return true
+		}
 	}
+	return false
 }
 
 // InvalidStatusTransitionError represents an invalid status transition
@@ -118,12 +131,12 @@ func (e *InvalidStatusTransitionError) Error() string {
 }
 
 // GetTotal returns the item total (quantity × price)
-func (i *OrderItem) GetTotal() float64 {
-	return float64(i.Quantity) * i.Price
+func (i *OrderItem) GetTotal() Money {
+	return i.Price.Times(i.Quantity)
 }
 
 // NewOrderItem creates a new order item
-func NewOrderItem(productID uuid.UUID, productName string, quantity int, price float64) OrderItem {
+func NewOrderItem(productID uuid.UUID, productName string, quantity int, price Money) OrderItem {
 	return OrderItem{
 		ID:          uuid.New(),
 		ProductID:   productID,
@@ -133,21 +146,115 @@ func NewOrderItem(productID uuid.UUID, productName string, quantity int, price f
 	}
 }
 
-// CreateOrderRequest represents the request to create an order
+// CreateOrderRequest represents the request to create an order.
+// IdempotencyKey comes from the Idempotency-Key header; retries that reuse
+// it get the original order back instead of a duplicate.
 type CreateOrderRequest struct {
 	CustomerID      uuid.UUID              `json:"customerId"`
 	ShippingAddress string                 `json:"shippingAddress"`
 	Items           []CreateOrderItemInput `json:"items"`
+	IdempotencyKey  string                 `json:"-"`
 }
 
-// CreateOrderItemInput represents input for creating an order item
+// CreateOrderItemInput represents input for creating an order item.
+// Items with a ProductID reserve stock from that product's inventory.
 type CreateOrderItemInput struct {
-	ProductName string  `json:"productName"`
-	Quantity    int     `json:"quantity"`
-	UnitPrice   float64 `json:"unitPrice"`
+	ProductID   uuid.UUID `json:"productId,omitempty"`
+	ProductName string    `json:"productName"`
+	Quantity    int       `json:"quantity"`
+	UnitPrice   Money     `json:"unitPrice"`
+}
+
+// MaxBatchGetOrderIDs caps the number of orders fetched by a single batch-get request
+const MaxBatchGetOrderIDs = 100
+
+// BatchGetOrdersRequest represents the request to fetch multiple orders by ID.
+// Orders are scoped to CustomerID; IDs belonging to other customers are reported as not found.
+type BatchGetOrdersRequest struct {
+	CustomerID uuid.UUID   `json:"customerId"`
+	IDs        []uuid.UUID `json:"ids"`
+}
+
+// BatchGetOrdersResponse contains the orders found and the IDs that were not
+type BatchGetOrdersResponse struct {
+	Orders   []*Order    `json:"orders"`
+	NotFound []uuid.UUID `json:"notFound"`
+}
+
+// Order listing limits
+const (
+	DefaultOrderPageSize = 50
+	MaxOrderPageSize     = 200
+)
+
+// OrderFilter filters and pages an order listing; zero fields match all.
+// CreatedAfter is inclusive and CreatedBefore exclusive.
+type OrderFilter struct {
+	CustomerID    uuid.UUID
+	Status        OrderStatus
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Limit         int
+	Offset        int
+}
+
+// WithDefaults returns f with its paging clamped to the listing limits
+func (f OrderFilter) WithDefaults() OrderFilter {
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+	if f.Limit <= 0 {
+		f.Limit = DefaultOrderPageSize
+	}
+	if f.Limit > MaxOrderPageSize {
+		f.Limit = MaxOrderPageSize
+	}
+	return f
+}
+
+// OrderPage is one page of an order listing and the number of orders matching
+// the filter across all pages
+type OrderPage struct {
+	Orders []*Order `json:"orders"`
+	Total  int      `json:"total"`
+	Offset int      `json:"offset"`
+	Limit  int      `json:"limit"`
 }
 
 // UpdateOrderStatusRequest represents the request to update order status
 type UpdateOrderStatusRequest struct {
 	Status OrderStatus `json:"status"`
+	Actor  string      `json:"actor,omitempty"`
+	Reason string      `json:"reason,omitempty"`
+}
+
+// DefaultOrderActor is recorded when a state transition does not name an actor
+const DefaultOrderActor = "system"
+
+// OrderHistoryEntry is a single state transition stored in the append-only
+// order_events table. FromStatus is empty for the creation entry.
+type OrderHistoryEntry struct {
+	ID         uuid.UUID   `json:"id"`
+	OrderID    uuid.UUID   `json:"orderId"`
+	FromStatus OrderStatus `json:"fromStatus,omitempty"`
+	ToStatus   OrderStatus `json:"toStatus"`
+	Actor      string      `json:"actor"`
+	Reason     string      `json:"reason,omitempty"`
+	OccurredAt time.Time   `json:"occurredAt"`
+}
+
+// NewOrderHistoryEntry creates a history entry for a transition of orderID
+func NewOrderHistoryEntry(orderID uuid.UUID, from, to OrderStatus, actor, reason string) OrderHistoryEntry {
+	if actor == "" {
+		actor = DefaultOrderActor
+	}
+	return OrderHistoryEntry{
+		ID:         uuid.New(),
+		OrderID:    orderID,
+		FromStatus: from,
+		ToStatus:   to,
+		Actor:      actor,
+		Reason:     reason,
+		OccurredAt: time.Now().UTC(),
+	}
 }
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductStatus represents the lifecycle status of a product
+type ProductStatus string
+
+const (
+	ProductStatusActive       ProductStatus = "active"
+	ProductStatusInactive     ProductStatus = "inactive"
+	ProductStatusDiscontinued ProductStatus = "discontinued"
+)
+
+// Product entity for SQL Server storage. StockQuantity is the inventory on hand;
+// CreateOrder decrements it in the same transaction that inserts the order.
+type Product struct {
+	ID            uuid.UUID     `json:"id"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description,omitempty"`
+	Price         Money         `json:"price"`
+	Category      string        `json:"category"`
+	StockQuantity int           `json:"stockQuantity"`
+	Status        ProductStatus `json:"status"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+}
+
+// IsValid reports whether s is a known product status
+func (s ProductStatus) IsValid() bool {
+	switch s {
+	case ProductStatusActive, ProductStatusInactive, ProductStatusDiscontinued:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo checks if a status transition is valid. Active and inactive
+// products can be toggled; discontinued is final.
+func (p *Product) CanTransitionTo(newStatus ProductStatus) bool {
+	if newStatus == p.Status {
+		return true
+	}
+	switch p.Status {
+	case ProductStatusActive:
+		return newStatus == ProductStatusInactive || newStatus == ProductStatusDiscontinued
+	case ProductStatusInactive:
+		return newStatus == ProductStatusActive || newStatus == ProductStatusDiscontinued
+	default:
+		return false
+	}
+}
+
+// CreateProductRequest represents the request to create a product.
+// Status defaults to active.
+type CreateProductRequest struct {
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	Price         Money         `json:"price"`
+	Category      string        `json:"category"`
+	StockQuantity int           `json:"stockQuantity"`
+	Status        ProductStatus `json:"status,omitempty"`
+}
+
+// UpdateProductRequest represents a partial product update; nil fields are left unchanged
+type UpdateProductRequest struct {
+	Name          *string        `json:"name,omitempty"`
+	Description   *string        `json:"description,omitempty"`
+	Price         *Money         `json:"price,omitempty"`
+	Category      *string        `json:"category,omitempty"`
+	StockQuantity *int           `json:"stockQuantity,omitempty"`
+	Status        *ProductStatus `json:"status,omitempty"`
+}
+
+// Product listing limits
+const (
+	DefaultProductPageSize = 50
+	MaxProductPageSize     = 200
+)
+
+// ListProductsOptions filters and pages a product listing; empty filters match all
+type ListProductsOptions struct {
+	Category string
+	Status   ProductStatus
+	Offset   int
+	Limit    int
+}
+
+// ProductPage is one page of a product listing
+type ProductPage struct {
+	Products []*Product `json:"products"`
+	Offset   int        `json:"offset"`
+	Limit    int        `json:"limit"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Saga statuses
+const (
+	SagaRunning      = "running"
+	SagaCompleted    = "completed"
+	SagaCompensating = "compensating"
+	SagaCompensated  = "compensated"
+	SagaFailed       = "failed" // a compensation failed; needs a retry or manual repair
+)
+
+// Saga step statuses
+const (
+	SagaStepPending            = "pending"
+	SagaStepCompleted          = "completed"
+	SagaStepFailed             = "failed"
+	SagaStepCompensated        = "compensated"
+	SagaStepCompensationFailed = "compensation_failed"
+)
+
+// SagaStepState records the progress of one saga step
+type SagaStepState struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SagaRecord is the persisted state of a saga. It is saved after every step so
+// a saga interrupted by a crash can be resumed, or its compensation finished.
+type SagaRecord struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Status      string          `json:"status"`
+	Steps       []SagaStepState `json:"steps"`
+	Error       string          `json:"error,omitempty"`
+	StartedAt   time.Time       `json:"startedAt"`
+	CompletedAt *time.Time      `json:"completedAt,omitempty"`
+}
+
+// Step returns the named step, adding a pending one if it is not recorded yet
+func (r *SagaRecord) Step(name string) *SagaStepState {
+	for i := range r.Steps {
+		if r.Steps[i].Name == name {
+			return &r.Steps[i]
+		}
+	}
+	r.Steps = append(r.Steps, SagaStepState{Name: name, Status: SagaStepPending})
+	return &r.Steps[len(r.Steps)-1]
+}
@@ -6,10 +6,15 @@ import (
 	"github.com/google/uuid"
 )
 
-// DeviceTelemetry for ScyllaDB storage - demonstrates time-series data
+// DeviceTelemetry for ScyllaDB storage - demonstrates time-series data.
+// EventTime is when the reading was taken and IngestTime when it arrived; a
+// reading buffered on an offline device has an EventTime well before its
+// IngestTime. Timestamp is the event time, kept for existing clients.
 type DeviceTelemetry struct {
 	DeviceID      string            `json:"deviceId"`
 	Timestamp     time.Time         `json:"timestamp"`
+	EventTime     time.Time         `json:"eventTime"`  // When the reading was taken (device clock)
+	IngestTime    time.Time         `json:"ingestTime"` // When the server received it
 	Metric        string            `json:"metric"`
 	Value         float64           `json:"value"`
 	Unit          string            `json:"unit"`
@@ -24,9 +29,12 @@ type DeviceTelemetry struct {
 
 // NewDeviceTelemetry creates a new telemetry record
 func NewDeviceTelemetry(deviceID, metric string, value float64, unit string) *DeviceTelemetry {
+	now := time.Now().UTC()
 	return &DeviceTelemetry{
 		DeviceID:      deviceID,
-		Timestamp:     time.Now().UTC(),
+		Timestamp:     now,
+		EventTime:     now,
+		IngestTime:    now,
 		Metric:        metric,
 		Value:         value,
 		Unit:          unit,
@@ -84,6 +92,23 @@ type AggregatedTelemetry struct {
 	Unit        string        `json:"unit"`
 }
 
+// Telemetry stats granularities, from finest to coarsest
+const (
+	TelemetryGranularityRaw    = "raw"
+	TelemetryGranularityHourly = "hourly"
+	TelemetryGranularityDaily  = "daily"
+)
+
+// TelemetryStats is a device's telemetry summarized into windows. Granularity
+// reports whether the windows were computed from raw rows or read from rollups.
+type TelemetryStats struct {
+	DeviceID    string                 `json:"deviceId"`
+	Start       time.Time              `json:"start"`
+	End         time.Time              `json:"end"`
+	Granularity string                 `json:"granularity"`
+	Windows     []*AggregatedTelemetry `json:"windows"`
+}
+
 // Device represents device metadata
 type Device struct {
 	DeviceID        string            `json:"deviceId"`
@@ -118,10 +143,36 @@ func NewDevice(deviceID, deviceName, deviceType, location string) *Device {
 
 // RecordTelemetryRequest represents the request to record telemetry
 type RecordTelemetryRequest struct {
-	DeviceID string  `json:"deviceId"`
-	Metric   string  `json:"metric"`
-	Value    float64 `json:"value"`
-	Unit     string  `json:"unit"`
+	DeviceID  string     `json:"deviceId"`
+	Metric    string     `json:"metric"`
+	Value     float64    `json:"value"`
+	Unit      string     `json:"unit"`
+	Timestamp *time.Time `json:"timestamp,omitempty"` // Event time (device clock); ingest time when omitted
+}
+
+// MaxTelemetryBatchSize caps the readings accepted by one batch request
+const MaxTelemetryBatchSize = 1000
+
+// RecordTelemetryBatchRequest represents a batch of readings, typically
+// buffered by one device
+type RecordTelemetryBatchRequest struct {
+	Readings []RecordTelemetryRequest `json:"readings"`
+}
+
+// BatchResult reports the outcome of each record in a batch, in request order
+type BatchResult struct {
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// BatchItemResult is one record's outcome; Error (and Code, for registry
+// errors) are set when it failed
+type BatchItemResult struct {
+	Index         int    `json:"index"`
+	CorrelationID string `json:"correlationId,omitempty"`
+	Error         string `json:"error,omitempty"`
+	Code          string `json:"code,omitempty"`
 }
 
 // TelemetryQueryParams represents parameters for querying telemetry
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/keyvault"
 	"github.com/google/uuid"
 )
 
@@ -20,6 +21,7 @@ type UserProfile struct {
 	CreatedAt      time.Time              `json:"createdAt" bson:"createdAt"`
 	UpdatedAt      time.Time              `json:"updatedAt" bson:"updatedAt"`
 	LastLoginAt    *time.Time             `json:"lastLoginAt,omitempty" bson:"lastLoginAt,omitempty"`
+	DeletedAt      *time.Time             `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
 }
 
 // UserPreferences holds user preference settings
@@ -130,6 +132,11 @@ func (u *UserProfile) UpdateLastLogin() {
 	u.UpdatedAt = now
 }
 
+// IsDeleted reports whether the profile has been soft-deleted
+func (u *UserProfile) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
 // GetFullName returns the user's full name
 func (u *UserProfile) GetFullName() string {
 	return u.FirstName + " " + u.LastName
@@ -142,6 +149,55 @@ type CreateUserRequest struct {
 	LastName  string `json:"lastName"`
 }
 
+// UserDataExport is the GDPR data export bundle for a single user. It is streamed
+// field by field, so Orders and Sessions are never held in memory all at once.
+type UserDataExport struct {
+	UserID       uuid.UUID                  `json:"userId"`
+	ExportedAt   time.Time                  `json:"exportedAt"`
+	Profile      *UserProfile               `json:"profile"`
+	Orders       []Order                    `json:"orders"`
+	Sessions     []Session                  `json:"sessions"`
+	Integrations []keyvault.UserIntegration `json:"integrations"`     // Metadata only, never secret values
+	Errors       map[string]string          `json:"errors,omitempty"` // Section -> error for stores that failed mid-export
+}
+
+// Erasure statuses (records and steps)
+const (
+	ErasurePending    = "pending"
+	ErasureInProgress = "in_progress"
+	ErasureCompleted  = "completed"
+	ErasureFailed     = "failed"
+)
+
+// ErasureStep records the outcome of one store in a user erasure
+type ErasureStep struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// ErasureRecord is the compensation log for a GDPR erasure. It holds no PII, so
+// it is kept after erasure as evidence and lets a retry skip completed steps.
+type ErasureRecord struct {
+	UserID      uuid.UUID     `json:"userId"`
+	Status      string        `json:"status"`
+	Steps       []ErasureStep `json:"steps"`
+	StartedAt   time.Time     `json:"startedAt"`
+	CompletedAt *time.Time    `json:"completedAt,omitempty"`
+}
+
+// Step returns the named step, adding a pending one if it is not recorded yet
+func (r *ErasureRecord) Step(name string) *ErasureStep {
+	for i := range r.Steps {
+		if r.Steps[i].Name == name {
+			return &r.Steps[i]
+		}
+	}
+	r.Steps = append(r.Steps, ErasureStep{Name: name, Status: ErasurePending})
+	return &r.Steps[len(r.Steps)-1]
+}
+
 // UpdatePreferencesRequest represents the request to update user preferences
 type UpdatePreferencesRequest struct {
 	Preferences UserPreferences `json:"preferences"`
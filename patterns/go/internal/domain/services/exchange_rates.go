@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+)
+
+// ExchangeRateProvider returns how many units of currency `to` one unit of
+// currency `from` is worth. Implementations may call external rate services;
+// wrap them with NewCachedExchangeRates to bound the request rate.
+type ExchangeRateProvider interface {
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// StaticExchangeRates serves fixed rates expressed against a base currency:
+// Rates["EUR"] = 1.08 means one EUR is worth 1.08 units of Base
+type StaticExchangeRates struct {
+	Base  string
+	Rates map[string]float64
+}
+
+// Rate implements ExchangeRateProvider, crossing through Base when neither
+// currency is the base
+func (r StaticExchangeRates) Rate(ctx context.Context, from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	fromRate, err := r.toBase(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := r.toBase(to)
+	if err != nil {
+		return 0, err
+	}
+	return fromRate / toRate, nil
+}
+
+func (r StaticExchangeRates) toBase(currency string) (float64, error) {
+	if currency == r.Base {
+		return 1, nil
+	}
+	rate, ok := r.Rates[currency]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("%w: %s", errors.ErrExchangeRateNotFound, currency)
+	}
+	return rate, nil
+}
+
+// cachedExchangeRates caches rates from another provider for a TTL
+type cachedExchangeRates struct {
+	provider ExchangeRateProvider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	rates map[string]cachedRate
+}
+
+type cachedRate struct {
+	rate      float64
+	expiresAt time.Time
+}
+
+// NewCachedExchangeRates wraps provider so each currency pair is fetched at most once per ttl.
+// Failed lookups are not cached.
+func NewCachedExchangeRates(provider ExchangeRateProvider, ttl time.Duration) ExchangeRateProvider {
+	return &cachedExchangeRates{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		rates:    make(map[string]cachedRate),
+	}
+}
+
+func (c *cachedExchangeRates) Rate(ctx context.Context, from, to string) (float64, error) {
+	key := from + "/" + to
+
+	c.mu.Lock()
+	cached, ok := c.rates[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(cached.expiresAt) {
+		return cached.rate, nil
+	}
+
+	rate, err := c.provider.Rate(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.rates[key] = cachedRate{rate: rate, expiresAt: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return rate, nil
+}
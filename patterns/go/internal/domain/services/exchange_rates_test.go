@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+)
+
+// countingRates is a fake ExchangeRateProvider that counts lookups
+type countingRates struct {
+	rates StaticExchangeRates
+	calls int
+}
+
+func (c *countingRates) Rate(ctx context.Context, from, to string) (float64, error) {
+	c.calls++
+	return c.rates.Rate(ctx, from, to)
+}
+
+func TestGetSQLServerAnalytics_ConvertsToReportingCurrency(t *testing.T) {
+	rates := StaticExchangeRates{Base: "USD", Rates: map[string]float64{"EUR": 1.10, "GBP": 1.25}}
+
+	tests := []struct {
+		name            string
+		provider        ExchangeRateProvider
+		wantRevenue     string
+		wantAverage     string
+		wantUnconverted []string
+	}{
+		{
+			name:        "all currencies converted",
+			provider:    rates,
+			wantRevenue: "370.00", // 100 USD + 100 EUR × 1.10 + 128 GBP × 1.25
+			wantAverage: "61.67",  // 370 / 6 orders
+		},
+		{
+			name:            "missing rate is reported, not summed",
+			provider:        StaticExchangeRates{Base: "USD", Rates: map[string]float64{"EUR": 1.10}},
+			wantRevenue:     "210.00",
+			wantAverage:     "52.50",
+			wantUnconverted: []string{"GBP"},
+		},
+		{
+			name:            "no provider sums the reporting currency only",
+			wantRevenue:     "100.00",
+			wantAverage:     "50.00",
+			wantUnconverted: []string{"EUR", "GBP"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			svc := newTestService(nil, nil, nil)
+			svc.sqlDB = db
+			if tt.provider != nil {
+				svc.SetExchangeRates(tt.provider, "USD")
+			}
+
+			mock.ExpectQuery(`GROUP BY Currency`).WillReturnRows(sqlmock.NewRows([]string{"Currency", "total_orders", "total_revenue"}).
+				AddRow("USD", 2, []byte("100.00")).
+				AddRow("EUR", 2, []byte("100.00")).
+				AddRow("GBP", 2, []byte("128.00")))
+
+			analytics, err := svc.getSQLServerAnalytics(context.Background(), time.Now().Add(-time.Hour), time.Now())
+			if err != nil {
+				t.Fatalf("getSQLServerAnalytics() error = %v", err)
+			}
+
+			if analytics.ReportingCurrency != "USD" {
+				t.Errorf("ReportingCurrency = %v, want USD", analytics.ReportingCurrency)
+			}
+			if analytics.TotalOrders != 6 {
+				t.Errorf("TotalOrders = %v, want 6", analytics.TotalOrders)
+			}
+			if got := analytics.TotalRevenue.String(); got != tt.wantRevenue {
+				t.Errorf("TotalRevenue = %v, want %v", got, tt.wantRevenue)
+			}
+			if got := analytics.AverageOrderValue.String(); got != tt.wantAverage {
+				t.Errorf("AverageOrderValue = %v, want %v", got, tt.wantAverage)
+			}
+			if got := analytics.RevenueByCurrency["GBP"].String(); got != "128.00" {
+				t.Errorf("RevenueByCurrency[GBP] = %v, want 128.00", got)
+			}
+			if !reflect.DeepEqual(analytics.UnconvertedCurrencies, tt.wantUnconverted) {
+				t.Errorf("UnconvertedCurrencies = %v, want %v", analytics.UnconvertedCurrencies, tt.wantUnconverted)
+			}
+		})
+	}
+}
+
+func TestStaticExchangeRates_CrossRate(t *testing.T) {
+	rates := StaticExchangeRates{Base: "USD", Rates: map[string]float64{"EUR": 1.10, "GBP": 1.32}}
+
+	got, err := rates.Rate(context.Background(), "GBP", "EUR")
+	if err != nil {
+		t.Fatalf("Rate() error = %v", err)
+	}
+	if want := 1.2; got < want-1e-9 || got > want+1e-9 {
+		t.Errorf("Rate(GBP, EUR) = %v, want %v", got, want)
+	}
+
+	if _, err := rates.Rate(context.Background(), "JPY", "USD"); !goerrors.Is(err, errors.ErrExchangeRateNotFound) {
+		t.Errorf("Rate(JPY, USD) error = %v, want %v", err, errors.ErrExchangeRateNotFound)
+	}
+}
+
+func TestCachedExchangeRates_TTL(t *testing.T) {
+	fake := &countingRates{rates: StaticExchangeRates{Base: "USD", Rates: map[string]float64{"EUR": 1.10}}}
+	now := time.Now()
+	cached := NewCachedExchangeRates(fake, time.Minute).(*cachedExchangeRates)
+	cached.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := cached.Rate(context.Background(), "EUR", "USD"); err != nil {
+			t.Fatalf("Rate() error = %v", err)
+		}
+	}
+	if fake.calls != 1 {
+		t.Errorf("provider calls within TTL = %v, want 1", fake.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := cached.Rate(context.Background(), "EUR", "USD"); err != nil {
+		t.Fatalf("Rate() error = %v", err)
+	}
+	if fake.calls != 2 {
+		t.Errorf("provider calls after TTL = %v, want 2", fake.calls)
+	}
+
+	// Failures are not cached
+	for i := 0; i < 2; i++ {
+		_, _ = cached.Rate(context.Background(), "JPY", "USD")
+	}
+	if fake.calls != 4 {
+		t.Errorf("provider calls after failed lookups = %v, want 4", fake.calls)
+	}
+}
@@ -0,0 +1,459 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/keyvault"
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/redis"
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/scylladb"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/sli"
+	"go.uber.org/zap"
+)
+
+// newTestService builds a PatternsService backed by the given fakes (nil = not configured)
+func newTestService(scylla scylladb.Session, redis *fakeRedis, producer *fakeProducer) *PatternsService {
+	svc := NewPatternsService(nil, nil, "", nil, nil, nil, &logger.Logger{Logger: zap.NewNop()}, sli.NewPatternsSli("patterns-test"))
+	if scylla != nil {
+		svc.scyllaSession = scylla
+	}
+	if redis != nil {
+		svc.redisClient = redis
+		svc.sessions = NewRedisSessionStore(redis)
+	}
+	if producer != nil {
+		svc.kafkaProducer = producer
+	}
+	return svc
+}
+
+// fakeRedis is an in-memory redis.Client
+type fakeRedis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	sets    map[string]map[string]bool
+	lists   map[string][]string
+	zsets   map[string]map[string]float64
+	expires map[string]time.Duration
+	err     error
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{
+		values:  map[string]string{},
+		sets:    map[string]map[string]bool{},
+		lists:   map[string][]string{},
+		zsets:   map[string]map[string]float64{},
+		expires: map[string]time.Duration{},
+	}
+}
+
+func (f *fakeRedis) Get(ctx context.Context, key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.values[key], nil
+}
+
+func (f *fakeRedis) Set(ctx context.Context, key string, value interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	return f.store(key, value)
+}
+
+// store writes value the way the real client encodes it; f.mu must be held
+func (f *fakeRedis) store(key string, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		f.values[key] = v
+	case []byte:
+		f.values[key] = string(v)
+	default:
+		// Like the real client, complex values are stored as JSON
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		f.values[key] = string(data)
+	}
+	return nil
+}
+
+func (f *fakeRedis) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if err := f.Set(ctx, key, value); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if ttl > 0 {
+		f.expires[key] = ttl
+	} else {
+		delete(f.expires, key)
+	}
+	return nil
+}
+
+func (f *fakeRedis) SetIfExists(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	_, exists := f.values[key]
+	f.mu.Unlock()
+	if !exists {
+		return false, nil
+	}
+	return true, f.SetWithTTL(ctx, key, value, ttl)
+}
+
+func (f *fakeRedis) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return false, f.err
+	}
+	if _, exists := f.values[key]; exists {
+		return false, nil
+	}
+	if ttl > 0 {
+		f.expires[key] = ttl
+	}
+	return true, f.store(key, value)
+}
+
+func (f *fakeRedis) Del(ctx context.Context, keys ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	for _, key := range keys {
+		delete(f.values, key)
+		delete(f.sets, key)
+		delete(f.lists, key)
+		delete(f.zsets, key)
+	}
+	return nil
+}
+
+func (f *fakeRedis) SMembers(ctx context.Context, key string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	members := []string{}
+	for member := range f.sets[key] {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (f *fakeRedis) SAdd(ctx context.Context, key string, members ...interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	if f.sets[key] == nil {
+		f.sets[key] = map[string]bool{}
+	}
+	for _, member := range members {
+		if s, ok := member.(string); ok {
+			f.sets[key][s] = true
+		}
+	}
+	return nil
+}
+
+func (f *fakeRedis) SRem(ctx context.Context, key string, members ...interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	for _, member := range members {
+		if s, ok := member.(string); ok {
+			delete(f.sets[key], s)
+		}
+	}
+	return nil
+}
+
+func (f *fakeRedis) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	list := f.lists[key]
+	if stop < 0 || stop >= int64(len(list)) {
+		stop = int64(len(list)) - 1
+	}
+	if start > stop {
+		return []string{}, nil
+	}
+	return append([]string{}, list[start:stop+1]...), nil
+}
+
+func (f *fakeRedis) ZAdd(ctx context.Context, key string, members ...redis.ScoredMember) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	if f.zsets[key] == nil {
+		f.zsets[key] = map[string]float64{}
+	}
+	for _, m := range members {
+		f.zsets[key][m.Member] = m.Score
+	}
+	return nil
+}
+
+func (f *fakeRedis) ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return 0, f.err
+	}
+	if f.zsets[key] == nil {
+		f.zsets[key] = map[string]float64{}
+	}
+	f.zsets[key][member] += increment
+	return f.zsets[key][member], nil
+}
+
+// ranked returns a sorted set's members by descending score; callers hold f.mu
+func (f *fakeRedis) ranked(key string) []redis.ScoredMember {
+	members := make([]redis.ScoredMember, 0, len(f.zsets[key]))
+	for member, score := range f.zsets[key] {
+		members = append(members, redis.ScoredMember{Member: member, Score: score})
+	}
+	// Like Redis, ties are ordered by member descending
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Score != members[j].Score {
+			return members[i].Score > members[j].Score
+		}
+		return members[i].Member > members[j].Member
+	})
+	return members
+}
+
+func (f *fakeRedis) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.ScoredMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	members := f.ranked(key)
+	if stop < 0 || stop >= int64(len(members)) {
+		stop = int64(len(members)) - 1
+	}
+	if start > stop {
+		return []redis.ScoredMember{}, nil
+	}
+	return members[start : stop+1], nil
+}
+
+func (f *fakeRedis) ZRevRank(ctx context.Context, key string, member string) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return 0, f.err
+	}
+	for i, m := range f.ranked(key) {
+		if m.Member == member {
+			return int64(i), nil
+		}
+	}
+	return -1, nil
+}
+
+func (f *fakeRedis) Expire(ctx context.Context, key string, duration time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.expires[key] = duration
+	return nil
+}
+
+func (f *fakeRedis) Health(ctx context.Context) error { return f.err }
+func (f *fakeRedis) Close(ctx context.Context) error  { return nil }
+
+// fakeScylla is a scylladb.Session that records executed statements
+type fakeScylla struct {
+	mu          sync.Mutex
+	execs       []string
+	execArgs    [][]interface{}
+	execErr     error
+	execFn      func(query string, args []interface{}) error // per-statement error; overrides execErr
+	rowErr      error
+	rowValue    []interface{}
+	delay       time.Duration // QueryRow blocks this long, ignoring ctx (a slow store)
+	iterRows    [][]interface{}
+	iterQueries []string
+	iterArgs    [][]interface{}
+	rowQueries  []string
+	rowArgs     [][]interface{}
+}
+
+func (f *fakeScylla) QueryContext(ctx context.Context, query string, args ...interface{}) error {
+	return f.execErr
+}
+
+func (f *fakeScylla) ExecContext(ctx context.Context, query string, args ...interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.execs = append(f.execs, query)
+	f.execArgs = append(f.execArgs, args)
+	if f.execFn != nil {
+		return f.execFn(query, args)
+	}
+	return f.execErr
+}
+
+func (f *fakeScylla) QueryRow(ctx context.Context, query string, args ...interface{}) scylladb.Row {
+	time.Sleep(f.delay)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rowQueries = append(f.rowQueries, query)
+	f.rowArgs = append(f.rowArgs, args)
+	return fakeRow{values: f.rowValue, err: f.rowErr}
+}
+
+func (f *fakeScylla) QueryIter(ctx context.Context, query string, args ...interface{}) scylladb.Iterator {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.iterQueries = append(f.iterQueries, query)
+	f.iterArgs = append(f.iterArgs, args)
+	return &fakeIter{rows: f.iterRows}
+}
+
+func (f *fakeScylla) Health(ctx context.Context) error { return f.execErr }
+func (f *fakeScylla) Close(ctx context.Context) error  { return nil }
+
+type fakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, d := range dest {
+		if i >= len(r.values) {
+			break
+		}
+		if p, ok := d.(*int64); ok {
+			if v, ok := r.values[i].(int64); ok {
+				*p = v
+			}
+		}
+	}
+	return nil
+}
+
+// fakeIter yields rows, assigning each value to the matching Scan destination
+type fakeIter struct {
+	rows [][]interface{}
+}
+
+func (it *fakeIter) Scan(dest ...interface{}) bool {
+	if len(it.rows) == 0 {
+		return false
+	}
+	row := it.rows[0]
+	it.rows = it.rows[1:]
+	for i, d := range dest {
+		if i < len(row) {
+			reflect.ValueOf(d).Elem().Set(reflect.ValueOf(row[i]))
+		}
+	}
+	return true
+}
+
+func (it *fakeIter) Close() error { return nil }
+
+// fakeKeyVault is an in-memory keyvault.CachedClient; unimplemented methods panic
+type fakeKeyVault struct {
+	keyvault.CachedClient
+	mu        sync.Mutex
+	secrets   map[string]string
+	deleteErr error
+}
+
+func newFakeKeyVault() *fakeKeyVault {
+	return &fakeKeyVault{secrets: map[string]string{}}
+}
+
+func (f *fakeKeyVault) SetSecret(ctx context.Context, name, value string, tags map[string]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.secrets[name] = value
+	return nil
+}
+
+func (f *fakeKeyVault) DeleteSecret(ctx context.Context, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	if _, ok := f.secrets[name]; !ok {
+		return fmt.Errorf("keyvault returned status 404: secret %s not found", name)
+	}
+	delete(f.secrets, name)
+	return nil
+}
+
+func (f *fakeKeyVault) ListSecrets(ctx context.Context, prefix string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var names []string
+	for name := range f.secrets {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// fakeProducer is a kafka.Producer that records sent messages
+type fakeProducer struct {
+	mu       sync.Mutex
+	messages []sentMessage
+	err      error
+}
+
+type sentMessage struct {
+	topic   string
+	key     string
+	value   []byte
+	headers map[string]string
+}
+
+func (f *fakeProducer) SendMessage(ctx context.Context, topic, key string, value []byte, headers map[string]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.messages = append(f.messages, sentMessage{topic: topic, key: key, value: value, headers: headers})
+	return nil
+}
+
+func (f *fakeProducer) Close(ctx context.Context) error  { return nil }
+func (f *fakeProducer) Health(ctx context.Context) error { return f.err }
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+)
+
+// defaultLeaderboardWindows are maintained alongside the all-time board
+var defaultLeaderboardWindows = []models.LeaderboardWindow{
+	models.LeaderboardDaily,
+	models.LeaderboardWeekly,
+	models.LeaderboardMonthly,
+}
+
+// SetLeaderboardWindows configures which periodic leaderboards score updates
+// are written to. The all-time board is always maintained.
+func (s *PatternsService) SetLeaderboardWindows(windows ...models.LeaderboardWindow) {
+	s.leaderboardWindows = nil
+	for _, w := range windows {
+		if w != models.LeaderboardAllTime {
+			s.leaderboardWindows = append(s.leaderboardWindows, w)
+		}
+	}
+}
+
+func (s *PatternsService) leaderboardWindowEnabled(window models.LeaderboardWindow) bool {
+	if window == models.LeaderboardAllTime {
+		return true
+	}
+	for _, w := range s.leaderboardWindows {
+		if w == window {
+			return true
+		}
+	}
+	return false
+}
+
+// leaderboardKey returns the Redis set key for category's board in the window
+// containing t, and when that window ends (zero for all-time). Periodic keys
+// are suffixed with the period, e.g. leaderboard:chess:weekly:2026-W07.
+func leaderboardKey(category string, window models.LeaderboardWindow, t time.Time) (string, time.Time) {
+	base := fmt.Sprintf("leaderboard:%s", category)
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch window {
+	case models.LeaderboardDaily:
+		return fmt.Sprintf("%s:daily:%s", base, day.Format("2006-01-02")), day.AddDate(0, 0, 1)
+	case models.LeaderboardWeekly:
+		year, week := t.ISOWeek()
+		// ISO weeks start on Monday
+		monday := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return fmt.Sprintf("%s:weekly:%d-W%02d", base, year, week), monday.AddDate(0, 0, 7)
+	case models.LeaderboardMonthly:
+		month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return fmt.Sprintf("%s:monthly:%s", base, month.Format("2006-01")), month.AddDate(0, 1, 0)
+	default:
+		return base, time.Time{}
+	}
+}
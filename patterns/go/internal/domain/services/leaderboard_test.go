@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/eventbus"
+)
+
+// leaderboardScores reads the all-time leaderboard as user -> score
+func leaderboardScores(t *testing.T, svc *PatternsService, category string) map[string]float64 {
+	t.Helper()
+	return windowScores(t, svc, category, models.LeaderboardAllTime)
+}
+
+// windowScores reads the current period of a windowed leaderboard as user -> score
+func windowScores(t *testing.T, svc *PatternsService, category string, window models.LeaderboardWindow) map[string]float64 {
+	t.Helper()
+	entries, err := svc.GetLeaderboard(context.Background(), category, window, 100)
+	if err != nil {
+		t.Fatalf("GetLeaderboard(%s, %s) error = %v", category, window, err)
+	}
+	scores := make(map[string]float64, len(entries))
+	for _, e := range entries {
+		scores[e.UserID] = e.Score
+	}
+	return scores
+}
+
+// replay feeds recorded Kafka messages through a subscriber into svc
+func replay(t *testing.T, svc *PatternsService, messages []sentMessage) {
+	t.Helper()
+	sub := eventbus.NewSubscriber(svc.logger)
+	if err := eventbus.Subscribe(sub, svc.ApplyLeaderboardEvent); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	for _, m := range messages {
+		msg := &sarama.ConsumerMessage{Topic: m.topic, Key: []byte(m.key), Value: m.value}
+		if err := sub.Handle(context.Background(), msg); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+	}
+}
+
+func TestRebuildLeaderboardsFromEvents(t *testing.T) {
+	producer := &fakeProducer{}
+	live := newTestService(nil, newFakeRedis(), producer)
+
+	updates := []struct {
+		category string
+		user     string
+		score    float64
+	}{
+		{"chess", "alice", 10},
+		{"chess", "bob", 20},
+		{"go", "alice", 7},
+		{"chess", "alice", 35},
+		{"chess", "carol", 5},
+		{"chess", "bob", 15},
+	}
+	for _, u := range updates {
+		if err := live.UpdateLeaderboard(context.Background(), u.category, u.user, u.score); err != nil {
+			t.Fatalf("UpdateLeaderboard() error = %v", err)
+		}
+	}
+
+	for _, m := range producer.messages {
+		if m.topic != eventbus.TopicLeaderboards {
+			t.Fatalf("score event published to %s, want %s", m.topic, eventbus.TopicLeaderboards)
+		}
+	}
+
+	// Redis is flushed; rebuild from the topic, twice to prove idempotency
+	rebuilt := newTestService(nil, newFakeRedis(), nil)
+	replay(t, rebuilt, producer.messages)
+	replay(t, rebuilt, producer.messages)
+
+	for _, category := range []string{"chess", "go"} {
+		want := leaderboardScores(t, live, category)
+		if got := leaderboardScores(t, rebuilt, category); !reflect.DeepEqual(got, want) {
+			t.Errorf("rebuilt %s leaderboard = %v, want %v", category, got, want)
+		}
+	}
+	if got := leaderboardScores(t, rebuilt, "chess"); got["alice"] != 35 || got["bob"] != 15 {
+		t.Errorf("chess = %v, want latest scores alice 35, bob 15", got)
+	}
+}
+
+func TestApplyLeaderboardEvent_IgnoresStaleEvents(t *testing.T) {
+	svc := newTestService(nil, newFakeRedis(), nil)
+
+	newer := models.NewScoreUpdatedEvent("chess", "alice", 50, "test")
+	older := models.NewScoreUpdatedEvent("chess", "alice", 10, "test")
+	older.Timestamp = newer.Timestamp.Add(-time.Minute)
+
+	for _, event := range []*models.LeaderboardEvent{newer, older} {
+		if err := svc.ApplyLeaderboardEvent(context.Background(), event); err != nil {
+			t.Fatalf("ApplyLeaderboardEvent() error = %v", err)
+		}
+	}
+
+	if got := leaderboardScores(t, svc, "chess")["alice"]; got != 50 {
+		t.Errorf("alice = %v, want 50 (stale event ignored)", got)
+	}
+}
+
+// scoreAt applies a score event stamped at ts
+func scoreAt(t *testing.T, svc *PatternsService, user string, score float64, ts time.Time) {
+	t.Helper()
+	event := models.NewScoreUpdatedEvent("chess", user, score, "test")
+	event.Timestamp = ts
+	if err := svc.ApplyLeaderboardEvent(context.Background(), event); err != nil {
+		t.Fatalf("ApplyLeaderboardEvent() error = %v", err)
+	}
+}
+
+func TestLeaderboardWindows_IsolateScores(t *testing.T) {
+	svc := newTestService(nil, newFakeRedis(), nil)
+	// Wednesday; the ISO week started Monday 2026-02-09
+	now := time.Date(2026, 2, 11, 15, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	scoreAt(t, svc, "alice", 10, now.AddDate(0, 0, -9)) // earlier this month, last week
+	scoreAt(t, svc, "bob", 20, now.AddDate(0, 0, -2))   // this week, not today
+	scoreAt(t, svc, "carol", 30, now.Add(-time.Hour))   // today
+
+	tests := []struct {
+		window models.LeaderboardWindow
+		want   map[string]float64
+	}{
+		{models.LeaderboardAllTime, map[string]float64{"alice": 10, "bob": 20, "carol": 30}},
+		{models.LeaderboardMonthly, map[string]float64{"alice": 10, "bob": 20, "carol": 30}},
+		{models.LeaderboardWeekly, map[string]float64{"bob": 20, "carol": 30}},
+		{models.LeaderboardDaily, map[string]float64{"carol": 30}},
+	}
+	for _, tt := range tests {
+		if got := windowScores(t, svc, "chess", tt.window); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestLeaderboardWindows_Rollover(t *testing.T) {
+	redis := newFakeRedis()
+	svc := newTestService(nil, redis, nil)
+	now := time.Date(2026, 2, 11, 23, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	scoreAt(t, svc, "alice", 10, now)
+
+	// Today's board expires at midnight; the all-time board never does
+	dailyKey, _ := leaderboardKey("chess", models.LeaderboardDaily, now)
+	if got := redis.expires[dailyKey]; got != time.Hour {
+		t.Errorf("daily TTL = %v, want 1h until midnight", got)
+	}
+	if _, ok := redis.expires["leaderboard:chess"]; ok {
+		t.Error("all-time leaderboard has a TTL, want none")
+	}
+
+	// Past midnight the daily board starts empty while the others carry on
+	now = now.Add(2 * time.Hour)
+	scoreAt(t, svc, "bob", 5, now)
+
+	if got := windowScores(t, svc, "chess", models.LeaderboardDaily); !reflect.DeepEqual(got, map[string]float64{"bob": 5}) {
+		t.Errorf("daily after rollover = %v, want only bob", got)
+	}
+	if got := windowScores(t, svc, "chess", models.LeaderboardWeekly); len(got) != 2 {
+		t.Errorf("weekly after rollover = %v, want alice and bob", got)
+	}
+
+	// A late event for yesterday only reaches boards whose period is still open
+	scoreAt(t, svc, "carol", 50, now.Add(-3*time.Hour))
+	yesterday, _ := leaderboardKey("chess", models.LeaderboardDaily, now.Add(-3*time.Hour))
+	if _, ok := redis.zsets[yesterday]["carol"]; ok {
+		t.Error("late event written to an expired daily board")
+	}
+	if got := leaderboardScores(t, svc, "chess")["carol"]; got != 50 {
+		t.Errorf("all-time carol = %v, want 50", got)
+	}
+}
+
+func TestGetLeaderboard_RejectsUnknownOrDisabledWindow(t *testing.T) {
+	svc := newTestService(nil, newFakeRedis(), nil)
+	svc.SetLeaderboardWindows(models.LeaderboardDaily)
+
+	if _, err := svc.GetLeaderboard(context.Background(), "chess", "yearly", 10); !goerrors.Is(err, errors.ErrInvalidLeaderboardWindow) {
+		t.Errorf("yearly error = %v, want ErrInvalidLeaderboardWindow", err)
+	}
+	if _, err := svc.GetLeaderboard(context.Background(), "chess", models.LeaderboardWeekly, 10); !goerrors.Is(err, errors.ErrLeaderboardWindowOff) {
+		t.Errorf("weekly error = %v, want ErrLeaderboardWindowOff", err)
+	}
+	if _, err := svc.GetLeaderboard(context.Background(), "chess", "", 10); err != nil {
+		t.Errorf("default window error = %v, want nil", err)
+	}
+}
+
+func TestGetLeaderboard_SortedByScoreWithRanks(t *testing.T) {
+	svc := newTestService(nil, newFakeRedis(), nil)
+
+	// Written out of order; bob's second update replaces rather than adds
+	for _, u := range []struct {
+		user  string
+		score float64
+	}{{"carol", 30}, {"alice", 50}, {"dave", 5}, {"bob", 10}, {"bob", 40}} {
+		if err := svc.UpdateLeaderboard(context.Background(), "chess", u.user, u.score); err != nil {
+			t.Fatalf("UpdateLeaderboard(%s) error = %v", u.user, err)
+		}
+	}
+
+	got, err := svc.GetLeaderboard(context.Background(), "chess", models.LeaderboardAllTime, 3)
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	want := []models.LeaderboardEntry{
+		{UserID: "alice", Score: 50, Rank: 1},
+		{UserID: "bob", Score: 40, Rank: 2},
+		{UserID: "carol", Score: 30, Rank: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetLeaderboard() = %+v, want %+v", got, want)
+	}
+}
+
+func TestIncrementLeaderboard_ConcurrentIncrementsAreNotLost(t *testing.T) {
+	producer := &fakeProducer{}
+	svc := newTestService(nil, newFakeRedis(), producer)
+
+	const workers, perWorker = 8, 50
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				if _, err := svc.IncrementLeaderboard(context.Background(), "chess", "alice", 1.5); err != nil {
+					t.Errorf("IncrementLeaderboard() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := 1.5 * workers * perWorker
+	for _, window := range []models.LeaderboardWindow{models.LeaderboardAllTime, models.LeaderboardDaily} {
+		if got := windowScores(t, svc, "chess", window)["alice"]; got != want {
+			t.Errorf("%s alice = %v, want %v", window, got, want)
+		}
+	}
+
+	// Replaying the increments rebuilds the same total, and replaying again adds nothing
+	rebuilt := newTestService(nil, newFakeRedis(), nil)
+	replay(t, rebuilt, producer.messages)
+	replay(t, rebuilt, producer.messages)
+	if got := leaderboardScores(t, rebuilt, "chess")["alice"]; got != want {
+		t.Errorf("rebuilt alice = %v, want %v", got, want)
+	}
+}
+
+func TestIncrementLeaderboard_AddsToAbsoluteScore(t *testing.T) {
+	svc := newTestService(nil, newFakeRedis(), nil)
+
+	if err := svc.UpdateLeaderboard(context.Background(), "chess", "alice", 100); err != nil {
+		t.Fatalf("UpdateLeaderboard() error = %v", err)
+	}
+	score, err := svc.IncrementLeaderboard(context.Background(), "chess", "alice", -25)
+	if err != nil {
+		t.Fatalf("IncrementLeaderboard() error = %v", err)
+	}
+	if score != 75 {
+		t.Errorf("IncrementLeaderboard() = %v, want 75", score)
+	}
+}
+
+func TestGetLeaderboardAround(t *testing.T) {
+	svc := newTestService(nil, newFakeRedis(), nil)
+	// Ranks: u1 (100) ... u10 (10)
+	for i := 1; i <= 10; i++ {
+		if err := svc.UpdateLeaderboard(context.Background(), "chess", fmt.Sprintf("u%d", i), float64(110-10*i)); err != nil {
+			t.Fatalf("UpdateLeaderboard() error = %v", err)
+		}
+	}
+
+	users := func(entries []models.LeaderboardEntry) []string {
+		ids := make([]string, len(entries))
+		for i, e := range entries {
+			ids[i] = fmt.Sprintf("%d:%s", e.Rank, e.UserID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name   string
+		user   string
+		radius int
+		rank   int
+		want   []string
+	}{
+		{"mid-pack", "u5", 2, 5, []string{"3:u3", "4:u4", "5:u5", "6:u6", "7:u7"}},
+		{"near the top", "u2", 3, 2, []string{"1:u1", "2:u2", "3:u3", "4:u4", "5:u5"}},
+		{"last place", "u10", 1, 10, []string{"9:u9", "10:u10"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.GetLeaderboardAround(context.Background(), "chess", tt.user, tt.radius)
+			if err != nil {
+				t.Fatalf("GetLeaderboardAround() error = %v", err)
+			}
+			if !got.OnBoard || got.Rank != tt.rank {
+				t.Errorf("onBoard = %v rank = %d, want true, %d", got.OnBoard, got.Rank, tt.rank)
+			}
+			if ids := users(got.Entries); !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("entries = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+
+	t.Run("absent user", func(t *testing.T) {
+		got, err := svc.GetLeaderboardAround(context.Background(), "chess", "nobody", 2)
+		if err != nil {
+			t.Fatalf("GetLeaderboardAround() error = %v", err)
+		}
+		if got.OnBoard || got.Rank != 0 || len(got.Entries) != 0 {
+			t.Errorf("got %+v, want not on board with no entries", got)
+		}
+	})
+}
@@ -0,0 +1,851 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/sli"
+	"github.com/google/uuid"
+)
+
+var (
+	orderColumns      = []string{"Id", "CustomerID", "TotalAmount", "Currency", "Status", "ShippingAddress", "CreatedAt", "UpdatedAt"}
+	orderEventColumns = []string{"Id", "OrderID", "FromStatus", "ToStatus", "Actor", "Reason", "OccurredAt"}
+)
+
+// capturedEvent records the arguments of an order_events insert so they can
+// be replayed as rows when the history is read back
+type capturedEvent struct {
+	values []driver.Value
+}
+
+// captureArg is a sqlmock.Argument that stores the matched value in *dst
+type captureArg struct {
+	dst *driver.Value
+}
+
+func (a captureArg) Match(v driver.Value) bool {
+	*a.dst = v
+	return true
+}
+
+// expectOrderEventInsert expects an order_events insert and captures its arguments
+func expectOrderEventInsert(mock sqlmock.Sqlmock) *capturedEvent {
+	event := &capturedEvent{values: make([]driver.Value, len(orderEventColumns))}
+	args := make([]driver.Value, len(event.values))
+	for i := range event.values {
+		args[i] = captureArg{dst: &event.values[i]}
+	}
+	mock.ExpectExec(`INSERT INTO order_events`).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+	return event
+}
+
+func TestOrderHistory_CreateConfirmShip(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	svc := newTestService(nil, nil, nil)
+	svc.sqlDB = db
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	// create
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO Orders`).WillReturnResult(sqlmock.NewResult(0, 1))
+	created := expectOrderEventInsert(mock)
+	mock.ExpectCommit()
+
+	order, err := svc.CreateOrder(ctx, &models.CreateOrderRequest{
+		CustomerID:      customerID,
+		ShippingAddress: "1 Main St",
+		Items:           []models.CreateOrderItemInput{{ProductName: "widget", Quantity: 2, UnitPrice: 5}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	// confirm (pending -> processing), then ship (processing -> shipped)
+	transition := func(from, to models.OrderStatus, actor, reason string) *capturedEvent {
+		mock.ExpectQuery(`FROM Orders`).
+			WillReturnRows(sqlmock.NewRows(orderColumns).
+				AddRow(order.ID, customerID, order.TotalAmount, "USD", string(from), "1 Main St", order.CreatedAt, order.UpdatedAt))
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE Orders SET Status`).WillReturnResult(sqlmock.NewResult(0, 1))
+		event := expectOrderEventInsert(mock)
+		mock.ExpectCommit()
+
+		if _, err := svc.UpdateOrderStatus(ctx, order.ID, to, actor, reason); err != nil {
+			t.Fatalf("UpdateOrderStatus(%s) error = %v", to, err)
+		}
+		return event
+	}
+	confirmed := transition(models.OrderStatusPending, models.OrderStatusProcessing, "ops@example.com", "payment captured")
+	shipped := transition(models.OrderStatusProcessing, models.OrderStatusShipped, "", "")
+
+	// read back what was written
+	rows := sqlmock.NewRows(orderEventColumns)
+	for _, event := range []*capturedEvent{created, confirmed, shipped} {
+		rows.AddRow(event.values...)
+	}
+	mock.ExpectQuery(`FROM order_events`).WillReturnRows(rows)
+
+	history, err := svc.GetOrderHistory(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetOrderHistory() error = %v", err)
+	}
+
+	want := []struct {
+		from, to      models.OrderStatus
+		actor, reason string
+	}{
+		{"", models.OrderStatusPending, customerID.String(), "order created"},
+		{models.OrderStatusPending, models.OrderStatusProcessing, "ops@example.com", "payment captured"},
+		{models.OrderStatusProcessing, models.OrderStatusShipped, models.DefaultOrderActor, ""},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("len(history) = %v, want %v", len(history), len(want))
+	}
+	for i, w := range want {
+		got := history[i]
+		if got.OrderID != order.ID {
+			t.Errorf("history[%d].OrderID = %v, want %v", i, got.OrderID, order.ID)
+		}
+		if got.FromStatus != w.from || got.ToStatus != w.to {
+			t.Errorf("history[%d] = %s -> %s, want %s -> %s", i, got.FromStatus, got.ToStatus, w.from, w.to)
+		}
+		if got.Actor != w.actor {
+			t.Errorf("history[%d].Actor = %v, want %v", i, got.Actor, w.actor)
+		}
+		if got.Reason != w.reason {
+			t.Errorf("history[%d].Reason = %v, want %v", i, got.Reason, w.reason)
+		}
+		if i > 0 && got.OccurredAt.Before(history[i-1].OccurredAt) {
+			t.Errorf("history[%d].OccurredAt = %v, before previous %v", i, got.OccurredAt, history[i-1].OccurredAt)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestListOrders(t *testing.T) {
+	customerID := uuid.New()
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := after.AddDate(0, 1, 0)
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		filter    models.OrderFilter
+		where     string
+		whereArgs []driver.Value
+		offset    int
+		limit     int
+	}{
+		{
+			name:   "no filters uses default page",
+			filter: models.OrderFilter{},
+			where:  `FROM Orders\s+ORDER BY`,
+			limit:  models.DefaultOrderPageSize,
+		},
+		{
+			name: "all filters",
+			filter: models.OrderFilter{
+				CustomerID:    customerID,
+				Status:        models.OrderStatusShipped,
+				CreatedAfter:  after,
+				CreatedBefore: before,
+				Offset:        20,
+				Limit:         500,
+			},
+			where: `WHERE CustomerID = @customer AND Status = @status AND CreatedAt >= @after AND CreatedAt < @before`,
+			whereArgs: []driver.Value{
+				sql.Named("customer", customerID),
+				sql.Named("status", "shipped"),
+				sql.Named("after", after),
+				sql.Named("before", before),
+			},
+			offset: 20,
+			limit:  models.MaxOrderPageSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			svc := newTestService(nil, nil, nil)
+			svc.sqlDB = db
+
+			mock.ExpectQuery(`SELECT COUNT\(\*\) FROM Orders`).WithArgs(tt.whereArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+			pageArgs := append(tt.whereArgs, sql.Named("offset", tt.offset), sql.Named("limit", tt.limit))
+			mock.ExpectQuery(tt.where).WithArgs(pageArgs...).
+				WillReturnRows(sqlmock.NewRows(orderColumns).
+					AddRow(uuid.New(), customerID, 10.0, "USD", "shipped", "1 Main St", now, now).
+					AddRow(uuid.New(), customerID, 20.0, "USD", "shipped", "1 Main St", now, now))
+
+			orders, total, err := svc.ListOrders(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("ListOrders() error = %v", err)
+			}
+			if total != 42 {
+				t.Errorf("total = %v, want 42", total)
+			}
+			if len(orders) != 2 || orders[0].Status != models.OrderStatusShipped {
+				t.Errorf("orders = %+v, want 2 shipped orders", orders)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestListOrders_UnknownStatus(t *testing.T) {
+	svc := newTestService(nil, nil, nil)
+
+	// Rejected before any query, so no database is needed
+	_, _, err := svc.ListOrders(context.Background(), models.OrderFilter{Status: "shipped' OR 1=1 --"})
+	if !errors.HasCode(err, "PAT-VAL-001") {
+		t.Errorf("ListOrders() error = %v, want code PAT-VAL-001", err)
+	}
+}
+
+func TestUpdateOrderStatus_RollsBackWhenEventInsertFails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	svc := newTestService(nil, nil, nil)
+	svc.sqlDB = db
+	id := uuid.New()
+	now := time.Now()
+	insertErr := goerrors.New("insert failed")
+
+	mock.ExpectQuery(`FROM Orders`).
+		WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(id, uuid.New(), 10.0, "USD", "pending", "1 Main St", now, now))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE Orders SET Status`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO order_events`).WillReturnError(insertErr)
+	mock.ExpectRollback()
+
+	if _, err := svc.UpdateOrderStatus(context.Background(), id, models.OrderStatusProcessing, "ops", ""); !goerrors.Is(err, insertErr) {
+		t.Errorf("UpdateOrderStatus() error = %v, want %v", err, insertErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateOrderStatus_StateMachine(t *testing.T) {
+	statuses := []models.OrderStatus{
+		models.OrderStatusPending, models.OrderStatusProcessing, models.OrderStatusShipped,
+		models.OrderStatusDelivered, models.OrderStatusCancelled,
+	}
+	legal := map[models.OrderStatus][]models.OrderStatus{
+		models.OrderStatusPending:    {models.OrderStatusProcessing, models.OrderStatusCancelled},
+		models.OrderStatusProcessing: {models.OrderStatusShipped, models.OrderStatusCancelled},
+		models.OrderStatusShipped:    {models.OrderStatusDelivered},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			allowed := false
+			for _, next := range legal[from] {
+				allowed = allowed || next == to
+			}
+
+			t.Run(fmt.Sprintf("%s to %s", from, to), func(t *testing.T) {
+				db, mock, err := sqlmock.New()
+				if err != nil {
+					t.Fatalf("sqlmock.New() error = %v", err)
+				}
+				defer db.Close()
+
+				svc := newTestService(nil, nil, nil)
+				svc.sqlDB = db
+				id := uuid.New()
+				now := time.Now()
+
+				mock.ExpectQuery(`FROM Orders`).
+					WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(id, uuid.New(), 10.0, "USD", string(from), "1 Main St", now, now))
+				if allowed {
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE Orders SET Status`).WillReturnResult(sqlmock.NewResult(0, 1))
+					if to == models.OrderStatusCancelled {
+						mock.ExpectExec(`JOIN order_reservations`).WillReturnResult(sqlmock.NewResult(0, 1))
+					}
+					mock.ExpectExec(`INSERT INTO order_events`).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+				}
+
+				order, err := svc.UpdateOrderStatus(context.Background(), id, to, "ops", "")
+				if allowed {
+					if err != nil {
+						t.Fatalf("UpdateOrderStatus() error = %v", err)
+					}
+					if order.Status != to {
+						t.Errorf("Status = %v, want %v", order.Status, to)
+					}
+				} else if !errors.HasCode(err, "PAT-PRD-006") {
+					t.Errorf("UpdateOrderStatus() error = %v, want code PAT-PRD-006", err)
+				}
+
+				// Illegal moves are rejected before touching the Orders row
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("unmet expectations: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestUpdateOrderStatus_ConcurrentTransition(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	svc := newTestService(nil, nil, nil)
+	svc.sqlDB = db
+	id := uuid.New()
+	now := time.Now()
+
+	// The order was processing when read, but a concurrent cancel moved it
+	// before this ship landed, so the guarded update matches no row
+	mock.ExpectQuery(`FROM Orders`).
+		WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(id, uuid.New(), 10.0, "USD", "processing", "1 Main St", now, now))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE Orders SET Status = @p1, UpdatedAt = @p2 WHERE Id = @p3 AND Status = @p4`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err = svc.UpdateOrderStatus(context.Background(), id, models.OrderStatusShipped, "ops", "")
+	if !errors.HasCode(err, "PAT-PRD-006") {
+		t.Errorf("UpdateOrderStatus() error = %v, want code PAT-PRD-006", err)
+	}
+	// No order_events row is written for the lost transition
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateOrderStatus_CancelReleasesStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	svc := newTestService(nil, nil, nil)
+	svc.sqlDB = db
+	productID := uuid.New()
+
+	// create: 3 units are reserved against the order
+	var orderID driver.Value
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE Products SET StockQuantity = StockQuantity - @p1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO order_reservations`).
+		WithArgs(captureArg{dst: &orderID}, sql.Named("p2", productID), sql.Named("p3", 3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO Orders`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO order_events`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	order, err := svc.CreateOrder(context.Background(), newStockedOrderRequest(productID, 3))
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if got := orderID; got != order.ID.String() {
+		t.Errorf("reservation OrderID = %v, want %v", got, order.ID)
+	}
+
+	// cancel: the reservation is returned in the same transaction as the
+	// status change, before it commits
+	mock.ExpectQuery(`FROM Orders`).
+		WillReturnRows(sqlmock.NewRows(orderColumns).
+			AddRow(order.ID, order.CustomerID, order.TotalAmount, "USD", "pending", "1 Main St", order.CreatedAt, order.UpdatedAt))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE Orders SET Status`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE p SET StockQuantity = p.StockQuantity \+ r.Quantity`).
+		WithArgs(sql.Named("p1", order.ID), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO order_events`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if _, err := svc.UpdateOrderStatus(context.Background(), order.ID, models.OrderStatusCancelled, "ops", "customer request"); err != nil {
+		t.Fatalf("UpdateOrderStatus() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateOrderStatus_CancelRollsBackWhenRestockFails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	svc := newTestService(nil, nil, nil)
+	svc.sqlDB = db
+	id := uuid.New()
+	now := time.Now()
+	restockErr := goerrors.New("lock timeout")
+
+	mock.ExpectQuery(`FROM Orders`).
+		WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(id, uuid.New(), 10.0, "USD", "processing", "1 Main St", now, now))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE Orders SET Status`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`JOIN order_reservations`).WillReturnError(restockErr)
+	mock.ExpectRollback()
+
+	if _, err := svc.UpdateOrderStatus(context.Background(), id, models.OrderStatusCancelled, "ops", ""); !goerrors.Is(err, restockErr) {
+		t.Errorf("UpdateOrderStatus() error = %v, want %v", err, restockErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCreateOrder_RejectsNegativeUnitPrice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	svc := newTestService(nil, nil, nil)
+	svc.sqlDB = db
+
+	var req models.CreateOrderRequest
+	body := `{"customerId":"` + uuid.New().String() + `","shippingAddress":"1 Main St",
+		"items":[{"productName":"widget","quantity":1,"unitPrice":"10.00"},{"productName":"refund","quantity":1,"unitPrice":"-50.00"}]}`
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatalf("invalid request: %v", err)
+	}
+
+	_, err = svc.CreateOrder(context.Background(), &req)
+	if !errors.HasCode(err, "PAT-VAL-001") {
+		t.Errorf("CreateOrder() error = %v, want code PAT-VAL-001", err)
+	}
+	// Rejected before any order is written
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCreateOrder_ReturnsRecordedValidationError(t *testing.T) {
+	item := models.CreateOrderItemInput{ProductName: "widget", Quantity: 1, UnitPrice: 10}
+	tests := []struct {
+		name     string
+		req      models.CreateOrderRequest
+		wantCode string
+	}{
+		{
+			name:     "missing customer",
+			req:      models.CreateOrderRequest{Items: []models.CreateOrderItemInput{item}},
+			wantCode: "PAT-VAL-002",
+		},
+		{
+			name:     "no items",
+			req:      models.CreateOrderRequest{CustomerID: uuid.New()},
+			wantCode: "PAT-ORD-002",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(nil, nil, nil)
+			failuresBefore := svc.sli.FailureBreakdown(sli.OperationOrderCreation)
+
+			_, err := svc.CreateOrder(context.Background(), &tt.req)
+			if !errors.HasCode(err, tt.wantCode) {
+				t.Errorf("CreateOrder() error = %v, want code %s", err, tt.wantCode)
+			}
+			failures := svc.sli.FailureBreakdown(sli.OperationOrderCreation)
+			if got := failures[tt.wantCode] - failuresBefore[tt.wantCode]; got != 1 {
+				t.Errorf("SLI failures[%s] = %d, want 1", tt.wantCode, got)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderStatus_UnknownStatus(t *testing.T) {
+	svc := newTestService(nil, nil, nil)
+
+	_, err := svc.UpdateOrderStatus(context.Background(), uuid.New(), "returned", "ops", "")
+	if !errors.HasCode(err, "PAT-VAL-001") {
+		t.Errorf("UpdateOrderStatus() error = %v, want code PAT-VAL-001", err)
+	}
+}
+
+func TestGetOrderHistory_UnknownOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	svc := newTestService(nil, nil, nil)
+	svc.sqlDB = db
+
+	mock.ExpectQuery(`FROM order_events`).WillReturnRows(sqlmock.NewRows(orderEventColumns))
+	mock.ExpectQuery(`FROM Orders`).WillReturnRows(sqlmock.NewRows(orderColumns))
+
+	if _, err := svc.GetOrderHistory(context.Background(), uuid.New()); !goerrors.Is(err, errors.ErrOrderNotFound) {
+		t.Errorf("GetOrderHistory() error = %v, want %v", err, errors.ErrOrderNotFound)
+	}
+}
+
+func newStockedOrderRequest(productID uuid.UUID, quantity int) *models.CreateOrderRequest {
+	return &models.CreateOrderRequest{
+		CustomerID:      uuid.New(),
+		ShippingAddress: "1 Main St",
+		Items:           []models.CreateOrderItemInput{{ProductID: productID, ProductName: "widget", Quantity: quantity, UnitPrice: 5}},
+	}
+}
+
+func TestCreateOrder_ReservesStock(t *testing.T) {
+	productID := uuid.New()
+
+	tests := []struct {
+		name     string
+		quantity int
+		updated  int64          // rows matched by the conditional decrement
+		product  []driver.Value // stock and status from the follow-up lookup; nil = product missing
+		wantCode string
+	}{
+		{name: "sufficient stock", quantity: 2, updated: 1},
+		{name: "insufficient stock", quantity: 3, updated: 0, product: []driver.Value{1, "active"}, wantCode: "PAT-PRD-008"},
+		{name: "discontinued product", quantity: 1, updated: 0, product: []driver.Value{5, "discontinued"}, wantCode: "PAT-PRD-009"},
+		{name: "unknown product", quantity: 1, updated: 0, wantCode: "PAT-PRD-001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			svc := newTestService(nil, nil, nil)
+			svc.sqlDB = db
+
+			mock.ExpectBegin()
+			// Only active products can be decremented
+			mock.ExpectExec(`UPDATE Products SET StockQuantity = StockQuantity - @p1`).
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sql.Named("p4", "active")).
+				WillReturnResult(sqlmock.NewResult(0, tt.updated))
+			if tt.wantCode == "" {
+				mock.ExpectExec(`INSERT INTO order_reservations`).
+					WithArgs(sqlmock.AnyArg(), sql.Named("p2", productID), sql.Named("p3", tt.quantity)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO Orders`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO order_events`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				rows := sqlmock.NewRows([]string{"StockQuantity", "Status"})
+				if tt.product != nil {
+					rows.AddRow(tt.product...)
+				}
+				mock.ExpectQuery(`SELECT StockQuantity, Status FROM Products`).WillReturnRows(rows)
+				mock.ExpectRollback()
+			}
+
+			order, err := svc.CreateOrder(context.Background(), newStockedOrderRequest(productID, tt.quantity))
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("CreateOrder() error = %v", err)
+				}
+				if got := order.Items[0].ProductID; got != productID {
+					t.Errorf("Items[0].ProductID = %v, want %v", got, productID)
+				}
+			} else if !errors.HasCode(err, tt.wantCode) {
+				t.Errorf("CreateOrder() error = %v, want code %v", err, tt.wantCode)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateOrder_IdempotencyKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	redis := newFakeRedis()
+	svc := newTestService(nil, redis, nil)
+	svc.sqlDB = db
+	ctx := context.Background()
+	req := &models.CreateOrderRequest{
+		CustomerID:      uuid.New(),
+		ShippingAddress: "1 Main St",
+		Items:           []models.CreateOrderItemInput{{ProductName: "widget", Quantity: 2, UnitPrice: 5}},
+		IdempotencyKey:  "retry-1",
+	}
+
+	// first call creates the order
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO Orders`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO order_events`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	first, err := svc.CreateOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("first CreateOrder() error = %v", err)
+	}
+	key := orderIdempotencyKey(req.CustomerID, req.IdempotencyKey)
+	if got := redis.values[key]; got != first.ID.String() {
+		t.Errorf("idempotency entry = %q, want %v", got, first.ID)
+	}
+	if got := redis.expires[key]; got != orderIdempotencyTTL {
+		t.Errorf("idempotency TTL = %v, want %v", got, orderIdempotencyTTL)
+	}
+
+	// the retry reads the original order back without inserting
+	mock.ExpectQuery(`FROM Orders`).WithArgs(first.ID).
+		WillReturnRows(sqlmock.NewRows(orderColumns).
+			AddRow(first.ID, req.CustomerID, first.TotalAmount, "USD", "pending", "1 Main St", first.CreatedAt, first.UpdatedAt))
+
+	second, err := svc.CreateOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("second CreateOrder() error = %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("second order ID = %v, want %v", second.ID, first.ID)
+	}
+
+	// the same key from another customer is a different order
+	other := *req
+	other.CustomerID = uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO Orders`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO order_events`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	third, err := svc.CreateOrder(ctx, &other)
+	if err != nil {
+		t.Fatalf("other customer CreateOrder() error = %v", err)
+	}
+	if third.ID == first.ID {
+		t.Error("other customer got the first customer's order")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+// claimGatedRedis holds the caller that wins SetNX until proceed is closed,
+// so other requests can race it while its order is being created
+type claimGatedRedis struct {
+	*fakeRedis
+	claimed chan struct{}
+	proceed chan struct{}
+}
+
+func (g *claimGatedRedis) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ok, err := g.fakeRedis.SetNX(ctx, key, value, ttl)
+	if ok {
+		close(g.claimed)
+		<-g.proceed
+	}
+	return ok, err
+}
+
+func TestCreateOrder_IdempotencyKeyConcurrentRetries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	redis := &claimGatedRedis{fakeRedis: newFakeRedis(), claimed: make(chan struct{}), proceed: make(chan struct{})}
+	svc := newTestService(nil, nil, nil)
+	svc.redisClient = redis
+	svc.sqlDB = db
+	req := &models.CreateOrderRequest{
+		CustomerID:      uuid.New(),
+		ShippingAddress: "1 Main St",
+		Items:           []models.CreateOrderItemInput{{ProductName: "widget", Quantity: 1, UnitPrice: 5}},
+		IdempotencyKey:  "retry-1",
+	}
+
+	// Only one of the concurrent requests inserts an order
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO Orders`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO order_events`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	var original *models.Order
+	done := make(chan error)
+	go func() {
+		var err error
+		original, err = svc.CreateOrder(context.Background(), req)
+		done <- err
+	}()
+	<-redis.claimed
+
+	// Retries while the original holds the key are rejected, not inserted
+	const retries = 3
+	errs := make([]error, retries)
+	var wg sync.WaitGroup
+	for i := 0; i < retries; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = svc.CreateOrder(context.Background(), req)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if !errors.HasCode(err, "PAT-ORD-004") {
+			t.Errorf("retry %d error = %v, want code PAT-ORD-004", i, err)
+		}
+	}
+
+	close(redis.proceed)
+	if err := <-done; err != nil {
+		t.Fatalf("original CreateOrder() error = %v", err)
+	}
+	if got := redis.values[orderIdempotencyKey(req.CustomerID, req.IdempotencyKey)]; got != original.ID.String() {
+		t.Errorf("idempotency entry = %q, want %v", got, original.ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCreateOrder_IdempotencyKeyReleasedOnFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	redis := newFakeRedis()
+	svc := newTestService(nil, redis, nil)
+	svc.sqlDB = db
+	req := &models.CreateOrderRequest{
+		CustomerID:      uuid.New(),
+		ShippingAddress: "1 Main St",
+		Items:           []models.CreateOrderItemInput{{ProductName: "widget", Quantity: 1, UnitPrice: 5}},
+		IdempotencyKey:  "retry-1",
+	}
+	key := orderIdempotencyKey(req.CustomerID, req.IdempotencyKey)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO Orders`).WillReturnError(goerrors.New("deadlock"))
+	mock.ExpectRollback()
+
+	if _, err := svc.CreateOrder(context.Background(), req); err == nil {
+		t.Fatal("CreateOrder() error = nil, want insert failure")
+	}
+	if value, ok := redis.values[key]; ok {
+		t.Errorf("idempotency entry = %q after failed order, want released", value)
+	}
+
+	// The client's retry with the same key creates the order
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO Orders`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO order_events`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	order, err := svc.CreateOrder(context.Background(), req)
+	if err != nil {
+		t.Fatalf("retry CreateOrder() error = %v", err)
+	}
+	if got := redis.values[key]; got != order.ID.String() {
+		t.Errorf("idempotency entry = %q, want %v", got, order.ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCreateOrder_ConcurrentOrdersForLastUnit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+	mock.MatchExpectationsInOrder(false)
+
+	svc := newTestService(nil, nil, nil)
+	svc.sqlDB = db
+	productID := uuid.New()
+
+	// The database applies the conditional decrements one at a time: the first
+	// takes the last unit, the second then matches no row and sees zero stock
+	mock.ExpectBegin()
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE Products`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE Products`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO order_reservations`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT StockQuantity, Status FROM Products`).WillReturnRows(sqlmock.NewRows([]string{"StockQuantity", "Status"}).AddRow(0, "active"))
+	mock.ExpectExec(`INSERT INTO Orders`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO order_events`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectRollback()
+
+	const orders = 2
+	errs := make([]error, orders)
+	var wg sync.WaitGroup
+	for i := 0; i < orders; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = svc.CreateOrder(context.Background(), newStockedOrderRequest(productID, 1))
+		}(i)
+	}
+	wg.Wait()
+
+	var succeeded, short int
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.HasCode(err, "PAT-PRD-008"):
+			short++
+		default:
+			t.Errorf("CreateOrder() unexpected error = %v", err)
+		}
+	}
+	if succeeded != 1 || short != 1 {
+		t.Errorf("succeeded = %v, insufficient stock = %v, want 1 and 1", succeeded, short)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -4,10 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/your-github-org/ai-scaffolder/core/go/analytics/validation"
 	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/kafka"
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/keyvault"
 	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/redis"
 	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/scylladb"
 	"github.com/your-github-org/ai-scaffolder/core/go/logger"
@@ -15,9 +22,12 @@ import (
 	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
 	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
 	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/sli"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/safego"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/eventbus"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
@@ -25,12 +35,39 @@ import (
 // This demonstrates how to properly use all Core packages for a production service
 type PatternsService struct {
 	// Core Infrastructure Clients
-	sqlDB         *sql.DB          // Core.Infrastructure.SqlServer
-	mongoClient   *mongo.Client    // Core.Infrastructure.MongoDB
-	mongoDatabase string           // MongoDB database name
-	scyllaSession scylladb.Session // Core.Infrastructure.ScyllaDB
-	redisClient   redis.Client     // Core.Infrastructure.Redis
-	kafkaProducer kafka.Producer   // Core.Infrastructure.Kafka
+	sqlDB         *sql.DB               // Core.Infrastructure.SqlServer
+	mongoClient   *mongo.Client         // Core.Infrastructure.MongoDB
+	mongoDatabase string                // MongoDB database name
+	scyllaSession scylladb.Session      // Core.Infrastructure.ScyllaDB
+	redisClient   redis.Client          // Core.Infrastructure.Redis
+	kafkaProducer kafka.Producer        // Core.Infrastructure.Kafka
+	keyVault      keyvault.CachedClient // Core.Infrastructure.KeyVault (optional, user integrations)
+
+	// Analytics currency conversion
+	exchangeRates     ExchangeRateProvider
+	reportingCurrency string
+
+	// Telemetry TTLs and read-side retention window
+	telemetryRetention TelemetryRetention
+
+	// Bounds on device-reported reading timestamps
+	telemetryTimestamps TelemetryTimestampPolicy
+	validator           *validation.Validator // Core.Analytics.Validation
+
+	// Device-to-shard routing for telemetry (nil = primary scyllaSession only)
+	telemetryShards *DeviceShardResolver
+
+	// Periodic leaderboards maintained alongside the all-time board
+	leaderboardWindows []models.LeaderboardWindow
+
+	// User sessions (Redis-backed when Redis is connected), sliding by
+	// sessionTTL on each access up to sessionMaxLifetime after creation
+	sessions           SessionStore
+	sessionTTL         time.Duration
+	sessionMaxLifetime time.Duration
+	maxSessionsPerUser int
+
+	now func() time.Time
 
 	// Core packages
 	logger *logger.Logger   // Core.Logger
@@ -40,8 +77,36 @@ type PatternsService struct {
 	mongoCircuitBreaker  *reliability.CircuitBreaker
 	scyllaCircuitBreaker *reliability.CircuitBreaker
 	kafkaCircuitBreaker  *reliability.CircuitBreaker
+
+	// Order events that couldn't be published while Kafka was unavailable
+	deadLetters *eventbus.DeadLetterBuffer
+
+	// Analytics timeouts
+	analyticsStoreTimeout time.Duration
+	analyticsDeadline     time.Duration
+
+	// Backends reported unhealthy by reconnection supervisors
+	backendMu   sync.RWMutex
+	backendDown map[string]bool
 }
 
+const (
+	defaultAnalyticsStoreTimeout = 5 * time.Second
+	defaultAnalyticsDeadline     = 10 * time.Second
+	defaultReportingCurrency     = "USD"
+	defaultDeadLetterBufferSize  = 1000
+
+	// orderIdempotencyTTL is how long a client can retry CreateOrder with
+	// the same idempotency key and get the original order back
+	orderIdempotencyTTL = 24 * time.Hour
+
+	// orderIdempotencyPending marks a key whose order is being created. The
+	// marker expires after orderIdempotencyPendingTTL if the request dies
+	// before it can release or replace it.
+	orderIdempotencyPending    = "pending"
+	orderIdempotencyPendingTTL = time.Minute
+)
+
 // NewPatternsService creates a new patterns service with Core infrastructure clients
 func NewPatternsService(
 	sqlDB *sql.DB,
@@ -53,7 +118,7 @@ func NewPatternsService(
 	log *logger.Logger,
 	sliTracker *sli.PatternsSli,
 ) *PatternsService {
-	return &PatternsService{
+	svc := &PatternsService{
 		sqlDB:                sqlDB,
 		mongoClient:          mongoClient,
 		mongoDatabase:        mongoDatabase,
@@ -65,9 +130,52 @@ func NewPatternsService(
 		mongoCircuitBreaker:  reliability.NewCircuitBreaker("mongodb", 5, 30*time.Second),
 		scyllaCircuitBreaker: reliability.NewCircuitBreaker("scylladb", 5, 30*time.Second),
 		kafkaCircuitBreaker:  reliability.NewCircuitBreaker("kafka", 5, 30*time.Second),
+		deadLetters:          eventbus.NewDeadLetterBuffer(defaultDeadLetterBufferSize),
+
+		analyticsStoreTimeout: defaultAnalyticsStoreTimeout,
+		analyticsDeadline:     defaultAnalyticsDeadline,
+		reportingCurrency:     defaultReportingCurrency,
+		leaderboardWindows:    defaultLeaderboardWindows,
+		telemetryTimestamps:   defaultTelemetryTimestampPolicy,
+		validator:             validation.NewValidator(validation.Config{Logger: log}),
+		sessionTTL:            defaultSessionTTL,
+		sessionMaxLifetime:    defaultSessionMaxLifetime,
+		maxSessionsPerUser:    defaultMaxSessionsPerUser,
+		now:                   time.Now,
+
+		backendDown: make(map[string]bool),
+	}
+	if redisClient != nil {
+		svc.sessions = NewRedisSessionStore(redisClient)
+	}
+	return svc
+}
+
+// SetAnalyticsTimeouts configures the per-store timeout and overall deadline for GetAnalytics.
+// Zero values keep the defaults.
+func (s *PatternsService) SetAnalyticsTimeouts(storeTimeout, deadline time.Duration) {
+	if storeTimeout > 0 {
+		s.analyticsStoreTimeout = storeTimeout
+	}
+	if deadline > 0 {
+		s.analyticsDeadline = deadline
+	}
+}
+
+// SetExchangeRates configures analytics to report revenue in reportingCurrency,
+// converting other currencies with provider. An empty currency keeps the default.
+func (s *PatternsService) SetExchangeRates(provider ExchangeRateProvider, reportingCurrency string) {
+	s.exchangeRates = provider
+	if reportingCurrency != "" {
+		s.reportingCurrency = reportingCurrency
 	}
 }
 
+// SetKeyVault enables user integration features backed by KeyVault
+func (s *PatternsService) SetKeyVault(kv keyvault.CachedClient) {
+	s.keyVault = kv
+}
+
 // =============================================================================
 // SQL Server Operations - Orders (Transactional Data)
 // Demonstrates: Core.Infrastructure.SqlServer usage
@@ -84,19 +192,58 @@ func (s *PatternsService) CreateOrder(ctx context.Context, req *models.CreateOrd
 
 	// Validate request
 	if req.CustomerID == uuid.Nil {
-		s.sli.RecordOrderCreationFailure()
-		return nil, errors.ErrInvalidCustomerID
+		err := errors.MissingParameter("customer_id")
+		s.sli.RecordOrderCreationFailure(err)
+		return nil, err
 	}
 	if len(req.Items) == 0 {
-		s.sli.RecordOrderCreationFailure()
-		return nil, errors.ErrEmptyOrderItems
+		err := errors.ProductErrors.CreateError("PAT-ORD-002")
+		s.sli.RecordOrderCreationFailure(err)
+		return nil, err
+	}
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			err := errors.ValidationError(fmt.Sprintf("quantity for %q must be positive", item.ProductName))
+			s.sli.RecordOrderCreationFailure(err)
+			return nil, err
+		}
+		if item.UnitPrice < 0 {
+			err := errors.ValidationError(fmt.Sprintf("unit price for %q cannot be negative", item.ProductName))
+			s.sli.RecordOrderCreationFailure(err)
+			return nil, err
+		}
+	}
+
+	// A retry with a known idempotency key returns the original order; one
+	// racing the original request is rejected until that request finishes
+	existing, claimed, err := s.claimIdempotencyKey(ctx, req)
+	if err != nil {
+		s.sli.RecordOrderCreationFailure(err)
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+	if existing != nil {
+		log.Info("Returning existing order for idempotency key",
+			zap.String("order_id", existing.ID.String()))
+		return existing, nil
+	}
+	committed := false
+	if claimed {
+		defer func() {
+			if !committed {
+				s.releaseIdempotencyKey(ctx, req)
+			}
+		}()
 	}
 
 	// Convert request items to model items
 	orderItems := make([]models.OrderItem, len(req.Items))
 	for i, item := range req.Items {
+		productID := item.ProductID
+		if productID == uuid.Nil {
+			productID = uuid.New() // untracked item, no inventory
+		}
 		orderItems[i] = models.NewOrderItem(
-			uuid.New(), // generate product ID
+			productID,
 			item.ProductName,
 			item.Quantity,
 			item.UnitPrice,
@@ -106,12 +253,27 @@ func (s *PatternsService) CreateOrder(ctx context.Context, req *models.CreateOrd
 	// Create order model
 	order := models.NewOrder(req.CustomerID, req.ShippingAddress, orderItems)
 
+	// Insert the order and its creation event in one transaction
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Failed to begin order transaction", zap.Error(err))
+		s.sli.RecordOrderCreationFailure(errors.DatabaseError(err))
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	if err := reserveStock(ctx, tx, order.ID, req.Items); err != nil {
+		log.Warn("Failed to reserve stock", zap.Error(err))
+		s.sli.RecordOrderCreationFailure(err)
+		return nil, err
+	}
+
 	// Insert into SQL Server using Core.Infrastructure.SqlServer
 	query := `
 		INSERT INTO Orders (Id, CustomerID, TotalAmount, Currency, Status, ShippingAddress, CreatedAt, UpdatedAt)
 		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8)`
 
-	_, err := s.sqlDB.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		sql.Named("p1", order.ID),
 		sql.Named("p2", order.CustomerID),
 		sql.Named("p3", order.TotalAmount),
@@ -123,9 +285,26 @@ func (s *PatternsService) CreateOrder(ctx context.Context, req *models.CreateOrd
 	)
 	if err != nil {
 		log.Error("Failed to create order in SQL Server", zap.Error(err))
-		s.sli.RecordOrderCreationFailure()
+		s.sli.RecordOrderCreationFailure(errors.DatabaseError(err))
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	entry := models.NewOrderHistoryEntry(order.ID, "", order.Status, order.CustomerID.String(), "order created")
+	entry.OccurredAt = order.CreatedAt
+	if err := insertOrderEvent(ctx, tx, entry); err != nil {
+		log.Error("Failed to record order created event", zap.Error(err))
+		s.sli.RecordOrderCreationFailure(errors.DatabaseError(err))
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error("Failed to commit order transaction", zap.Error(err))
+		s.sli.RecordOrderCreationFailure(errors.DatabaseError(err))
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
+	committed = true
+
+	s.rememberIdempotentOrder(ctx, req, order.ID)
 
 	// Publish event via Kafka using Core.Infrastructure.Kafka
 	if s.kafkaProducer != nil {
@@ -143,6 +322,84 @@ func (s *PatternsService) CreateOrder(ctx context.Context, req *models.CreateOrd
 	return order, nil
 }
 
+// orderIdempotencyKey scopes a client's idempotency key to the customer so
+// keys from different customers never collide
+func orderIdempotencyKey(customerID uuid.UUID, key string) string {
+	return fmt.Sprintf("idempotency:order:%s:%s", customerID, key)
+}
+
+// claimIdempotencyKey reserves req's idempotency key with a pending marker
+// (SET NX) before the order is inserted, so of several concurrent retries
+// only one creates the order. If the key is already taken it returns the
+// order created with it, or PAT-ORD-004 while that request is still running.
+// claimed reports whether this call holds the marker and must release it if
+// the order isn't created. Without Redis, or if Redis fails, the request is
+// treated as new rather than rejected.
+func (s *PatternsService) claimIdempotencyKey(ctx context.Context, req *models.CreateOrderRequest) (*models.Order, bool, error) {
+	if req.IdempotencyKey == "" || s.redisClient == nil {
+		return nil, false, nil
+	}
+	log := s.logger.WithContext(ctx)
+	key := orderIdempotencyKey(req.CustomerID, req.IdempotencyKey)
+
+	claimed, err := s.redisClient.SetNX(ctx, key, orderIdempotencyPending, orderIdempotencyPendingTTL)
+	if err != nil {
+		log.Warn("Failed to claim idempotency key", zap.Error(err))
+		return nil, false, nil
+	}
+	if claimed {
+		return nil, true, nil
+	}
+
+	value, err := s.redisClient.Get(ctx, key)
+	if err != nil {
+		log.Warn("Failed to look up idempotency key", zap.Error(err))
+		return nil, false, nil
+	}
+	// An empty value means the original request failed and released the key
+	// since SET NX; the client retries as it would for a pending one
+	if value == orderIdempotencyPending || value == "" {
+		return nil, false, errors.IdempotencyKeyInProgress(req.IdempotencyKey)
+	}
+	orderID, err := uuid.Parse(value)
+	if err != nil {
+		log.Warn("Ignoring malformed idempotency entry", zap.String("value", value))
+		return nil, false, nil
+	}
+
+	order, err := s.GetOrder(ctx, orderID)
+	if goerrors.Is(err, errors.ErrOrderNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.DatabaseError(err)
+	}
+	return order, false, nil
+}
+
+// releaseIdempotencyKey drops the pending marker of an order that wasn't
+// created, so the client can retry with the same key. If this fails the
+// marker expires after orderIdempotencyPendingTTL.
+func (s *PatternsService) releaseIdempotencyKey(ctx context.Context, req *models.CreateOrderRequest) {
+	key := orderIdempotencyKey(req.CustomerID, req.IdempotencyKey)
+	if err := s.redisClient.Del(ctx, key); err != nil {
+		s.logger.WithContext(ctx).Warn("Failed to release idempotency key", zap.Error(err))
+	}
+}
+
+// rememberIdempotentOrder maps req's idempotency key to orderID for
+// orderIdempotencyTTL. The order already exists, so a failure is only logged.
+func (s *PatternsService) rememberIdempotentOrder(ctx context.Context, req *models.CreateOrderRequest, orderID uuid.UUID) {
+	if req.IdempotencyKey == "" || s.redisClient == nil {
+		return
+	}
+	key := orderIdempotencyKey(req.CustomerID, req.IdempotencyKey)
+	if err := s.redisClient.SetWithTTL(ctx, key, orderID.String(), orderIdempotencyTTL); err != nil {
+		s.logger.WithContext(ctx).Warn("Failed to store idempotency key",
+			zap.String("order_id", orderID.String()), zap.Error(err))
+	}
+}
+
 // GetOrder retrieves an order by ID from SQL Server
 func (s *PatternsService) GetOrder(ctx context.Context, id uuid.UUID) (*models.Order, error) {
 	log := s.logger.WithContext(ctx)
@@ -175,13 +432,191 @@ func (s *PatternsService) GetOrder(ctx context.Context, id uuid.UUID) (*models.O
 	return &order, nil
 }
 
-// UpdateOrderStatus updates an order's status in SQL Server
-func (s *PatternsService) UpdateOrderStatus(ctx context.Context, id uuid.UUID, newStatus models.OrderStatus) (*models.Order, error) {
+// BatchGetOrders retrieves up to models.MaxBatchGetOrderIDs orders belonging to
+// customerID with a single IN query, returning the IDs that were not found
+func (s *PatternsService) BatchGetOrders(ctx context.Context, customerID uuid.UUID, ids []uuid.UUID) (*models.BatchGetOrdersResponse, error) {
+	log := s.logger.WithContext(ctx)
+
+	if customerID == uuid.Nil {
+		return nil, errors.ErrInvalidCustomerID
+	}
+	if len(ids) == 0 {
+		return nil, errors.ErrEmptyOrderIDs
+	}
+	if len(ids) > models.MaxBatchGetOrderIDs {
+		return nil, fmt.Errorf("%w: %d exceeds limit of %d", errors.ErrTooManyOrderIDs, len(ids), models.MaxBatchGetOrderIDs)
+	}
+
+	log.Debug("Batch getting orders",
+		zap.String("customer_id", customerID.String()),
+		zap.Int("count", len(ids)))
+
+	// De-duplicate so the IN list and not-found list contain each ID once
+	unique := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	args := []interface{}{sql.Named("p1", customerID)}
+	placeholders := make([]string, len(unique))
+	for i, id := range unique {
+		name := fmt.Sprintf("p%d", i+2)
+		placeholders[i] = "@" + name
+		args = append(args, sql.Named(name, id))
+	}
+
+	query := `
+		SELECT Id, CustomerID, TotalAmount, Currency, Status, ShippingAddress, CreatedAt, UpdatedAt
+		FROM Orders
+		WHERE CustomerID = @p1 AND Id IN (` + strings.Join(placeholders, ", ") + `)`
+
+	rows, err := s.sqlDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error("Failed to batch get orders from SQL Server", zap.Error(err))
+		return nil, fmt.Errorf("failed to batch get orders: %w", err)
+	}
+	defer rows.Close()
+
+	found := make(map[uuid.UUID]*models.Order, len(unique))
+	for rows.Next() {
+		var order models.Order
+		var status string
+		if err := rows.Scan(
+			&order.ID, &order.CustomerID, &order.TotalAmount, &order.Currency,
+			&status, &order.ShippingAddress, &order.CreatedAt, &order.UpdatedAt,
+		); err != nil {
+			log.Error("Failed to scan order", zap.Error(err))
+			return nil, fmt.Errorf("failed to batch get orders: %w", err)
+		}
+		order.Status = models.OrderStatus(status)
+		found[order.ID] = &order
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Failed to iterate orders", zap.Error(err))
+		return nil, fmt.Errorf("failed to batch get orders: %w", err)
+	}
+
+	// Preserve the requested order in the response
+	result := &models.BatchGetOrdersResponse{
+		Orders:   make([]*models.Order, 0, len(found)),
+		NotFound: []uuid.UUID{},
+	}
+	for _, id := range unique {
+		if order, ok := found[id]; ok {
+			result.Orders = append(result.Orders, order)
+		} else {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+
+	return result, nil
+}
+
+// ListOrders returns a page of orders matching filter, newest first, and the
+// total number of matching orders
+func (s *PatternsService) ListOrders(ctx context.Context, filter models.OrderFilter) ([]*models.Order, int, error) {
+	log := s.logger.WithContext(ctx)
+
+	filter = filter.WithDefaults()
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, 0, errors.ValidationError(fmt.Sprintf("unknown order status %q", filter.Status))
+	}
+
+	var where []string
+	var args []interface{}
+	if filter.CustomerID != uuid.Nil {
+		where = append(where, "CustomerID = @customer")
+		args = append(args, sql.Named("customer", filter.CustomerID))
+	}
+	if filter.Status != "" {
+		where = append(where, "Status = @status")
+		args = append(args, sql.Named("status", string(filter.Status)))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		where = append(where, "CreatedAt >= @after")
+		args = append(args, sql.Named("after", filter.CreatedAfter))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		where = append(where, "CreatedAt < @before")
+		args = append(args, sql.Named("before", filter.CreatedBefore))
+	}
+	clause := ""
+	if len(where) > 0 {
+		clause = ` WHERE ` + strings.Join(where, " AND ")
+	}
+
+	log.Debug("Listing orders",
+		zap.String("customer_id", filter.CustomerID.String()),
+		zap.String("status", string(filter.Status)),
+		zap.Int("offset", filter.Offset),
+		zap.Int("limit", filter.Limit))
+
+	var total int
+	if err := s.sqlDB.QueryRowContext(ctx, `SELECT COUNT(*) FROM Orders`+clause, args...).Scan(&total); err != nil {
+		log.Error("Failed to count orders", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	query := `
+		SELECT Id, CustomerID, TotalAmount, Currency, Status, ShippingAddress, CreatedAt, UpdatedAt
+		FROM Orders` + clause + `
+		ORDER BY CreatedAt DESC, Id OFFSET @offset ROWS FETCH NEXT @limit ROWS ONLY`
+	pageArgs := append(args, sql.Named("offset", filter.Offset), sql.Named("limit", filter.Limit))
+
+	rows, err := s.sqlDB.QueryContext(ctx, query, pageArgs...)
+	if err != nil {
+		log.Error("Failed to list orders", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to list orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []*models.Order{}
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			log.Error("Failed to scan order", zap.Error(err))
+			return nil, 0, fmt.Errorf("failed to list orders: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Failed to iterate orders", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	return orders, total, nil
+}
+
+func scanOrder(row rowScanner) (*models.Order, error) {
+	var order models.Order
+	var status string
+	if err := row.Scan(
+		&order.ID, &order.CustomerID, &order.TotalAmount, &order.Currency,
+		&status, &order.ShippingAddress, &order.CreatedAt, &order.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	order.Status = models.OrderStatus(status)
+	return &order, nil
+}
+
+// UpdateOrderStatus updates an order's status in SQL Server and appends the
+// transition, attributed to actor, to order_events in the same transaction
+func (s *PatternsService) UpdateOrderStatus(ctx context.Context, id uuid.UUID, newStatus models.OrderStatus, actor, reason string) (*models.Order, error) {
 	log := s.logger.WithContext(ctx)
 
 	log.Info("Updating order status",
 		zap.String("order_id", id.String()),
-		zap.String("new_status", string(newStatus)))
+		zap.String("new_status", string(newStatus)),
+		zap.String("actor", actor))
+
+	if !newStatus.IsValid() {
+		return nil, errors.ValidationError(fmt.Sprintf("unknown order status %q", newStatus))
+	}
 
 	// Get current order
 	order, err := s.GetOrder(ctx, id)
@@ -189,22 +624,69 @@ func (s *PatternsService) UpdateOrderStatus(ctx context.Context, id uuid.UUID, n
 		return nil, err
 	}
 
+	if !order.CanTransitionTo(newStatus) {
+		log.Warn("Rejected order status transition",
+			zap.String("from_status", string(order.Status)),
+			zap.String("to_status", string(newStatus)))
+		return nil, errors.InvalidStatusTransition(order.Status, newStatus)
+	}
+
 	previousStatus := order.Status
+	entry := models.NewOrderHistoryEntry(id, previousStatus, newStatus, actor, reason)
+
+	tx, err := s.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("Failed to begin order transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
+	defer tx.Rollback() // no-op after Commit
 
-	// Update in SQL Server
-	query := `UPDATE Orders SET Status = @p1, UpdatedAt = @p2 WHERE Id = @p3`
-	_, err = s.sqlDB.ExecContext(ctx, query,
+	// Update in SQL Server. The status guard makes the transition
+	// compare-and-set: if a concurrent update moved the order since it was
+	// read, no row matches and this transition is rejected.
+	query := `UPDATE Orders SET Status = @p1, UpdatedAt = @p2 WHERE Id = @p3 AND Status = @p4`
+	result, err := tx.ExecContext(ctx, query,
 		sql.Named("p1", string(newStatus)),
-		sql.Named("p2", time.Now()),
+		sql.Named("p2", entry.OccurredAt),
 		sql.Named("p3", id),
+		sql.Named("p4", string(previousStatus)),
 	)
 	if err != nil {
 		log.Error("Failed to update order status", zap.Error(err))
 		return nil, fmt.Errorf("failed to update order: %w", err)
 	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		log.Error("Failed to read updated order count", zap.Error(err))
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
+	if updated != 1 {
+		log.Warn("Order status changed concurrently",
+			zap.String("from_status", string(previousStatus)),
+			zap.String("to_status", string(newStatus)))
+		return nil, errors.InvalidStatusTransition(previousStatus, newStatus)
+	}
+
+	// A cancelled order gives its reserved stock back in the same transaction
+	if newStatus == models.OrderStatusCancelled {
+		if err := releaseStock(ctx, tx, id); err != nil {
+			log.Error("Failed to release order stock", zap.Error(err))
+			return nil, fmt.Errorf("failed to update order: %w", err)
+		}
+	}
+
+	if err := insertOrderEvent(ctx, tx, entry); err != nil {
+		log.Error("Failed to record order status event", zap.Error(err))
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error("Failed to commit order transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
 
 	order.Status = newStatus
-	order.UpdatedAt = time.Now()
+	order.UpdatedAt = entry.OccurredAt
 
 	// Publish status change event via Kafka
 	if s.kafkaProducer != nil {
@@ -217,6 +699,165 @@ func (s *PatternsService) UpdateOrderStatus(ctx context.Context, id uuid.UUID, n
 	return order, nil
 }
 
+// GetOrderHistory returns the order's state transitions, oldest first
+func (s *PatternsService) GetOrderHistory(ctx context.Context, id uuid.UUID) ([]models.OrderHistoryEntry, error) {
+	log := s.logger.WithContext(ctx)
+
+	log.Debug("Getting order history", zap.String("order_id", id.String()))
+
+	query := `
+		SELECT Id, OrderID, FromStatus, ToStatus, Actor, Reason, OccurredAt
+		FROM order_events
+		WHERE OrderID = @p1
+		ORDER BY OccurredAt ASC`
+
+	rows, err := s.sqlDB.QueryContext(ctx, query, sql.Named("p1", id))
+	if err != nil {
+		log.Error("Failed to get order history from SQL Server", zap.Error(err))
+		return nil, fmt.Errorf("failed to get order history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.OrderHistoryEntry{}
+	for rows.Next() {
+		var entry models.OrderHistoryEntry
+		var from, to string
+		var reason sql.NullString
+		if err := rows.Scan(&entry.ID, &entry.OrderID, &from, &to, &entry.Actor, &reason, &entry.OccurredAt); err != nil {
+			log.Error("Failed to scan order event", zap.Error(err))
+			return nil, fmt.Errorf("failed to get order history: %w", err)
+		}
+		entry.FromStatus = models.OrderStatus(from)
+		entry.ToStatus = models.OrderStatus(to)
+		entry.Reason = reason.String
+		history = append(history, entry)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Failed to iterate order events", zap.Error(err))
+		return nil, fmt.Errorf("failed to get order history: %w", err)
+	}
+
+	// An empty history is only valid for orders that predate order_events
+	if len(history) == 0 {
+		if _, err := s.GetOrder(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return history, nil
+}
+
+// reserveStock decrements inventory for every item with a ProductID within tx
+// and records each reservation in order_reservations so cancelling the order
+// can return it (see releaseStock). Each decrement is a single conditional
+// UPDATE, so concurrent orders cannot oversell: a decrement that would take
+// stock below zero, or hits a product that isn't active, matches no row.
+// Products are locked in ID order to avoid deadlocks between orders.
+func reserveStock(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, items []models.CreateOrderItemInput) error {
+	requested := make(map[uuid.UUID]int)
+	for _, item := range items {
+		if item.ProductID != uuid.Nil {
+			requested[item.ProductID] += item.Quantity
+		}
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(requested))
+	for id := range requested {
+		productIDs = append(productIDs, id)
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i].String() < productIDs[j].String() })
+
+	query := `
+		UPDATE Products SET StockQuantity = StockQuantity - @p1, UpdatedAt = @p2
+		WHERE Id = @p3 AND Status = @p4 AND StockQuantity >= @p1`
+	reservation := `
+		INSERT INTO order_reservations (OrderID, ProductID, Quantity)
+		VALUES (@p1, @p2, @p3)`
+
+	for _, id := range productIDs {
+		result, err := tx.ExecContext(ctx, query,
+			sql.Named("p1", requested[id]),
+			sql.Named("p2", time.Now().UTC()),
+			sql.Named("p3", id),
+			sql.Named("p4", string(models.ProductStatusActive)),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to reserve stock: %w", err)
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to reserve stock: %w", err)
+		}
+		if updated == 1 {
+			_, err := tx.ExecContext(ctx, reservation,
+				sql.Named("p1", orderID),
+				sql.Named("p2", id),
+				sql.Named("p3", requested[id]),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to reserve stock: %w", err)
+			}
+			continue
+		}
+
+		// Nothing updated: the product is unknown, not for sale or short of stock
+		var available int
+		var status string
+		err = tx.QueryRowContext(ctx, `SELECT StockQuantity, Status FROM Products WHERE Id = @p1`, sql.Named("p1", id)).Scan(&available, &status)
+		if err == sql.ErrNoRows {
+			return errors.NotFound("product", id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reserve stock: %w", err)
+		}
+		if models.ProductStatus(status) != models.ProductStatusActive {
+			return errors.ProductUnavailable(id, status)
+		}
+		return errors.InsufficientStock(available, requested[id]).WithContext("product_id", id.String())
+	}
+
+	return nil
+}
+
+// releaseStock returns the stock reserved by orderID within tx. Cancelled is
+// a final status and UpdateOrderStatus only moves an order out of the status
+// it read, so each order's reservations are released at most once. Orders
+// placed before order_reservations existed have nothing to release.
+func releaseStock(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	query := `
+		UPDATE p SET StockQuantity = p.StockQuantity + r.Quantity, UpdatedAt = @p2
+		FROM Products p
+		JOIN order_reservations r ON r.ProductID = p.Id
+		WHERE r.OrderID = @p1`
+
+	_, err := tx.ExecContext(ctx, query,
+		sql.Named("p1", orderID),
+		sql.Named("p2", time.Now().UTC()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release stock: %w", err)
+	}
+	return nil
+}
+
+// insertOrderEvent appends entry to order_events within tx
+func insertOrderEvent(ctx context.Context, tx *sql.Tx, entry models.OrderHistoryEntry) error {
+	query := `
+		INSERT INTO order_events (Id, OrderID, FromStatus, ToStatus, Actor, Reason, OccurredAt)
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7)`
+
+	_, err := tx.ExecContext(ctx, query,
+		sql.Named("p1", entry.ID),
+		sql.Named("p2", entry.OrderID),
+		sql.Named("p3", string(entry.FromStatus)),
+		sql.Named("p4", string(entry.ToStatus)),
+		sql.Named("p5", entry.Actor),
+		sql.Named("p6", entry.Reason),
+		sql.Named("p7", entry.OccurredAt),
+	)
+	return err
+}
+
 // =============================================================================
 // MongoDB Operations - User Profiles (Document Data)
 // Demonstrates: Core.Infrastructure.MongoDB usage
@@ -227,24 +868,49 @@ func (s *PatternsService) CreateUser(ctx context.Context, req *models.CreateUser
 	log := s.logger.WithContext(ctx)
 	start := time.Now()
 
+	// Emails are compared case-insensitively, so store them normalized
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
 	log.Info("Creating user profile",
-		zap.String("email", req.Email))
+		zap.String("email", email))
 
 	// Validate request
-	if req.Email == "" {
+	if email == "" {
 		return nil, errors.ErrInvalidEmail
 	}
 
 	// Create user profile
-	profile := models.NewUserProfile(req.Email, req.FirstName, req.LastName)
-
-	// Execute with circuit breaker (Core.Reliability)
-	err := s.mongoCircuitBreaker.Execute(func() error {
+	profile := models.NewUserProfile(email, req.FirstName, req.LastName)
+
+	// Execute with circuit breaker (Core.Reliability). Soft-deleted profiles
+	// keep their email so they can still be restored. A taken email is the
+	// caller's mistake, not a MongoDB failure, so it doesn't count against
+	// the breaker.
+	var emailTaken bool
+	err := s.mongoCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
 		collection := s.mongoClient.Database(s.mongoDatabase).Collection("user_profiles")
-		_, err := collection.InsertOne(ctx, profile)
+		existing, err := collection.CountDocuments(ctx, bson.M{"email": email}, options.Count().SetLimit(1))
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			emailTaken = true
+			return nil
+		}
+		_, err = collection.InsertOne(ctx, profile)
+		// The unique email_1 index catches a concurrent registration that
+		// passed the check above
+		if mongo.IsDuplicateKeyError(err) {
+			emailTaken = true
+			return nil
+		}
 		return err
 	})
 
+	if emailTaken {
+		log.Warn("Email already registered", zap.String("email", email))
+		return nil, errors.EmailAlreadyRegistered(email)
+	}
 	if err != nil {
 		log.Error("Failed to create user in MongoDB", zap.Error(err))
 		return nil, fmt.Errorf("failed to create user: %w", err)
@@ -273,9 +939,10 @@ func (s *PatternsService) GetUser(ctx context.Context, id uuid.UUID) (*models.Us
 
 	var profile models.UserProfile
 
-	err := s.mongoCircuitBreaker.Execute(func() error {
+	err := s.mongoCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
 		collection := s.mongoClient.Database(s.mongoDatabase).Collection("user_profiles")
-		filter := bson.M{"_id": id.String()}
+		// deletedAt: nil matches profiles where the field is missing, i.e. not soft-deleted
+		filter := bson.M{"_id": id.String(), "deletedAt": nil}
 		return collection.FindOne(ctx, filter).Decode(&profile)
 	})
 
@@ -290,13 +957,133 @@ func (s *PatternsService) GetUser(ctx context.Context, id uuid.UUID) (*models.Us
 	return &profile, nil
 }
 
+// SoftDeleteUser marks a user profile as deleted. Deleted users are hidden from
+// reads and analytics and can be restored until PurgeDeletedUsers removes them.
+func (s *PatternsService) SoftDeleteUser(ctx context.Context, id uuid.UUID) error {
+	log := s.logger.WithContext(ctx)
+
+	log.Info("Soft-deleting user profile", zap.String("user_id", id.String()))
+
+	now := time.Now().UTC()
+	var profile models.UserProfile
+
+	err := s.mongoCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		collection := s.mongoClient.Database(s.mongoDatabase).Collection("user_profiles")
+		filter := bson.M{"_id": id.String(), "deletedAt": nil}
+		update := bson.M{"$set": bson.M{"deletedAt": now, "updatedAt": now}}
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		return collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&profile)
+	})
+
+	if err == mongo.ErrNoDocuments {
+		return errors.ErrUserNotFound
+	}
+	if err != nil {
+		log.Error("Failed to soft-delete user in MongoDB", zap.Error(err))
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	if s.kafkaProducer != nil {
+		event := models.NewUserDeletedEvent(&profile, "ai-patterns")
+		if err := s.publishUserEvent(ctx, event); err != nil {
+			log.Warn("Failed to publish user deleted event", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// RestoreUser undoes a soft delete that has not yet been purged
+func (s *PatternsService) RestoreUser(ctx context.Context, id uuid.UUID) (*models.UserProfile, error) {
+	log := s.logger.WithContext(ctx)
+
+	log.Info("Restoring user profile", zap.String("user_id", id.String()))
+
+	var profile models.UserProfile
+
+	err := s.mongoCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		collection := s.mongoClient.Database(s.mongoDatabase).Collection("user_profiles")
+		filter := bson.M{"_id": id.String(), "deletedAt": bson.M{"$ne": nil}}
+		update := bson.M{
+			"$unset": bson.M{"deletedAt": ""},
+			"$set":   bson.M{"updatedAt": time.Now().UTC()},
+		}
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		return collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&profile)
+	})
+
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.ErrUserNotFound
+	}
+	if err != nil {
+		log.Error("Failed to restore user in MongoDB", zap.Error(err))
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	if s.kafkaProducer != nil {
+		event := models.NewUserRestoredEvent(&profile, "ai-patterns")
+		if err := s.publishUserEvent(ctx, event); err != nil {
+			log.Warn("Failed to publish user restored event", zap.Error(err))
+		}
+	}
+
+	return &profile, nil
+}
+
+// PurgeDeletedUsers permanently removes users soft-deleted more than retention ago
+func (s *PatternsService) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	log := s.logger.WithContext(ctx)
+
+	cutoff := time.Now().UTC().Add(-retention)
+	var purged int64
+
+	err := s.mongoCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		collection := s.mongoClient.Database(s.mongoDatabase).Collection("user_profiles")
+		result, err := collection.DeleteMany(ctx, bson.M{"deletedAt": bson.M{"$lte": cutoff}})
+		if err != nil {
+			return err
+		}
+		purged = result.DeletedCount
+		return nil
+	})
+
+	if err != nil {
+		log.Error("Failed to purge deleted users", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge deleted users: %w", err)
+	}
+
+	if purged > 0 {
+		log.Info("Purged soft-deleted users",
+			zap.Int64("count", purged),
+			zap.Time("deleted_before", cutoff))
+	}
+
+	return purged, nil
+}
+
+// RunUserPurge runs PurgeDeletedUsers every interval until ctx is cancelled
+func (s *PatternsService) RunUserPurge(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors are logged by PurgeDeletedUsers; retry on the next tick
+			_, _ = s.PurgeDeletedUsers(ctx, retention)
+		}
+	}
+}
+
 // UpdateUserPreferences updates user preferences in MongoDB
 func (s *PatternsService) UpdateUserPreferences(ctx context.Context, id uuid.UUID, prefs models.UserPreferences) error {
 	log := s.logger.WithContext(ctx)
 
 	log.Info("Updating user preferences", zap.String("user_id", id.String()))
 
-	err := s.mongoCircuitBreaker.Execute(func() error {
+	err := s.mongoCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
 		collection := s.mongoClient.Database(s.mongoDatabase).Collection("user_profiles")
 		filter := bson.M{"_id": id.String()}
 		update := bson.M{
@@ -331,6 +1118,18 @@ func (s *PatternsService) RecordTelemetry(ctx context.Context, req *models.Recor
 		zap.String("device_id", req.DeviceID),
 		zap.String("metric", req.Metric))
 
+	// Readings are timestamped by the device when it sends a time, within
+	// the configured clock skew
+	ingestTime := start.UTC()
+	eventTime, err := s.telemetryTimestamp(ctx, req, ingestTime)
+	if err != nil {
+		log.Warn("Rejected telemetry timestamp",
+			zap.String("device_id", req.DeviceID),
+			zap.Timep("device_timestamp", req.Timestamp),
+			zap.Error(err))
+		return nil, err
+	}
+
 	// Create telemetry record
 	telemetry := &models.DeviceTelemetry{
 		CorrelationID: uuid.New(),
@@ -338,30 +1137,25 @@ func (s *PatternsService) RecordTelemetry(ctx context.Context, req *models.Recor
 		Metric:        req.Metric,
 		Value:         req.Value,
 		Unit:          req.Unit,
-		Timestamp:     time.Now(),
+		Timestamp:     eventTime,
+		EventTime:     eventTime,
+		IngestTime:    ingestTime,
 	}
 
 	// Insert into ScyllaDB using Core.Infrastructure.ScyllaDB
-	err := s.scyllaCircuitBreaker.Execute(func() error {
-		query := `
-			INSERT INTO device_telemetry (correlation_id, device_id, metric, value, unit, timestamp)
-			VALUES (?, ?, ?, ?, ?, ?)`
-		return s.scyllaSession.ExecContext(ctx, query,
-			telemetry.CorrelationID,
-			telemetry.DeviceID,
-			telemetry.Metric,
-			telemetry.Value,
-			telemetry.Unit,
-			telemetry.Timestamp,
-		)
+	err = s.scyllaCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		query, args := s.telemetryInsert(telemetry)
+		return s.telemetrySession(telemetry.DeviceID).ExecContext(ctx, query, args...)
 	})
 
 	if err != nil {
 		log.Error("Failed to record telemetry in ScyllaDB", zap.Error(err))
-		s.sli.RecordTelemetryIngestionFailure()
+		s.sli.RecordTelemetryIngestionFailure(errors.ProductErrors.WrapError(err, "PAT-INFRA-005", err))
 		return nil, fmt.Errorf("failed to record telemetry: %w", err)
 	}
 
+	s.addTelemetryCount(ctx, telemetry.DeviceID, telemetry.EventTime, 1)
+
 	// Publish telemetry event via Kafka
 	if s.kafkaProducer != nil {
 		event := models.NewTelemetryReceivedEvent(telemetry, "ai-patterns")
@@ -378,10 +1172,51 @@ func (s *PatternsService) RecordTelemetry(ctx context.Context, req *models.Recor
 	return telemetry, nil
 }
 
-// GetTelemetryHistory retrieves telemetry history from ScyllaDB
+// telemetryInsert returns the statement and arguments inserting telemetry.
+// The timestamp clustering column holds event time. TTL 0 means the row
+// never expires.
+func (s *PatternsService) telemetryInsert(telemetry *models.DeviceTelemetry) (string, []interface{}) {
+	ttl := s.telemetryRetention.ttlSeconds(telemetry.Metric)
+	if s.telemetryTimestamps.StoreIngestTime {
+		query := `
+			INSERT INTO device_telemetry (correlation_id, device_id, metric, value, unit, timestamp, ingest_time)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			USING TTL ?`
+		return query, []interface{}{
+			telemetry.CorrelationID,
+			telemetry.DeviceID,
+			telemetry.Metric,
+			telemetry.Value,
+			telemetry.Unit,
+			telemetry.EventTime,
+			telemetry.IngestTime,
+			ttl,
+		}
+	}
+
+	query := `
+		INSERT INTO device_telemetry (correlation_id, device_id, metric, value, unit, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?)
+		USING TTL ?`
+	return query, []interface{}{
+		telemetry.CorrelationID,
+		telemetry.DeviceID,
+		telemetry.Metric,
+		telemetry.Value,
+		telemetry.Unit,
+		telemetry.EventTime,
+		ttl,
+	}
+}
+
+// GetTelemetryHistory retrieves telemetry history from ScyllaDB.
+// Rows older than their metric's retention are excluded even if not yet purged.
 func (s *PatternsService) GetTelemetryHistory(ctx context.Context, deviceID string, startTime, endTime time.Time) ([]*models.DeviceTelemetry, error) {
 	log := s.logger.WithContext(ctx)
 
+	now := time.Now().UTC()
+	startTime = s.telemetryRetention.clampStart(startTime, now)
+
 	log.Debug("Getting telemetry history",
 		zap.String("device_id", deviceID),
 		zap.Time("start", startTime),
@@ -389,19 +1224,30 @@ func (s *PatternsService) GetTelemetryHistory(ctx context.Context, deviceID stri
 
 	var results []*models.DeviceTelemetry
 
-	err := s.scyllaCircuitBreaker.Execute(func() error {
+	err := s.scyllaCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		var t models.DeviceTelemetry
+		columns := "correlation_id, device_id, metric, value, unit, timestamp"
+		dest := []interface{}{&t.CorrelationID, &t.DeviceID, &t.Metric, &t.Value, &t.Unit, &t.EventTime}
+		if s.telemetryTimestamps.StoreIngestTime {
+			columns += ", ingest_time"
+			dest = append(dest, &t.IngestTime)
+		}
+
 		query := `
-			SELECT correlation_id, device_id, metric, value, unit, timestamp
+			SELECT ` + columns + `
 			FROM device_telemetry
 			WHERE device_id = ? AND timestamp >= ? AND timestamp <= ?
 			ORDER BY timestamp DESC
 			LIMIT 1000`
 
-		iter := s.scyllaSession.QueryIter(ctx, query, deviceID, startTime, endTime)
+		iter := s.telemetrySession(deviceID).QueryIter(ctx, query, deviceID, startTime, endTime)
 		defer iter.Close()
 
-		var t models.DeviceTelemetry
-		for iter.Scan(&t.CorrelationID, &t.DeviceID, &t.Metric, &t.Value, &t.Unit, &t.Timestamp) {
+		for iter.Scan(dest...) {
+			if s.telemetryRetention.expired(t.Metric, t.EventTime, now) {
+				continue
+			}
+			t.Timestamp = t.EventTime
 			record := t // copy
 			results = append(results, &record)
 		}
@@ -421,7 +1267,9 @@ func (s *PatternsService) GetTelemetryHistory(ctx context.Context, deviceID stri
 // Demonstrates: Core.Infrastructure.Redis usage
 // =============================================================================
 
-// UpdateLeaderboard updates a leaderboard entry in Redis
+// UpdateLeaderboard sets a user's score (last write wins) on the category's
+// all-time board and on each enabled window (daily/weekly/monthly). Use
+// IncrementLeaderboard for additive updates that may race.
 func (s *PatternsService) UpdateLeaderboard(ctx context.Context, category, userID string, score float64) error {
 	log := s.logger.WithContext(ctx)
 
@@ -430,63 +1278,202 @@ func (s *PatternsService) UpdateLeaderboard(ctx context.Context, category, userI
 		zap.String("user_id", userID),
 		zap.Float64("score", score))
 
-	// Store in Redis using Core.Infrastructure.Redis
-	key := fmt.Sprintf("leaderboard:%s", category)
-	entry := map[string]interface{}{
-		"user_id": userID,
-		"score":   score,
-	}
-
-	if err := s.redisClient.Set(ctx, fmt.Sprintf("%s:%s", key, userID), entry); err != nil {
+	event := models.NewScoreUpdatedEvent(category, userID, score, "ai-patterns")
+	if _, err := s.applyLeaderboardEvent(ctx, event, true); err != nil {
 		log.Error("Failed to update leaderboard in Redis", zap.Error(err))
 		return fmt.Errorf("failed to update leaderboard: %w", err)
 	}
 
-	// Add to set for tracking
-	if err := s.redisClient.SAdd(ctx, key, userID); err != nil {
-		log.Warn("Failed to add to leaderboard set", zap.Error(err))
+	// Publish the score so leaderboards can be rebuilt from Kafka
+	if s.kafkaProducer != nil {
+		if err := s.publishLeaderboardEvent(ctx, event); err != nil {
+			log.Warn("Failed to publish score updated event", zap.Error(err))
+		}
 	}
 
 	return nil
 }
 
-// GetLeaderboard retrieves leaderboard entries from Redis
-func (s *PatternsService) GetLeaderboard(ctx context.Context, category string, top int) ([]models.LeaderboardEntry, error) {
+// IncrementLeaderboard atomically adds delta to a user's score on the
+// category's all-time board and each enabled window, using ZINCRBY so
+// concurrent increments from different servers are never lost. It returns
+// the user's new all-time score.
+func (s *PatternsService) IncrementLeaderboard(ctx context.Context, category, userID string, delta float64) (float64, error) {
 	log := s.logger.WithContext(ctx)
 
-	log.Debug("Getting leaderboard",
+	log.Info("Incrementing leaderboard score",
 		zap.String("category", category),
-		zap.Int("top", top))
-
-	key := fmt.Sprintf("leaderboard:%s", category)
+		zap.String("user_id", userID),
+		zap.Float64("delta", delta))
 
-	// Get members from set using Core.Infrastructure.Redis
-	members, err := s.redisClient.SMembers(ctx, key)
+	event := models.NewScoreIncrementedEvent(category, userID, delta, "ai-patterns")
+	// No dedupe: a fresh event can't have been applied yet
+	score, err := s.applyLeaderboardEvent(ctx, event, false)
 	if err != nil {
-		log.Error("Failed to get leaderboard members from Redis", zap.Error(err))
-		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+		log.Error("Failed to increment leaderboard in Redis", zap.Error(err))
+		return 0, fmt.Errorf("failed to increment leaderboard: %w", err)
 	}
+	event.Score = score
 
-	var entries []models.LeaderboardEntry
-	for i, userID := range members {
-		if i >= top {
-			break
+	if s.kafkaProducer != nil {
+		if err := s.publishLeaderboardEvent(ctx, event); err != nil {
+			log.Warn("Failed to publish score incremented event", zap.Error(err))
 		}
+	}
 
-		// Get entry data
-		data, err := s.redisClient.Get(ctx, fmt.Sprintf("%s:%s", key, userID))
-		if err != nil || data == "" {
-			continue
+	return score, nil
+}
+
+// leaderboardEntry is the Redis value stored per leaderboard member. It
+// records when the member's score last changed so replays can skip events
+// that have already been applied.
+type leaderboardEntry struct {
+	UserID    string    `json:"user_id"`
+	Score     float64   `json:"score"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// leaderboardDedupeWindow is how long applied increment IDs are remembered.
+// It matches Kafka's default log retention, so a replay never sees an
+// increment whose marker has already expired.
+const leaderboardDedupeWindow = 7 * 24 * time.Hour
+
+// ApplyLeaderboardEvent writes a score event to Redis. It is idempotent:
+// absolute scores no newer than the stored entry are ignored, and increments
+// already applied are recognised by event ID, so replaying the leaderboard
+// topic (see cmd/leaderboard-rebuild) converges on the latest scores.
+// Windowed boards are keyed by the event's period; periods that have already
+// ended are skipped since their keys have expired.
+func (s *PatternsService) ApplyLeaderboardEvent(ctx context.Context, event *models.LeaderboardEvent) error {
+	_, err := s.applyLeaderboardEvent(ctx, event, true)
+	return err
+}
+
+// applyLeaderboardEvent writes event to every board it belongs to and returns
+// the resulting all-time score. With dedupe, events already reflected in
+// Redis are skipped.
+func (s *PatternsService) applyLeaderboardEvent(ctx context.Context, event *models.LeaderboardEvent, dedupe bool) (float64, error) {
+	// Concurrent increments are published in arrival order, not timestamp
+	// order, so they are deduplicated by ID rather than by timestamp
+	var marker string
+	if dedupe && event.IsIncrement() {
+		marker = fmt.Sprintf("leaderboard-applied:%s", event.EventID)
+		if data, err := s.redisClient.Get(ctx, marker); err == nil && data != "" {
+			return event.Score, nil
 		}
+	}
+	checkStale := dedupe && !event.IsIncrement()
 
-		var entry map[string]interface{}
-		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+	key, _ := leaderboardKey(event.Category, models.LeaderboardAllTime, event.Timestamp)
+	score, err := s.applyLeaderboardScore(ctx, key, 0, event, checkStale)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	for _, window := range s.leaderboardWindows {
+		key, end := leaderboardKey(event.Category, window, event.Timestamp)
+		if !end.After(now) {
 			continue
 		}
+		if _, err := s.applyLeaderboardScore(ctx, key, end.Sub(now), event, checkStale); err != nil {
+			return 0, err
+		}
+	}
+
+	if marker != "" {
+		if err := s.redisClient.Set(ctx, marker, "1"); err != nil {
+			return 0, err
+		}
+		if err := s.redisClient.Expire(ctx, marker, leaderboardDedupeWindow); err != nil {
+			s.logger.WithContext(ctx).Warn("Failed to set leaderboard dedupe expiration", zap.Error(err))
+		}
+	}
+
+	return score, nil
+}
+
+// applyLeaderboardScore writes event to the board at key and returns the
+// member's resulting score; ttl > 0 expires the board
+func (s *PatternsService) applyLeaderboardScore(ctx context.Context, key string, ttl time.Duration, event *models.LeaderboardEvent, checkStale bool) (float64, error) {
+	log := s.logger.WithContext(ctx)
+	entryKey := fmt.Sprintf("%s:%s", key, event.UserID)
+
+	if checkStale {
+		if data, err := s.redisClient.Get(ctx, entryKey); err == nil && data != "" {
+			var current leaderboardEntry
+			if json.Unmarshal([]byte(data), &current) == nil && !current.UpdatedAt.Before(event.Timestamp) {
+				return current.Score, nil
+			}
+		}
+	}
 
+	// Store in a sorted set using Core.Infrastructure.Redis
+	score := event.Score
+	if event.IsIncrement() {
+		var err error
+		if score, err = s.redisClient.ZIncrBy(ctx, key, event.Delta, event.UserID); err != nil {
+			return 0, err
+		}
+	} else if err := s.redisClient.ZAdd(ctx, key, redis.ScoredMember{Member: event.UserID, Score: score}); err != nil {
+		return 0, err
+	}
+
+	entry := leaderboardEntry{
+		UserID:    event.UserID,
+		Score:     score,
+		UpdatedAt: event.Timestamp,
+	}
+	if err := s.redisClient.Set(ctx, entryKey, entry); err != nil {
+		log.Warn("Failed to record leaderboard entry", zap.Error(err))
+	}
+
+	// Old windows expire on their own once their period has ended
+	if ttl > 0 {
+		for _, k := range []string{entryKey, key} {
+			if err := s.redisClient.Expire(ctx, k, ttl); err != nil {
+				log.Warn("Failed to set leaderboard expiration", zap.String("key", k), zap.Error(err))
+			}
+		}
+	}
+
+	return score, nil
+}
+
+// GetLeaderboard retrieves leaderboard entries from Redis for the current
+// period of window (all-time if empty)
+func (s *PatternsService) GetLeaderboard(ctx context.Context, category string, window models.LeaderboardWindow, top int) ([]models.LeaderboardEntry, error) {
+	log := s.logger.WithContext(ctx)
+
+	if window == "" {
+		window = models.LeaderboardAllTime
+	}
+	if !window.IsValid() {
+		return nil, errors.ErrInvalidLeaderboardWindow
+	}
+	if !s.leaderboardWindowEnabled(window) {
+		return nil, fmt.Errorf("%w: %s", errors.ErrLeaderboardWindowOff, window)
+	}
+
+	log.Debug("Getting leaderboard",
+		zap.String("category", category),
+		zap.String("window", string(window)),
+		zap.Int("top", top))
+
+	key, _ := leaderboardKey(category, window, s.now())
+
+	// Highest scores first using Core.Infrastructure.Redis
+	members, err := s.redisClient.ZRevRangeWithScores(ctx, key, 0, int64(top)-1)
+	if err != nil {
+		log.Error("Failed to get leaderboard members from Redis", zap.Error(err))
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	entries := make([]models.LeaderboardEntry, 0, len(members))
+	for i, m := range members {
 		entries = append(entries, models.LeaderboardEntry{
-			UserID: userID,
-			Score:  entry["score"].(float64),
+			UserID: m.Member,
+			Score:  m.Score,
 			Rank:   i + 1,
 		})
 	}
@@ -494,32 +1481,82 @@ func (s *PatternsService) GetLeaderboard(ctx context.Context, category string, t
 	return entries, nil
 }
 
-// CreateSession creates a user session in Redis
+// GetLeaderboardAround returns userID's rank on the category's all-time board
+// with up to radius entries ranked above and below them. A user without a
+// score gets a result with OnBoard false rather than an error.
+func (s *PatternsService) GetLeaderboardAround(ctx context.Context, category, userID string, radius int) (*models.LeaderboardAround, error) {
+	log := s.logger.WithContext(ctx)
+
+	log.Debug("Getting leaderboard around user",
+		zap.String("category", category),
+		zap.String("user_id", userID),
+		zap.Int("radius", radius))
+
+	if radius < 0 {
+		radius = 0
+	}
+	key, _ := leaderboardKey(category, models.LeaderboardAllTime, s.now())
+	result := &models.LeaderboardAround{UserID: userID, Entries: []models.LeaderboardEntry{}}
+
+	rank, err := s.redisClient.ZRevRank(ctx, key, userID)
+	if err != nil {
+		log.Error("Failed to get leaderboard rank from Redis", zap.Error(err))
+		return nil, fmt.Errorf("failed to get leaderboard rank: %w", err)
+	}
+	if rank < 0 {
+		return result, nil
+	}
+
+	start := rank - int64(radius)
+	if start < 0 {
+		start = 0
+	}
+	members, err := s.redisClient.ZRevRangeWithScores(ctx, key, start, rank+int64(radius))
+	if err != nil {
+		log.Error("Failed to get leaderboard members from Redis", zap.Error(err))
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	result.OnBoard = true
+	result.Rank = int(rank) + 1
+	for i, m := range members {
+		result.Entries = append(result.Entries, models.LeaderboardEntry{
+			UserID: m.Member,
+			Score:  m.Score,
+			Rank:   int(start) + i + 1,
+		})
+	}
+
+	return result, nil
+}
+
+// CreateSession creates a user session in the session store (Redis by default)
 func (s *PatternsService) CreateSession(ctx context.Context, req *models.CreateSessionRequest) (*models.Session, error) {
 	log := s.logger.WithContext(ctx)
 
 	log.Info("Creating session",
 		zap.String("user_id", req.UserID.String()))
 
-	session := &models.Session{
-		SessionID: uuid.New().String(),
-		UserID:    req.UserID,
-		UserEmail: req.UserEmail,
-		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().Add(24 * time.Hour),
-	}
-
-	// Store in Redis using Core.Infrastructure.Redis
-	key := fmt.Sprintf("session:%s", session.SessionID)
-	if err := s.redisClient.Set(ctx, key, session); err != nil {
-		log.Error("Failed to create session in Redis", zap.Error(err))
+	store, err := s.sessionStore()
+	if err != nil {
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
-	// Set expiration
-	if err := s.redisClient.Expire(ctx, key, 24*time.Hour); err != nil {
-		log.Warn("Failed to set session expiration", zap.Error(err))
+	now := s.now()
+	session := &models.Session{
+		SessionID:    uuid.New().String(),
+		UserID:       req.UserID,
+		UserEmail:    req.UserEmail,
+		Device:       req.Device,
+		IPAddress:    req.IPAddress,
+		CreatedAt:    now,
+		MaxExpiresAt: now.Add(s.sessionMaxLifetime),
+	}
+	if err := store.Create(ctx, session, s.sessionTTL); err != nil {
+		log.Error("Failed to create session", zap.Error(err))
+		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
+	s.evictExcessSessions(ctx, store, session)
 
 	// Publish session created event via Kafka
 	if s.kafkaProducer != nil {
@@ -532,30 +1569,28 @@ func (s *PatternsService) CreateSession(ctx context.Context, req *models.CreateS
 	return session, nil
 }
 
-// GetSession retrieves a session from Redis
+// GetSession retrieves a session from the session store. Each access slides
+// the session's expiry forward by the idle timeout, up to its max lifetime.
 func (s *PatternsService) GetSession(ctx context.Context, sessionID string) (*models.Session, error) {
 	log := s.logger.WithContext(ctx)
 
 	log.Debug("Getting session", zap.String("session_id", sessionID))
 
-	key := fmt.Sprintf("session:%s", sessionID)
-	data, err := s.redisClient.Get(ctx, key)
+	store, err := s.sessionStore()
 	if err != nil {
-		log.Error("Failed to get session from Redis", zap.Error(err))
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
-	if data == "" {
-		return nil, errors.ErrSessionNotFound
+	session, err := store.Refresh(ctx, sessionID, s.sessionTTL)
+	if goerrors.Is(err, errors.ErrSessionNotFound) {
+		return nil, err
 	}
-
-	var session models.Session
-	if err := json.Unmarshal([]byte(data), &session); err != nil {
-		log.Error("Failed to unmarshal session", zap.Error(err))
-		return nil, fmt.Errorf("failed to parse session: %w", err)
+	if err != nil {
+		log.Error("Failed to get session", zap.Error(err))
+		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
-	return &session, nil
+	return session, nil
 }
 
 // =============================================================================
@@ -563,36 +1598,105 @@ func (s *PatternsService) GetSession(ctx context.Context, sessionID string) (*mo
 // Demonstrates: Core.Infrastructure.Kafka usage
 // =============================================================================
 
+// Topics, keys and headers are chosen by the eventbus package from the event
+// type. Events are encoded before the circuit breaker: an event that can't be
+// marshalled is a bug, not a Kafka failure, so it neither trips the breaker
+// nor lands in the dead-letter buffer.
+
+// publishOrderEvent falls back to the dead-letter buffer when Kafka is down or
+// the circuit is open; RunDeadLetterReplay republishes the buffered message
 func (s *PatternsService) publishOrderEvent(ctx context.Context, event *models.OrderEvent) error {
-	return s.kafkaCircuitBreaker.Execute(func() error {
-		payload, _ := json.Marshal(event)
-		headers := map[string]string{
-			"event_type":     event.EventType,
-			"correlation_id": event.CorrelationID,
-		}
-		return s.kafkaProducer.SendMessage(ctx, "orders.events", event.OrderID.String(), payload, headers)
+	msg, err := eventbus.Encode(ctx, event)
+	if err != nil {
+		return errors.ExternalServiceError("kafka", err)
+	}
+	return s.kafkaCircuitBreaker.ExecuteWithFallback(func() error {
+		return eventbus.Send(ctx, s.kafkaProducer, msg)
+	}, func() error {
+		s.deadLetters.Add(msg)
+		s.logger.WithContext(ctx).Warn("Kafka unavailable, order event buffered for replay",
+			zap.String("event_type", event.EventName()),
+			zap.String("order_id", event.EventKey()),
+			zap.Int("buffered", s.deadLetters.Len()),
+			zap.Int64("evicted", s.deadLetters.Evicted()),
+		)
+		return nil
 	})
 }
 
-func (s *PatternsService) publishUserEvent(ctx context.Context, event *models.UserEvent) error {
-	return s.kafkaCircuitBreaker.Execute(func() error {
-		payload, _ := json.Marshal(event)
-		headers := map[string]string{
-			"event_type":     event.EventType,
-			"correlation_id": event.CorrelationID,
-		}
-		return s.kafkaProducer.SendMessage(ctx, "users.events", event.UserID.String(), payload, headers)
+// DeadLetters returns the buffer of order events that couldn't be published
+func (s *PatternsService) DeadLetters() *eventbus.DeadLetterBuffer {
+	return s.deadLetters
+}
+
+// ReplayDeadLetters republishes buffered order events through the Kafka
+// circuit breaker, oldest first, and returns how many were sent. While the
+// circuit is open the first send fails fast and the events stay buffered.
+func (s *PatternsService) ReplayDeadLetters(ctx context.Context) (int, error) {
+	if s.deadLetters.Len() == 0 {
+		return 0, nil
+	}
+
+	sent, err := s.deadLetters.Replay(func(msg *eventbus.Message) error {
+		return s.sendEvent(ctx, msg)
 	})
+	log := s.logger.WithContext(ctx)
+	if err != nil {
+		log.Warn("Dead-letter replay stopped, Kafka still unavailable",
+			zap.Int("sent", sent),
+			zap.Int("buffered", s.deadLetters.Len()),
+			zap.Error(err))
+		return sent, err
+	}
+	log.Info("Dead-letter events republished", zap.Int("sent", sent))
+	return sent, nil
 }
 
-func (s *PatternsService) publishTelemetryEvent(ctx context.Context, event *models.TelemetryEvent) error {
-	return s.kafkaCircuitBreaker.Execute(func() error {
-		payload, _ := json.Marshal(event)
-		headers := map[string]string{
-			"event_type":     event.EventType,
-			"correlation_id": event.CorrelationID,
+// RunDeadLetterReplay calls ReplayDeadLetters every interval until ctx is
+// cancelled
+func (s *PatternsService) RunDeadLetterReplay(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors are logged by ReplayDeadLetters
+			s.ReplayDeadLetters(ctx)
 		}
-		return s.kafkaProducer.SendMessage(ctx, "telemetry.events", event.DeviceID, payload, headers)
+	}
+}
+
+func (s *PatternsService) publishUserEvent(ctx context.Context, event *models.UserEvent) error {
+	msg, err := eventbus.Encode(ctx, event)
+	if err != nil {
+		return errors.ExternalServiceError("kafka", err)
+	}
+	return s.sendEvent(ctx, msg)
+}
+
+func (s *PatternsService) publishTelemetryEvent(ctx context.Context, event *models.TelemetryEvent) error {
+	msg, err := eventbus.Encode(ctx, event)
+	if err != nil {
+		return errors.ExternalServiceError("kafka", err)
+	}
+	return s.sendEvent(ctx, msg)
+}
+
+func (s *PatternsService) publishLeaderboardEvent(ctx context.Context, event *models.LeaderboardEvent) error {
+	msg, err := eventbus.Encode(ctx, event)
+	if err != nil {
+		return errors.ExternalServiceError("kafka", err)
+	}
+	return s.sendEvent(ctx, msg)
+}
+
+// sendEvent sends an encoded event through the Kafka circuit breaker
+func (s *PatternsService) sendEvent(ctx context.Context, msg *eventbus.Message) error {
+	return s.kafkaCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		return eventbus.Send(ctx, s.kafkaProducer, msg)
 	})
 }
 
@@ -613,79 +1717,217 @@ func (s *PatternsService) GetAnalytics(ctx context.Context, startDate, endDate t
 		StartDate:   startDate,
 		EndDate:     endDate,
 		GeneratedAt: time.Now(),
+		Sources:     make(map[string]models.SourceStatus),
 	}
 
+	// Each configured store contributes a query; results are applied to the
+	// result only by this goroutine, so late stores can't race the response
+	var queries []analyticsQuery
+
 	// SQL Server analytics
 	if s.sqlDB != nil {
-		sqlAnalytics, err := s.getSQLServerAnalytics(ctx, startDate, endDate)
-		if err != nil {
-			log.Warn("Failed to get SQL Server analytics", zap.Error(err))
-		} else {
-			result.SQLServer = sqlAnalytics
-		}
+		queries = append(queries, analyticsQuery{models.SourceSQLServer, func(ctx context.Context) (func(*models.PlatformAnalyticsResult), error) {
+			analytics, err := s.getSQLServerAnalytics(ctx, startDate, endDate)
+			return func(r *models.PlatformAnalyticsResult) { r.SQLServer = analytics }, err
+		}})
+	} else {
+		result.Sources[models.SourceSQLServer] = models.SourceStatus{Status: models.SourceStatusSkipped}
 	}
 
 	// MongoDB analytics
 	if s.mongoClient != nil {
-		mongoAnalytics, err := s.getMongoDBAnalytics(ctx, startDate, endDate)
-		if err != nil {
-			log.Warn("Failed to get MongoDB analytics", zap.Error(err))
-		} else {
-			result.MongoDB = mongoAnalytics
-		}
+		queries = append(queries, analyticsQuery{models.SourceMongoDB, func(ctx context.Context) (func(*models.PlatformAnalyticsResult), error) {
+			analytics, err := s.getMongoDBAnalytics(ctx, startDate, endDate)
+			return func(r *models.PlatformAnalyticsResult) { r.MongoDB = analytics }, err
+		}})
+	} else {
+		result.Sources[models.SourceMongoDB] = models.SourceStatus{Status: models.SourceStatusSkipped}
 	}
 
 	// ScyllaDB analytics
 	if s.scyllaSession != nil {
-		scyllaAnalytics, err := s.getScyllaDBAnalytics(ctx, startDate, endDate)
-		if err != nil {
-			log.Warn("Failed to get ScyllaDB analytics", zap.Error(err))
-		} else {
-			result.ScyllaDB = scyllaAnalytics
-		}
+		queries = append(queries, analyticsQuery{models.SourceScyllaDB, func(ctx context.Context) (func(*models.PlatformAnalyticsResult), error) {
+			analytics, err := s.getScyllaDBAnalytics(ctx, startDate, endDate)
+			return func(r *models.PlatformAnalyticsResult) { r.ScyllaDB = analytics }, err
+		}})
+	} else {
+		result.Sources[models.SourceScyllaDB] = models.SourceStatus{Status: models.SourceStatusSkipped}
 	}
 
 	// Redis analytics
 	if s.redisClient != nil {
-		redisAnalytics, err := s.getRedisAnalytics(ctx)
-		if err != nil {
-			log.Warn("Failed to get Redis analytics", zap.Error(err))
-		} else {
-			result.Redis = redisAnalytics
-		}
+		queries = append(queries, analyticsQuery{models.SourceRedis, func(ctx context.Context) (func(*models.PlatformAnalyticsResult), error) {
+			analytics, err := s.getRedisAnalytics(ctx)
+			return func(r *models.PlatformAnalyticsResult) { r.Redis = analytics }, err
+		}})
+	} else {
+		result.Sources[models.SourceRedis] = models.SourceStatus{Status: models.SourceStatusSkipped}
+	}
+
+	s.runAnalyticsQueries(ctx, queries, result)
+
+	if result.IsPartial() {
+		log.Warn("Returning partial analytics", zap.Any("sources", result.Sources))
 	}
 
 	return result, nil
 }
 
+// analyticsQuery queries a single store; on success the returned func applies its data to the result
+type analyticsQuery struct {
+	source string
+	run    func(ctx context.Context) (func(*models.PlatformAnalyticsResult), error)
+}
+
+type analyticsOutcome struct {
+	source string
+	apply  func(*models.PlatformAnalyticsResult)
+	err    error
+}
+
+// runAnalyticsQueries runs the queries concurrently, each with its own timeout,
+// and waits until all complete or the overall deadline passes.
+// Stores that have not answered by the deadline are marked timed out.
+func (s *PatternsService) runAnalyticsQueries(ctx context.Context, queries []analyticsQuery, result *models.PlatformAnalyticsResult) {
+	log := s.logger.WithContext(ctx)
+
+	deadlineCtx, cancel := context.WithTimeout(ctx, s.analyticsDeadline)
+	defer cancel()
+
+	// Buffered so stores finishing after the deadline don't block. A query
+	// that panics is logged and reported as timed out at the deadline.
+	outcomes := make(chan analyticsOutcome, len(queries))
+	for _, q := range queries {
+		safego.Go(deadlineCtx, s.logger, func(ctx context.Context) {
+			storeCtx, storeCancel := context.WithTimeout(ctx, s.analyticsStoreTimeout)
+			defer storeCancel()
+
+			apply, err := q.run(storeCtx)
+			if err == nil && storeCtx.Err() != nil {
+				err = storeCtx.Err()
+			}
+			outcomes <- analyticsOutcome{source: q.source, apply: apply, err: err}
+		})
+	}
+
+	for pending := len(queries); pending > 0; pending-- {
+		select {
+		case outcome := <-outcomes:
+			if outcome.err != nil {
+				log.Warn("Failed to get analytics",
+					zap.String("source", outcome.source),
+					zap.Error(outcome.err))
+			} else {
+				outcome.apply(result)
+			}
+			result.Sources[outcome.source] = sourceStatus(outcome.err)
+		case <-deadlineCtx.Done():
+			for _, q := range queries {
+				if _, done := result.Sources[q.source]; !done {
+					log.Warn("Analytics store timed out", zap.String("source", q.source))
+					result.Sources[q.source] = models.SourceStatus{
+						Status: models.SourceStatusTimedOut,
+						Error:  deadlineCtx.Err().Error(),
+					}
+				}
+			}
+			return
+		}
+	}
+}
+
+// sourceStatus converts a store query error into its reported status
+func sourceStatus(err error) models.SourceStatus {
+	if goerrors.Is(err, context.DeadlineExceeded) {
+		return models.SourceStatus{Status: models.SourceStatusTimedOut, Error: err.Error()}
+	}
+	if err != nil {
+		return models.SourceStatus{Status: models.SourceStatusFailed, Error: err.Error()}
+	}
+	return models.SourceStatus{Status: models.SourceStatusOK}
+}
+
+// getSQLServerAnalytics sums revenue per currency and converts each sum to the
+// reporting currency. Currencies without a rate are excluded from the total and
+// listed in UnconvertedCurrencies rather than mixed in unconverted.
 func (s *PatternsService) getSQLServerAnalytics(ctx context.Context, start, end time.Time) (*models.SQLServerAnalytics, error) {
+	log := s.logger.WithContext(ctx)
+
 	query := `
-		SELECT 
+		SELECT
+			Currency,
 			COUNT(*) as total_orders,
-			COALESCE(SUM(TotalAmount), 0) as total_revenue,
-			COALESCE(AVG(TotalAmount), 0) as avg_order_value
+			COALESCE(SUM(TotalAmount), 0) as total_revenue
 		FROM Orders
-		WHERE CreatedAt BETWEEN @p1 AND @p2`
+		WHERE CreatedAt BETWEEN @p1 AND @p2
+		GROUP BY Currency`
+
+	rows, err := s.sqlDB.QueryContext(ctx, query, sql.Named("p1", start), sql.Named("p2", end))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	row := s.sqlDB.QueryRowContext(ctx, query, sql.Named("p1", start), sql.Named("p2", end))
+	analytics := &models.SQLServerAnalytics{
+		ReportingCurrency: s.reportingCurrency,
+		RevenueByCurrency: make(map[string]models.Money),
+	}
+	var convertedOrders int64
+	for rows.Next() {
+		var currency string
+		var orders int64
+		var revenue models.Money
+		if err := rows.Scan(&currency, &orders, &revenue); err != nil {
+			return nil, err
+		}
+		analytics.TotalOrders += orders
+		analytics.RevenueByCurrency[currency] = revenue
 
-	var analytics models.SQLServerAnalytics
-	if err := row.Scan(&analytics.TotalOrders, &analytics.TotalRevenue, &analytics.AverageOrderValue); err != nil {
+		rate, err := s.exchangeRate(ctx, currency)
+		if err != nil {
+			log.Warn("Excluding currency from analytics revenue",
+				zap.String("currency", currency),
+				zap.String("reporting_currency", s.reportingCurrency),
+				zap.Error(err))
+			analytics.UnconvertedCurrencies = append(analytics.UnconvertedCurrencies, currency)
+			continue
+		}
+		analytics.TotalRevenue += revenue.Convert(rate)
+		convertedOrders += orders
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return &analytics, nil
+	if convertedOrders > 0 {
+		analytics.AverageOrderValue = models.Money(math.Round(float64(analytics.TotalRevenue) / float64(convertedOrders)))
+	}
+	sort.Strings(analytics.UnconvertedCurrencies)
+
+	return analytics, nil
+}
+
+// exchangeRate returns the rate from currency to the reporting currency
+func (s *PatternsService) exchangeRate(ctx context.Context, currency string) (float64, error) {
+	if currency == s.reportingCurrency {
+		return 1, nil
+	}
+	if s.exchangeRates == nil {
+		return 0, fmt.Errorf("%w: no exchange rate provider configured", errors.ErrExchangeRateNotFound)
+	}
+	return s.exchangeRates.Rate(ctx, currency, s.reportingCurrency)
 }
 
 func (s *PatternsService) getMongoDBAnalytics(ctx context.Context, start, end time.Time) (*models.MongoDBAnalytics, error) {
 	collection := s.mongoClient.Database(s.mongoDatabase).Collection("user_profiles")
 
-	totalUsers, err := collection.CountDocuments(ctx, bson.M{})
+	totalUsers, err := collection.CountDocuments(ctx, bson.M{"deletedAt": nil})
 	if err != nil {
 		return nil, err
 	}
 
 	newRegistrations, err := collection.CountDocuments(ctx, bson.M{
+		"deletedAt": nil,
 		"created_at": bson.M{
 			"$gte": start,
 			"$lte": end,
@@ -704,11 +1946,15 @@ func (s *PatternsService) getMongoDBAnalytics(ctx context.Context, start, end ti
 func (s *PatternsService) getScyllaDBAnalytics(ctx context.Context, start, end time.Time) (*models.ScyllaDBAnalytics, error) {
 	var analytics models.ScyllaDBAnalytics
 
-	query := `SELECT COUNT(*) FROM device_telemetry WHERE timestamp >= ? AND timestamp <= ? ALLOW FILTERING`
-	row := s.scyllaSession.QueryRow(ctx, query, start, end)
-	if err := row.Scan(&analytics.TotalRecords); err != nil {
+	// Don't count pre-TTL rows that are past retention but not yet purged
+	start = s.telemetryRetention.clampStart(start, time.Now().UTC())
+
+	// Hourly counters, not a full scan of device_telemetry
+	count, err := s.countTelemetry(ctx, start, end)
+	if err != nil {
 		return nil, err
 	}
+	analytics.TotalRecords = count
 
 	return &analytics, nil
 }
@@ -717,7 +1963,7 @@ func (s *PatternsService) getRedisAnalytics(ctx context.Context) (*models.RedisA
 	// Count active sessions by checking keys
 	sessions, err := s.redisClient.SMembers(ctx, "active_sessions")
 	if err != nil {
-		return &models.RedisAnalytics{}, nil
+		return nil, err
 	}
 
 	return &models.RedisAnalytics{
@@ -730,6 +1976,42 @@ func (s *PatternsService) getRedisAnalytics(ctx context.Context) (*models.RedisA
 // Demonstrates: Health checking with Core infrastructure packages
 // =============================================================================
 
+// SetBackendHealthy records a backend's connection status (called by reconnection supervisors)
+func (s *PatternsService) SetBackendHealthy(backend string, healthy bool) {
+	s.backendMu.Lock()
+	defer s.backendMu.Unlock()
+	s.backendDown[backend] = !healthy
+}
+
+// Capabilities reports which infrastructure backends are configured and connected.
+// Cheap: only inspects client presence and supervisor status, never calls the backends.
+func (s *PatternsService) Capabilities() models.ServiceCapabilities {
+	s.backendMu.RLock()
+	defer s.backendMu.RUnlock()
+
+	return models.ServiceCapabilities{
+		APIVersions: []string{"v1"},
+		Backends: map[string]bool{
+			"sqlserver": s.sqlDB != nil && !s.backendDown["sqlserver"],
+			"mongodb":   s.mongoClient != nil && !s.backendDown["mongodb"],
+			"scylladb":  s.scyllaSession != nil && !s.backendDown["scylladb"],
+			"redis":     s.redisClient != nil && !s.backendDown["redis"],
+			"kafka":     s.kafkaProducer != nil && !s.backendDown["kafka"],
+		},
+	}
+}
+
+// CircuitBreakers returns call counters and state for the mongodb, scylladb
+// and kafka circuit breakers. The same data is exported to Prometheus as
+// circuit_breaker_* metrics labelled by breaker name.
+func (s *PatternsService) CircuitBreakers() []reliability.CircuitBreakerMetrics {
+	return []reliability.CircuitBreakerMetrics{
+		s.mongoCircuitBreaker.Metrics(),
+		s.scyllaCircuitBreaker.Metrics(),
+		s.kafkaCircuitBreaker.Metrics(),
+	}
+}
+
 // HealthCheck checks the health of all infrastructure components
 func (s *PatternsService) HealthCheck(ctx context.Context) map[string]string {
 	health := make(map[string]string)
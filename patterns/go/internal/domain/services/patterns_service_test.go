@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	coreerrors "github.com/your-github-org/ai-scaffolder/core/go/errors"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/core/go/reliability"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/eventbus"
+)
+
+func TestGetAnalytics_ReportsFailedSource(t *testing.T) {
+	scylla := &fakeScylla{rowErr: errors.New("read timeout")}
+	redis := newFakeRedis()
+	redis.SAdd(context.Background(), "active_sessions", "s1", "s2")
+
+	svc := newTestService(scylla, redis, nil)
+
+	end := time.Now()
+	result, err := svc.GetAnalytics(context.Background(), end.Add(-24*time.Hour), end)
+	if err != nil {
+		t.Fatalf("GetAnalytics() error = %v", err)
+	}
+
+	tests := []struct {
+		source     string
+		wantStatus string
+		wantError  string
+	}{
+		{models.SourceScyllaDB, models.SourceStatusFailed, "read timeout"},
+		{models.SourceRedis, models.SourceStatusOK, ""},
+		{models.SourceSQLServer, models.SourceStatusSkipped, ""},
+		{models.SourceMongoDB, models.SourceStatusSkipped, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got, ok := result.Sources[tt.source]
+			if !ok {
+				t.Fatalf("Sources missing %s", tt.source)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", got.Status, tt.wantStatus)
+			}
+			if got.Error != tt.wantError {
+				t.Errorf("Error = %v, want %v", got.Error, tt.wantError)
+			}
+		})
+	}
+
+	if result.ScyllaDB != nil {
+		t.Error("ScyllaDB result should be nil when the store fails")
+	}
+	if result.Redis == nil || result.Redis.ActiveSessions != 2 {
+		t.Errorf("Redis = %+v, want 2 active sessions", result.Redis)
+	}
+	if !result.IsPartial() {
+		t.Error("IsPartial() = false, want true")
+	}
+}
+
+func TestGetAnalytics_RedisFailureReported(t *testing.T) {
+	redis := newFakeRedis()
+	redis.err = errors.New("connection refused")
+
+	svc := newTestService(&fakeScylla{rowValue: []interface{}{int64(7)}}, redis, nil)
+
+	result, err := svc.GetAnalytics(context.Background(), time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatalf("GetAnalytics() error = %v", err)
+	}
+
+	if got := result.Sources[models.SourceRedis].Status; got != models.SourceStatusFailed {
+		t.Errorf("redis Status = %v, want %v", got, models.SourceStatusFailed)
+	}
+	if result.ScyllaDB == nil || result.ScyllaDB.TotalRecords != 7 {
+		t.Errorf("ScyllaDB = %+v, want 7 total records", result.ScyllaDB)
+	}
+}
+
+func TestGetAnalytics_SlowStoreTimesOut(t *testing.T) {
+	scylla := &fakeScylla{delay: 2 * time.Second, rowValue: []interface{}{int64(1)}}
+	redis := newFakeRedis()
+	redis.SAdd(context.Background(), "active_sessions", "s1")
+
+	svc := newTestService(scylla, redis, nil)
+	svc.SetAnalyticsTimeouts(50*time.Millisecond, 100*time.Millisecond)
+
+	start := time.Now()
+	result, err := svc.GetAnalytics(context.Background(), start.Add(-time.Hour), start)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("GetAnalytics() error = %v", err)
+	}
+
+	if elapsed > time.Second {
+		t.Errorf("GetAnalytics() took %v, want prompt return after deadline", elapsed)
+	}
+	if got := result.Sources[models.SourceScyllaDB].Status; got != models.SourceStatusTimedOut {
+		t.Errorf("scylladb Status = %v, want %v", got, models.SourceStatusTimedOut)
+	}
+	if result.ScyllaDB != nil {
+		t.Error("ScyllaDB result should be nil when the store times out")
+	}
+	if got := result.Sources[models.SourceRedis].Status; got != models.SourceStatusOK {
+		t.Errorf("redis Status = %v, want %v", got, models.SourceStatusOK)
+	}
+	if result.Redis == nil || result.Redis.ActiveSessions != 1 {
+		t.Errorf("Redis = %+v, want 1 active session", result.Redis)
+	}
+	if !result.IsPartial() {
+		t.Error("IsPartial() = false, want true")
+	}
+}
+
+func TestCapabilities_ReflectsBackendHealth(t *testing.T) {
+	svc := newTestService(&fakeScylla{}, newFakeRedis(), nil)
+
+	caps := svc.Capabilities()
+	if !caps.Backends["redis"] || !caps.Backends["scylladb"] {
+		t.Fatalf("backends = %v, want redis and scylladb enabled", caps.Backends)
+	}
+
+	svc.SetBackendHealthy("redis", false)
+	if svc.Capabilities().Backends["redis"] {
+		t.Error("redis reported available while its connection is down")
+	}
+	if !svc.Capabilities().Backends["scylladb"] {
+		t.Error("scylladb should be unaffected by redis status")
+	}
+
+	svc.SetBackendHealthy("redis", true)
+	if !svc.Capabilities().Backends["redis"] {
+		t.Error("redis not reported available after reconnect")
+	}
+}
+
+func TestPublishOrderEvent_DeadLettersWhenKafkaFails(t *testing.T) {
+	producer := &fakeProducer{}
+	svc := newTestService(nil, nil, producer)
+
+	if err := svc.publishOrderEvent(context.Background(), &models.OrderEvent{}); err != nil {
+		t.Fatalf("publishOrderEvent() error = %v", err)
+	}
+	if svc.DeadLetters().Len() != 0 || len(producer.messages) != 1 {
+		t.Fatalf("published %d, buffered %d; want 1, 0", len(producer.messages), svc.DeadLetters().Len())
+	}
+
+	producer.err = errors.New("broker unavailable")
+	for i := 0; i < 6; i++ { // The 6th is rejected by the open circuit
+		if err := svc.publishOrderEvent(context.Background(), &models.OrderEvent{}); err != nil {
+			t.Fatalf("publishOrderEvent() error = %v, want nil with the event buffered", err)
+		}
+	}
+	if got := svc.DeadLetters().Len(); got != 6 {
+		t.Errorf("buffered %d events, want 6", got)
+	}
+}
+
+func TestReplayDeadLetters_RepublishesOnceKafkaRecovers(t *testing.T) {
+	producer := &fakeProducer{err: errors.New("broker unavailable")}
+	svc := newTestService(nil, nil, producer)
+
+	ctx := logger.ContextWithCorrelationID(context.Background(), "corr-123")
+	for i := 0; i < 6; i++ { // Five failures open the circuit
+		if err := svc.publishOrderEvent(ctx, &models.OrderEvent{OrderID: uuid.New()}); err != nil {
+			t.Fatalf("publishOrderEvent() error = %v", err)
+		}
+	}
+
+	// Kafka is back but the circuit is still open: nothing is sent yet
+	producer.err = nil
+	if sent, err := svc.ReplayDeadLetters(context.Background()); err == nil || sent != 0 {
+		t.Fatalf("ReplayDeadLetters() = %d, %v; want 0 and the open-circuit error", sent, err)
+	}
+	if got := svc.DeadLetters().Len(); got != 6 {
+		t.Fatalf("buffered %d events, want 6 kept for the next replay", got)
+	}
+
+	// Once the circuit lets calls through again, every event goes out
+	svc.kafkaCircuitBreaker.Reset()
+	sent, err := svc.ReplayDeadLetters(context.Background())
+	if err != nil || sent != 6 {
+		t.Fatalf("ReplayDeadLetters() = %d, %v; want 6, nil", sent, err)
+	}
+	if svc.DeadLetters().Len() != 0 || len(producer.messages) != 6 {
+		t.Fatalf("published %d, buffered %d; want 6, 0", len(producer.messages), svc.DeadLetters().Len())
+	}
+	// The encoded message is replayed, so the request's correlation ID survives
+	if got := producer.messages[0].headers[eventbus.HeaderCorrelationID]; got != "corr-123" {
+		t.Errorf("replayed correlation ID = %q, want corr-123", got)
+	}
+}
+
+func TestPublishEvent_MarshalError(t *testing.T) {
+	producer := &fakeProducer{}
+	svc := newTestService(nil, nil, producer)
+
+	// NaN can't be encoded as JSON
+	event := models.NewTelemetryReceivedEvent(&models.DeviceTelemetry{DeviceID: "device-1", Value: math.NaN()}, "test")
+	err := svc.publishTelemetryEvent(context.Background(), event)
+
+	var serviceErr *coreerrors.ServiceError
+	if !errors.As(err, &serviceErr) || serviceErr.Code != "PAT-INFRA-003" {
+		t.Fatalf("publishTelemetryEvent() error = %v, want PAT-INFRA-003", err)
+	}
+	if !errors.Is(err, eventbus.ErrMarshalEvent) {
+		t.Errorf("publishTelemetryEvent() error = %v, want it to wrap ErrMarshalEvent", err)
+	}
+	if len(producer.messages) != 0 {
+		t.Errorf("published %d messages, want none", len(producer.messages))
+	}
+	if kafka := svc.CircuitBreakers()[2]; kafka.Calls != 0 {
+		t.Errorf("kafka breaker calls = %d, want 0 (marshal errors aren't Kafka failures)", kafka.Calls)
+	}
+}
+
+func TestCircuitBreakers_CountKafkaFailures(t *testing.T) {
+	svc := newTestService(nil, nil, &fakeProducer{err: errors.New("broker unavailable")})
+
+	for i := 0; i < 6; i++ {
+		svc.publishOrderEvent(context.Background(), &models.OrderEvent{})
+	}
+
+	breakers := svc.CircuitBreakers()
+	if len(breakers) != 3 || breakers[0].Name != "mongodb" || breakers[1].Name != "scylladb" {
+		t.Fatalf("CircuitBreakers() = %+v", breakers)
+	}
+	kafka := breakers[2]
+	if kafka.Name != "kafka" || kafka.State != reliability.StateOpen {
+		t.Fatalf("kafka breaker = %+v, want open", kafka)
+	}
+	if kafka.Calls != 6 || kafka.Failures != 5 || kafka.Rejections != 1 {
+		t.Errorf("kafka counters = %+v, want 6 calls, 5 failures, 1 rejection", kafka)
+	}
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// =============================================================================
+// SQL Server Operations - Products (Catalogue & Inventory)
+// Demonstrates: Core.Infrastructure.SqlServer usage with registry error codes
+// =============================================================================
+
+const productColumns = `Id, Name, Description, Price, Category, StockQuantity, Status, CreatedAt, UpdatedAt`
+
+// CreateProduct validates and inserts a new product
+func (s *PatternsService) CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error) {
+	log := s.logger.WithContext(ctx)
+
+	now := time.Now().UTC()
+	product := &models.Product{
+		ID:            uuid.New(),
+		Name:          strings.TrimSpace(req.Name),
+		Description:   req.Description,
+		Price:         req.Price,
+		Category:      strings.TrimSpace(req.Category),
+		StockQuantity: req.StockQuantity,
+		Status:        req.Status,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if product.Status == "" {
+		product.Status = models.ProductStatusActive
+	}
+	if err := validateProduct(product); err != nil {
+		return nil, err
+	}
+
+	log.Info("Creating product",
+		zap.String("product_id", product.ID.String()),
+		zap.String("category", product.Category))
+
+	query := `
+		INSERT INTO Products (` + productColumns + `)
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9)`
+
+	_, err := s.sqlDB.ExecContext(ctx, query,
+		sql.Named("p1", product.ID),
+		sql.Named("p2", product.Name),
+		sql.Named("p3", product.Description),
+		sql.Named("p4", product.Price),
+		sql.Named("p5", product.Category),
+		sql.Named("p6", product.StockQuantity),
+		sql.Named("p7", string(product.Status)),
+		sql.Named("p8", product.CreatedAt),
+		sql.Named("p9", product.UpdatedAt),
+	)
+	if err != nil {
+		log.Error("Failed to create product in SQL Server", zap.Error(err))
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+
+	return product, nil
+}
+
+// GetProduct retrieves a product by ID
+func (s *PatternsService) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	log := s.logger.WithContext(ctx)
+
+	query := `SELECT ` + productColumns + ` FROM Products WHERE Id = @p1`
+	product, err := scanProduct(s.sqlDB.QueryRowContext(ctx, query, sql.Named("p1", id)))
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFound("product", id)
+	}
+	if err != nil {
+		log.Error("Failed to get product from SQL Server", zap.Error(err))
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	return product, nil
+}
+
+// UpdateProduct applies the non-nil fields of req. Stock is only written when
+// StockQuantity is set, so the update cannot overwrite concurrent order reservations.
+func (s *PatternsService) UpdateProduct(ctx context.Context, id uuid.UUID, req *models.UpdateProductRequest) (*models.Product, error) {
+	log := s.logger.WithContext(ctx)
+
+	product, err := s.GetProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		product.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		product.Description = *req.Description
+	}
+	if req.Price != nil {
+		product.Price = *req.Price
+	}
+	if req.Category != nil {
+		product.Category = strings.TrimSpace(*req.Category)
+	}
+	if req.StockQuantity != nil {
+		product.StockQuantity = *req.StockQuantity
+	}
+	if req.Status != nil {
+		if !req.Status.IsValid() {
+			return nil, errors.ValidationError(fmt.Sprintf("unknown product status %q", *req.Status))
+		}
+		if !product.CanTransitionTo(*req.Status) {
+			return nil, errors.InvalidStatusTransition(product.Status, *req.Status)
+		}
+		product.Status = *req.Status
+	}
+	if err := validateProduct(product); err != nil {
+		return nil, err
+	}
+	product.UpdatedAt = time.Now().UTC()
+
+	set := []string{"Name = @p1", "Description = @p2", "Price = @p3", "Category = @p4", "Status = @p5", "UpdatedAt = @p6"}
+	args := []interface{}{
+		sql.Named("p1", product.Name),
+		sql.Named("p2", product.Description),
+		sql.Named("p3", product.Price),
+		sql.Named("p4", product.Category),
+		sql.Named("p5", string(product.Status)),
+		sql.Named("p6", product.UpdatedAt),
+		sql.Named("p7", id),
+	}
+	if req.StockQuantity != nil {
+		set = append(set, "StockQuantity = @p8")
+		args = append(args, sql.Named("p8", product.StockQuantity))
+	}
+
+	query := `UPDATE Products SET ` + strings.Join(set, ", ") + ` WHERE Id = @p7`
+	result, err := s.sqlDB.ExecContext(ctx, query, args...)
+	if err != nil {
+		log.Error("Failed to update product", zap.Error(err))
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return nil, errors.NotFound("product", id)
+	}
+
+	return product, nil
+}
+
+// DeleteProduct deletes a product that is not active. The status check is part
+// of the DELETE so a concurrent reactivation cannot slip between check and delete.
+func (s *PatternsService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	log := s.logger.WithContext(ctx)
+
+	query := `DELETE FROM Products WHERE Id = @p1 AND Status <> @p2`
+	result, err := s.sqlDB.ExecContext(ctx, query,
+		sql.Named("p1", id),
+		sql.Named("p2", string(models.ProductStatusActive)),
+	)
+	if err != nil {
+		log.Error("Failed to delete product", zap.Error(err))
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+	if deleted == 1 {
+		log.Info("Product deleted", zap.String("product_id", id.String()))
+		return nil
+	}
+
+	// Nothing deleted: the product is unknown or still active
+	if _, err := s.GetProduct(ctx, id); err != nil {
+		return err
+	}
+	return errors.ProductErrors.CreateError("PAT-PRD-007", id)
+}
+
+// ListProducts returns products ordered by name, filtered by category and status
+func (s *PatternsService) ListProducts(ctx context.Context, opts models.ListProductsOptions) (*models.ProductPage, error) {
+	log := s.logger.WithContext(ctx)
+
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = models.DefaultProductPageSize
+	}
+	if opts.Limit > models.MaxProductPageSize {
+		opts.Limit = models.MaxProductPageSize
+	}
+	if opts.Status != "" && !opts.Status.IsValid() {
+		return nil, errors.ValidationError(fmt.Sprintf("unknown product status %q", opts.Status))
+	}
+
+	var where []string
+	args := []interface{}{
+		sql.Named("offset", opts.Offset),
+		sql.Named("limit", opts.Limit),
+	}
+	if opts.Category != "" {
+		where = append(where, "Category = @category")
+		args = append(args, sql.Named("category", opts.Category))
+	}
+	if opts.Status != "" {
+		where = append(where, "Status = @status")
+		args = append(args, sql.Named("status", string(opts.Status)))
+	}
+
+	query := `SELECT ` + productColumns + ` FROM Products`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	query += ` ORDER BY Name, Id OFFSET @offset ROWS FETCH NEXT @limit ROWS ONLY`
+
+	rows, err := s.sqlDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error("Failed to list products", zap.Error(err))
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+	defer rows.Close()
+
+	page := &models.ProductPage{Products: []*models.Product{}, Offset: opts.Offset, Limit: opts.Limit}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			log.Error("Failed to scan product", zap.Error(err))
+			return nil, fmt.Errorf("failed to list products: %w", err)
+		}
+		page.Products = append(page.Products, product)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Failed to iterate products", zap.Error(err))
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	return page, nil
+}
+
+// validateProduct checks the fields covered by the PAT-PRD-002..005 error codes
+func validateProduct(product *models.Product) error {
+	switch {
+	case product.Name == "":
+		return errors.ProductErrors.CreateError("PAT-PRD-002")
+	case product.Price <= 0:
+		return errors.ProductErrors.CreateError("PAT-PRD-003", product.Price)
+	case product.Category == "":
+		return errors.ProductErrors.CreateError("PAT-PRD-004")
+	case product.StockQuantity < 0:
+		return errors.ProductErrors.CreateError("PAT-PRD-005", product.StockQuantity)
+	case !product.Status.IsValid():
+		return errors.ValidationError(fmt.Sprintf("unknown product status %q", product.Status))
+	}
+	return nil
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProduct(row rowScanner) (*models.Product, error) {
+	var product models.Product
+	var description sql.NullString
+	var status string
+	if err := row.Scan(
+		&product.ID, &product.Name, &description, &product.Price, &product.Category,
+		&product.StockQuantity, &status, &product.CreatedAt, &product.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	product.Description = description.String
+	product.Status = models.ProductStatus(status)
+	return &product, nil
+}
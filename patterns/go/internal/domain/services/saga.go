@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/redis"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// =============================================================================
+// Saga Coordinator - Multi-step workflows with compensation
+// Demonstrates: orchestrated sagas with state persisted in Core.Infrastructure.Redis
+//
+// The coordinator is a building block and is not wired into order creation:
+// CreateOrder reserves stock inside its SQL transaction, so it has nothing to
+// compensate. There is no background recovery scan either; a caller resumes
+// an interrupted saga by calling Execute again with the same id.
+// =============================================================================
+
+// finishedSagaTTL is how long completed and compensated saga records are kept
+// for inspection. Running and failed records never expire, since they are
+// still needed to resume or repair the saga.
+const finishedSagaTTL = 7 * 24 * time.Hour
+
+// SagaStep is one step of a saga. Do performs the step and Compensate undoes
+// it; Compensate may be nil for steps with nothing to undo. Both may run again
+// when a saga is resumed after a crash, so they must be idempotent.
+type SagaStep struct {
+	Name       string
+	Do         func(ctx context.Context) error
+	Compensate func(ctx context.Context) error
+}
+
+// SagaStore persists saga records between steps
+type SagaStore interface {
+	// Load returns the stored record, or nil if the saga has not started
+	Load(ctx context.Context, id uuid.UUID) (*models.SagaRecord, error)
+	Save(ctx context.Context, record *models.SagaRecord) error
+}
+
+// SagaCoordinator runs sagas: steps execute in order and, when one fails, the
+// completed steps are compensated in reverse order
+type SagaCoordinator struct {
+	store  SagaStore
+	logger *logger.Logger
+}
+
+// NewSagaCoordinator creates a saga coordinator. A nil store disables
+// persistence, so interrupted sagas cannot be resumed.
+func NewSagaCoordinator(store SagaStore, log *logger.Logger) *SagaCoordinator {
+	return &SagaCoordinator{store: store, logger: log}
+}
+
+// Execute runs the saga identified by id. Calling it again with the same id
+// and steps resumes from the persisted state: completed steps are skipped, an
+// interrupted compensation is finished, and a finished saga is returned as is.
+//
+// On a step failure the returned error wraps both errors.ErrSagaCompensated and
+// the step's error. If a compensation fails it wraps errors.ErrSagaCompensationFailed.
+func (c *SagaCoordinator) Execute(ctx context.Context, id uuid.UUID, name string, steps []SagaStep) (*models.SagaRecord, error) {
+	log := c.logger.WithContext(ctx)
+
+	record, err := c.load(ctx, id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch record.Status {
+	case models.SagaCompleted:
+		return record, nil
+	case models.SagaCompensated:
+		return record, fmt.Errorf("%w: %s", errors.ErrSagaCompensated, record.Error)
+	case models.SagaCompensating, models.SagaFailed:
+		log.Info("Resuming saga compensation", zap.String("saga_id", id.String()), zap.String("saga", name))
+		return c.compensate(ctx, record, steps, goerrors.New(record.Error))
+	}
+
+	for _, step := range steps {
+		state := record.Step(step.Name)
+		if state.Status == models.SagaStepCompleted {
+			continue
+		}
+
+		if err := step.Do(ctx); err != nil {
+			log.Warn("Saga step failed, compensating",
+				zap.String("saga_id", id.String()),
+				zap.String("saga", name),
+				zap.String("step", step.Name),
+				zap.Error(err))
+			c.setStep(state, models.SagaStepFailed, err)
+			record.Status = models.SagaCompensating
+			record.Error = fmt.Sprintf("step %s: %v", step.Name, err)
+			c.save(ctx, record)
+			return c.compensate(ctx, record, steps, fmt.Errorf("step %s: %w", step.Name, err))
+		}
+
+		c.setStep(state, models.SagaStepCompleted, nil)
+		c.save(ctx, record)
+	}
+
+	now := time.Now().UTC()
+	record.Status = models.SagaCompleted
+	record.CompletedAt = &now
+	c.save(ctx, record)
+
+	log.Info("Saga completed", zap.String("saga_id", id.String()), zap.String("saga", name))
+	return record, nil
+}
+
+// compensate undoes the completed steps in reverse order. Steps whose
+// compensation failed earlier are retried.
+func (c *SagaCoordinator) compensate(ctx context.Context, record *models.SagaRecord, steps []SagaStep, cause error) (*models.SagaRecord, error) {
+	log := c.logger.WithContext(ctx)
+
+	byName := make(map[string]SagaStep, len(steps))
+	for _, step := range steps {
+		byName[step.Name] = step
+	}
+
+	// Recorded steps are in execution order; steps that never ran are absent
+	for i := len(record.Steps) - 1; i >= 0; i-- {
+		state := &record.Steps[i]
+		if state.Status != models.SagaStepCompleted && state.Status != models.SagaStepCompensationFailed {
+			continue
+		}
+		step, ok := byName[state.Name]
+		if !ok {
+			return record, fmt.Errorf("%w: step %s is not part of saga %s", errors.ErrSagaCompensationFailed, state.Name, record.Name)
+		}
+
+		if step.Compensate != nil {
+			if err := step.Compensate(ctx); err != nil {
+				log.Error("Saga compensation failed",
+					zap.String("saga_id", record.ID.String()),
+					zap.String("saga", record.Name),
+					zap.String("step", step.Name),
+					zap.Error(err))
+				c.setStep(state, models.SagaStepCompensationFailed, err)
+				record.Status = models.SagaFailed
+				c.save(ctx, record)
+				return record, fmt.Errorf("%w: step %s: %w (compensating %w)", errors.ErrSagaCompensationFailed, step.Name, err, cause)
+			}
+		}
+
+		c.setStep(state, models.SagaStepCompensated, nil)
+		c.save(ctx, record)
+	}
+
+	now := time.Now().UTC()
+	record.Status = models.SagaCompensated
+	record.CompletedAt = &now
+	c.save(ctx, record)
+
+	return record, fmt.Errorf("%w: %w", errors.ErrSagaCompensated, cause)
+}
+
+func (c *SagaCoordinator) setStep(state *models.SagaStepState, status string, err error) {
+	state.Status = status
+	state.Error = ""
+	if err != nil {
+		state.Error = err.Error()
+	}
+	state.UpdatedAt = time.Now().UTC()
+}
+
+// load returns the stored record, or a new running one
+func (c *SagaCoordinator) load(ctx context.Context, id uuid.UUID, name string) (*models.SagaRecord, error) {
+	if c.store != nil {
+		record, err := c.store.Load(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load saga %s: %w", id, err)
+		}
+		if record != nil {
+			return record, nil
+		}
+	}
+	return &models.SagaRecord{ID: id, Name: name, Status: models.SagaRunning, StartedAt: time.Now().UTC()}, nil
+}
+
+// save persists the record. A failed save is logged rather than returned: the
+// step has already run, and steps are idempotent so a resume may safely redo it.
+func (c *SagaCoordinator) save(ctx context.Context, record *models.SagaRecord) {
+	if c.store == nil {
+		return
+	}
+	if err := c.store.Save(ctx, record); err != nil {
+		c.logger.WithContext(ctx).Warn("Failed to save saga state",
+			zap.String("saga_id", record.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// redisSagaStore keeps saga records in Redis under saga:{id}; finished
+// records expire after finishedSagaTTL
+type redisSagaStore struct {
+	client redis.Client
+}
+
+// NewRedisSagaStore creates a SagaStore backed by Redis
+func NewRedisSagaStore(client redis.Client) SagaStore {
+	return &redisSagaStore{client: client}
+}
+
+func sagaKey(id uuid.UUID) string {
+	return fmt.Sprintf("saga:%s", id)
+}
+
+func (s *redisSagaStore) Load(ctx context.Context, id uuid.UUID) (*models.SagaRecord, error) {
+	data, err := s.client.Get(ctx, sagaKey(id))
+	if err != nil {
+		return nil, err
+	}
+	if data == "" {
+		return nil, nil
+	}
+
+	var record models.SagaRecord
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return nil, fmt.Errorf("failed to decode saga record: %w", err)
+	}
+	return &record, nil
+}
+
+func (s *redisSagaStore) Save(ctx context.Context, record *models.SagaRecord) error {
+	if record.Status == models.SagaCompleted || record.Status == models.SagaCompensated {
+		return s.client.SetWithTTL(ctx, sagaKey(record.ID), record, finishedSagaTTL)
+	}
+	return s.client.Set(ctx, sagaKey(record.ID), record)
+}
+
+// NewSagaCoordinator returns a coordinator persisting to the service's Redis
+// client, or without persistence when Redis is not connected. Callers own the
+// saga ids and must re-run Execute after a crash to resume their sagas.
+func (s *PatternsService) NewSagaCoordinator() *SagaCoordinator {
+	if s.redisClient == nil {
+		return NewSagaCoordinator(nil, s.logger)
+	}
+	return NewSagaCoordinator(NewRedisSagaStore(s.redisClient), s.logger)
+}
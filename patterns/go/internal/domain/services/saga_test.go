@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"reflect"
+	"testing"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// sagaJournal records the order in which saga actions ran
+type sagaJournal struct {
+	calls []string
+}
+
+// step builds a saga step that journals "do:<name>" and "undo:<name>",
+// failing Do with doErr and Compensate with undoErr when set
+func (j *sagaJournal) step(name string, doErr, undoErr error) SagaStep {
+	return SagaStep{
+		Name: name,
+		Do: func(ctx context.Context) error {
+			j.calls = append(j.calls, "do:"+name)
+			return doErr
+		},
+		Compensate: func(ctx context.Context) error {
+			j.calls = append(j.calls, "undo:"+name)
+			return undoErr
+		},
+	}
+}
+
+func newTestSagaCoordinator(redis *fakeRedis) *SagaCoordinator {
+	return NewSagaCoordinator(NewRedisSagaStore(redis), &logger.Logger{Logger: zap.NewNop()})
+}
+
+func stepStatuses(record *models.SagaRecord) map[string]string {
+	statuses := make(map[string]string, len(record.Steps))
+	for _, step := range record.Steps {
+		statuses[step.Name] = step.Status
+	}
+	return statuses
+}
+
+func TestSagaCoordinator_Execute(t *testing.T) {
+	stockErr := errors.InsufficientStock(1, 3)
+
+	tests := []struct {
+		name         string
+		failStep     string
+		wantCalls    []string
+		wantStatus   string
+		wantErr      error
+		wantStatuses map[string]string
+	}{
+		{
+			name:       "all steps succeed",
+			wantCalls:  []string{"do:reserve_stock", "do:charge_payment", "do:create_shipment"},
+			wantStatus: models.SagaCompleted,
+			wantStatuses: map[string]string{
+				"reserve_stock":   models.SagaStepCompleted,
+				"charge_payment":  models.SagaStepCompleted,
+				"create_shipment": models.SagaStepCompleted,
+			},
+		},
+		{
+			name:       "mid-saga failure compensates in reverse",
+			failStep:   "create_shipment",
+			wantCalls:  []string{"do:reserve_stock", "do:charge_payment", "do:create_shipment", "undo:charge_payment", "undo:reserve_stock"},
+			wantStatus: models.SagaCompensated,
+			wantErr:    errors.ErrSagaCompensated,
+			wantStatuses: map[string]string{
+				"reserve_stock":   models.SagaStepCompensated,
+				"charge_payment":  models.SagaStepCompensated,
+				"create_shipment": models.SagaStepFailed,
+			},
+		},
+		{
+			name:       "first step failure has nothing to compensate",
+			failStep:   "reserve_stock",
+			wantCalls:  []string{"do:reserve_stock"},
+			wantStatus: models.SagaCompensated,
+			wantErr:    errors.ErrSagaCompensated,
+			wantStatuses: map[string]string{
+				"reserve_stock": models.SagaStepFailed,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			journal := &sagaJournal{}
+			var steps []SagaStep
+			for _, name := range []string{"reserve_stock", "charge_payment", "create_shipment"} {
+				var doErr error
+				if name == tt.failStep {
+					doErr = stockErr
+				}
+				steps = append(steps, journal.step(name, doErr, nil))
+			}
+
+			redis := newFakeRedis()
+			id := uuid.New()
+			record, err := newTestSagaCoordinator(redis).Execute(context.Background(), id, "fulfil_order", steps)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Execute() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil {
+				if !goerrors.Is(err, tt.wantErr) {
+					t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				if !goerrors.Is(err, stockErr) {
+					t.Errorf("Execute() error = %v, want it to wrap the step error", err)
+				}
+			}
+			if !reflect.DeepEqual(journal.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", journal.calls, tt.wantCalls)
+			}
+			if record.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", record.Status, tt.wantStatus)
+			}
+			if got := stepStatuses(record); !reflect.DeepEqual(got, tt.wantStatuses) {
+				t.Errorf("step statuses = %v, want %v", got, tt.wantStatuses)
+			}
+
+			stored, err := NewRedisSagaStore(redis).Load(context.Background(), id)
+			if err != nil || stored == nil {
+				t.Fatalf("Load() = %v, %v, want the persisted record", stored, err)
+			}
+			if stored.Status != tt.wantStatus {
+				t.Errorf("stored Status = %v, want %v", stored.Status, tt.wantStatus)
+			}
+			if got := redis.expires[sagaKey(id)]; got != finishedSagaTTL {
+				t.Errorf("saga TTL = %v, want %v once finished", got, finishedSagaTTL)
+			}
+		})
+	}
+}
+
+func TestSagaCoordinator_Resume(t *testing.T) {
+	t.Run("skips steps completed before a crash", func(t *testing.T) {
+		redis := newFakeRedis()
+		id := uuid.New()
+		if err := NewRedisSagaStore(redis).Save(context.Background(), &models.SagaRecord{
+			ID:     id,
+			Name:   "fulfil_order",
+			Status: models.SagaRunning,
+			Steps:  []models.SagaStepState{{Name: "reserve_stock", Status: models.SagaStepCompleted}},
+		}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		journal := &sagaJournal{}
+		steps := []SagaStep{journal.step("reserve_stock", nil, nil), journal.step("charge_payment", nil, nil)}
+		record, err := newTestSagaCoordinator(redis).Execute(context.Background(), id, "fulfil_order", steps)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+
+		if want := []string{"do:charge_payment"}; !reflect.DeepEqual(journal.calls, want) {
+			t.Errorf("calls = %v, want %v", journal.calls, want)
+		}
+		if record.Status != models.SagaCompleted {
+			t.Errorf("Status = %v, want %v", record.Status, models.SagaCompleted)
+		}
+	})
+
+	t.Run("retries a failed compensation", func(t *testing.T) {
+		redis := newFakeRedis()
+		coordinator := newTestSagaCoordinator(redis)
+		id := uuid.New()
+		undoErr := goerrors.New("refund gateway unavailable")
+
+		journal := &sagaJournal{}
+		steps := []SagaStep{
+			journal.step("reserve_stock", nil, nil),
+			journal.step("charge_payment", nil, undoErr),
+			journal.step("create_shipment", goerrors.New("carrier rejected"), nil),
+		}
+		record, err := coordinator.Execute(context.Background(), id, "fulfil_order", steps)
+		if !goerrors.Is(err, errors.ErrSagaCompensationFailed) {
+			t.Fatalf("Execute() error = %v, want %v", err, errors.ErrSagaCompensationFailed)
+		}
+		if record.Status != models.SagaFailed {
+			t.Errorf("Status = %v, want %v", record.Status, models.SagaFailed)
+		}
+		if got, ok := redis.expires[sagaKey(id)]; ok {
+			t.Errorf("failed saga TTL = %v, want none so it can be repaired", got)
+		}
+
+		// The refund succeeds on retry; stock is released afterwards
+		journal.calls = nil
+		steps[1] = journal.step("charge_payment", nil, nil)
+		record, err = coordinator.Execute(context.Background(), id, "fulfil_order", steps)
+		if !goerrors.Is(err, errors.ErrSagaCompensated) {
+			t.Fatalf("Execute() error = %v, want %v", err, errors.ErrSagaCompensated)
+		}
+		if want := []string{"undo:charge_payment", "undo:reserve_stock"}; !reflect.DeepEqual(journal.calls, want) {
+			t.Errorf("calls = %v, want %v", journal.calls, want)
+		}
+		if record.Status != models.SagaCompensated {
+			t.Errorf("Status = %v, want %v", record.Status, models.SagaCompensated)
+		}
+		if got := redis.expires[sagaKey(id)]; got != finishedSagaTTL {
+			t.Errorf("saga TTL = %v, want %v once compensated", got, finishedSagaTTL)
+		}
+	})
+}
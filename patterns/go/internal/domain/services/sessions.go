@@ -0,0 +1,501 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/redis"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+// Session expiry defaults. Sessions slide: each access extends them by the
+// idle timeout, but never past the max lifetime from creation.
+const (
+	defaultSessionTTL         = 24 * time.Hour
+	defaultSessionMaxLifetime = 7 * 24 * time.Hour
+)
+
+// defaultMaxSessionsPerUser caps a user's concurrent sessions; creating one
+// more evicts the oldest
+const defaultMaxSessionsPerUser = 10
+
+// SessionStore persists user sessions
+type SessionStore interface {
+	// Create stores a new session and sets its ExpiresAt to ttl from now,
+	// capped at its MaxExpiresAt when set
+	Create(ctx context.Context, session *models.Session, ttl time.Duration) error
+
+	// Get returns the session, or errors.ErrSessionNotFound if it does not
+	// exist or has expired
+	Get(ctx context.Context, sessionID string) (*models.Session, error)
+
+	// Delete removes a session. Deleting a missing session is not an error.
+	Delete(ctx context.Context, sessionID string) error
+
+	// Refresh records activity on the session and extends it to expire ttl
+	// from now, never past its MaxExpiresAt. The session and its new expiry
+	// are written in one step, and a session deleted concurrently is not
+	// recreated.
+	Refresh(ctx context.Context, sessionID string, ttl time.Duration) (*models.Session, error)
+
+	// Touch records activity on the session without extending it
+	Touch(ctx context.Context, sessionID string) error
+
+	// ListByUser returns the user's live sessions
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Session, error)
+
+	// DeleteByUser removes all of the user's sessions
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// SetSessionStore replaces the session store (Redis by default)
+func (s *PatternsService) SetSessionStore(store SessionStore) {
+	s.sessions = store
+}
+
+// SetSessionExpiry configures sliding session expiry: sessions expire after
+// idleTimeout without access and at most maxLifetime after creation. Setting
+// maxLifetime equal to idleTimeout gives fixed, non-sliding sessions.
+func (s *PatternsService) SetSessionExpiry(idleTimeout, maxLifetime time.Duration) {
+	s.sessionTTL = idleTimeout
+	s.sessionMaxLifetime = maxLifetime
+}
+
+// SetMaxSessionsPerUser caps each user's concurrent sessions. When a new
+// session takes a user over the cap, their oldest sessions are revoked.
+// Zero or less removes the cap.
+func (s *PatternsService) SetMaxSessionsPerUser(max int) {
+	s.maxSessionsPerUser = max
+}
+
+// ListSessions returns the user's active sessions, newest first
+func (s *PatternsService) ListSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	store, err := s.sessionStore()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions, err := store.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	sortSessionsNewestFirst(sessions)
+	if sessions == nil {
+		sessions = []models.Session{}
+	}
+	return sessions, nil
+}
+
+// RevokeSession ends one of the user's sessions. It returns
+// errors.ErrSessionNotFound if the session doesn't exist or belongs to
+// another user.
+func (s *PatternsService) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	log := s.logger.WithContext(ctx)
+
+	store, err := s.sessionStore()
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	session, err := store.Get(ctx, sessionID)
+	if err != nil {
+		if goerrors.Is(err, errors.ErrSessionNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if session.UserID != userID {
+		return errors.ErrSessionNotFound
+	}
+
+	if err := store.Delete(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	log.Info("Session revoked",
+		zap.String("user_id", userID.String()),
+		zap.String("session_id", sessionID))
+	return nil
+}
+
+// RevokeAllSessions ends every session of the user (e.g. "log out everywhere"
+// or after a password change)
+func (s *PatternsService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	log := s.logger.WithContext(ctx)
+
+	store, err := s.sessionStore()
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	if err := store.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	log.Info("All sessions revoked", zap.String("user_id", userID.String()))
+	return nil
+}
+
+// evictExcessSessions revokes the user's oldest sessions so that, with the
+// newly created one, they have at most maxSessionsPerUser. Failures are
+// logged; the new session stays valid.
+func (s *PatternsService) evictExcessSessions(ctx context.Context, store SessionStore, created *models.Session) {
+	if s.maxSessionsPerUser <= 0 {
+		return
+	}
+	log := s.logger.WithContext(ctx)
+	userID := created.UserID
+
+	sessions, err := store.ListByUser(ctx, userID)
+	if err != nil {
+		log.Warn("Failed to list sessions for limit check", zap.Error(err))
+		return
+	}
+
+	others := make([]models.Session, 0, len(sessions))
+	for _, session := range sessions {
+		if session.SessionID != created.SessionID {
+			others = append(others, session)
+		}
+	}
+	keep := s.maxSessionsPerUser - 1
+	if len(others) <= keep {
+		return
+	}
+
+	sortSessionsNewestFirst(others)
+	for _, session := range others[keep:] {
+		if err := store.Delete(ctx, session.SessionID); err != nil {
+			log.Warn("Failed to evict session", zap.String("session_id", session.SessionID), zap.Error(err))
+			continue
+		}
+		log.Info("Evicted oldest session over per-user limit",
+			zap.String("user_id", userID.String()),
+			zap.String("session_id", session.SessionID),
+			zap.Int("max_sessions", s.maxSessionsPerUser))
+	}
+}
+
+// sortSessionsNewestFirst orders sessions by creation time, newest first
+func sortSessionsNewestFirst(sessions []models.Session) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+}
+
+// sessionExpiry returns the expiry for a session extended ttl from now,
+// capped at its MaxExpiresAt
+func sessionExpiry(session *models.Session, now time.Time, ttl time.Duration) time.Time {
+	expiresAt := now.Add(ttl)
+	if !session.MaxExpiresAt.IsZero() && session.MaxExpiresAt.Before(expiresAt) {
+		return session.MaxExpiresAt
+	}
+	return expiresAt
+}
+
+// sessionStore returns the configured store, or an error when sessions are unavailable
+func (s *PatternsService) sessionStore() (SessionStore, error) {
+	if s.sessions == nil {
+		return nil, fmt.Errorf("redis not connected")
+	}
+	return s.sessions, nil
+}
+
+// =============================================================================
+// Redis
+// =============================================================================
+
+// redisSessionStore keeps sessions in Redis under session:{id}, indexed by
+// user in user:{id}:sessions. Index members may outlive their session keys;
+// readers skip missing sessions.
+type redisSessionStore struct {
+	client redis.Client
+	now    func() time.Time
+}
+
+// NewRedisSessionStore creates a SessionStore backed by Redis
+func NewRedisSessionStore(client redis.Client) SessionStore {
+	return &redisSessionStore{client: client, now: time.Now}
+}
+
+func sessionKey(sessionID string) string {
+	return fmt.Sprintf("session:%s", sessionID)
+}
+
+func (s *redisSessionStore) Create(ctx context.Context, session *models.Session, ttl time.Duration) error {
+	now := s.now()
+	session.ExpiresAt = sessionExpiry(session, now, ttl)
+	if err := s.client.SetWithTTL(ctx, sessionKey(session.SessionID), session, session.ExpiresAt.Sub(now)); err != nil {
+		return err
+	}
+
+	// Index by user so a user's sessions can be found (data export, erasure).
+	// The index must outlive the session however far it slides.
+	userKey := userSessionsKey(session.UserID)
+	if err := s.client.SAdd(ctx, userKey, session.SessionID); err != nil {
+		return fmt.Errorf("failed to index session by user: %w", err)
+	}
+	if err := s.client.Expire(ctx, userKey, s.indexTTL(session, now, ttl)); err != nil {
+		return fmt.Errorf("failed to set user session index expiration: %w", err)
+	}
+	return nil
+}
+
+// indexTTL returns how long the user's session index must live for session.
+// Capped sessions keep it until their cap, which is never before that of the
+// user's older sessions; uncapped ones until their current expiry.
+func (s *redisSessionStore) indexTTL(session *models.Session, now time.Time, ttl time.Duration) time.Duration {
+	if session.MaxExpiresAt.IsZero() {
+		return ttl
+	}
+	return session.MaxExpiresAt.Sub(now)
+}
+
+func (s *redisSessionStore) Get(ctx context.Context, sessionID string) (*models.Session, error) {
+	data, err := s.client.Get(ctx, sessionKey(sessionID))
+	if err != nil {
+		return nil, err
+	}
+	if data == "" {
+		return nil, errors.ErrSessionNotFound
+	}
+
+	var session models.Session
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+	return &session, nil
+}
+
+func (s *redisSessionStore) Delete(ctx context.Context, sessionID string) error {
+	session, err := s.Get(ctx, sessionID)
+	if goerrors.Is(err, errors.ErrSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.Del(ctx, sessionKey(sessionID)); err != nil {
+		return err
+	}
+	return s.client.SRem(ctx, userSessionsKey(session.UserID), sessionID)
+}
+
+func (s *redisSessionStore) Refresh(ctx context.Context, sessionID string, ttl time.Duration) (*models.Session, error) {
+	session, err := s.Get(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	session.LastAccessedAt = now
+	session.ExpiresAt = sessionExpiry(session, now, ttl)
+	if err := s.save(ctx, session); err != nil {
+		return nil, err
+	}
+	if session.MaxExpiresAt.IsZero() {
+		if err := s.client.Expire(ctx, userSessionsKey(session.UserID), ttl); err != nil {
+			return nil, fmt.Errorf("failed to set user session index expiration: %w", err)
+		}
+	}
+	return session, nil
+}
+
+func (s *redisSessionStore) Touch(ctx context.Context, sessionID string) error {
+	session, err := s.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	session.LastAccessedAt = s.now()
+	return s.save(ctx, session)
+}
+
+// save rewrites an existing session together with its expiry at
+// session.ExpiresAt. Sessions deleted since they were read are not recreated.
+func (s *redisSessionStore) save(ctx context.Context, session *models.Session) error {
+	ttl := session.ExpiresAt.Sub(s.now())
+	if ttl <= 0 {
+		return errors.ErrSessionNotFound
+	}
+
+	saved, err := s.client.SetIfExists(ctx, sessionKey(session.SessionID), session, ttl)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return errors.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (s *redisSessionStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	sessionIDs, err := s.client.SMembers(ctx, userSessionsKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []models.Session
+	var expired []interface{}
+	for _, sessionID := range sessionIDs {
+		session, err := s.Get(ctx, sessionID)
+		if goerrors.Is(err, errors.ErrSessionNotFound) {
+			expired = append(expired, sessionID)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get session %s: %w", sessionID, err)
+		}
+		if session.UserID != userID {
+			continue
+		}
+		sessions = append(sessions, *session)
+	}
+
+	// Drop index members whose sessions have expired
+	if len(expired) > 0 {
+		if err := s.client.SRem(ctx, userSessionsKey(userID), expired...); err != nil {
+			return nil, fmt.Errorf("failed to prune expired sessions: %w", err)
+		}
+	}
+	return sessions, nil
+}
+
+func (s *redisSessionStore) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	indexKey := userSessionsKey(userID)
+	sessionIDs, err := s.client.SMembers(ctx, indexKey)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(sessionIDs)+1)
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, sessionKey(sessionID))
+	}
+	keys = append(keys, indexKey)
+
+	return s.client.Del(ctx, keys...)
+}
+
+// =============================================================================
+// In-memory
+// =============================================================================
+
+// memorySessionStore keeps sessions in process memory. It is meant for tests
+// and single-instance development; sessions are lost on restart.
+type memorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]models.Session
+	now      func() time.Time
+}
+
+// NewMemorySessionStore creates an in-memory SessionStore
+func NewMemorySessionStore() SessionStore {
+	return &memorySessionStore{sessions: map[string]models.Session{}, now: time.Now}
+}
+
+// live returns the session if it exists and has not expired; callers hold m.mu
+func (m *memorySessionStore) live(sessionID string) (models.Session, bool) {
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		return models.Session{}, false
+	}
+	if !session.ExpiresAt.After(m.now()) {
+		delete(m.sessions, sessionID)
+		return models.Session{}, false
+	}
+	return session, true
+}
+
+func (m *memorySessionStore) Create(ctx context.Context, session *models.Session, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session.ExpiresAt = sessionExpiry(session, m.now(), ttl)
+	m.sessions[session.SessionID] = *session
+	return nil
+}
+
+func (m *memorySessionStore) Get(ctx context.Context, sessionID string) (*models.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.live(sessionID)
+	if !ok {
+		return nil, errors.ErrSessionNotFound
+	}
+	return &session, nil
+}
+
+func (m *memorySessionStore) Delete(ctx context.Context, sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, sessionID)
+	return nil
+}
+
+func (m *memorySessionStore) Refresh(ctx context.Context, sessionID string, ttl time.Duration) (*models.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.live(sessionID)
+	if !ok {
+		return nil, errors.ErrSessionNotFound
+	}
+	now := m.now()
+	session.LastAccessedAt = now
+	session.ExpiresAt = sessionExpiry(&session, now, ttl)
+	m.sessions[sessionID] = session
+	return &session, nil
+}
+
+func (m *memorySessionStore) Touch(ctx context.Context, sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.live(sessionID)
+	if !ok {
+		return errors.ErrSessionNotFound
+	}
+	session.LastAccessedAt = m.now()
+	m.sessions[sessionID] = session
+	return nil
+}
+
+func (m *memorySessionStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var sessions []models.Session
+	for id, session := range m.sessions {
+		if session.UserID != userID {
+			continue
+		}
+		if live, ok := m.live(id); ok {
+			sessions = append(sessions, live)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *memorySessionStore) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, session := range m.sessions {
+		if session.UserID == userID {
+			delete(m.sessions, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+)
+
+// sessionStores returns each SessionStore implementation with a controllable clock
+func sessionStores(now *time.Time) map[string]SessionStore {
+	clock := func() time.Time { return *now }
+
+	memory := NewMemorySessionStore().(*memorySessionStore)
+	memory.now = clock
+	redis := NewRedisSessionStore(newFakeRedis()).(*redisSessionStore)
+	redis.now = clock
+
+	return map[string]SessionStore{"memory": memory, "redis": redis}
+}
+
+func TestSessionFlow(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, store := range sessionStores(&now) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newTestService(nil, nil, nil)
+			svc.SetSessionStore(store)
+			svc.now = func() time.Time { return now }
+			userID := uuid.New()
+
+			session, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: userID, UserEmail: "flow@example.com"})
+			if err != nil {
+				t.Fatalf("CreateSession() error = %v", err)
+			}
+			if want := now.Add(defaultSessionTTL); !session.ExpiresAt.Equal(want) {
+				t.Errorf("ExpiresAt = %v, want %v", session.ExpiresAt, want)
+			}
+
+			got, err := svc.GetSession(ctx, session.SessionID)
+			if err != nil {
+				t.Fatalf("GetSession() error = %v", err)
+			}
+			if got.UserID != userID || got.UserEmail != "flow@example.com" {
+				t.Errorf("GetSession() = %+v, want the created session", got)
+			}
+
+			now = now.Add(time.Hour)
+			if err := store.Touch(ctx, session.SessionID); err != nil {
+				t.Fatalf("Touch() error = %v", err)
+			}
+			got, _ = store.Get(ctx, session.SessionID)
+			if !got.LastAccessedAt.Equal(now) || !got.ExpiresAt.Equal(session.ExpiresAt) {
+				t.Errorf("after Touch lastAccessed = %v expires = %v, want %v and unchanged expiry", got.LastAccessedAt, got.ExpiresAt, now)
+			}
+
+			refreshed, err := store.Refresh(ctx, session.SessionID, 2*time.Hour)
+			if err != nil {
+				t.Fatalf("Refresh() error = %v", err)
+			}
+			if want := now.Add(2 * time.Hour); !refreshed.ExpiresAt.Equal(want) {
+				t.Errorf("after Refresh expires = %v, want %v", refreshed.ExpiresAt, want)
+			}
+
+			listed, err := store.ListByUser(ctx, userID)
+			if err != nil || len(listed) != 1 || listed[0].SessionID != session.SessionID {
+				t.Errorf("ListByUser() = %v, %v; want the one session", listed, err)
+			}
+
+			if err := store.Delete(ctx, session.SessionID); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := svc.GetSession(ctx, session.SessionID); !goerrors.Is(err, errors.ErrSessionNotFound) {
+				t.Errorf("GetSession() after Delete error = %v, want ErrSessionNotFound", err)
+			}
+			if err := store.Delete(ctx, session.SessionID); err != nil {
+				t.Errorf("Delete() of a missing session error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestGetSession_SlidingExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, store := range sessionStores(&now) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newTestService(nil, nil, nil)
+			svc.SetSessionStore(store)
+			svc.SetSessionExpiry(time.Hour, 3*time.Hour)
+			svc.now = func() time.Time { return now }
+			created := now
+
+			session, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: uuid.New()})
+			if err != nil {
+				t.Fatalf("CreateSession() error = %v", err)
+			}
+			if want := created.Add(3 * time.Hour); !session.MaxExpiresAt.Equal(want) {
+				t.Errorf("MaxExpiresAt = %v, want %v", session.MaxExpiresAt, want)
+			}
+
+			// Each access within the idle timeout extends the session
+			now = now.Add(50 * time.Minute)
+			got, err := svc.GetSession(ctx, session.SessionID)
+			if err != nil {
+				t.Fatalf("GetSession() error = %v", err)
+			}
+			if want := now.Add(time.Hour); !got.ExpiresAt.Equal(want) {
+				t.Errorf("ExpiresAt after access = %v, want %v", got.ExpiresAt, want)
+			}
+			if !got.LastAccessedAt.Equal(now) {
+				t.Errorf("LastAccessedAt = %v, want %v", got.LastAccessedAt, now)
+			}
+
+			// Past the original expiry, the session is still alive
+			now = now.Add(50 * time.Minute)
+			if _, err := svc.GetSession(ctx, session.SessionID); err != nil {
+				t.Fatalf("GetSession() after original expiry error = %v", err)
+			}
+
+			// Near the max lifetime, extensions stop at the cap
+			now = created.Add(2*time.Hour + 30*time.Minute)
+			if _, err := svc.GetSession(ctx, session.SessionID); err != nil {
+				t.Fatalf("GetSession() near max lifetime error = %v", err)
+			}
+			now = created.Add(2*time.Hour + 50*time.Minute)
+			got, err = svc.GetSession(ctx, session.SessionID)
+			if err != nil {
+				t.Fatalf("GetSession() near max lifetime error = %v", err)
+			}
+			if !got.ExpiresAt.Equal(session.MaxExpiresAt) {
+				t.Errorf("ExpiresAt near max lifetime = %v, want capped at %v", got.ExpiresAt, session.MaxExpiresAt)
+			}
+
+			// However active, the session ends at its max lifetime
+			now = session.MaxExpiresAt
+			if _, err := svc.GetSession(ctx, session.SessionID); !goerrors.Is(err, errors.ErrSessionNotFound) {
+				t.Errorf("GetSession() at max lifetime error = %v, want ErrSessionNotFound", err)
+			}
+		})
+	}
+}
+
+func TestGetSession_IdleExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemorySessionStore().(*memorySessionStore)
+	store.now = func() time.Time { return now }
+	svc := newTestService(nil, nil, nil)
+	svc.SetSessionStore(store)
+	svc.SetSessionExpiry(time.Hour, 3*time.Hour)
+	svc.now = store.now
+	ctx := context.Background()
+
+	session, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: uuid.New()})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	now = now.Add(61 * time.Minute)
+	if _, err := svc.GetSession(ctx, session.SessionID); !goerrors.Is(err, errors.ErrSessionNotFound) {
+		t.Errorf("GetSession() after idle timeout error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestRedisSessionStore_RefreshSetsCappedTTL(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	redis := newFakeRedis()
+	store := NewRedisSessionStore(redis).(*redisSessionStore)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	session := &models.Session{SessionID: "capped", UserID: uuid.New(), CreatedAt: now, MaxExpiresAt: now.Add(90 * time.Minute)}
+	if err := store.Create(ctx, session, time.Hour); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got := redis.expires[userSessionsKey(session.UserID)]; got != 90*time.Minute {
+		t.Errorf("user index TTL = %v, want the session's max lifetime", got)
+	}
+
+	now = now.Add(45 * time.Minute)
+	if _, err := store.Refresh(ctx, "capped", time.Hour); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if got := redis.expires[sessionKey("capped")]; got != 45*time.Minute {
+		t.Errorf("session TTL after Refresh = %v, want 45m (capped)", got)
+	}
+
+	// A session deleted after being read is not recreated by the write-back
+	stale, _ := store.Get(ctx, "capped")
+	if err := store.Delete(ctx, "capped"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.save(ctx, stale); !goerrors.Is(err, errors.ErrSessionNotFound) {
+		t.Errorf("save() of a deleted session error = %v, want ErrSessionNotFound", err)
+	}
+	if _, ok := redis.values[sessionKey("capped")]; ok {
+		t.Error("deleted session was recreated")
+	}
+}
+
+func TestGetSession_ConcurrentAccessNeverPassesCap(t *testing.T) {
+	store := NewMemorySessionStore()
+	svc := newTestService(nil, nil, nil)
+	svc.SetSessionStore(store)
+	// Every extension would pass the cap, so every one must be clamped to it
+	svc.SetSessionExpiry(2*time.Hour, time.Hour)
+	ctx := context.Background()
+
+	session, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: uuid.New()})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				got, err := svc.GetSession(ctx, session.SessionID)
+				if err != nil {
+					t.Errorf("GetSession() error = %v", err)
+					return
+				}
+				if got.ExpiresAt.After(session.MaxExpiresAt) {
+					t.Errorf("ExpiresAt = %v, past max %v", got.ExpiresAt, session.MaxExpiresAt)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCreateSession_EvictsOldestOverLimit(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, store := range sessionStores(&now) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newTestService(nil, nil, nil)
+			svc.SetSessionStore(store)
+			svc.SetMaxSessionsPerUser(3)
+			svc.now = func() time.Time { return now }
+			userID := uuid.New()
+
+			var ids []string
+			for _, device := range []string{"laptop", "phone", "tablet", "tv"} {
+				session, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: userID, Device: device, IPAddress: "203.0.113.7"})
+				if err != nil {
+					t.Fatalf("CreateSession(%s) error = %v", device, err)
+				}
+				ids = append(ids, session.SessionID)
+				now = now.Add(time.Minute)
+			}
+
+			// Another user's sessions don't count towards the limit
+			if _, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: uuid.New()}); err != nil {
+				t.Fatalf("CreateSession(other user) error = %v", err)
+			}
+
+			if _, err := svc.GetSession(ctx, ids[0]); !goerrors.Is(err, errors.ErrSessionNotFound) {
+				t.Errorf("GetSession(oldest) error = %v, want ErrSessionNotFound", err)
+			}
+
+			sessions, err := svc.ListSessions(ctx, userID)
+			if err != nil {
+				t.Fatalf("ListSessions() error = %v", err)
+			}
+			var devices []string
+			for _, session := range sessions {
+				devices = append(devices, session.Device)
+				if session.IPAddress != "203.0.113.7" {
+					t.Errorf("session %s IPAddress = %q, want 203.0.113.7", session.SessionID, session.IPAddress)
+				}
+			}
+			if want := []string{"tv", "tablet", "phone"}; !reflect.DeepEqual(devices, want) {
+				t.Errorf("ListSessions() devices = %v, want %v", devices, want)
+			}
+		})
+	}
+}
+
+func TestCreateSession_NoLimit(t *testing.T) {
+	svc := newTestService(nil, nil, nil)
+	svc.SetSessionStore(NewMemorySessionStore())
+	svc.SetMaxSessionsPerUser(0)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	for i := 0; i < defaultMaxSessionsPerUser+5; i++ {
+		if _, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: userID}); err != nil {
+			t.Fatalf("CreateSession() error = %v", err)
+		}
+	}
+	if sessions, _ := svc.ListSessions(ctx, userID); len(sessions) != defaultMaxSessionsPerUser+5 {
+		t.Errorf("ListSessions() = %d sessions, want %d", len(sessions), defaultMaxSessionsPerUser+5)
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	svc := newTestService(nil, nil, nil)
+	svc.SetSessionStore(NewMemorySessionStore())
+	ctx := context.Background()
+	userID := uuid.New()
+
+	session, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: userID})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	// Users can only revoke their own sessions
+	if err := svc.RevokeSession(ctx, uuid.New(), session.SessionID); !goerrors.Is(err, errors.ErrSessionNotFound) {
+		t.Errorf("RevokeSession(other user) error = %v, want ErrSessionNotFound", err)
+	}
+	if _, err := svc.GetSession(ctx, session.SessionID); err != nil {
+		t.Errorf("GetSession() after another user's revoke error = %v, want the session", err)
+	}
+
+	if err := svc.RevokeSession(ctx, userID, session.SessionID); err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+	if _, err := svc.GetSession(ctx, session.SessionID); !goerrors.Is(err, errors.ErrSessionNotFound) {
+		t.Errorf("GetSession() after revoke error = %v, want ErrSessionNotFound", err)
+	}
+	if err := svc.RevokeSession(ctx, userID, session.SessionID); !goerrors.Is(err, errors.ErrSessionNotFound) {
+		t.Errorf("RevokeSession() twice error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestRevokeAllSessions_ClearsUserIndex(t *testing.T) {
+	redis := newFakeRedis()
+	svc := newTestService(nil, redis, nil)
+	ctx := context.Background()
+	userID := uuid.New()
+	other := uuid.New()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		session, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: userID})
+		if err != nil {
+			t.Fatalf("CreateSession() error = %v", err)
+		}
+		ids = append(ids, session.SessionID)
+	}
+	kept, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: other})
+	if err != nil {
+		t.Fatalf("CreateSession(other user) error = %v", err)
+	}
+
+	if err := svc.RevokeAllSessions(ctx, userID); err != nil {
+		t.Fatalf("RevokeAllSessions() error = %v", err)
+	}
+
+	if members := redis.sets[userSessionsKey(userID)]; len(members) != 0 {
+		t.Errorf("user session set after RevokeAllSessions = %v, want empty", members)
+	}
+	for _, id := range ids {
+		if _, err := svc.GetSession(ctx, id); !goerrors.Is(err, errors.ErrSessionNotFound) {
+			t.Errorf("GetSession(%s) after RevokeAllSessions error = %v, want ErrSessionNotFound", id, err)
+		}
+	}
+	if sessions, _ := svc.ListSessions(ctx, userID); len(sessions) != 0 {
+		t.Errorf("ListSessions() after RevokeAllSessions = %v, want none", sessions)
+	}
+	if _, err := svc.GetSession(ctx, kept.SessionID); err != nil {
+		t.Errorf("GetSession(other user) error = %v, want their session kept", err)
+	}
+}
+
+func TestRedisSessionStore_ListPrunesExpiredFromIndex(t *testing.T) {
+	redis := newFakeRedis()
+	store := NewRedisSessionStore(redis)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	for _, id := range []string{"live", "expired"} {
+		if err := store.Create(ctx, &models.Session{SessionID: id, UserID: userID}, time.Hour); err != nil {
+			t.Fatalf("Create(%s) error = %v", id, err)
+		}
+	}
+	delete(redis.values, sessionKey("expired")) // Redis expired the key
+
+	sessions, err := store.ListByUser(ctx, userID)
+	if err != nil || len(sessions) != 1 || sessions[0].SessionID != "live" {
+		t.Fatalf("ListByUser() = %v, %v; want only the live session", sessions, err)
+	}
+	if members := redis.sets[userSessionsKey(userID)]; len(members) != 1 || !members["live"] {
+		t.Errorf("user session set = %v, want only the live session", members)
+	}
+}
+
+func TestMemorySessionStore_Expiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemorySessionStore().(*memorySessionStore)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	userID := uuid.New()
+	for _, id := range []string{"short", "long"} {
+		ttl := time.Hour
+		if id == "long" {
+			ttl = 3 * time.Hour
+		}
+		if err := store.Create(ctx, &models.Session{SessionID: id, UserID: userID}, ttl); err != nil {
+			t.Fatalf("Create(%s) error = %v", id, err)
+		}
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := store.Get(ctx, "short"); !goerrors.Is(err, errors.ErrSessionNotFound) {
+		t.Errorf("Get(short) after expiry error = %v, want ErrSessionNotFound", err)
+	}
+	if err := store.Touch(ctx, "short"); !goerrors.Is(err, errors.ErrSessionNotFound) {
+		t.Errorf("Touch(short) after expiry error = %v, want ErrSessionNotFound", err)
+	}
+	if listed, _ := store.ListByUser(ctx, userID); len(listed) != 1 || listed[0].SessionID != "long" {
+		t.Errorf("ListByUser() = %v, want only the unexpired session", listed)
+	}
+
+	if err := store.DeleteByUser(ctx, userID); err != nil {
+		t.Fatalf("DeleteByUser() error = %v", err)
+	}
+	if _, err := store.Get(ctx, "long"); !goerrors.Is(err, errors.ErrSessionNotFound) {
+		t.Errorf("Get(long) after DeleteByUser error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestSessions_WithoutStore(t *testing.T) {
+	svc := newTestService(nil, nil, nil)
+
+	if _, err := svc.CreateSession(context.Background(), &models.CreateSessionRequest{UserID: uuid.New()}); err == nil {
+		t.Error("CreateSession() without a session store error = nil, want error")
+	}
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/scylladb"
+)
+
+// DefaultVirtualNodes is the number of ring points per shard when none is configured
+const DefaultVirtualNodes = 128
+
+// DeviceShardResolver maps device IDs to ScyllaDB shards (keyspaces or clusters)
+// with consistent hashing. Each shard owns many virtual nodes on the ring, so
+// adding or removing a shard moves only about 1/N of devices.
+type DeviceShardResolver struct {
+	points []ringPoint
+	shards map[string]scylladb.Session
+}
+
+type ringPoint struct {
+	hash  uint64
+	shard string
+}
+
+// NewDeviceShardResolver builds a ring over shards (name -> session) with
+// virtualNodes points per shard; virtualNodes <= 0 uses DefaultVirtualNodes.
+func NewDeviceShardResolver(shards map[string]scylladb.Session, virtualNodes int) (*DeviceShardResolver, error) {
+	if len(shards) == 0 {
+		return nil, fmt.Errorf("device shard resolver needs at least one shard")
+	}
+	if virtualNodes <= 0 {
+		virtualNodes = DefaultVirtualNodes
+	}
+
+	r := &DeviceShardResolver{
+		points: make([]ringPoint, 0, len(shards)*virtualNodes),
+		shards: make(map[string]scylladb.Session, len(shards)),
+	}
+	for name, session := range shards {
+		if session == nil {
+			return nil, fmt.Errorf("shard %q has no session", name)
+		}
+		r.shards[name] = session
+		for i := 0; i < virtualNodes; i++ {
+			r.points = append(r.points, ringPoint{hash: ringHash(fmt.Sprintf("%s#%d", name, i)), shard: name})
+		}
+	}
+	// Break hash ties by name so the ring doesn't depend on map order
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash != r.points[j].hash {
+			return r.points[i].hash < r.points[j].hash
+		}
+		return r.points[i].shard < r.points[j].shard
+	})
+
+	return r, nil
+}
+
+// Shard returns the name of the shard that owns deviceID
+func (r *DeviceShardResolver) Shard(deviceID string) string {
+	h := ringHash(deviceID)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0 // wrap around the ring
+	}
+	return r.points[i].shard
+}
+
+// Session returns the session for the shard that owns deviceID
+func (r *DeviceShardResolver) Session(deviceID string) scylladb.Session {
+	return r.shards[r.Shard(deviceID)]
+}
+
+// Sessions returns every shard's session, ordered by shard name
+func (r *DeviceShardResolver) Sessions() []scylladb.Session {
+	names := make([]string, 0, len(r.shards))
+	for name := range r.shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sessions := make([]scylladb.Session, len(names))
+	for i, name := range names {
+		sessions[i] = r.shards[name]
+	}
+	return sessions
+}
+
+// ringHash is FNV-1a with a splitmix64 finalizer; FNV alone clusters on
+// short keys that differ only in their last characters
+func ringHash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// SetTelemetryShards routes telemetry by device across the resolver's shards.
+// Without a resolver all telemetry uses the primary ScyllaDB session.
+func (s *PatternsService) SetTelemetryShards(resolver *DeviceShardResolver) {
+	s.telemetryShards = resolver
+}
+
+// telemetrySession returns the session that stores deviceID's telemetry
+func (s *PatternsService) telemetrySession(deviceID string) scylladb.Session {
+	if s.telemetryShards == nil {
+		return s.scyllaSession
+	}
+	return s.telemetryShards.Session(deviceID)
+}
+
+// forEachTelemetrySession runs fn against every shard through the ScyllaDB
+// circuit breaker, stopping at the first error
+func (s *PatternsService) forEachTelemetrySession(ctx context.Context, fn func(session scylladb.Session) error) error {
+	for _, session := range s.telemetrySessions() {
+		if err := s.scyllaCircuitBreaker.ExecuteWithContext(ctx, func(context.Context) error { return fn(session) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// telemetrySessions returns every session holding telemetry, for fleet-wide scans
+func (s *PatternsService) telemetrySessions() []scylladb.Session {
+	if s.telemetryShards == nil {
+		return []scylladb.Session{s.scyllaSession}
+	}
+	return s.telemetryShards.Sessions()
+}
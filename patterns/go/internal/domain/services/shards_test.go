@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/scylladb"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+)
+
+func newTestShards(t *testing.T, names ...string) (*DeviceShardResolver, map[string]*fakeScylla) {
+	t.Helper()
+	fakes := make(map[string]*fakeScylla, len(names))
+	sessions := make(map[string]scylladb.Session, len(names))
+	for _, name := range names {
+		fakes[name] = &fakeScylla{}
+		sessions[name] = fakes[name]
+	}
+	resolver, err := NewDeviceShardResolver(sessions, 0)
+	if err != nil {
+		t.Fatalf("NewDeviceShardResolver() error = %v", err)
+	}
+	return resolver, fakes
+}
+
+func TestDeviceShardResolver_StableMapping(t *testing.T) {
+	a, _ := newTestShards(t, "primary", "shard-2", "shard-3")
+	b, _ := newTestShards(t, "shard-3", "primary", "shard-2")
+
+	for i := 0; i < 1000; i++ {
+		device := fmt.Sprintf("device-%d", i)
+		if a.Shard(device) != b.Shard(device) {
+			t.Fatalf("Shard(%s) = %s and %s, want the same shard regardless of config order", device, a.Shard(device), b.Shard(device))
+		}
+	}
+
+	// Adding a shard only moves devices onto the new shard
+	c, _ := newTestShards(t, "primary", "shard-2", "shard-3", "shard-4")
+	moved := 0
+	for i := 0; i < 10000; i++ {
+		device := fmt.Sprintf("device-%d", i)
+		before, after := a.Shard(device), c.Shard(device)
+		if before != after {
+			if after != "shard-4" {
+				t.Fatalf("Shard(%s) moved %s -> %s, want moves only onto shard-4", device, before, after)
+			}
+			moved++
+		}
+	}
+	if moved < 1500 || moved > 3500 {
+		t.Errorf("moved = %d of 10000, want about a quarter", moved)
+	}
+}
+
+func TestDeviceShardResolver_EvenDistribution(t *testing.T) {
+	resolver, _ := newTestShards(t, "primary", "shard-2", "shard-3", "shard-4")
+
+	const devices = 20000
+	counts := map[string]int{}
+	for i := 0; i < devices; i++ {
+		counts[resolver.Shard(fmt.Sprintf("sensor-%05d", i))]++
+	}
+
+	want := devices / 4
+	for shard, n := range counts {
+		if n < want*8/10 || n > want*12/10 {
+			t.Errorf("shard %s owns %d devices, want %d ±20%%", shard, n, want)
+		}
+	}
+	if len(counts) != 4 {
+		t.Errorf("devices spread over %d shards, want 4", len(counts))
+	}
+}
+
+func TestRecordTelemetry_WritesToDeviceShard(t *testing.T) {
+	resolver, fakes := newTestShards(t, "primary", "shard-2")
+	primary := &fakeScylla{}
+	svc := newTestService(primary, nil, nil)
+	svc.SetTelemetryShards(resolver)
+
+	for i := 0; i < 20; i++ {
+		device := fmt.Sprintf("device-%d", i)
+		if _, err := svc.RecordTelemetry(context.Background(), &models.RecordTelemetryRequest{DeviceID: device, Metric: "temperature"}); err != nil {
+			t.Fatalf("RecordTelemetry() error = %v", err)
+		}
+		if _, err := svc.GetTelemetryHistory(context.Background(), device, time.Now().Add(-time.Hour), time.Now()); err != nil {
+			t.Fatalf("GetTelemetryHistory() error = %v", err)
+		}
+
+		// The insert and its hourly count update both land on the device's shard
+		owner := fakes[resolver.Shard(device)]
+		if got := owner.execArgs[len(owner.execArgs)-2][1]; got != device {
+			t.Errorf("last insert on shard %s = %v, want %s", resolver.Shard(device), got, device)
+		}
+		if got := owner.execArgs[len(owner.execArgs)-1][3]; got != device {
+			t.Errorf("last count update on shard %s = %v, want %s", resolver.Shard(device), got, device)
+		}
+		if got := owner.iterArgs[len(owner.iterArgs)-1][0]; got != device {
+			t.Errorf("last history query on shard %s = %v, want %s", resolver.Shard(device), got, device)
+		}
+	}
+
+	if len(primary.execs) != 0 {
+		t.Errorf("service session got %d inserts, want all routed through the resolver", len(primary.execs))
+	}
+	if len(fakes["primary"].execs) == 0 || len(fakes["shard-2"].execs) == 0 {
+		t.Errorf("inserts per shard = %d/%d, want both shards used", len(fakes["primary"].execs), len(fakes["shard-2"].execs))
+	}
+}
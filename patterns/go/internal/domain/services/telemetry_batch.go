@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	coreerrors "github.com/your-github-org/ai-scaffolder/core/go/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxTelemetryRowsPerBatch keeps each ScyllaDB batch well under the default
+// batch_size_fail_threshold; larger device batches are split
+const maxTelemetryRowsPerBatch = 100
+
+// RecordTelemetryBatch stores a batch of readings with one ScyllaDB batch per
+// device and publishes a single TelemetryBatchReceived event for the readings
+// stored. Each reading succeeds or fails on its own and is reported in the
+// result in request order; an error is returned only for an empty or
+// oversized batch.
+func (s *PatternsService) RecordTelemetryBatch(ctx context.Context, reqs []models.RecordTelemetryRequest) (*models.BatchResult, error) {
+	log := s.logger.WithContext(ctx)
+	start := time.Now()
+
+	if len(reqs) == 0 {
+		return nil, errors.ErrEmptyTelemetryBatch
+	}
+	if len(reqs) > models.MaxTelemetryBatchSize {
+		return nil, fmt.Errorf("%w: %d exceeds limit of %d", errors.ErrTelemetryBatchTooLarge, len(reqs), models.MaxTelemetryBatchSize)
+	}
+
+	result := &models.BatchResult{Results: make([]models.BatchItemResult, len(reqs))}
+	fail := func(i int, err error) {
+		result.Results[i].Error = err.Error()
+		var serviceErr *coreerrors.ServiceError
+		if goerrors.As(err, &serviceErr) {
+			result.Results[i].Code = serviceErr.Code
+		}
+		result.Failed++
+		s.sli.RecordTelemetryIngestionFailure(err)
+	}
+
+	// Validate each reading and group the valid ones by device (partition)
+	ingestTime := start.UTC()
+	readings := make([]*models.DeviceTelemetry, len(reqs))
+	byDevice := make(map[string][]int)
+	var devices []string
+	for i := range reqs {
+		req := &reqs[i]
+		result.Results[i].Index = i
+
+		if req.DeviceID == "" {
+			fail(i, errors.MissingParameter("deviceId"))
+			continue
+		}
+		eventTime, err := s.telemetryTimestamp(ctx, req, ingestTime)
+		if err != nil {
+			fail(i, err)
+			continue
+		}
+
+		readings[i] = &models.DeviceTelemetry{
+			CorrelationID: uuid.New(),
+			DeviceID:      req.DeviceID,
+			Metric:        req.Metric,
+			Value:         req.Value,
+			Unit:          req.Unit,
+			Timestamp:     eventTime,
+			EventTime:     eventTime,
+			IngestTime:    ingestTime,
+		}
+		if _, ok := byDevice[req.DeviceID]; !ok {
+			devices = append(devices, req.DeviceID)
+		}
+		byDevice[req.DeviceID] = append(byDevice[req.DeviceID], i)
+	}
+
+	stored := make([]bool, len(reqs))
+	for _, deviceID := range devices {
+		indexes := byDevice[deviceID]
+		for len(indexes) > 0 {
+			chunk := indexes[:min(maxTelemetryRowsPerBatch, len(indexes))]
+			indexes = indexes[len(chunk):]
+
+			batch := make([]*models.DeviceTelemetry, len(chunk))
+			for j, i := range chunk {
+				batch[j] = readings[i]
+			}
+			err := s.scyllaCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+				query, args := s.telemetryBatchInsert(batch)
+				return s.telemetrySession(deviceID).ExecContext(ctx, query, args...)
+			})
+			if err != nil {
+				log.Error("Failed to record telemetry batch in ScyllaDB",
+					zap.String("device_id", deviceID),
+					zap.Int("readings", len(chunk)),
+					zap.Error(err))
+				wrapped := errors.ProductErrors.WrapError(err, "PAT-INFRA-005", err)
+				for _, i := range chunk {
+					fail(i, wrapped)
+				}
+				continue
+			}
+
+			for _, i := range chunk {
+				stored[i] = true
+				result.Results[i].CorrelationID = readings[i].CorrelationID.String()
+				result.Succeeded++
+				s.sli.RecordTelemetryIngestionSuccess(time.Since(start))
+			}
+		}
+	}
+
+	// Readings in request order, with one count update per device and hour
+	var published []*models.DeviceTelemetry
+	counts := make(map[telemetryCountKey]int64)
+	for i, ok := range stored {
+		if ok {
+			published = append(published, readings[i])
+			counts[telemetryCountKey{readings[i].DeviceID, readings[i].EventTime.UTC().Truncate(time.Hour)}]++
+		}
+	}
+	for key, n := range counts {
+		s.addTelemetryCount(ctx, key.deviceID, key.hour, n)
+	}
+
+	if len(published) > 0 && s.kafkaProducer != nil {
+		event := models.NewTelemetryBatchReceivedEvent(published, "ai-patterns")
+		if err := s.publishTelemetryEvent(ctx, event); err != nil {
+			log.Warn("Failed to publish telemetry batch event", zap.Error(err))
+		}
+	}
+
+	log.Info("Telemetry batch recorded",
+		zap.Int("received", len(reqs)),
+		zap.Int("succeeded", result.Succeeded),
+		zap.Int("failed", result.Failed),
+		zap.Duration("duration", time.Since(start)))
+
+	return result, nil
+}
+
+type telemetryCountKey struct {
+	deviceID string
+	hour     time.Time
+}
+
+// telemetryBatchInsert returns an unlogged batch inserting readings. Callers
+// pass one device's readings so the batch is a single-partition write.
+func (s *PatternsService) telemetryBatchInsert(readings []*models.DeviceTelemetry) (string, []interface{}) {
+	var query strings.Builder
+	var args []interface{}
+
+	query.WriteString("BEGIN UNLOGGED BATCH")
+	for _, t := range readings {
+		insert, insertArgs := s.telemetryInsert(t)
+		query.WriteString(insert)
+		query.WriteString(";")
+		args = append(args, insertArgs...)
+	}
+	query.WriteString("\nAPPLY BATCH")
+
+	return query.String(), args
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/sli"
+)
+
+// sliSuccesses reads the successful telemetry ingestions counted by the SLI tracker
+func sliSuccesses(t *testing.T) float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	var total float64
+	for _, family := range families {
+		if family.GetName() != "sli_requests_success_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "operation" && label.GetValue() == sli.OperationTelemetryIngestion {
+					total += metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return total
+}
+
+func TestRecordTelemetryBatch_PartialFailure(t *testing.T) {
+	scylla := &fakeScylla{execFn: func(query string, args []interface{}) error {
+		if strings.Contains(query, "BATCH") && args[1] == "broken" {
+			return goerrors.New("write timeout")
+		}
+		return nil
+	}}
+	producer := &fakeProducer{}
+	svc := newTestService(scylla, nil, producer)
+
+	future := time.Now().Add(time.Hour)
+	reqs := []models.RecordTelemetryRequest{
+		{DeviceID: "device-1", Metric: "temperature", Value: 21.5},
+		{DeviceID: "", Metric: "temperature", Value: 1},
+		{DeviceID: "device-1", Metric: "humidity", Value: 40},
+		{DeviceID: "device-1", Metric: "temperature", Value: 22, Timestamp: &future},
+		{DeviceID: "broken", Metric: "temperature", Value: 3},
+	}
+
+	successesBefore := sliSuccesses(t)
+	failuresBefore := svc.sli.FailureBreakdown(sli.OperationTelemetryIngestion)
+
+	result, err := svc.RecordTelemetryBatch(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("RecordTelemetryBatch() error = %v", err)
+	}
+
+	if result.Succeeded != 2 || result.Failed != 3 {
+		t.Errorf("succeeded = %d, failed = %d, want 2 and 3", result.Succeeded, result.Failed)
+	}
+	wantCodes := []string{"", "PAT-VAL-002", "", "PAT-TEL-003", "PAT-INFRA-005"}
+	for i, want := range wantCodes {
+		got := result.Results[i]
+		if got.Index != i || got.Code != want {
+			t.Errorf("results[%d] = %+v, want index %d code %q", i, got, i, want)
+		}
+		if stored := got.CorrelationID != ""; stored != (want == "") {
+			t.Errorf("results[%d].CorrelationID = %q, want set only when stored", i, got.CorrelationID)
+		}
+	}
+
+	// device-1's two readings go in one batch, then one count update for their hour
+	var batches, counts int
+	for i, query := range scylla.execs {
+		switch {
+		case strings.Contains(query, "BATCH") && scylla.execArgs[i][1] == "device-1":
+			batches++
+			if n := strings.Count(query, "INSERT INTO device_telemetry"); n != 2 {
+				t.Errorf("device-1 batch has %d inserts, want 2", n)
+			}
+		case strings.Contains(query, "device_telemetry_counts"):
+			counts++
+			if got := scylla.execArgs[i][0]; got != int64(2) {
+				t.Errorf("count increment = %v, want 2", got)
+			}
+		}
+	}
+	if batches != 1 || counts != 1 {
+		t.Errorf("device-1 batches = %d, count updates = %d, want 1 and 1", batches, counts)
+	}
+
+	// One event for the stored readings
+	if len(producer.messages) != 1 {
+		t.Fatalf("published %d messages, want 1", len(producer.messages))
+	}
+	var event models.TelemetryEvent
+	if err := json.Unmarshal(producer.messages[0].value, &event); err != nil {
+		t.Fatalf("invalid event: %v", err)
+	}
+	if event.EventType != "TelemetryBatchReceived" || len(event.Readings) != 2 || producer.messages[0].key != "device-1" {
+		t.Errorf("event = %s with %d readings keyed %q, want TelemetryBatchReceived with 2 keyed device-1",
+			event.EventType, len(event.Readings), producer.messages[0].key)
+	}
+
+	// The SLI tracker counts every record, not the batch
+	if got := sliSuccesses(t) - successesBefore; got != 2 {
+		t.Errorf("SLI successes = %v, want 2", got)
+	}
+	failures := svc.sli.FailureBreakdown(sli.OperationTelemetryIngestion)
+	for _, code := range []string{"PAT-VAL-002", "PAT-TEL-003", "PAT-INFRA-005"} {
+		if got := failures[code] - failuresBefore[code]; got != 1 {
+			t.Errorf("SLI failures[%s] = %d, want 1", code, got)
+		}
+	}
+}
+
+func TestRecordTelemetryBatch_SplitsLargeDeviceBatches(t *testing.T) {
+	scylla := &fakeScylla{}
+	svc := newTestService(scylla, nil, nil)
+
+	reqs := make([]models.RecordTelemetryRequest, 2*maxTelemetryRowsPerBatch+1)
+	for i := range reqs {
+		reqs[i] = models.RecordTelemetryRequest{DeviceID: "device-1", Metric: "temperature", Value: float64(i)}
+	}
+
+	result, err := svc.RecordTelemetryBatch(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("RecordTelemetryBatch() error = %v", err)
+	}
+	if result.Succeeded != len(reqs) {
+		t.Errorf("succeeded = %d, want %d", result.Succeeded, len(reqs))
+	}
+
+	var batches int
+	for _, query := range scylla.execs {
+		if strings.Contains(query, "BATCH") {
+			batches++
+		}
+	}
+	if batches != 3 {
+		t.Errorf("batches = %d, want 3", batches)
+	}
+}
+
+func TestRecordTelemetryBatch_RejectsEmptyOrOversized(t *testing.T) {
+	svc := newTestService(&fakeScylla{}, nil, nil)
+
+	if _, err := svc.RecordTelemetryBatch(context.Background(), nil); !goerrors.Is(err, errors.ErrEmptyTelemetryBatch) {
+		t.Errorf("empty batch error = %v, want ErrEmptyTelemetryBatch", err)
+	}
+	reqs := make([]models.RecordTelemetryRequest, models.MaxTelemetryBatchSize+1)
+	if _, err := svc.RecordTelemetryBatch(context.Background(), reqs); !goerrors.Is(err, errors.ErrTelemetryBatchTooLarge) {
+		t.Errorf("oversized batch error = %v, want ErrTelemetryBatchTooLarge", err)
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Telemetry counts answer "how many readings" without scanning the cluster.
+//
+// device_telemetry is partitioned by device_id, so counting every device's
+// readings by time (WHERE timestamp ... ALLOW FILTERING) reads every
+// partition and times out at scale. Instead RecordTelemetry increments a
+// counter per device and hour of event time:
+//
+//	CREATE TABLE device_telemetry_counts (
+//	    day TIMESTAMP, hour TIMESTAMP, device_id TEXT, records COUNTER,
+//	    PRIMARY KEY ((day), hour, device_id))
+//
+// A fleet-wide count reads one partition per day in range, sliced by hour,
+// so its cost grows with the range rather than the data. Counts are
+// hour-granular: readings anywhere in the first and last hour of a range are
+// included. Counters don't expire with the raw rows' TTL; reads are clamped
+// to the retention horizon instead.
+//
+// One device's count stays within its device_telemetry partition (see
+// CountTelemetryByDevice) and is exact.
+
+// maxCountDaysPerQuery keeps the partition IN list under ScyllaDB's default
+// max_partition_key_restrictions_per_query
+const maxCountDaysPerQuery = 100
+
+// addTelemetryCount adds n readings to the device's count for the hour of
+// eventTime. Counter updates aren't idempotent, so a failure is logged rather
+// than retried or failing readings that are already stored.
+func (s *PatternsService) addTelemetryCount(ctx context.Context, deviceID string, eventTime time.Time, n int64) {
+	hour := eventTime.UTC().Truncate(time.Hour)
+	query := `UPDATE device_telemetry_counts SET records = records + ? WHERE day = ? AND hour = ? AND device_id = ?`
+
+	err := s.scyllaCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		return s.telemetrySession(deviceID).ExecContext(ctx, query, n, hour.Truncate(24*time.Hour), hour, deviceID)
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("Failed to increment telemetry count",
+			zap.String("device_id", deviceID),
+			zap.Time("hour", hour),
+			zap.Error(err))
+	}
+}
+
+// countTelemetry sums every device's hourly counts for the hours overlapping
+// [start, end] across all shards
+func (s *PatternsService) countTelemetry(ctx context.Context, start, end time.Time) (int64, error) {
+	first := start.UTC().Truncate(time.Hour)
+	last := end.UTC().Truncate(time.Hour)
+
+	var days []time.Time
+	for day := first.Truncate(24 * time.Hour); !day.After(last); day = day.Add(24 * time.Hour) {
+		days = append(days, day)
+	}
+
+	query := `SELECT SUM(records) FROM device_telemetry_counts WHERE day IN ? AND hour >= ? AND hour <= ?`
+	var total int64
+	for _, session := range s.telemetrySessions() {
+		for i := 0; i < len(days); i += maxCountDaysPerQuery {
+			chunk := days[i:min(i+maxCountDaysPerQuery, len(days))]
+			var count int64
+			if err := session.QueryRow(ctx, query, chunk, first, last).Scan(&count); err != nil {
+				return 0, err
+			}
+			total += count
+		}
+	}
+	return total, nil
+}
+
+// CountTelemetryByDevice counts deviceID's readings with event time in
+// [start, end]. The query is restricted to the device's partition, so its
+// cost is bounded by that device's readings in range.
+func (s *PatternsService) CountTelemetryByDevice(ctx context.Context, deviceID string, start, end time.Time) (int64, error) {
+	log := s.logger.WithContext(ctx)
+
+	start = s.telemetryRetention.clampStart(start, time.Now().UTC())
+
+	var count int64
+	err := s.scyllaCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		query := `SELECT COUNT(*) FROM device_telemetry WHERE device_id = ? AND timestamp >= ? AND timestamp <= ?`
+		return s.telemetrySession(deviceID).QueryRow(ctx, query, deviceID, start, end).Scan(&count)
+	})
+	if err != nil {
+		log.Error("Failed to count telemetry in ScyllaDB",
+			zap.String("device_id", deviceID),
+			zap.Error(err))
+		return 0, fmt.Errorf("failed to count telemetry: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+)
+
+func TestRecordTelemetry_IncrementsHourlyCount(t *testing.T) {
+	scylla := &fakeScylla{}
+	svc := newTestService(scylla, nil, nil)
+
+	readingTime := time.Now().UTC().Add(-time.Minute)
+	if _, err := svc.RecordTelemetry(context.Background(), &models.RecordTelemetryRequest{
+		DeviceID:  "device-1",
+		Metric:    "temperature",
+		Value:     21.5,
+		Timestamp: &readingTime,
+	}); err != nil {
+		t.Fatalf("RecordTelemetry() error = %v", err)
+	}
+
+	if len(scylla.execs) != 2 || !strings.Contains(scylla.execs[1], "records = records + ?") {
+		t.Fatalf("execs = %v, want an insert then a count update", scylla.execs)
+	}
+	hour := readingTime.Truncate(time.Hour)
+	want := []interface{}{int64(1), hour.Truncate(24 * time.Hour), hour, "device-1"}
+	if got := scylla.execArgs[1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("count update args = %v, want %v", got, want)
+	}
+}
+
+func TestGetAnalytics_CountsFromHourlyCounters(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		end         time.Time
+		wantQueries int
+		wantDays    int // partitions named by the first query
+	}{
+		{name: "within a day", end: start.Add(2 * time.Hour), wantQueries: 1, wantDays: 1},
+		{name: "across days", end: start.AddDate(0, 0, 2), wantQueries: 1, wantDays: 3},
+		{name: "chunked", end: start.AddDate(0, 0, 150), wantQueries: 2, wantDays: maxCountDaysPerQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scylla := &fakeScylla{rowValue: []interface{}{int64(5)}}
+			svc := newTestService(scylla, newFakeRedis(), nil)
+
+			result, err := svc.GetAnalytics(context.Background(), start, tt.end)
+			if err != nil {
+				t.Fatalf("GetAnalytics() error = %v", err)
+			}
+			if result.ScyllaDB == nil || result.ScyllaDB.TotalRecords != int64(5*tt.wantQueries) {
+				t.Errorf("ScyllaDB = %+v, want %d total records", result.ScyllaDB, 5*tt.wantQueries)
+			}
+
+			if len(scylla.rowQueries) != tt.wantQueries {
+				t.Fatalf("queries = %v, want %d", scylla.rowQueries, tt.wantQueries)
+			}
+			for _, q := range scylla.rowQueries {
+				if strings.Contains(q, "ALLOW FILTERING") || !strings.Contains(q, "device_telemetry_counts") {
+					t.Errorf("query = %q, want a counter read without ALLOW FILTERING", q)
+				}
+			}
+			args := scylla.rowArgs[0]
+			if days := args[0].([]time.Time); len(days) != tt.wantDays || !days[0].Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("days = %v, want %d starting 2026-01-01", days, tt.wantDays)
+			}
+			if got := args[1].(time.Time); !got.Equal(start.Truncate(time.Hour)) {
+				t.Errorf("first hour = %v, want %v", got, start.Truncate(time.Hour))
+			}
+		})
+	}
+}
+
+func TestCountTelemetryByDevice(t *testing.T) {
+	end := time.Now().UTC()
+	start := end.Add(-24 * time.Hour)
+
+	scylla := &fakeScylla{rowValue: []interface{}{int64(12)}}
+	svc := newTestService(scylla, nil, nil)
+
+	count, err := svc.CountTelemetryByDevice(context.Background(), "device-1", start, end)
+	if err != nil {
+		t.Fatalf("CountTelemetryByDevice() error = %v", err)
+	}
+	if count != 12 {
+		t.Errorf("count = %d, want 12", count)
+	}
+	if q := scylla.rowQueries[0]; !strings.Contains(q, "device_id = ?") || strings.Contains(q, "ALLOW FILTERING") {
+		t.Errorf("query = %q, want one restricted to the device partition", q)
+	}
+	if want := []interface{}{"device-1", start, end}; !reflect.DeepEqual(scylla.rowArgs[0], want) {
+		t.Errorf("args = %v, want %v", scylla.rowArgs[0], want)
+	}
+
+	scylla.rowErr = goerrors.New("read timeout")
+	if _, err := svc.CountTelemetryByDevice(context.Background(), "device-1", start, end); !goerrors.Is(err, scylla.rowErr) {
+		t.Errorf("CountTelemetryByDevice() error = %v, want read timeout", err)
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/scylladb"
+	"go.uber.org/zap"
+)
+
+// TelemetryRetention controls how long telemetry rows live in ScyllaDB.
+// Inserts are written USING TTL, so ScyllaDB expires rows on its own; the
+// retention window is also applied to reads so rows written before TTLs were
+// enabled are hidden until PurgeExpiredTelemetry removes them.
+type TelemetryRetention struct {
+	Default time.Duration            // Applies to metrics without an override; zero keeps rows forever
+	Metrics map[string]time.Duration // Per-metric overrides, e.g. "vibration": 7 days
+}
+
+// TTL returns the retention for metric; zero means rows never expire
+func (r TelemetryRetention) TTL(metric string) time.Duration {
+	if ttl, ok := r.Metrics[metric]; ok {
+		return ttl
+	}
+	return r.Default
+}
+
+// ttlSeconds returns the CQL TTL for metric; 0 disables expiry
+func (r TelemetryRetention) ttlSeconds(metric string) int {
+	return int(r.TTL(metric) / time.Second)
+}
+
+// expired reports whether a row for metric recorded at ts is past its retention
+func (r TelemetryRetention) expired(metric string, ts, now time.Time) bool {
+	ttl := r.TTL(metric)
+	return ttl > 0 && ts.Before(now.Add(-ttl))
+}
+
+// horizon returns the oldest timestamp any metric can still have, or the zero
+// time when some metric is kept forever
+func (r TelemetryRetention) horizon(now time.Time) time.Time {
+	longest := r.Default
+	for _, ttl := range r.Metrics {
+		if ttl == 0 || longest == 0 {
+			return time.Time{}
+		}
+		if ttl > longest {
+			longest = ttl
+		}
+	}
+	if longest == 0 {
+		return time.Time{}
+	}
+	return now.Add(-longest)
+}
+
+// shortest returns the smallest non-zero retention, or zero if none is set
+func (r TelemetryRetention) shortest() time.Duration {
+	shortest := r.Default
+	for _, ttl := range r.Metrics {
+		if ttl > 0 && (shortest == 0 || ttl < shortest) {
+			shortest = ttl
+		}
+	}
+	return shortest
+}
+
+// clampStart moves start forward to the retention horizon
+func (r TelemetryRetention) clampStart(start, now time.Time) time.Time {
+	if h := r.horizon(now); start.Before(h) {
+		return h
+	}
+	return start
+}
+
+// SetTelemetryRetention configures the TTL applied to telemetry inserts
+func (s *PatternsService) SetTelemetryRetention(retention TelemetryRetention) {
+	s.telemetryRetention = retention
+}
+
+// PurgeExpiredTelemetry deletes telemetry rows older than their retention window.
+// Rows written with a TTL expire on their own; this removes rows recorded
+// before retention was configured.
+func (s *PatternsService) PurgeExpiredTelemetry(ctx context.Context) (int64, error) {
+	log := s.logger.WithContext(ctx)
+
+	shortest := s.telemetryRetention.shortest()
+	if shortest == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	type rowKey struct {
+		deviceID  string
+		timestamp time.Time
+	}
+	var expired []rowKey
+
+	err := s.forEachTelemetrySession(ctx, func(session scylladb.Session) error {
+		query := `
+			SELECT device_id, metric, timestamp
+			FROM device_telemetry
+			WHERE timestamp < ?
+			ALLOW FILTERING`
+
+		iter := session.QueryIter(ctx, query, now.Add(-shortest))
+		defer iter.Close()
+
+		var deviceID, metric string
+		var timestamp time.Time
+		for iter.Scan(&deviceID, &metric, &timestamp) {
+			if s.telemetryRetention.expired(metric, timestamp, now) {
+				expired = append(expired, rowKey{deviceID: deviceID, timestamp: timestamp})
+			}
+		}
+		return iter.Close()
+	})
+	if err != nil {
+		log.Error("Failed to scan expired telemetry", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge expired telemetry: %w", err)
+	}
+
+	var purged int64
+	for _, row := range expired {
+		err := s.scyllaCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+			return s.telemetrySession(row.deviceID).ExecContext(ctx,
+				`DELETE FROM device_telemetry WHERE device_id = ? AND timestamp = ?`,
+				row.deviceID, row.timestamp)
+		})
+		if err != nil {
+			log.Error("Failed to purge expired telemetry",
+				zap.Int64("purged", purged),
+				zap.Error(err))
+			return purged, fmt.Errorf("failed to purge expired telemetry: %w", err)
+		}
+		purged++
+	}
+
+	if purged > 0 {
+		log.Info("Purged expired telemetry", zap.Int64("count", purged))
+	}
+
+	return purged, nil
+}
+
+// RunTelemetryPurge runs PurgeExpiredTelemetry every interval until ctx is cancelled
+func (s *PatternsService) RunTelemetryPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors are logged by PurgeExpiredTelemetry; retry on the next tick
+			_, _ = s.PurgeExpiredTelemetry(ctx)
+		}
+	}
+}
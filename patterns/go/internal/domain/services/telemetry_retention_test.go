@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+)
+
+func TestRecordTelemetry_AppliesTTL(t *testing.T) {
+	retention := TelemetryRetention{
+		Default: 90 * 24 * time.Hour,
+		Metrics: map[string]time.Duration{"vibration": 7 * 24 * time.Hour},
+	}
+
+	tests := []struct {
+		name      string
+		retention *TelemetryRetention
+		metric    string
+		wantTTL   int
+	}{
+		{name: "default retention", retention: &retention, metric: "temperature", wantTTL: 7776000},
+		{name: "per-metric override", retention: &retention, metric: "vibration", wantTTL: 604800},
+		{name: "no retention configured", metric: "temperature", wantTTL: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scylla := &fakeScylla{}
+			svc := newTestService(scylla, nil, nil)
+			if tt.retention != nil {
+				svc.SetTelemetryRetention(*tt.retention)
+			}
+
+			_, err := svc.RecordTelemetry(context.Background(), &models.RecordTelemetryRequest{
+				DeviceID: "device-1",
+				Metric:   tt.metric,
+				Value:    21.5,
+				Unit:     "celsius",
+			})
+			if err != nil {
+				t.Fatalf("RecordTelemetry() error = %v", err)
+			}
+
+			// The insert, then the hourly count update
+			if len(scylla.execs) != 2 || !strings.Contains(scylla.execs[0], "USING TTL ?") {
+				t.Fatalf("execs = %v, want an insert USING TTL and a count update", scylla.execs)
+			}
+			args := scylla.execArgs[0]
+			if got := args[len(args)-1]; got != tt.wantTTL {
+				t.Errorf("TTL = %v, want %v", got, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestGetTelemetryHistory_HidesExpiredRows(t *testing.T) {
+	now := time.Now().UTC()
+	scylla := &fakeScylla{iterRows: [][]interface{}{
+		{uuid.New(), "device-1", "temperature", 21.5, "celsius", now.Add(-time.Hour)},
+		{uuid.New(), "device-1", "vibration", 0.4, "g", now.Add(-2 * time.Hour)},
+		{uuid.New(), "device-1", "vibration", 0.3, "g", now.Add(-3 * 24 * time.Hour)}, // written before TTLs
+	}}
+	svc := newTestService(scylla, nil, nil)
+	svc.SetTelemetryRetention(TelemetryRetention{
+		Default: 30 * 24 * time.Hour,
+		Metrics: map[string]time.Duration{"vibration": 24 * time.Hour},
+	})
+
+	history, err := svc.GetTelemetryHistory(context.Background(), "device-1", now.Add(-365*24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("GetTelemetryHistory() error = %v", err)
+	}
+	if len(history) != 2 {
+		t.Errorf("history = %d rows, want 2 (expired vibration row hidden)", len(history))
+	}
+
+	start := scylla.iterArgs[0][1].(time.Time)
+	if want := now.Add(-30 * 24 * time.Hour); start.Before(want.Add(-time.Minute)) {
+		t.Errorf("query start = %v, want clamped to retention horizon %v", start, want)
+	}
+}
+
+func TestPurgeExpiredTelemetry(t *testing.T) {
+	now := time.Now().UTC()
+	old := now.Add(-2 * 24 * time.Hour)
+	scylla := &fakeScylla{iterRows: [][]interface{}{
+		{"device-1", "vibration", old},
+		{"device-1", "temperature", old}, // within its 30 day retention
+	}}
+	svc := newTestService(scylla, nil, nil)
+	svc.SetTelemetryRetention(TelemetryRetention{
+		Default: 30 * 24 * time.Hour,
+		Metrics: map[string]time.Duration{"vibration": 24 * time.Hour},
+	})
+
+	purged, err := svc.PurgeExpiredTelemetry(context.Background())
+	if err != nil {
+		t.Fatalf("PurgeExpiredTelemetry() error = %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("purged = %v, want 1", purged)
+	}
+	if len(scylla.execs) != 1 || !strings.HasPrefix(scylla.execs[0], "DELETE FROM device_telemetry") {
+		t.Fatalf("execs = %v, want one DELETE", scylla.execs)
+	}
+	if got := scylla.execArgs[0]; got[0] != "device-1" || !got[1].(time.Time).Equal(old) {
+		t.Errorf("DELETE args = %v, want device-1 at %v", got, old)
+	}
+
+	// Without retention nothing is scanned
+	scylla = &fakeScylla{}
+	if purged, err := newTestService(scylla, nil, nil).PurgeExpiredTelemetry(context.Background()); err != nil || purged != 0 {
+		t.Errorf("PurgeExpiredTelemetry() without retention = %v, %v, want 0, nil", purged, err)
+	}
+	if len(scylla.iterArgs) != 0 {
+		t.Errorf("queries without retention = %d, want 0", len(scylla.iterArgs))
+	}
+}
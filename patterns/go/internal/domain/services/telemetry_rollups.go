@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/scylladb"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+// Telemetry rollups pre-aggregate raw rows into hourly and daily buckets so
+// long-range stats queries read a few hundred rows instead of millions.
+//
+// Rollup tables share a layout:
+//
+//	CREATE TABLE device_telemetry_hourly (
+//	    device_id TEXT, bucket TIMESTAMP, metric TEXT, unit TEXT,
+//	    value_count BIGINT, value_min DOUBLE, value_max DOUBLE,
+//	    value_sum DOUBLE, value_sum_squares DOUBLE,
+//	    PRIMARY KEY (device_id, bucket, metric))
+
+const (
+	// Ranges up to rawStatsMaxRange are aggregated from raw rows; up to
+	// hourlyStatsMaxRange from hourly rollups; anything wider from daily rollups
+	rawStatsMaxRange    = 48 * time.Hour
+	hourlyStatsMaxRange = 60 * 24 * time.Hour
+
+	// Window used when aggregating raw rows
+	rawStatsWindow = time.Hour
+)
+
+// telemetryRollup describes one rollup table
+type telemetryRollup struct {
+	granularity string
+	table       string
+	window      time.Duration
+}
+
+var (
+	hourlyRollup = telemetryRollup{granularity: models.TelemetryGranularityHourly, table: "device_telemetry_hourly", window: time.Hour}
+	dailyRollup  = telemetryRollup{granularity: models.TelemetryGranularityDaily, table: "device_telemetry_daily", window: 24 * time.Hour}
+)
+
+// rollupForRange picks the coarsest data needed for a query range; nil means raw rows
+func rollupForRange(start, end time.Time) *telemetryRollup {
+	switch span := end.Sub(start); {
+	case span <= rawStatsMaxRange:
+		return nil
+	case span <= hourlyStatsMaxRange:
+		return &hourlyRollup
+	default:
+		return &dailyRollup
+	}
+}
+
+// telemetryAccumulator folds values into rollup statistics
+type telemetryAccumulator struct {
+	unit       string
+	count      int64
+	min, max   float64
+	sum        float64
+	sumSquares float64
+}
+
+func (a *telemetryAccumulator) add(value float64) {
+	if a.count == 0 || value < a.min {
+		a.min = value
+	}
+	if a.count == 0 || value > a.max {
+		a.max = value
+	}
+	a.count++
+	a.sum += value
+	a.sumSquares += value * value
+}
+
+func (a *telemetryAccumulator) aggregated(deviceID, metric string, windowStart time.Time, window time.Duration) *models.AggregatedTelemetry {
+	agg := &models.AggregatedTelemetry{
+		DeviceID:    deviceID,
+		Metric:      metric,
+		WindowStart: windowStart,
+		WindowEnd:   windowStart.Add(window),
+		WindowSize:  window,
+		Count:       a.count,
+		Min:         a.min,
+		Max:         a.max,
+		Sum:         a.sum,
+		Unit:        a.unit,
+	}
+	if a.count > 0 {
+		agg.Average = a.sum / float64(a.count)
+		// Population standard deviation; clamp rounding noise below zero
+		agg.StdDev = math.Sqrt(math.Max(0, a.sumSquares/float64(a.count)-agg.Average*agg.Average))
+	}
+	return agg
+}
+
+type rollupKey struct {
+	deviceID string
+	metric   string
+	bucket   time.Time
+}
+
+// RollupTelemetry aggregates raw telemetry in [bucketStart, bucketStart+window)
+// into the rollup table for window (time.Hour or 24*time.Hour). Rollup rows
+// are upserts, so re-running a bucket replaces it.
+func (s *PatternsService) RollupTelemetry(ctx context.Context, bucketStart time.Time, window time.Duration) (int, error) {
+	log := s.logger.WithContext(ctx)
+
+	var rollup telemetryRollup
+	switch window {
+	case hourlyRollup.window:
+		rollup = hourlyRollup
+	case dailyRollup.window:
+		rollup = dailyRollup
+	default:
+		return 0, fmt.Errorf("unsupported rollup window %s", window)
+	}
+
+	bucketStart = bucketStart.UTC().Truncate(window)
+	bucketEnd := bucketStart.Add(window)
+	buckets := make(map[rollupKey]*telemetryAccumulator)
+
+	err := s.forEachTelemetrySession(ctx, func(session scylladb.Session) error {
+		query := `
+			SELECT device_id, metric, value, unit, timestamp
+			FROM device_telemetry
+			WHERE timestamp >= ? AND timestamp < ?
+			ALLOW FILTERING`
+
+		iter := session.QueryIter(ctx, query, bucketStart, bucketEnd)
+		defer iter.Close()
+
+		var deviceID, metric, unit string
+		var value float64
+		var timestamp time.Time
+		for iter.Scan(&deviceID, &metric, &value, &unit, &timestamp) {
+			key := rollupKey{deviceID: deviceID, metric: metric, bucket: bucketStart}
+			acc, ok := buckets[key]
+			if !ok {
+				acc = &telemetryAccumulator{unit: unit}
+				buckets[key] = acc
+			}
+			acc.add(value)
+		}
+		return iter.Close()
+	})
+	if err != nil {
+		log.Error("Failed to read telemetry for rollup", zap.String("table", rollup.table), zap.Error(err))
+		return 0, fmt.Errorf("failed to roll up telemetry: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (device_id, bucket, metric, unit, value_count, value_min, value_max, value_sum, value_sum_squares)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, rollup.table)
+
+	written := 0
+	for key, acc := range buckets {
+		err := s.scyllaCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+			return s.telemetrySession(key.deviceID).ExecContext(ctx, query,
+				key.deviceID, key.bucket, key.metric, acc.unit,
+				acc.count, acc.min, acc.max, acc.sum, acc.sumSquares)
+		})
+		if err != nil {
+			log.Error("Failed to write telemetry rollup", zap.String("table", rollup.table), zap.Error(err))
+			return written, fmt.Errorf("failed to roll up telemetry: %w", err)
+		}
+		written++
+	}
+
+	log.Debug("Telemetry rolled up",
+		zap.String("table", rollup.table),
+		zap.Time("bucket", bucketStart),
+		zap.Int("rows", written))
+
+	return written, nil
+}
+
+// RunTelemetryRollups rolls up each hour and day once it has ended, checking
+// every interval until ctx is cancelled. A failed bucket is retried on the next tick.
+func (s *PatternsService) RunTelemetryRollups(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastHour, lastDay time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now().UTC()
+			// Errors are logged by RollupTelemetry
+			if hour := now.Truncate(time.Hour).Add(-time.Hour); !hour.Equal(lastHour) {
+				if _, err := s.RollupTelemetry(ctx, hour, time.Hour); err == nil {
+					lastHour = hour
+				}
+			}
+			if day := now.Truncate(24 * time.Hour).Add(-24 * time.Hour); !day.Equal(lastDay) {
+				if _, err := s.RollupTelemetry(ctx, day, 24*time.Hour); err == nil {
+					lastDay = day
+				}
+			}
+		}
+	}
+}
+
+// GetTelemetryStats summarizes a device's telemetry between start and end.
+// Narrow ranges are aggregated hourly from raw rows; wider ranges are read
+// from the hourly or daily rollup tables.
+func (s *PatternsService) GetTelemetryStats(ctx context.Context, deviceID string, start, end time.Time) (*models.TelemetryStats, error) {
+	log := s.logger.WithContext(ctx)
+
+	stats := &models.TelemetryStats{
+		DeviceID: deviceID,
+		Start:    start,
+		End:      end,
+	}
+
+	var windows []*models.AggregatedTelemetry
+	var err error
+	if rollup := rollupForRange(start, end); rollup != nil {
+		stats.Granularity = rollup.granularity
+		windows, err = s.readTelemetryRollup(ctx, *rollup, deviceID, start, end)
+	} else {
+		stats.Granularity = models.TelemetryGranularityRaw
+		windows, err = s.aggregateRawTelemetry(ctx, deviceID, start, end)
+	}
+	if err != nil {
+		log.Error("Failed to get telemetry stats",
+			zap.String("device_id", deviceID),
+			zap.String("granularity", stats.Granularity),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get telemetry stats: %w", err)
+	}
+
+	stats.Windows = windows
+	return stats, nil
+}
+
+func (s *PatternsService) readTelemetryRollup(ctx context.Context, rollup telemetryRollup, deviceID string, start, end time.Time) ([]*models.AggregatedTelemetry, error) {
+	var windows []*models.AggregatedTelemetry
+
+	err := s.scyllaCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		query := fmt.Sprintf(`
+			SELECT bucket, metric, unit, value_count, value_min, value_max, value_sum, value_sum_squares
+			FROM %s
+			WHERE device_id = ? AND bucket >= ? AND bucket < ?`, rollup.table)
+
+		iter := s.telemetrySession(deviceID).QueryIter(ctx, query, deviceID, start.UTC().Truncate(rollup.window), end)
+		defer iter.Close()
+
+		var bucket time.Time
+		var metric string
+		var acc telemetryAccumulator
+		for iter.Scan(&bucket, &metric, &acc.unit, &acc.count, &acc.min, &acc.max, &acc.sum, &acc.sumSquares) {
+			windows = append(windows, acc.aggregated(deviceID, metric, bucket, rollup.window))
+		}
+		return iter.Close()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+func (s *PatternsService) aggregateRawTelemetry(ctx context.Context, deviceID string, start, end time.Time) ([]*models.AggregatedTelemetry, error) {
+	now := time.Now().UTC()
+	start = s.telemetryRetention.clampStart(start, now)
+	buckets := make(map[rollupKey]*telemetryAccumulator)
+
+	err := s.scyllaCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		query := `
+			SELECT metric, value, unit, timestamp
+			FROM device_telemetry
+			WHERE device_id = ? AND timestamp >= ? AND timestamp <= ?`
+
+		iter := s.telemetrySession(deviceID).QueryIter(ctx, query, deviceID, start, end)
+		defer iter.Close()
+
+		var metric, unit string
+		var value float64
+		var timestamp time.Time
+		for iter.Scan(&metric, &value, &unit, &timestamp) {
+			if s.telemetryRetention.expired(metric, timestamp, now) {
+				continue
+			}
+			key := rollupKey{metric: metric, bucket: timestamp.UTC().Truncate(rawStatsWindow)}
+			acc, ok := buckets[key]
+			if !ok {
+				acc = &telemetryAccumulator{unit: unit}
+				buckets[key] = acc
+			}
+			acc.add(value)
+		}
+		return iter.Close()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	windows := make([]*models.AggregatedTelemetry, 0, len(buckets))
+	for key, acc := range buckets {
+		windows = append(windows, acc.aggregated(deviceID, key.metric, key.bucket, rawStatsWindow))
+	}
+	// Match the rollup tables' clustering order: bucket, then metric
+	sort.Slice(windows, func(i, j int) bool {
+		if !windows[i].WindowStart.Equal(windows[j].WindowStart) {
+			return windows[i].WindowStart.Before(windows[j].WindowStart)
+		}
+		return windows[i].Metric < windows[j].Metric
+	})
+
+	return windows, nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetTelemetryStats_PicksGranularityByRange(t *testing.T) {
+	end := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		span      time.Duration
+		wantTable string
+		wantGran  string
+	}{
+		{name: "narrow range reads raw rows", span: 6 * time.Hour, wantTable: "FROM device_telemetry\n", wantGran: "raw"},
+		{name: "month reads hourly rollups", span: 30 * 24 * time.Hour, wantTable: "FROM device_telemetry_hourly", wantGran: "hourly"},
+		{name: "year reads daily rollups", span: 365 * 24 * time.Hour, wantTable: "FROM device_telemetry_daily", wantGran: "daily"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scylla := &fakeScylla{}
+			svc := newTestService(scylla, nil, nil)
+
+			stats, err := svc.GetTelemetryStats(context.Background(), "device-1", end.Add(-tt.span), end)
+			if err != nil {
+				t.Fatalf("GetTelemetryStats() error = %v", err)
+			}
+			if stats.Granularity != tt.wantGran {
+				t.Errorf("Granularity = %v, want %v", stats.Granularity, tt.wantGran)
+			}
+			if len(scylla.iterQueries) != 1 || !strings.Contains(scylla.iterQueries[0], tt.wantTable) {
+				t.Errorf("queries = %q, want one reading %q", scylla.iterQueries, tt.wantTable)
+			}
+		})
+	}
+}
+
+func TestGetTelemetryStats_RawWindows(t *testing.T) {
+	end := time.Now().UTC().Truncate(time.Hour)
+	hour := end.Add(-2 * time.Hour)
+	scylla := &fakeScylla{iterRows: [][]interface{}{
+		{"temperature", 20.0, "celsius", hour.Add(10 * time.Minute)},
+		{"temperature", 24.0, "celsius", hour.Add(40 * time.Minute)},
+		{"temperature", 30.0, "celsius", hour.Add(70 * time.Minute)},
+	}}
+	svc := newTestService(scylla, nil, nil)
+
+	stats, err := svc.GetTelemetryStats(context.Background(), "device-1", end.Add(-6*time.Hour), end)
+	if err != nil {
+		t.Fatalf("GetTelemetryStats() error = %v", err)
+	}
+	if len(stats.Windows) != 2 {
+		t.Fatalf("Windows = %d, want 2 hourly windows", len(stats.Windows))
+	}
+
+	first := stats.Windows[0]
+	if !first.WindowStart.Equal(hour) || first.Count != 2 || first.Min != 20 || first.Max != 24 || first.Average != 22 || first.StdDev != 2 {
+		t.Errorf("first window = %+v, want %v count 2 min 20 max 24 avg 22 stddev 2", first, hour)
+	}
+}
+
+func TestRollupTelemetry_WritesBuckets(t *testing.T) {
+	bucket := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	scylla := &fakeScylla{iterRows: [][]interface{}{
+		{"device-1", "temperature", 20.0, "celsius", bucket.Add(5 * time.Minute)},
+		{"device-1", "temperature", 22.0, "celsius", bucket.Add(35 * time.Minute)},
+		{"device-2", "temperature", 18.0, "celsius", bucket.Add(50 * time.Minute)},
+	}}
+	svc := newTestService(scylla, nil, nil)
+
+	written, err := svc.RollupTelemetry(context.Background(), bucket.Add(20*time.Minute), time.Hour)
+	if err != nil {
+		t.Fatalf("RollupTelemetry() error = %v", err)
+	}
+	if written != 2 {
+		t.Errorf("written = %v, want 2 (one per device and metric)", written)
+	}
+
+	if got := scylla.iterArgs[0]; !got[0].(time.Time).Equal(bucket) || !got[1].(time.Time).Equal(bucket.Add(time.Hour)) {
+		t.Errorf("raw range = %v, want the hour starting %v", got, bucket)
+	}
+	for i, query := range scylla.execs {
+		if !strings.Contains(query, "INSERT INTO device_telemetry_hourly") {
+			t.Errorf("exec %d = %q, want hourly rollup insert", i, query)
+		}
+		if args := scylla.execArgs[i]; args[0] == "device-1" && (args[4] != int64(2) || args[7] != 42.0) {
+			t.Errorf("device-1 rollup = %v, want count 2 sum 42", args)
+		}
+	}
+
+	if _, err := svc.RollupTelemetry(context.Background(), bucket, 15*time.Minute); err == nil {
+		t.Error("RollupTelemetry(15m) error = nil, want unsupported window")
+	}
+}
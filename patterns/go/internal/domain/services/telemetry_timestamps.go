@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+)
+
+// TelemetryTimestampPolicy bounds the timestamps devices report for readings.
+// Device clocks drift, so readings slightly ahead of server time are accepted;
+// readings far in the future or past are rejected rather than corrupting
+// time-series analysis.
+//
+// StoreIngestTime also writes the server receive time to the
+// device_telemetry.ingest_time column, which must exist:
+//
+//	ALTER TABLE device_telemetry ADD ingest_time TIMESTAMP
+type TelemetryTimestampPolicy struct {
+	MaxFutureSkew   time.Duration // How far ahead of server time a reading may be
+	MaxAge          time.Duration // How old a reading may be; zero accepts any age
+	StoreIngestTime bool          // Persist ingest time alongside event time
+}
+
+var defaultTelemetryTimestampPolicy = TelemetryTimestampPolicy{
+	MaxFutureSkew: 5 * time.Minute,
+	MaxAge:        24 * time.Hour,
+}
+
+// SetTelemetryTimestampPolicy configures validation of device timestamps
+func (s *PatternsService) SetTelemetryTimestampPolicy(policy TelemetryTimestampPolicy) {
+	s.telemetryTimestamps = policy
+}
+
+// telemetryTimestamp returns a reading's event time: the device's timestamp
+// when it sent one, otherwise ingestTime. Device timestamps outside the
+// policy fail with PAT-TEL-003.
+func (s *PatternsService) telemetryTimestamp(ctx context.Context, req *models.RecordTelemetryRequest, ingestTime time.Time) (time.Time, error) {
+	if req.Timestamp == nil {
+		return ingestTime, nil
+	}
+
+	maxAge := s.telemetryTimestamps.MaxAge
+	if maxAge <= 0 {
+		maxAge = math.MaxInt64
+	}
+	result := s.validator.ValidateTimestampWithSkew(ctx, *req.Timestamp, maxAge, s.telemetryTimestamps.MaxFutureSkew)
+	if !result.IsValid {
+		return time.Time{}, errors.TelemetryClockSkew(req.DeviceID, result.ErrorMessage)
+	}
+	return req.Timestamp.UTC(), nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+)
+
+func TestRecordTelemetry_ValidatesDeviceTimestamp(t *testing.T) {
+	now := time.Now().UTC()
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(d)
+		return &ts
+	}
+
+	tests := []struct {
+		name      string
+		timestamp *time.Time
+		wantErr   bool
+	}{
+		{name: "in window", timestamp: at(-time.Minute)},
+		{name: "within clock skew", timestamp: at(time.Minute)},
+		{name: "omitted uses server time"},
+		{name: "too far in the future", timestamp: at(time.Hour), wantErr: true},
+		{name: "stale", timestamp: at(-48 * time.Hour), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scylla := &fakeScylla{}
+			svc := newTestService(scylla, nil, nil)
+
+			telemetry, err := svc.RecordTelemetry(context.Background(), &models.RecordTelemetryRequest{
+				DeviceID:  "device-1",
+				Metric:    "temperature",
+				Value:     21.5,
+				Unit:      "celsius",
+				Timestamp: tt.timestamp,
+			})
+
+			if tt.wantErr {
+				if !errors.HasCode(err, "PAT-TEL-003") {
+					t.Fatalf("RecordTelemetry() error = %v, want PAT-TEL-003", err)
+				}
+				if len(scylla.execs) != 0 {
+					t.Errorf("execs = %v, want none for a rejected reading", scylla.execs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RecordTelemetry() error = %v", err)
+			}
+
+			want := telemetry.IngestTime
+			if tt.timestamp != nil {
+				want = *tt.timestamp
+			}
+			if !telemetry.EventTime.Equal(want) || !telemetry.Timestamp.Equal(want) {
+				t.Errorf("EventTime, Timestamp = %v, %v, want %v", telemetry.EventTime, telemetry.Timestamp, want)
+			}
+			if got := scylla.execArgs[0][5].(time.Time); !got.Equal(want) {
+				t.Errorf("stored timestamp = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRecordTelemetry_StoresIngestTime(t *testing.T) {
+	scylla := &fakeScylla{}
+	svc := newTestService(scylla, nil, nil)
+	svc.SetTelemetryTimestampPolicy(TelemetryTimestampPolicy{
+		MaxFutureSkew:   time.Minute,
+		StoreIngestTime: true,
+	})
+
+	// MaxAge zero accepts readings of any age
+	old := time.Now().UTC().Add(-30 * 24 * time.Hour)
+	telemetry, err := svc.RecordTelemetry(context.Background(), &models.RecordTelemetryRequest{
+		DeviceID:  "device-1",
+		Metric:    "temperature",
+		Timestamp: &old,
+	})
+	if err != nil {
+		t.Fatalf("RecordTelemetry() error = %v", err)
+	}
+
+	if len(scylla.execs) != 2 || !strings.Contains(scylla.execs[0], "ingest_time") {
+		t.Fatalf("execs = %v, want an insert with ingest_time and a count update", scylla.execs)
+	}
+	args := scylla.execArgs[0]
+	if got := args[5].(time.Time); !got.Equal(old) {
+		t.Errorf("stored timestamp = %v, want %v", got, old)
+	}
+	if got := args[6].(time.Time); !got.Equal(telemetry.IngestTime) || got.Before(old) {
+		t.Errorf("stored ingest_time = %v, want %v", got, telemetry.IngestTime)
+	}
+}
+
+func TestGetTelemetryHistory_ReturnsEventAndIngestTime(t *testing.T) {
+	now := time.Now().UTC()
+	eventTime := now.Add(-2 * time.Hour) // buffered while the device was offline
+	scylla := &fakeScylla{iterRows: [][]interface{}{
+		{uuid.New(), "device-1", "temperature", 21.5, "celsius", eventTime, now},
+	}}
+	svc := newTestService(scylla, nil, nil)
+	svc.SetTelemetryTimestampPolicy(TelemetryTimestampPolicy{StoreIngestTime: true})
+
+	history, err := svc.GetTelemetryHistory(context.Background(), "device-1", now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("GetTelemetryHistory() error = %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("history = %d rows, want 1", len(history))
+	}
+	if !strings.Contains(scylla.iterQueries[0], "ingest_time") {
+		t.Errorf("query = %q, want ingest_time selected", scylla.iterQueries[0])
+	}
+	got := history[0]
+	if !got.EventTime.Equal(eventTime) || !got.Timestamp.Equal(eventTime) || !got.IngestTime.Equal(now) {
+		t.Errorf("times = event %v, timestamp %v, ingest %v; want %v, %v, %v",
+			got.EventTime, got.Timestamp, got.IngestTime, eventTime, eventTime, now)
+	}
+}
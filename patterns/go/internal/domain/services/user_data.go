@@ -0,0 +1,427 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/keyvault"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// =============================================================================
+// User Data (GDPR) - Export and erasure across all stores
+// Demonstrates: Core.Infrastructure.SqlServer + MongoDB + Redis + KeyVault
+// =============================================================================
+
+// userSessionsKey is the Redis set indexing a user's session IDs
+func userSessionsKey(userID uuid.UUID) string {
+	return fmt.Sprintf("user_sessions:%s", userID)
+}
+
+// ExportUserData streams a models.UserDataExport JSON bundle for a user to w.
+// Every query is filtered by userID, so the bundle only contains that user's data.
+// Soft-deleted users are included until they are purged. ErrUserNotFound is
+// returned before anything is written; a store failing after streaming has
+// started is reported in the bundle's "errors" field instead.
+func (s *PatternsService) ExportUserData(ctx context.Context, userID uuid.UUID, w io.Writer) error {
+	log := s.logger.WithContext(ctx)
+
+	log.Info("Exporting user data", zap.String("user_id", userID.String()))
+
+	profile, err := s.getUserProfileIncludingDeleted(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	stream := newJSONObjectStream(w)
+	sectionErrors := make(map[string]string)
+
+	stream.field("userId", userID)
+	stream.field("exportedAt", time.Now().UTC())
+	stream.field("profile", profile)
+
+	stream.beginArray("orders")
+	if err := s.streamUserOrders(ctx, userID, stream); err != nil {
+		log.Error("Failed to export orders", zap.Error(err))
+		sectionErrors["orders"] = err.Error()
+	}
+	stream.endArray()
+
+	stream.beginArray("sessions")
+	if err := s.streamUserSessions(ctx, userID, stream); err != nil {
+		log.Error("Failed to export sessions", zap.Error(err))
+		sectionErrors["sessions"] = err.Error()
+	}
+	stream.endArray()
+
+	stream.beginArray("integrations")
+	if err := s.streamUserIntegrations(ctx, userID, stream); err != nil {
+		log.Error("Failed to export integrations", zap.Error(err))
+		sectionErrors["integrations"] = err.Error()
+	}
+	stream.endArray()
+
+	if len(sectionErrors) > 0 {
+		stream.field("errors", sectionErrors)
+	}
+
+	if err := stream.close(); err != nil {
+		return fmt.Errorf("failed to write user export: %w", err)
+	}
+
+	log.Info("User data exported",
+		zap.String("user_id", userID.String()),
+		zap.Int("failed_sections", len(sectionErrors)))
+
+	return nil
+}
+
+// getUserProfileIncludingDeleted reads a profile regardless of soft-delete state
+func (s *PatternsService) getUserProfileIncludingDeleted(ctx context.Context, id uuid.UUID) (*models.UserProfile, error) {
+	if s.mongoClient == nil {
+		return nil, fmt.Errorf("user profile store not configured")
+	}
+
+	var profile models.UserProfile
+
+	err := s.mongoCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		collection := s.mongoClient.Database(s.mongoDatabase).Collection("user_profiles")
+		return collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&profile)
+	})
+
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &profile, nil
+}
+
+func (s *PatternsService) streamUserOrders(ctx context.Context, userID uuid.UUID, stream *jsonObjectStream) error {
+	if s.sqlDB == nil {
+		return nil
+	}
+
+	query := `
+		SELECT Id, CustomerID, TotalAmount, Currency, Status, ShippingAddress, CreatedAt, UpdatedAt
+		FROM Orders
+		WHERE CustomerID = @p1
+		ORDER BY CreatedAt`
+
+	rows, err := s.sqlDB.QueryContext(ctx, query, sql.Named("p1", userID))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var order models.Order
+		var status string
+		if err := rows.Scan(
+			&order.ID, &order.CustomerID, &order.TotalAmount, &order.Currency,
+			&status, &order.ShippingAddress, &order.CreatedAt, &order.UpdatedAt,
+		); err != nil {
+			return err
+		}
+		order.Status = models.OrderStatus(status)
+		stream.element(order)
+	}
+
+	return rows.Err()
+}
+
+func (s *PatternsService) streamUserSessions(ctx context.Context, userID uuid.UUID, stream *jsonObjectStream) error {
+	if s.sessions == nil {
+		return nil
+	}
+
+	sessions, err := s.sessions.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		stream.element(session)
+	}
+
+	return nil
+}
+
+func (s *PatternsService) streamUserIntegrations(ctx context.Context, userID uuid.UUID, stream *jsonObjectStream) error {
+	if s.keyVault == nil {
+		return nil
+	}
+
+	// ListUserIntegrations returns metadata and masked keys only, never secret values
+	integrations, err := s.keyVault.ListUserIntegrations(ctx, userID.String())
+	if err != nil {
+		return err
+	}
+
+	for _, integration := range integrations {
+		if integration.Status == keyvault.StatusNotConfigured {
+			continue
+		}
+		stream.element(integration)
+	}
+
+	return nil
+}
+
+// Erasure steps, in execution order. The profile is deleted last so a failed
+// erasure can always be retried against an identifiable user.
+const (
+	erasureStepIntegrations = "integrations"
+	erasureStepSessions     = "sessions"
+	erasureStepOrders       = "orders"
+	erasureStepProfile      = "profile"
+)
+
+// erasedValue replaces PII in records that must be kept (e.g. orders for accounting)
+const erasedValue = "[erased]"
+
+// erasureKey is the Redis key holding a user's erasure record (compensation log)
+func erasureKey(userID uuid.UUID) string {
+	return fmt.Sprintf("erasure:%s", userID)
+}
+
+// EraseUser removes a user's personal data from every store: KeyVault
+// integration secrets, Redis sessions, order PII in SQL Server and the MongoDB
+// profile. Progress is recorded step by step in an ErasureRecord, so a retry
+// after a failure resumes at the failed step, and erasing an already-erased
+// user is a no-op. A UserErased audit event is emitted on completion.
+func (s *PatternsService) EraseUser(ctx context.Context, userID uuid.UUID) (*models.ErasureRecord, error) {
+	log := s.logger.WithContext(ctx)
+
+	record := s.loadErasureRecord(ctx, userID)
+	if record.Status == models.ErasureCompleted {
+		log.Info("User already erased", zap.String("user_id", userID.String()))
+		return record, nil
+	}
+
+	log.Info("Erasing user data", zap.String("user_id", userID.String()))
+	record.Status = models.ErasureInProgress
+
+	steps := []struct {
+		name string
+		run  func(context.Context, uuid.UUID) error
+	}{
+		{erasureStepIntegrations, s.eraseUserIntegrations},
+		{erasureStepSessions, s.eraseUserSessions},
+		{erasureStepOrders, s.anonymizeUserOrders},
+		{erasureStepProfile, s.deleteUserProfile},
+	}
+
+	for _, step := range steps {
+		recorded := record.Step(step.name)
+		if recorded.Status == models.ErasureCompleted {
+			continue
+		}
+
+		if err := step.run(ctx, userID); err != nil {
+			log.Error("User erasure step failed",
+				zap.String("user_id", userID.String()),
+				zap.String("step", step.name),
+				zap.Error(err))
+			recorded.Status = models.ErasureFailed
+			recorded.Error = err.Error()
+			record.Status = models.ErasureFailed
+			s.saveErasureRecord(ctx, record)
+			return record, fmt.Errorf("erasure step %s failed: %w", step.name, err)
+		}
+
+		now := time.Now().UTC()
+		recorded.Status = models.ErasureCompleted
+		recorded.Error = ""
+		recorded.CompletedAt = &now
+		s.saveErasureRecord(ctx, record)
+	}
+
+	now := time.Now().UTC()
+	record.Status = models.ErasureCompleted
+	record.CompletedAt = &now
+	s.saveErasureRecord(ctx, record)
+
+	// Audit record: structured log plus event, both without PII
+	log.Info("User erased",
+		zap.Bool("audit", true),
+		zap.String("user_id", userID.String()),
+		zap.Time("started_at", record.StartedAt),
+		zap.Time("completed_at", now))
+	if s.kafkaProducer != nil {
+		if err := s.publishUserEvent(ctx, models.NewUserErasedEvent(record, "ai-patterns")); err != nil {
+			log.Warn("Failed to publish user erased event", zap.Error(err))
+		}
+	}
+
+	return record, nil
+}
+
+// loadErasureRecord returns the stored erasure record, or a new one
+func (s *PatternsService) loadErasureRecord(ctx context.Context, userID uuid.UUID) *models.ErasureRecord {
+	record := &models.ErasureRecord{UserID: userID, Status: models.ErasurePending, StartedAt: time.Now().UTC()}
+	if s.redisClient == nil {
+		return record
+	}
+
+	data, err := s.redisClient.Get(ctx, erasureKey(userID))
+	if err != nil || data == "" {
+		return record
+	}
+
+	var stored models.ErasureRecord
+	if err := json.Unmarshal([]byte(data), &stored); err != nil {
+		s.logger.WithContext(ctx).Warn("Ignoring unreadable erasure record",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return record
+	}
+	return &stored
+}
+
+// saveErasureRecord persists the record; without it a retry redoes completed
+// steps, which is safe because every step is idempotent
+func (s *PatternsService) saveErasureRecord(ctx context.Context, record *models.ErasureRecord) {
+	if s.redisClient == nil {
+		return
+	}
+	if err := s.redisClient.Set(ctx, erasureKey(record.UserID), record); err != nil {
+		s.logger.WithContext(ctx).Warn("Failed to save erasure record",
+			zap.String("user_id", record.UserID.String()),
+			zap.Error(err))
+	}
+}
+
+func (s *PatternsService) eraseUserIntegrations(ctx context.Context, userID uuid.UUID) error {
+	if s.keyVault == nil {
+		return nil // Integrations are not enabled, so none were stored
+	}
+
+	// Delete every secret under the user's prefix, including integration types
+	// this service no longer knows about. DeleteSecret also invalidates the cache.
+	names, err := s.keyVault.ListSecrets(ctx, fmt.Sprintf("user:%s:", userID))
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := s.keyVault.DeleteSecret(ctx, name); err != nil {
+			return fmt.Errorf("failed to delete secret %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (s *PatternsService) eraseUserSessions(ctx context.Context, userID uuid.UUID) error {
+	store, err := s.sessionStore()
+	if err != nil {
+		return err
+	}
+	return store.DeleteByUser(ctx, userID)
+}
+
+func (s *PatternsService) anonymizeUserOrders(ctx context.Context, userID uuid.UUID) error {
+	if s.sqlDB == nil {
+		return fmt.Errorf("sql server not connected")
+	}
+
+	// Orders are retained for accounting; only the PII columns are cleared
+	query := `UPDATE Orders SET ShippingAddress = @p1, UpdatedAt = @p2 WHERE CustomerID = @p3`
+	_, err := s.sqlDB.ExecContext(ctx, query,
+		sql.Named("p1", erasedValue),
+		sql.Named("p2", time.Now()),
+		sql.Named("p3", userID),
+	)
+	return err
+}
+
+func (s *PatternsService) deleteUserProfile(ctx context.Context, userID uuid.UUID) error {
+	if s.mongoClient == nil {
+		return fmt.Errorf("mongodb not connected")
+	}
+
+	return s.mongoCircuitBreaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		collection := s.mongoClient.Database(s.mongoDatabase).Collection("user_profiles")
+		_, err := collection.DeleteOne(ctx, bson.M{"_id": userID.String()})
+		return err
+	})
+}
+
+// jsonObjectStream writes a JSON object incrementally. The first write error is
+// kept and returned by close; later writes become no-ops.
+type jsonObjectStream struct {
+	w          io.Writer
+	err        error
+	fields     int
+	arrayItems int
+}
+
+func newJSONObjectStream(w io.Writer) *jsonObjectStream {
+	stream := &jsonObjectStream{w: w}
+	stream.write([]byte("{"))
+	return stream
+}
+
+func (j *jsonObjectStream) field(name string, value interface{}) {
+	j.key(name)
+	j.value(value)
+}
+
+func (j *jsonObjectStream) beginArray(name string) {
+	j.key(name)
+	j.write([]byte("["))
+	j.arrayItems = 0
+}
+
+func (j *jsonObjectStream) element(value interface{}) {
+	if j.arrayItems > 0 {
+		j.write([]byte(","))
+	}
+	j.value(value)
+	j.arrayItems++
+}
+
+func (j *jsonObjectStream) endArray() {
+	j.write([]byte("]"))
+}
+
+func (j *jsonObjectStream) close() error {
+	j.write([]byte("}"))
+	return j.err
+}
+
+func (j *jsonObjectStream) key(name string) {
+	if j.fields > 0 {
+		j.write([]byte(","))
+	}
+	j.value(name)
+	j.write([]byte(":"))
+	j.fields++
+}
+
+func (j *jsonObjectStream) value(v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		if j.err == nil {
+			j.err = err
+		}
+		return
+	}
+	j.write(data)
+}
+
+func (j *jsonObjectStream) write(p []byte) {
+	if j.err != nil {
+		return
+	}
+	_, j.err = j.w.Write(p)
+}
@@ -0,0 +1,561 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	goerrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.uber.org/zap"
+)
+
+const testUsersNamespace = "patterns-test.user_profiles"
+
+// newMongoTestService builds a PatternsService on an mtest mock deployment
+func newMongoTestService(mt *mtest.T, producer *fakeProducer) *PatternsService {
+	svc := NewPatternsService(nil, mt.Client, "patterns-test", nil, nil, nil, &logger.Logger{Logger: zap.NewNop()}, nil)
+	if producer != nil {
+		svc.kafkaProducer = producer
+	}
+	return svc
+}
+
+// profileDoc encodes a profile the way the MongoDB driver stores it
+func profileDoc(mt *mtest.T, profile *models.UserProfile) bson.D {
+	raw, err := bson.Marshal(profile)
+	if err != nil {
+		mt.Fatalf("bson.Marshal() error = %v", err)
+	}
+	var doc bson.D
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		mt.Fatalf("bson.Unmarshal() error = %v", err)
+	}
+	return doc
+}
+
+// commandFilter returns the "filter" or "query" document of a started command
+func commandFilter(mt *mtest.T, key string) bson.Raw {
+	event := mt.GetStartedEvent()
+	if event == nil {
+		mt.Fatalf("no command was sent")
+	}
+	return event.Command.Lookup(key).Document()
+}
+
+func publishedEventType(t *testing.T, msg sentMessage) string {
+	t.Helper()
+	var event models.UserEvent
+	if err := json.Unmarshal(msg.value, &event); err != nil {
+		t.Fatalf("invalid event payload: %v", err)
+	}
+	return event.EventType
+}
+
+func TestSoftDeleteUser_HidesFromReads(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("deleted user is not found", func(mt *mtest.T) {
+		producer := &fakeProducer{}
+		svc := newMongoTestService(mt, producer)
+		ctx := context.Background()
+
+		profile := models.NewUserProfile("deleted@example.com", "Del", "Eted")
+		deletedAt := time.Now().UTC()
+		profile.DeletedAt = &deletedAt
+
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: profileDoc(mt, profile)}),
+			mtest.CreateCursorResponse(0, testUsersNamespace, mtest.FirstBatch),
+		)
+
+		if err := svc.SoftDeleteUser(ctx, profile.ID); err != nil {
+			t.Fatalf("SoftDeleteUser() error = %v", err)
+		}
+		deleteFilter := commandFilter(mt, "query")
+		if value, err := deleteFilter.LookupErr("deletedAt"); err != nil || value.Type != bson.TypeNull {
+			t.Errorf("delete filter = %v, want deletedAt: null so already-deleted users are not re-deleted", deleteFilter)
+		}
+
+		if _, err := svc.GetUser(ctx, profile.ID); !goerrors.Is(err, errors.ErrUserNotFound) {
+			t.Errorf("GetUser() after delete error = %v, want %v", err, errors.ErrUserNotFound)
+		}
+		readFilter := commandFilter(mt, "filter")
+		if value, err := readFilter.LookupErr("deletedAt"); err != nil || value.Type != bson.TypeNull {
+			t.Errorf("read filter = %v, want deletedAt: null", readFilter)
+		}
+
+		if len(producer.messages) != 1 {
+			t.Fatalf("published %d events, want 1", len(producer.messages))
+		}
+		if got := publishedEventType(t, producer.messages[0]); got != "UserDeleted" {
+			t.Errorf("event type = %v, want UserDeleted", got)
+		}
+	})
+
+	mt.Run("deleting a missing user", func(mt *mtest.T) {
+		svc := newMongoTestService(mt, &fakeProducer{})
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: nil}))
+
+		if err := svc.SoftDeleteUser(context.Background(), models.NewUserProfile("x@example.com", "", "").ID); !goerrors.Is(err, errors.ErrUserNotFound) {
+			t.Errorf("SoftDeleteUser() error = %v, want %v", err, errors.ErrUserNotFound)
+		}
+	})
+
+	mt.Run("analytics exclude deleted users", func(mt *mtest.T) {
+		svc := newMongoTestService(mt, nil)
+		count := func(n int32) bson.D {
+			return mtest.CreateCursorResponse(0, testUsersNamespace, mtest.FirstBatch, bson.D{{Key: "n", Value: n}})
+		}
+		mt.AddMockResponses(count(5), count(2))
+
+		analytics, err := svc.getMongoDBAnalytics(context.Background(), time.Now().Add(-time.Hour), time.Now())
+		if err != nil {
+			t.Fatalf("getMongoDBAnalytics() error = %v", err)
+		}
+		if analytics.TotalUsers != 5 || analytics.NewRegistrations != 2 {
+			t.Errorf("analytics = %+v, want TotalUsers 5, NewRegistrations 2", analytics)
+		}
+
+		for _, event := range []string{"total users", "new registrations"} {
+			started := mt.GetStartedEvent()
+			match := started.Command.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$match").Document()
+			if value, err := match.LookupErr("deletedAt"); err != nil || value.Type != bson.TypeNull {
+				t.Errorf("%s $match = %v, want deletedAt: null", event, match)
+			}
+		}
+	})
+}
+
+func TestRestoreUser(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("restored user is readable", func(mt *mtest.T) {
+		producer := &fakeProducer{}
+		svc := newMongoTestService(mt, producer)
+		ctx := context.Background()
+
+		profile := models.NewUserProfile("restored@example.com", "Re", "Stored")
+		doc := profileDoc(mt, profile)
+
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: doc}),
+			mtest.CreateCursorResponse(0, testUsersNamespace, mtest.FirstBatch, doc),
+		)
+
+		restored, err := svc.RestoreUser(ctx, profile.ID)
+		if err != nil {
+			t.Fatalf("RestoreUser() error = %v", err)
+		}
+		if restored.IsDeleted() {
+			t.Errorf("restored.DeletedAt = %v, want nil", restored.DeletedAt)
+		}
+
+		started := mt.GetStartedEvent()
+		restoreFilter := started.Command.Lookup("query").Document()
+		if _, ok := restoreFilter.Lookup("deletedAt").DocumentOK(); !ok {
+			t.Errorf("restore filter = %v, want deletedAt condition matching only deleted users", restoreFilter)
+		}
+		if _, err := started.Command.Lookup("update").Document().LookupErr("$unset", "deletedAt"); err != nil {
+			t.Errorf("restore update does not unset deletedAt: %v", started.Command.Lookup("update"))
+		}
+
+		got, err := svc.GetUser(ctx, profile.ID)
+		if err != nil {
+			t.Fatalf("GetUser() after restore error = %v", err)
+		}
+		if got.Email != profile.Email {
+			t.Errorf("GetUser().Email = %v, want %v", got.Email, profile.Email)
+		}
+
+		if len(producer.messages) != 1 {
+			t.Fatalf("published %d events, want 1", len(producer.messages))
+		}
+		if got := publishedEventType(t, producer.messages[0]); got != "UserRestored" {
+			t.Errorf("event type = %v, want UserRestored", got)
+		}
+	})
+
+	mt.Run("restoring a user that is not deleted", func(mt *mtest.T) {
+		svc := newMongoTestService(mt, nil)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: nil}))
+
+		if _, err := svc.RestoreUser(context.Background(), models.NewUserProfile("x@example.com", "", "").ID); !goerrors.Is(err, errors.ErrUserNotFound) {
+			t.Errorf("RestoreUser() error = %v, want %v", err, errors.ErrUserNotFound)
+		}
+	})
+}
+
+func TestPurgeDeletedUsers(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("deletes users past retention", func(mt *mtest.T) {
+		svc := newMongoTestService(mt, nil)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: int32(3)}))
+
+		before := time.Now().UTC().Add(-24 * time.Hour)
+		purged, err := svc.PurgeDeletedUsers(context.Background(), 24*time.Hour)
+		if err != nil {
+			t.Fatalf("PurgeDeletedUsers() error = %v", err)
+		}
+		if purged != 3 {
+			t.Errorf("purged = %v, want 3", purged)
+		}
+
+		started := mt.GetStartedEvent()
+		query := started.Command.Lookup("deletes").Array().Index(0).Value().Document().Lookup("q").Document()
+		cutoff, ok := query.Lookup("deletedAt", "$lte").DateTimeOK()
+		if !ok {
+			t.Fatalf("purge filter = %v, want deletedAt $lte cutoff", query)
+		}
+		if got := primitive.DateTime(cutoff).Time(); got.Before(before.Add(-time.Second)) || got.After(before.Add(time.Second)) {
+			t.Errorf("cutoff = %v, want ~%v", got, before)
+		}
+	})
+}
+
+func TestExportUserData_IncludesProfileAndOrders(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("bundle contains the user's data", func(mt *mtest.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New() error = %v", err)
+		}
+		defer db.Close()
+
+		redis := newFakeRedis()
+		svc := newMongoTestService(mt, nil)
+		svc.sqlDB = db
+		svc.redisClient = redis
+		svc.SetSessionStore(NewRedisSessionStore(redis))
+		ctx := context.Background()
+
+		profile := models.NewUserProfile("export@example.com", "Ex", "Port")
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, testUsersNamespace, mtest.FirstBatch, profileDoc(mt, profile)))
+
+		firstOrder, secondOrder := uuid.New(), uuid.New()
+		now := time.Now().UTC()
+		mock.ExpectQuery(`FROM Orders\s+WHERE CustomerID = @p1`).
+			WithArgs(sql.Named("p1", profile.ID)).
+			WillReturnRows(sqlmock.NewRows([]string{"Id", "CustomerID", "TotalAmount", "Currency", "Status", "ShippingAddress", "CreatedAt", "UpdatedAt"}).
+				AddRow(firstOrder, profile.ID, 25.0, "USD", "delivered", "1 Main St", now, now).
+				AddRow(secondOrder, profile.ID, 40.0, "USD", "pending", "1 Main St", now, now))
+
+		session, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: profile.ID, UserEmail: profile.Email})
+		if err != nil {
+			t.Fatalf("CreateSession() error = %v", err)
+		}
+		if _, err := svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: uuid.New(), UserEmail: "other@example.com"}); err != nil {
+			t.Fatalf("CreateSession() error = %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := svc.ExportUserData(ctx, profile.ID, &buf); err != nil {
+			t.Fatalf("ExportUserData() error = %v", err)
+		}
+
+		var bundle models.UserDataExport
+		if err := json.Unmarshal(buf.Bytes(), &bundle); err != nil {
+			t.Fatalf("export is not valid JSON: %v\n%s", err, buf.String())
+		}
+
+		if bundle.UserID != profile.ID {
+			t.Errorf("userId = %v, want %v", bundle.UserID, profile.ID)
+		}
+		if bundle.Profile == nil || bundle.Profile.Email != profile.Email {
+			t.Errorf("profile = %+v, want email %v", bundle.Profile, profile.Email)
+		}
+		if len(bundle.Orders) != 2 || bundle.Orders[0].ID != firstOrder || bundle.Orders[1].ID != secondOrder {
+			t.Errorf("orders = %+v, want %v and %v", bundle.Orders, firstOrder, secondOrder)
+		}
+		if len(bundle.Sessions) != 1 || bundle.Sessions[0].SessionID != session.SessionID {
+			t.Errorf("sessions = %+v, want only %v", bundle.Sessions, session.SessionID)
+		}
+		if len(bundle.Errors) != 0 {
+			t.Errorf("errors = %v, want none", bundle.Errors)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet SQL expectations: %v", err)
+		}
+	})
+
+	mt.Run("unknown user", func(mt *mtest.T) {
+		svc := newMongoTestService(mt, nil)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, testUsersNamespace, mtest.FirstBatch))
+
+		var buf bytes.Buffer
+		if err := svc.ExportUserData(context.Background(), uuid.New(), &buf); !goerrors.Is(err, errors.ErrUserNotFound) {
+			t.Errorf("ExportUserData() error = %v, want %v", err, errors.ErrUserNotFound)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("wrote %d bytes for unknown user, want none", buf.Len())
+		}
+	})
+}
+
+// erasureFixture wires a service to fakes holding data for the user and for a bystander
+type erasureFixture struct {
+	svc       *PatternsService
+	sqlMock   sqlmock.Sqlmock
+	redis     *fakeRedis
+	keyVault  *fakeKeyVault
+	producer  *fakeProducer
+	userID    uuid.UUID
+	sessionID string
+	otherID   string // bystander's session ID
+}
+
+func newErasureFixture(t *testing.T, mt *mtest.T) *erasureFixture {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	f := &erasureFixture{
+		sqlMock:  mock,
+		redis:    newFakeRedis(),
+		keyVault: newFakeKeyVault(),
+		producer: &fakeProducer{},
+		userID:   uuid.New(),
+	}
+	f.svc = newMongoTestService(mt, f.producer)
+	f.svc.sqlDB = db
+	f.svc.redisClient = f.redis
+	f.svc.SetSessionStore(NewRedisSessionStore(f.redis))
+	f.svc.SetKeyVault(f.keyVault)
+
+	ctx := context.Background()
+	bystander := uuid.New()
+	f.keyVault.secrets["user:"+f.userID.String()+":weather"] = "weather-api-key"
+	f.keyVault.secrets["user:"+f.userID.String()+":alexa"] = "alexa-token"
+	f.keyVault.secrets["user:"+bystander.String()+":weather"] = "bystander-key"
+
+	session, err := f.svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: f.userID, UserEmail: "erase@example.com"})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	other, err := f.svc.CreateSession(ctx, &models.CreateSessionRequest{UserID: bystander, UserEmail: "keep@example.com"})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	f.sessionID, f.otherID = session.SessionID, other.SessionID
+	f.producer.messages = nil
+
+	return f
+}
+
+// expectStoreErasure queues the SQL and MongoDB responses for one successful run
+func (f *erasureFixture) expectStoreErasure(mt *mtest.T) {
+	f.sqlMock.ExpectExec(`UPDATE Orders SET ShippingAddress = @p1`).
+		WithArgs(sql.Named("p1", erasedValue), sqlmock.AnyArg(), sql.Named("p3", f.userID)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: int32(1)}))
+}
+
+func TestEraseUser_RemovesSecretsAndSessions(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("erases and is idempotent", func(mt *mtest.T) {
+		f := newErasureFixture(t, mt)
+		f.expectStoreErasure(mt)
+		ctx := context.Background()
+
+		record, err := f.svc.EraseUser(ctx, f.userID)
+		if err != nil {
+			t.Fatalf("EraseUser() error = %v", err)
+		}
+		if record.Status != models.ErasureCompleted {
+			t.Errorf("status = %v, want %v", record.Status, models.ErasureCompleted)
+		}
+
+		for name := range f.keyVault.secrets {
+			if strings.Contains(name, f.userID.String()) {
+				t.Errorf("integration secret %s still exists", name)
+			}
+		}
+		if len(f.keyVault.secrets) != 1 {
+			t.Errorf("secrets = %v, want only the bystander's", f.keyVault.secrets)
+		}
+
+		if data, _ := f.redis.Get(ctx, "session:"+f.sessionID); data != "" {
+			t.Errorf("session %s still exists", f.sessionID)
+		}
+		if members, _ := f.redis.SMembers(ctx, userSessionsKey(f.userID)); len(members) != 0 {
+			t.Errorf("session index = %v, want empty", members)
+		}
+		if data, _ := f.redis.Get(ctx, "session:"+f.otherID); data == "" {
+			t.Error("bystander session was erased")
+		}
+
+		if err := f.sqlMock.ExpectationsWereMet(); err != nil {
+			t.Errorf("orders not anonymized: %v", err)
+		}
+
+		if len(f.producer.messages) != 1 {
+			t.Fatalf("published %d events, want 1 audit event", len(f.producer.messages))
+		}
+		audit := f.producer.messages[0]
+		if got := publishedEventType(t, audit); got != "UserErased" {
+			t.Errorf("event type = %v, want UserErased", got)
+		}
+		if strings.Contains(string(audit.value), "erase@example.com") {
+			t.Error("audit event contains the erased email")
+		}
+
+		// A retried erasure must not touch the stores again (no SQL or MongoDB
+		// responses are queued) or emit a second audit event
+		again, err := f.svc.EraseUser(ctx, f.userID)
+		if err != nil {
+			t.Fatalf("retried EraseUser() error = %v", err)
+		}
+		if again.Status != models.ErasureCompleted {
+			t.Errorf("retried status = %v, want %v", again.Status, models.ErasureCompleted)
+		}
+		if len(f.producer.messages) != 1 {
+			t.Errorf("published %d events after retry, want 1", len(f.producer.messages))
+		}
+	})
+
+	mt.Run("resumes after a failed step", func(mt *mtest.T) {
+		f := newErasureFixture(t, mt)
+		f.keyVault.deleteErr = goerrors.New("keyvault unavailable")
+		ctx := context.Background()
+
+		record, err := f.svc.EraseUser(ctx, f.userID)
+		if err == nil {
+			t.Fatal("EraseUser() error = nil, want integrations step failure")
+		}
+		if record.Status != models.ErasureFailed || record.Step(erasureStepIntegrations).Status != models.ErasureFailed {
+			t.Errorf("record = %+v, want integrations step failed", record)
+		}
+		if data, _ := f.redis.Get(ctx, "session:"+f.sessionID); data == "" {
+			t.Error("sessions were erased after an earlier step failed")
+		}
+
+		f.keyVault.deleteErr = nil
+		f.expectStoreErasure(mt)
+
+		record, err = f.svc.EraseUser(ctx, f.userID)
+		if err != nil {
+			t.Fatalf("retried EraseUser() error = %v", err)
+		}
+		if record.Status != models.ErasureCompleted {
+			t.Errorf("status = %v, want %v", record.Status, models.ErasureCompleted)
+		}
+		if len(f.keyVault.secrets) != 1 {
+			t.Errorf("secrets = %v, want only the bystander's", f.keyVault.secrets)
+		}
+		if data, _ := f.redis.Get(ctx, "session:"+f.sessionID); data != "" {
+			t.Errorf("session %s still exists", f.sessionID)
+		}
+	})
+}
+
+func TestCreateUser_EmailUniqueness(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	req := &models.CreateUserRequest{Email: "taken@example.com", FirstName: "Ada", LastName: "Lovelace"}
+	count := func(n int32) bson.D {
+		return mtest.CreateCursorResponse(0, testUsersNamespace, mtest.FirstBatch, bson.D{{Key: "n", Value: n}})
+	}
+
+	mt.Run("first registration", func(mt *mtest.T) {
+		producer := &fakeProducer{}
+		svc := newMongoTestService(mt, producer)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, testUsersNamespace, mtest.FirstBatch), mtest.CreateSuccessResponse())
+
+		profile, err := svc.CreateUser(context.Background(), req)
+		if err != nil {
+			t.Fatalf("CreateUser() error = %v", err)
+		}
+		if profile.Email != req.Email {
+			t.Errorf("email = %q, want %q", profile.Email, req.Email)
+		}
+
+		// The lookup matches on email, including soft-deleted profiles
+		match := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$match").Document()
+		if got := match.Lookup("email").StringValue(); got != req.Email {
+			t.Errorf("lookup email = %q, want %q", got, req.Email)
+		}
+		if _, err := match.LookupErr("deletedAt"); err == nil {
+			t.Errorf("lookup $match = %v, want no deletedAt filter", match)
+		}
+		if started := mt.GetStartedEvent(); started == nil || started.CommandName != "insert" {
+			t.Errorf("second command = %v, want insert", started)
+		}
+		if len(producer.messages) != 1 {
+			t.Errorf("published %d events, want 1", len(producer.messages))
+		}
+	})
+
+	mt.Run("email is normalized before lookup and insert", func(mt *mtest.T) {
+		svc := newMongoTestService(mt, nil)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, testUsersNamespace, mtest.FirstBatch), mtest.CreateSuccessResponse())
+
+		mixed := *req
+		mixed.Email = "  Taken@Example.COM "
+		profile, err := svc.CreateUser(context.Background(), &mixed)
+		if err != nil {
+			t.Fatalf("CreateUser() error = %v", err)
+		}
+		if profile.Email != req.Email {
+			t.Errorf("email = %q, want %q", profile.Email, req.Email)
+		}
+		match := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$match").Document()
+		if got := match.Lookup("email").StringValue(); got != req.Email {
+			t.Errorf("lookup email = %q, want %q", got, req.Email)
+		}
+		inserted := mt.GetStartedEvent().Command.Lookup("documents").Array().Index(0).Value().Document()
+		if got := inserted.Lookup("email").StringValue(); got != req.Email {
+			t.Errorf("inserted email = %q, want %q", got, req.Email)
+		}
+	})
+
+	mt.Run("email already registered", func(mt *mtest.T) {
+		producer := &fakeProducer{}
+		svc := newMongoTestService(mt, producer)
+		mt.AddMockResponses(count(1))
+
+		_, err := svc.CreateUser(context.Background(), req)
+		if !errors.HasCode(err, "PAT-USR-003") {
+			t.Fatalf("CreateUser() error = %v, want PAT-USR-003", err)
+		}
+		if failures := svc.mongoCircuitBreaker.Metrics().Failures; failures != 0 {
+			t.Errorf("breaker failures = %d, want 0 for a taken email", failures)
+		}
+		mt.GetStartedEvent()
+		if started := mt.GetStartedEvent(); started != nil {
+			t.Errorf("unexpected %s after duplicate lookup", started.CommandName)
+		}
+		if len(producer.messages) != 0 {
+			t.Errorf("published %d events, want 0", len(producer.messages))
+		}
+	})
+
+	mt.Run("concurrent registration hits the unique index", func(mt *mtest.T) {
+		svc := newMongoTestService(mt, nil)
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, testUsersNamespace, mtest.FirstBatch),
+			mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "E11000 duplicate key error"}),
+		)
+
+		if _, err := svc.CreateUser(context.Background(), req); !errors.HasCode(err, "PAT-USR-003") {
+			t.Errorf("CreateUser() error = %v, want PAT-USR-003", err)
+		}
+		if failures := svc.mongoCircuitBreaker.Metrics().Failures; failures != 0 {
+			t.Errorf("breaker failures = %d, want 0 for a duplicate key", failures)
+		}
+	})
+}
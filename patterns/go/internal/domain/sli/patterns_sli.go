@@ -2,9 +2,11 @@ package sli
 
 import (
 	"context"
+	goerrors "errors"
 	"sync"
 	"time"
 
+	coreerrors "github.com/your-github-org/ai-scaffolder/core/go/errors"
 	coresli "github.com/your-github-org/ai-scaffolder/core/go/sli"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -29,8 +31,22 @@ type PatternsSli struct {
 	// Telemetry metrics
 	telemetryRecords  *prometheus.CounterVec
 	anomaliesDetected *prometheus.CounterVec
+
+	// Failure breakdown by operation and error code
+	failuresMu     sync.RWMutex
+	failuresByCode map[string]map[string]int64
 }
 
+const (
+	// OperationOrderCreation is the SLI operation for order creation
+	OperationOrderCreation = "order_creation"
+	// OperationTelemetryIngestion is the SLI operation for telemetry ingestion
+	OperationTelemetryIngestion = "telemetry_ingestion"
+
+	// UnknownErrorCode is used for failures that carry no ServiceError code
+	UnknownErrorCode = "UNKNOWN"
+)
+
 var (
 	// Singleton instance
 	instance *PatternsSli
@@ -126,6 +142,8 @@ func NewPatternsSli(serviceName string) *PatternsSli {
 				},
 				[]string{"anomaly_type", "severity"},
 			),
+
+			failuresByCode: make(map[string]map[string]int64),
 		}
 	})
 	return instance
@@ -218,38 +236,72 @@ func (p *PatternsSli) GetMetrics(ctx context.Context) (*coresli.Metrics, error)
 func (p *PatternsSli) RecordOrderCreationSuccess(duration time.Duration) {
 	p.tracker.RecordRequest(context.Background(), coresli.RequestOutcome{
 		Success:   true,
-		Operation: "order_creation",
+		Operation: OperationOrderCreation,
 		Latency:   duration,
 		Timestamp: time.Now(),
 	})
-	p.tracker.RecordLatency(context.Background(), duration, "order_creation")
+	p.tracker.RecordLatency(context.Background(), duration, OperationOrderCreation)
 }
 
-// RecordOrderCreationFailure records a failed order creation
-func (p *PatternsSli) RecordOrderCreationFailure() {
-	p.tracker.RecordRequest(context.Background(), coresli.RequestOutcome{
-		Success:   false,
-		Operation: "order_creation",
-		Timestamp: time.Now(),
-	})
+// RecordOrderCreationFailure records a failed order creation, bucketed by the error's code
+func (p *PatternsSli) RecordOrderCreationFailure(err error) {
+	p.recordFailure(OperationOrderCreation, err)
 }
 
 // RecordTelemetryIngestionSuccess records a successful telemetry ingestion
 func (p *PatternsSli) RecordTelemetryIngestionSuccess(duration time.Duration) {
 	p.tracker.RecordRequest(context.Background(), coresli.RequestOutcome{
 		Success:   true,
-		Operation: "telemetry_ingestion",
+		Operation: OperationTelemetryIngestion,
 		Latency:   duration,
 		Timestamp: time.Now(),
 	})
-	p.tracker.RecordLatency(context.Background(), duration, "telemetry_ingestion")
+	p.tracker.RecordLatency(context.Background(), duration, OperationTelemetryIngestion)
 }
 
-// RecordTelemetryIngestionFailure records a failed telemetry ingestion
-func (p *PatternsSli) RecordTelemetryIngestionFailure() {
+// RecordTelemetryIngestionFailure records a failed telemetry ingestion, bucketed by the error's code
+func (p *PatternsSli) RecordTelemetryIngestionFailure(err error) {
+	p.recordFailure(OperationTelemetryIngestion, err)
+}
+
+// FailureBreakdown returns the number of failures per error code for an operation
+func (p *PatternsSli) FailureBreakdown(operation string) map[string]int64 {
+	p.failuresMu.RLock()
+	defer p.failuresMu.RUnlock()
+
+	breakdown := make(map[string]int64, len(p.failuresByCode[operation]))
+	for code, count := range p.failuresByCode[operation] {
+		breakdown[code] = count
+	}
+	return breakdown
+}
+
+// recordFailure records a failed request with the error code and severity of err
+// so failures can be correlated with specific error codes (validation vs DB, etc.)
+func (p *PatternsSli) recordFailure(operation string, err error) {
+	code, severity := errorCodeOf(err)
+
 	p.tracker.RecordRequest(context.Background(), coresli.RequestOutcome{
-		Success:   false,
-		Operation: "telemetry_ingestion",
-		Timestamp: time.Now(),
+		Success:       false,
+		ErrorCode:     code,
+		ErrorSeverity: severity,
+		Operation:     operation,
+		Timestamp:     time.Now(),
 	})
+
+	p.failuresMu.Lock()
+	defer p.failuresMu.Unlock()
+	if p.failuresByCode[operation] == nil {
+		p.failuresByCode[operation] = make(map[string]int64)
+	}
+	p.failuresByCode[operation][code]++
+}
+
+// errorCodeOf extracts the ServiceError code and severity from err
+func errorCodeOf(err error) (string, string) {
+	var serviceErr *coreerrors.ServiceError
+	if goerrors.As(err, &serviceErr) {
+		return serviceErr.Code, serviceErr.Severity
+	}
+	return UnknownErrorCode, coreerrors.SeverityMedium
 }
@@ -0,0 +1,59 @@
+package sli
+
+import (
+	goerrors "errors"
+	"fmt"
+	"testing"
+
+	coreerrors "github.com/your-github-org/ai-scaffolder/core/go/errors"
+)
+
+func TestRecordFailure_BreakdownByCode(t *testing.T) {
+	s := NewPatternsSli("patterns-sli-test")
+
+	orderBefore := s.FailureBreakdown(OperationOrderCreation)
+	telemetryBefore := s.FailureBreakdown(OperationTelemetryIngestion)
+
+	validation := coreerrors.New("PAT-VAL-002", coreerrors.SeverityLow, "Missing required parameter: customer_id")
+	database := coreerrors.Wrap(goerrors.New("connection reset"), "PAT-INFRA-001", coreerrors.SeverityCritical, "Database connection failed")
+
+	s.RecordOrderCreationFailure(validation)
+	s.RecordOrderCreationFailure(validation)
+	s.RecordOrderCreationFailure(fmt.Errorf("failed to create order: %w", database))
+	s.RecordOrderCreationFailure(goerrors.New("plain error"))
+	s.RecordTelemetryIngestionFailure(database)
+
+	tests := []struct {
+		operation string
+		before    map[string]int64
+		code      string
+		want      int64
+	}{
+		{OperationOrderCreation, orderBefore, "PAT-VAL-002", 2},
+		{OperationOrderCreation, orderBefore, "PAT-INFRA-001", 1},
+		{OperationOrderCreation, orderBefore, UnknownErrorCode, 1},
+		{OperationTelemetryIngestion, telemetryBefore, "PAT-INFRA-001", 1},
+		{OperationTelemetryIngestion, telemetryBefore, "PAT-VAL-002", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operation+"/"+tt.code, func(t *testing.T) {
+			got := s.FailureBreakdown(tt.operation)[tt.code] - tt.before[tt.code]
+			if got != tt.want {
+				t.Errorf("FailureBreakdown(%s)[%s] delta = %v, want %v", tt.operation, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailureBreakdown_ReturnsCopy(t *testing.T) {
+	s := NewPatternsSli("patterns-sli-test")
+	s.RecordOrderCreationFailure(coreerrors.New("PAT-ORD-002", coreerrors.SeverityLow, "Order must have at least one item"))
+
+	breakdown := s.FailureBreakdown(OperationOrderCreation)
+	breakdown["PAT-ORD-002"] = -100
+
+	if s.FailureBreakdown(OperationOrderCreation)["PAT-ORD-002"] < 1 {
+		t.Error("mutating the returned breakdown should not affect recorded failures")
+	}
+}
@@ -0,0 +1,109 @@
+package eventbus
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DeadLetterBuffer holds encoded messages that couldn't be published, oldest
+// first, until Replay republishes them. Messages keep their headers, so a
+// replay carries the original correlation ID. When full, the oldest message
+// is evicted to make room. The buffer is in memory: messages still buffered
+// at shutdown are lost.
+type DeadLetterBuffer struct {
+	mu       sync.Mutex
+	messages []*Message
+	size     int
+	evicted  int64
+}
+
+// NewDeadLetterBuffer creates a buffer holding up to size messages
+func NewDeadLetterBuffer(size int) *DeadLetterBuffer {
+	if size <= 0 {
+		size = 1000
+	}
+	return &DeadLetterBuffer{size: size}
+}
+
+// Add buffers msg, evicting the oldest message if the buffer is full
+func (b *DeadLetterBuffer) Add(msg *Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.messages) == b.size {
+		b.messages = b.messages[1:]
+		b.evicted++
+	}
+	b.messages = append(b.messages, msg)
+}
+
+// Drain removes and returns the buffered messages, oldest first
+func (b *DeadLetterBuffer) Drain() []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	messages := b.messages
+	b.messages = nil
+	return messages
+}
+
+// Replay sends the buffered messages oldest first and returns how many were
+// sent. It stops at the first failure; that message and the ones after it go
+// back to the front of the buffer, ahead of any added meanwhile.
+func (b *DeadLetterBuffer) Replay(send func(*Message) error) (int, error) {
+	messages := b.Drain()
+	for i, msg := range messages {
+		if err := send(msg); err != nil {
+			b.requeue(messages[i:])
+			return i, err
+		}
+	}
+	return len(messages), nil
+}
+
+// requeue puts messages back ahead of the buffered ones, evicting the oldest
+// if they no longer fit
+func (b *DeadLetterBuffer) requeue(messages []*Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = append(messages, b.messages...)
+	if over := len(b.messages) - b.size; over > 0 {
+		b.messages = b.messages[over:]
+		b.evicted += int64(over)
+	}
+}
+
+// Len returns the number of buffered messages
+func (b *DeadLetterBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.messages)
+}
+
+// Evicted returns how many messages were dropped because the buffer was full
+func (b *DeadLetterBuffer) Evicted() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.evicted
+}
+
+// RegisterMetrics exports Len as patterns_dead_letter_messages and Evicted as
+// patterns_dead_letter_evicted_total, so buffered and lost events are visible
+func (b *DeadLetterBuffer) RegisterMetrics(reg prometheus.Registerer) error {
+	buffered := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "patterns",
+		Name:      "dead_letter_messages",
+		Help:      "Events waiting in the dead-letter buffer to be republished",
+	}, func() float64 { return float64(b.Len()) })
+	evicted := prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: "patterns",
+		Name:      "dead_letter_evicted_total",
+		Help:      "Events dropped from the full dead-letter buffer and never published",
+	}, func() float64 { return float64(b.Evicted()) })
+
+	for _, collector := range []prometheus.Collector{buffered, evicted} {
+		if err := reg.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
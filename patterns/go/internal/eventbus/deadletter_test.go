@@ -0,0 +1,118 @@
+package eventbus
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func keys(messages []*Message) []string {
+	result := make([]string, len(messages))
+	for i, msg := range messages {
+		result[i] = msg.Key
+	}
+	return result
+}
+
+func TestDeadLetterBuffer_EvictsOldest(t *testing.T) {
+	buffer := NewDeadLetterBuffer(2)
+	for _, key := range []string{"a", "b", "c"} {
+		buffer.Add(&Message{Key: key})
+	}
+
+	if buffer.Len() != 2 || buffer.Evicted() != 1 {
+		t.Fatalf("Len() = %d, Evicted() = %d; want 2, 1", buffer.Len(), buffer.Evicted())
+	}
+
+	messages := buffer.Drain()
+	if got := strings.Join(keys(messages), ","); got != "b,c" {
+		t.Errorf("Drain() = %v, want messages b and c", got)
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("Len() = %d after Drain, want 0", buffer.Len())
+	}
+}
+
+func TestDeadLetterBuffer_Replay(t *testing.T) {
+	buffer := NewDeadLetterBuffer(10)
+	for _, key := range []string{"a", "b", "c"} {
+		buffer.Add(&Message{Key: key, Headers: map[string]string{HeaderCorrelationID: "corr-" + key}})
+	}
+
+	// Kafka fails at b: a is sent, b and c stay ahead of the newer d
+	var sent []*Message
+	sendErr := errors.New("broker unavailable")
+	n, err := buffer.Replay(func(msg *Message) error {
+		if msg.Key == "b" {
+			buffer.Add(&Message{Key: "d"})
+			return sendErr
+		}
+		sent = append(sent, msg)
+		return nil
+	})
+	if n != 1 || !errors.Is(err, sendErr) {
+		t.Fatalf("Replay() = %d, %v; want 1, %v", n, err, sendErr)
+	}
+	if got := strings.Join(keys(sent), ","); got != "a" || sent[0].Headers[HeaderCorrelationID] != "corr-a" {
+		t.Errorf("sent = %v, want a with its correlation header", got)
+	}
+
+	// Kafka is back: the rest go out in their original order
+	sent = nil
+	n, err = buffer.Replay(func(msg *Message) error {
+		sent = append(sent, msg)
+		return nil
+	})
+	if n != 3 || err != nil {
+		t.Fatalf("Replay() = %d, %v; want 3, nil", n, err)
+	}
+	if got := strings.Join(keys(sent), ","); got != "b,c,d" {
+		t.Errorf("sent = %v, want b,c,d", got)
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("Len() = %d after Replay, want 0", buffer.Len())
+	}
+}
+
+func TestDeadLetterBuffer_RequeueEvictsOldest(t *testing.T) {
+	buffer := NewDeadLetterBuffer(2)
+	buffer.Add(&Message{Key: "a"})
+	buffer.Add(&Message{Key: "b"})
+
+	// Two newer messages arrive while both replays fail
+	buffer.Replay(func(msg *Message) error {
+		buffer.Add(&Message{Key: "c"})
+		buffer.Add(&Message{Key: "d"})
+		return errors.New("broker unavailable")
+	})
+
+	if got := strings.Join(keys(buffer.Drain()), ","); got != "c,d" || buffer.Evicted() != 2 {
+		t.Errorf("buffered = %v, evicted = %d; want c,d and 2", got, buffer.Evicted())
+	}
+}
+
+func TestDeadLetterBuffer_RegisterMetrics(t *testing.T) {
+	buffer := NewDeadLetterBuffer(1)
+	reg := prometheus.NewRegistry()
+	if err := buffer.RegisterMetrics(reg); err != nil {
+		t.Fatalf("RegisterMetrics() error = %v", err)
+	}
+
+	buffer.Add(&Message{Key: "a"})
+	buffer.Add(&Message{Key: "b"})
+
+	want := `
+# HELP patterns_dead_letter_evicted_total Events dropped from the full dead-letter buffer and never published
+# TYPE patterns_dead_letter_evicted_total counter
+patterns_dead_letter_evicted_total 1
+# HELP patterns_dead_letter_messages Events waiting in the dead-letter buffer to be republished
+# TYPE patterns_dead_letter_messages gauge
+patterns_dead_letter_messages 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
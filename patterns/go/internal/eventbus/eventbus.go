@@ -0,0 +1,235 @@
+// Package eventbus publishes and consumes typed domain events over Kafka.
+//
+// Topic names live here: each event type is registered to one topic, so
+// service code publishes events rather than choosing topics and headers.
+// ConfigureTopics renames the topics from service config.
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/kafka"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+// Default Kafka topics, one per event type
+const (
+	TopicOrders       = "orders.events"
+	TopicUsers        = "users.events"
+	TopicTelemetry    = "telemetry.events"
+	TopicLeaderboards = "leaderboard.events"
+	TopicSystem       = "system.events"
+)
+
+// Message headers set on every published event
+const (
+	HeaderEventType     = "event_type"
+	HeaderCorrelationID = "correlation_id"
+)
+
+// ErrUnknownEvent is returned when an event type has no registered topic
+var ErrUnknownEvent = errors.New("event type has no registered topic")
+
+// ErrMarshalEvent is returned when an event can't be encoded as JSON
+var ErrMarshalEvent = errors.New("failed to marshal event")
+
+// Event is a domain event that can be published on the bus
+type Event interface {
+	EventName() string
+	EventKey() string
+	EventCorrelationID() string
+	SetEventCorrelationID(id string)
+}
+
+// Topics names the topic for each event type; empty fields keep the default
+type Topics struct {
+	Orders       string
+	Users        string
+	Telemetry    string
+	Leaderboards string
+	System       string
+}
+
+var (
+	topicsMu sync.RWMutex
+	topics   = topicMap(Topics{})
+)
+
+func topicMap(t Topics) map[reflect.Type]string {
+	or := func(name, def string) string {
+		if name == "" {
+			return def
+		}
+		return name
+	}
+	return map[reflect.Type]string{
+		reflect.TypeFor[*models.OrderEvent]():       or(t.Orders, TopicOrders),
+		reflect.TypeFor[*models.UserEvent]():        or(t.Users, TopicUsers),
+		reflect.TypeFor[*models.TelemetryEvent]():   or(t.Telemetry, TopicTelemetry),
+		reflect.TypeFor[*models.LeaderboardEvent](): or(t.Leaderboards, TopicLeaderboards),
+		reflect.TypeFor[*models.SystemEvent]():      or(t.System, TopicSystem),
+	}
+}
+
+// ConfigureTopics sets the topic names used by Publish and Subscribe. Call it
+// at startup, before subscribing, so publishers and subscribers agree.
+func ConfigureTopics(t Topics) {
+	topicsMu.Lock()
+	defer topicsMu.Unlock()
+	topics = topicMap(t)
+}
+
+// TopicFor returns the topic events of type T are published to
+func TopicFor[T Event]() (string, error) {
+	t := reflect.TypeFor[T]()
+	topicsMu.RLock()
+	topic, ok := topics[t]
+	topicsMu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownEvent, t)
+	}
+	return topic, nil
+}
+
+// Message is an event encoded for its topic, ready to Send
+type Message struct {
+	Topic   string
+	Key     string
+	Payload []byte
+	Headers map[string]string
+}
+
+// Encode encodes event as JSON for its type's topic, keyed by EventKey. If
+// the event has no correlation ID, the request's correlation ID from ctx is
+// used. Marshal failures wrap ErrMarshalEvent.
+func Encode[T Event](ctx context.Context, event T) (*Message, error) {
+	topic, err := TopicFor[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	if id := logger.CorrelationIDFromContext(ctx); id != "" {
+		event.SetEventCorrelationID(id)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrMarshalEvent, event.EventName(), err)
+	}
+
+	return &Message{
+		Topic:   topic,
+		Key:     event.EventKey(),
+		Payload: payload,
+		Headers: map[string]string{
+			HeaderEventType:     event.EventName(),
+			HeaderCorrelationID: event.EventCorrelationID(),
+		},
+	}, nil
+}
+
+// Send sends an encoded message
+func Send(ctx context.Context, producer kafka.Producer, msg *Message) error {
+	return producer.SendMessage(ctx, msg.Topic, msg.Key, msg.Payload, msg.Headers)
+}
+
+// Publish encodes event and sends it to its type's topic
+func Publish[T Event](ctx context.Context, producer kafka.Producer, event T) error {
+	msg, err := Encode(ctx, event)
+	if err != nil {
+		return err
+	}
+	return Send(ctx, producer, msg)
+}
+
+// Subscriber dispatches consumed messages to handlers registered with Subscribe
+type Subscriber struct {
+	logger *logger.Logger
+
+	mu       sync.RWMutex
+	handlers map[string][]func(ctx context.Context, payload []byte) error
+}
+
+// NewSubscriber creates a subscriber with no handlers
+func NewSubscriber(log *logger.Logger) *Subscriber {
+	return &Subscriber{
+		logger:   log,
+		handlers: make(map[string][]func(ctx context.Context, payload []byte) error),
+	}
+}
+
+// Subscribe registers handler for events of type T; T must be a registered
+// pointer event type such as *models.OrderEvent
+func Subscribe[T Event](s *Subscriber, handler func(ctx context.Context, event T) error) error {
+	topic, err := TopicFor[T]()
+	if err != nil {
+		return err
+	}
+	elem := reflect.TypeFor[T]().Elem()
+
+	decode := func(ctx context.Context, payload []byte) error {
+		event := reflect.New(elem).Interface().(T)
+		if err := json.Unmarshal(payload, event); err != nil {
+			return fmt.Errorf("failed to unmarshal %s message: %w", topic, err)
+		}
+		return handler(ctx, event)
+	}
+
+	s.mu.Lock()
+	s.handlers[topic] = append(s.handlers[topic], decode)
+	s.mu.Unlock()
+	return nil
+}
+
+// Topics returns the topics that have at least one handler
+func (s *Subscriber) Topics() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.handlers))
+	for topic := range s.handlers {
+		names = append(names, topic)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Handle dispatches msg to every handler for its topic, with the message's
+// correlation ID in ctx. It satisfies kafka.MessageHandler.
+func (s *Subscriber) Handle(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	s.mu.RLock()
+	handlers := s.handlers[msg.Topic]
+	s.mu.RUnlock()
+
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	for _, header := range msg.Headers {
+		if string(header.Key) == HeaderCorrelationID && len(header.Value) > 0 {
+			ctx = logger.ContextWithCorrelationID(ctx, string(header.Value))
+		}
+	}
+
+	var errs []error
+	for _, handle := range handlers {
+		if err := handle(ctx, msg.Value); err != nil {
+			if s.logger != nil {
+				s.logger.WithContext(ctx).Error("Event handler failed",
+					zap.String("topic", msg.Topic),
+					zap.Error(err))
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
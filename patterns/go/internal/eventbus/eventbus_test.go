@@ -0,0 +1,245 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"math"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+// recordingProducer is a kafka.Producer that records sent messages
+type recordingProducer struct {
+	messages []*sarama.ConsumerMessage
+}
+
+func (p *recordingProducer) SendMessage(ctx context.Context, topic, key string, value []byte, headers map[string]string) error {
+	msg := &sarama.ConsumerMessage{Topic: topic, Key: []byte(key), Value: value}
+	for k, v := range headers {
+		msg.Headers = append(msg.Headers, &sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+	}
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func (p *recordingProducer) Close(ctx context.Context) error  { return nil }
+func (p *recordingProducer) Health(ctx context.Context) error { return nil }
+
+func header(msg *sarama.ConsumerMessage, key string) string {
+	for _, h := range msg.Headers {
+		if string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// unregisteredEvent embeds BaseEvent but has no topic
+type unregisteredEvent struct {
+	models.BaseEvent
+}
+
+func (e *unregisteredEvent) EventKey() string { return "" }
+
+func TestPublish_RoutesByEventType(t *testing.T) {
+	order := &models.Order{ID: uuid.New(), CustomerID: uuid.New(), Status: models.OrderStatusPending}
+	telemetry := models.NewDeviceTelemetry("device-1", "temperature", 21.5, "celsius")
+
+	tests := []struct {
+		name      string
+		publish   func(ctx context.Context, p *recordingProducer) error
+		wantTopic string
+		wantKey   string
+		wantType  string
+	}{
+		{
+			name: "order event",
+			publish: func(ctx context.Context, p *recordingProducer) error {
+				return Publish(ctx, p, models.NewOrderCreatedEvent(order, "test"))
+			},
+			wantTopic: TopicOrders,
+			wantKey:   order.ID.String(),
+			wantType:  "OrderCreated",
+		},
+		{
+			name: "user event",
+			publish: func(ctx context.Context, p *recordingProducer) error {
+				return Publish(ctx, p, models.NewUserLoggedInEvent(order.CustomerID, "a@example.com", "test"))
+			},
+			wantTopic: TopicUsers,
+			wantKey:   order.CustomerID.String(),
+			wantType:  "UserLoggedIn",
+		},
+		{
+			name: "telemetry event",
+			publish: func(ctx context.Context, p *recordingProducer) error {
+				return Publish(ctx, p, models.NewTelemetryReceivedEvent(telemetry, "test"))
+			},
+			wantTopic: TopicTelemetry,
+			wantKey:   "device-1",
+			wantType:  "TelemetryReceived",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &recordingProducer{}
+			if err := tt.publish(context.Background(), producer); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+			if len(producer.messages) != 1 {
+				t.Fatalf("messages = %d, want 1", len(producer.messages))
+			}
+
+			msg := producer.messages[0]
+			if msg.Topic != tt.wantTopic || string(msg.Key) != tt.wantKey {
+				t.Errorf("sent to %s key %s, want %s key %s", msg.Topic, msg.Key, tt.wantTopic, tt.wantKey)
+			}
+			if got := header(msg, HeaderEventType); got != tt.wantType {
+				t.Errorf("event_type header = %q, want %q", got, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestPublish_CorrelationID(t *testing.T) {
+	producer := &recordingProducer{}
+	ctx := logger.ContextWithCorrelationID(context.Background(), "req-123")
+
+	event := models.NewUserLoggedInEvent(uuid.New(), "a@example.com", "test")
+	if err := Publish(ctx, producer, event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	// Events that already carry a correlation ID keep it
+	telemetry := models.NewTelemetryReceivedEvent(models.NewDeviceTelemetry("device-1", "temperature", 1, "c"), "test")
+	if err := Publish(ctx, producer, telemetry); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if got := header(producer.messages[0], HeaderCorrelationID); got != "req-123" {
+		t.Errorf("correlation_id from ctx = %q, want req-123", got)
+	}
+	var decoded models.UserEvent
+	if err := json.Unmarshal(producer.messages[0].Value, &decoded); err != nil || decoded.CorrelationID != "req-123" {
+		t.Errorf("payload correlationId = %q (%v), want req-123", decoded.CorrelationID, err)
+	}
+	if got := header(producer.messages[1], HeaderCorrelationID); got != telemetry.CorrelationID {
+		t.Errorf("correlation_id = %q, want the event's own %q", got, telemetry.CorrelationID)
+	}
+}
+
+func TestPublish_UnknownEvent(t *testing.T) {
+	producer := &recordingProducer{}
+	err := Publish(context.Background(), producer, &unregisteredEvent{})
+	if !goerrors.Is(err, ErrUnknownEvent) {
+		t.Errorf("Publish() error = %v, want ErrUnknownEvent", err)
+	}
+	if len(producer.messages) != 0 {
+		t.Errorf("messages = %d, want none", len(producer.messages))
+	}
+}
+
+func TestEncode_MarshalError(t *testing.T) {
+	event := &models.TelemetryEvent{DeviceID: "device-1", Value: math.Inf(1)}
+
+	msg, err := Encode(context.Background(), event)
+	if !goerrors.Is(err, ErrMarshalEvent) || msg != nil {
+		t.Errorf("Encode() = %v, %v; want nil, ErrMarshalEvent", msg, err)
+	}
+
+	producer := &recordingProducer{}
+	if err := Publish(context.Background(), producer, event); !goerrors.Is(err, ErrMarshalEvent) {
+		t.Errorf("Publish() error = %v, want ErrMarshalEvent", err)
+	}
+	if len(producer.messages) != 0 {
+		t.Errorf("messages = %d, want none", len(producer.messages))
+	}
+}
+
+func TestConfigureTopics(t *testing.T) {
+	t.Cleanup(func() { ConfigureTopics(Topics{}) })
+
+	ConfigureTopics(Topics{Orders: "staging.orders.events"})
+
+	producer := &recordingProducer{}
+	Publish(context.Background(), producer, &models.OrderEvent{})
+	Publish(context.Background(), producer, &models.UserEvent{})
+	if producer.messages[0].Topic != "staging.orders.events" {
+		t.Errorf("order event topic = %s, want staging.orders.events", producer.messages[0].Topic)
+	}
+	if producer.messages[1].Topic != TopicUsers {
+		t.Errorf("user event topic = %s, want default %s", producer.messages[1].Topic, TopicUsers)
+	}
+
+	sub := NewSubscriber(&logger.Logger{Logger: zap.NewNop()})
+	if err := Subscribe(sub, func(ctx context.Context, e *models.OrderEvent) error { return nil }); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if topics := sub.Topics(); len(topics) != 1 || topics[0] != "staging.orders.events" {
+		t.Errorf("Topics() = %v, want [staging.orders.events]", topics)
+	}
+}
+
+func TestSubscribe_DispatchesByTopic(t *testing.T) {
+	sub := NewSubscriber(&logger.Logger{Logger: zap.NewNop()})
+
+	var orders []*models.OrderEvent
+	var correlationIDs []string
+	if err := Subscribe(sub, func(ctx context.Context, event *models.OrderEvent) error {
+		orders = append(orders, event)
+		id := logger.CorrelationIDFromContext(ctx)
+		correlationIDs = append(correlationIDs, id)
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	telemetryCalls := 0
+	if err := Subscribe(sub, func(ctx context.Context, event *models.TelemetryEvent) error {
+		telemetryCalls++
+		return goerrors.New("handler failed")
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if got := sub.Topics(); len(got) != 2 || got[0] != TopicOrders || got[1] != TopicTelemetry {
+		t.Errorf("Topics() = %v, want [%s %s]", got, TopicOrders, TopicTelemetry)
+	}
+
+	// Round-trip through Publish so the subscriber sees real wire messages
+	producer := &recordingProducer{}
+	order := &models.Order{ID: uuid.New(), Status: models.OrderStatusPending}
+	ctx := logger.ContextWithCorrelationID(context.Background(), "req-9")
+	_ = Publish(ctx, producer, models.NewOrderCreatedEvent(order, "test"))
+	_ = Publish(ctx, producer, models.NewTelemetryReceivedEvent(models.NewDeviceTelemetry("d", "m", 1, "u"), "test"))
+	_ = Publish(ctx, producer, models.NewUserLoggedInEvent(uuid.New(), "a@example.com", "test"))
+
+	if err := sub.Handle(context.Background(), producer.messages[0]); err != nil {
+		t.Fatalf("Handle(order) error = %v", err)
+	}
+	if err := sub.Handle(context.Background(), producer.messages[1]); err == nil {
+		t.Error("Handle(telemetry) error = nil, want the handler's error")
+	}
+	if err := sub.Handle(context.Background(), producer.messages[2]); err != nil {
+		t.Errorf("Handle(user) error = %v, want nil for a topic without handlers", err)
+	}
+
+	if len(orders) != 1 || orders[0].OrderID != order.ID || orders[0].EventType != "OrderCreated" {
+		t.Errorf("order handler got %+v, want the published OrderCreated event", orders)
+	}
+	if len(correlationIDs) != 1 || correlationIDs[0] != "req-9" {
+		t.Errorf("handler correlation IDs = %v, want [req-9]", correlationIDs)
+	}
+	if telemetryCalls != 1 {
+		t.Errorf("telemetry handler calls = %d, want 1", telemetryCalls)
+	}
+
+	if err := Subscribe(sub, func(ctx context.Context, event *unregisteredEvent) error { return nil }); !goerrors.Is(err, ErrUnknownEvent) {
+		t.Errorf("Subscribe(unregistered) error = %v, want ErrUnknownEvent", err)
+	}
+}
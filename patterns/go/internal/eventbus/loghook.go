@@ -0,0 +1,148 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	coreerrors "github.com/your-github-org/ai-scaffolder/core/go/errors"
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/kafka"
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SystemEventHook is a zap core that publishes ERROR-level entries logged
+// with WithError(code, CRITICAL or HIGH) as SystemEvents on TopicSystem.
+//
+// Publishing is best-effort: entries are queued and sent by Run, and dropped
+// when the queue is full so logging never blocks on Kafka. Run doesn't log
+// publish failures, so a Kafka outage can't loop back into the hook.
+type SystemEventHook struct {
+	sink   *systemEventSink
+	fields []zapcore.Field // Added with With, e.g. correlation_id
+}
+
+type systemEventSink struct {
+	producer kafka.Producer
+	source   string
+	queue    chan *models.SystemEvent
+	dropped  atomic.Int64
+	failed   atomic.Int64
+}
+
+// NewSystemEventHook creates a hook that queues up to bufferSize events
+func NewSystemEventHook(producer kafka.Producer, source string, bufferSize int) *SystemEventHook {
+	if bufferSize <= 0 {
+		bufferSize = 100
+	}
+	return &SystemEventHook{sink: &systemEventSink{
+		producer: producer,
+		source:   source,
+		queue:    make(chan *models.SystemEvent, bufferSize),
+	}}
+}
+
+// Attach returns a copy of log that also writes to the hook
+func (h *SystemEventHook) Attach(log *logger.Logger) *logger.Logger {
+	return &logger.Logger{Logger: log.Logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, h)
+	}))}
+}
+
+// Run publishes queued events until ctx is cancelled
+func (h *SystemEventHook) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-h.sink.queue:
+			if err := Publish(ctx, h.sink.producer, event); err != nil {
+				h.sink.failed.Add(1)
+			}
+		}
+	}
+}
+
+// Dropped returns how many events were dropped because the queue was full
+func (h *SystemEventHook) Dropped() int64 {
+	return h.sink.dropped.Load()
+}
+
+// Failed returns how many events Kafka rejected
+func (h *SystemEventHook) Failed() int64 {
+	return h.sink.failed.Load()
+}
+
+// Enabled implements zapcore.Core; only ERROR and above can publish
+func (h *SystemEventHook) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.ErrorLevel
+}
+
+// With implements zapcore.Core
+func (h *SystemEventHook) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(h.fields)+len(fields))
+	merged = append(merged, h.fields...)
+	merged = append(merged, fields...)
+	return &SystemEventHook{sink: h.sink, fields: merged}
+}
+
+// Check implements zapcore.Core
+func (h *SystemEventHook) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if h.Enabled(entry.Level) {
+		return checked.AddCore(entry, h)
+	}
+	return checked
+}
+
+// Write implements zapcore.Core; it queues an event if the entry's severity
+// is CRITICAL or HIGH
+func (h *SystemEventHook) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range h.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	value := func(key string) string {
+		if v, ok := enc.Fields[key]; ok {
+			return fmt.Sprint(v)
+		}
+		return ""
+	}
+
+	severity := strings.ToUpper(value("severity"))
+	if severity != coreerrors.SeverityCritical && severity != coreerrors.SeverityHigh {
+		return nil
+	}
+
+	component := value("component")
+	if component == "" {
+		component = h.sink.source
+	}
+	level := "error"
+	if severity == coreerrors.SeverityCritical {
+		level = "critical"
+	}
+
+	event := models.NewSystemEvent(component, entry.Message, level, h.sink.source)
+	event.CorrelationID = value("correlation_id")
+	event.Details = value("error")
+	event.Metadata["error_code"] = value("error_code")
+	event.Metadata["severity"] = severity
+
+	select {
+	case h.sink.queue <- event:
+	default:
+		h.sink.dropped.Add(1)
+	}
+	return nil
+}
+
+// Sync implements zapcore.Core
+func (h *SystemEventHook) Sync() error {
+	return nil
+}
@@ -0,0 +1,83 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/models"
+	"go.uber.org/zap"
+)
+
+// channelProducer is a kafka.Producer that sends each message's value on a channel
+type channelProducer struct {
+	sent chan []byte
+}
+
+func (p *channelProducer) SendMessage(ctx context.Context, topic, key string, value []byte, headers map[string]string) error {
+	if topic != TopicSystem {
+		return goerrors.New("unexpected topic " + topic)
+	}
+	p.sent <- value
+	return nil
+}
+
+func (p *channelProducer) Close(ctx context.Context) error  { return nil }
+func (p *channelProducer) Health(ctx context.Context) error { return nil }
+
+func TestSystemEventHook_PublishesCriticalLogs(t *testing.T) {
+	producer := &channelProducer{sent: make(chan []byte, 10)}
+	hook := NewSystemEventHook(producer, "patterns", 10)
+	log := hook.Attach(&logger.Logger{Logger: zap.NewNop()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hook.Run(ctx)
+
+	reqCtx := logger.ContextWithCorrelationID(context.Background(), "corr-123")
+	log.Info("Order created")
+	log.Error("Plain error without severity")
+	log.WithContext(reqCtx).WithError("PAT-INFRA-001", "CRITICAL").Error("Database unreachable", zap.Error(goerrors.New("dial timeout")))
+
+	select {
+	case payload := <-producer.sent:
+		var event models.SystemEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			t.Fatalf("invalid SystemEvent: %v", err)
+		}
+		if event.Message != "Database unreachable" || event.Level != "critical" {
+			t.Errorf("event = %q at %q, want Database unreachable at critical", event.Message, event.Level)
+		}
+		if event.CorrelationID != "corr-123" || event.Details != "dial timeout" {
+			t.Errorf("correlationId = %q, details = %q", event.CorrelationID, event.Details)
+		}
+		if event.Metadata["error_code"] != "PAT-INFRA-001" {
+			t.Errorf("error_code = %q, want PAT-INFRA-001", event.Metadata["error_code"])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("critical log produced no system event")
+	}
+
+	// Only the critical entry is published
+	select {
+	case payload := <-producer.sent:
+		t.Errorf("unexpected system event: %s", payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSystemEventHook_DropsWhenQueueFull(t *testing.T) {
+	hook := NewSystemEventHook(&channelProducer{sent: make(chan []byte)}, "patterns", 1)
+	log := hook.Attach(&logger.Logger{Logger: zap.NewNop()})
+
+	// Run isn't started, so the second event doesn't fit and logging doesn't block
+	for i := 0; i < 2; i++ {
+		log.WithError("PAT-INFRA-001", "HIGH").Error("Cache unavailable")
+	}
+	if got := hook.Dropped(); got != 1 {
+		t.Errorf("Dropped() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,31 @@
+// Package safego launches goroutines that can't crash the service.
+//
+// A panic in a goroutine started with the go statement kills the process, and
+// the goroutine's logs lose the request they belong to. Go recovers panics
+// and logs them as PAT-INFRA-006 with the caller's correlation ID.
+package safego
+
+import (
+	"context"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"github.com/your-github-org/ai-scaffolder/patterns/go/internal/domain/errors"
+	"go.uber.org/zap"
+)
+
+// Go runs fn in a new goroutine with ctx. A panic in fn is recovered and
+// logged with ctx's correlation ID and a stack trace; fn's work is abandoned.
+func Go(ctx context.Context, log *logger.Logger, fn func(ctx context.Context)) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err := errors.GoroutinePanic(r)
+				log.WithContext(ctx).WithError(err.Code, err.Severity).Error("Recovered panic in goroutine",
+					zap.Error(err),
+					zap.Any("panic", r),
+					zap.Stack("stack"))
+			}
+		}()
+		fn(ctx)
+	}()
+}
@@ -0,0 +1,61 @@
+package safego
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestGo_RecoversPanicWithCorrelationID(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	log := &logger.Logger{Logger: zap.New(core)}
+	ctx := logger.ContextWithCorrelationID(context.Background(), "corr-123")
+
+	done := make(chan struct{})
+	Go(ctx, log, func(ctx context.Context) {
+		defer close(done)
+		panic("boom")
+	})
+
+	<-done
+	deadline := time.Now().Add(time.Second)
+	for logs.Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("logged %d entries, want 1", logs.Len())
+	}
+
+	fields := logs.All()[0].ContextMap()
+	if fields["correlation_id"] != "corr-123" {
+		t.Errorf("correlation_id = %v, want corr-123", fields["correlation_id"])
+	}
+	if fields["error_code"] != "PAT-INFRA-006" {
+		t.Errorf("error_code = %v, want PAT-INFRA-006", fields["error_code"])
+	}
+	if fields["panic"] != "boom" {
+		t.Errorf("panic = %v, want boom", fields["panic"])
+	}
+}
+
+func TestGo_PassesContext(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	ctx := logger.ContextWithCorrelationID(context.Background(), "corr-456")
+
+	got := make(chan context.Context, 1)
+	Go(ctx, log, func(ctx context.Context) { got <- ctx })
+
+	select {
+	case c := <-got:
+		if logger.CorrelationIDFromContext(c) != "corr-456" {
+			t.Errorf("correlation ID = %v, want corr-456", logger.CorrelationIDFromContext(c))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("fn was not run")
+	}
+}
@@ -0,0 +1,200 @@
+package selftest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/your-github-org/ai-scaffolder/core/go/infrastructure/scylladb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Modes control how startup reacts to self-test failures
+const (
+	ModeOff    = "off"    // Skip the self-test
+	ModeWarn   = "warn"   // Log failures and continue
+	ModeStrict = "strict" // Log failures and exit
+)
+
+// Requirements lists the schema objects the service expects to exist
+type Requirements struct {
+	SQLTables        []string
+	MongoCollections map[string][]MongoIndex // collection -> required indexes
+	ScyllaTables     []string
+}
+
+// DefaultRequirements returns the schema objects used by PatternsService
+func DefaultRequirements() Requirements {
+	return Requirements{
+		SQLTables: []string{"Orders", "order_events", "order_reservations", "Products"},
+		MongoCollections: map[string][]MongoIndex{
+			"user_profiles": {
+				{Name: "created_at_1"},          // Analytics registrations range query
+				{Name: "email_1", Unique: true}, // One profile per email (CreateUser)
+			},
+		},
+		ScyllaTables: []string{"device_telemetry", "device_telemetry_hourly", "device_telemetry_daily", "device_telemetry_counts"},
+	}
+}
+
+// MongoIndex is an index the service relies on, by name
+type MongoIndex struct {
+	Name   string
+	Unique bool // the index must enforce uniqueness, not just exist
+}
+
+// Config holds the clients to validate; nil clients are skipped
+type Config struct {
+	SQLDB          *sql.DB
+	MongoDatabase  *mongo.Database
+	ScyllaSession  scylladb.Session
+	ScyllaKeyspace string
+	Requirements   Requirements
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Backend  string
+	Resource string
+	Err      error
+	Hint     string // Actionable fix when the check fails
+}
+
+// Report collects all check results
+type Report struct {
+	Results []Result
+}
+
+// Failures returns the failed checks
+func (r *Report) Failures() []Result {
+	var failures []Result
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// OK reports whether every check passed
+func (r *Report) OK() bool {
+	return len(r.Failures()) == 0
+}
+
+func (r *Report) add(backend, resource string, err error, hint string) {
+	result := Result{Backend: backend, Resource: resource, Err: err}
+	if err != nil {
+		result.Hint = hint
+	}
+	r.Results = append(r.Results, result)
+}
+
+// Run verifies that the required tables, collections, indexes and keyspace exist
+func Run(ctx context.Context, cfg Config) *Report {
+	report := &Report{}
+
+	if cfg.SQLDB != nil {
+		checkSQLServer(ctx, cfg.SQLDB, cfg.Requirements.SQLTables, report)
+	}
+	if cfg.MongoDatabase != nil {
+		checkMongoDB(ctx, cfg.MongoDatabase, cfg.Requirements.MongoCollections, report)
+	}
+	if cfg.ScyllaSession != nil {
+		checkScyllaDB(ctx, cfg.ScyllaSession, cfg.ScyllaKeyspace, cfg.Requirements.ScyllaTables, report)
+	}
+
+	return report
+}
+
+func checkSQLServer(ctx context.Context, db *sql.DB, tables []string, report *Report) {
+	query := `SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_NAME = @p1`
+
+	for _, table := range tables {
+		var count int
+		err := db.QueryRowContext(ctx, query, sql.Named("p1", table)).Scan(&count)
+		if err == nil && count == 0 {
+			err = fmt.Errorf("table %s does not exist", table)
+		}
+		report.add("sqlserver", table, err,
+			fmt.Sprintf("Run the SQL Server migrations to create table %s", table))
+	}
+}
+
+func checkMongoDB(ctx context.Context, db *mongo.Database, collections map[string][]MongoIndex, report *Report) {
+	existing, err := db.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		report.add("mongodb", db.Name(), err, "Verify MongoDB connectivity and that the user can list collections")
+		return
+	}
+
+	found := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		found[name] = true
+	}
+
+	for collection, indexes := range collections {
+		if !found[collection] {
+			report.add("mongodb", collection, fmt.Errorf("collection %s does not exist", collection),
+				fmt.Sprintf("Create collection %s in database %s", collection, db.Name()))
+			continue
+		}
+		report.add("mongodb", collection, nil, "")
+
+		if len(indexes) == 0 {
+			continue
+		}
+
+		specs, err := db.Collection(collection).Indexes().ListSpecifications(ctx)
+		if err != nil {
+			report.add("mongodb", collection+" indexes", err, "Verify the user can list indexes")
+			continue
+		}
+		haveIndex := make(map[string]*mongo.IndexSpecification, len(specs))
+		for _, spec := range specs {
+			haveIndex[spec.Name] = spec
+		}
+		for _, index := range indexes {
+			kind := "index"
+			if index.Unique {
+				kind = "unique index"
+			}
+			var err error
+			hint := fmt.Sprintf("Create %s %s on collection %s", kind, index.Name, collection)
+			spec, ok := haveIndex[index.Name]
+			switch {
+			case !ok:
+				err = fmt.Errorf("index %s on %s does not exist", index.Name, collection)
+			case index.Unique && (spec.Unique == nil || !*spec.Unique):
+				err = fmt.Errorf("index %s on %s is not unique", index.Name, collection)
+				hint = fmt.Sprintf("Drop index %s on collection %s and recreate it as a %s", index.Name, collection, kind)
+			}
+			report.add("mongodb", collection+"."+index.Name, err, hint)
+		}
+	}
+}
+
+func checkScyllaDB(ctx context.Context, session scylladb.Session, keyspace string, tables []string, report *Report) {
+	var count int64
+	err := session.QueryRow(ctx,
+		`SELECT COUNT(*) FROM system_schema.keyspaces WHERE keyspace_name = ?`, keyspace).Scan(&count)
+	if err == nil && count == 0 {
+		err = fmt.Errorf("keyspace %s does not exist", keyspace)
+	}
+	report.add("scylladb", keyspace, err, fmt.Sprintf("Create keyspace %s", keyspace))
+	if err != nil {
+		return
+	}
+
+	for _, table := range tables {
+		var count int64
+		err := session.QueryRow(ctx,
+			`SELECT COUNT(*) FROM system_schema.tables WHERE keyspace_name = ? AND table_name = ?`,
+			keyspace, table).Scan(&count)
+		if err == nil && count == 0 {
+			err = fmt.Errorf("table %s.%s does not exist", keyspace, table)
+		}
+		report.add("scylladb", keyspace+"."+table, err,
+			fmt.Sprintf("Create table %s in keyspace %s", table, keyspace))
+	}
+}
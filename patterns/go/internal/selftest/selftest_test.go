@@ -0,0 +1,152 @@
+package selftest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+const tableQuery = `SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_NAME = @p1`
+
+func TestRun_DetectsMissingSQLTable(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(tableQuery).
+		WithArgs("Orders").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(tableQuery).
+		WithArgs("OrderItems").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	report := Run(context.Background(), Config{
+		SQLDB:        db,
+		Requirements: Requirements{SQLTables: []string{"Orders", "OrderItems"}},
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	if report.OK() {
+		t.Fatal("OK() = true, want false with a missing table")
+	}
+	if len(report.Results) != 2 {
+		t.Errorf("results = %v, want 2", len(report.Results))
+	}
+
+	failures := report.Failures()
+	if len(failures) != 1 {
+		t.Fatalf("failures = %v, want 1", len(failures))
+	}
+	failure := failures[0]
+	if failure.Backend != "sqlserver" || failure.Resource != "OrderItems" {
+		t.Errorf("failure = %s/%s, want sqlserver/OrderItems", failure.Backend, failure.Resource)
+	}
+	if !strings.Contains(failure.Err.Error(), "OrderItems") {
+		t.Errorf("error = %v, want it to name the missing table", failure.Err)
+	}
+	if failure.Hint == "" {
+		t.Error("failure should carry an actionable hint")
+	}
+}
+
+func TestRun_SQLQueryError(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(tableQuery).
+		WithArgs("Orders").
+		WillReturnError(errors.New("permission denied"))
+
+	report := Run(context.Background(), Config{
+		SQLDB:        db,
+		Requirements: Requirements{SQLTables: []string{"Orders"}},
+	})
+
+	failures := report.Failures()
+	if len(failures) != 1 || !strings.Contains(failures[0].Err.Error(), "permission denied") {
+		t.Errorf("failures = %+v, want the query error reported", failures)
+	}
+}
+
+func TestRun_AllPresent(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	for _, table := range DefaultRequirements().SQLTables {
+		mock.ExpectQuery(tableQuery).
+			WithArgs(table).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	}
+
+	report := Run(context.Background(), Config{
+		SQLDB:        db,
+		Requirements: DefaultRequirements(),
+	})
+
+	if !report.OK() {
+		t.Errorf("OK() = false, failures = %+v", report.Failures())
+	}
+}
+
+func TestRun_MongoIndexMustBeUnique(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	index := func(name string, unique bool) bson.D {
+		doc := bson.D{{Key: "v", Value: 2}, {Key: "key", Value: bson.D{{Key: strings.TrimSuffix(name, "_1"), Value: 1}}}, {Key: "name", Value: name}}
+		if unique {
+			doc = append(doc, bson.E{Key: "unique", Value: true})
+		}
+		return doc
+	}
+	requirements := Requirements{MongoCollections: map[string][]MongoIndex{
+		"user_profiles": {{Name: "created_at_1"}, {Name: "email_1", Unique: true}},
+	}}
+
+	for _, tt := range []struct {
+		name   string
+		unique bool
+	}{
+		{name: "non-unique email index fails", unique: false},
+		{name: "unique email index passes", unique: true},
+	} {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "patterns.$cmd.listCollections", mtest.FirstBatch, bson.D{{Key: "name", Value: "user_profiles"}}),
+				mtest.CreateCursorResponse(0, "patterns.user_profiles", mtest.FirstBatch,
+					index("created_at_1", false), index("email_1", tt.unique)),
+			)
+
+			report := Run(context.Background(), Config{MongoDatabase: mt.Client.Database("patterns"), Requirements: requirements})
+
+			failures := report.Failures()
+			if tt.unique {
+				if len(failures) != 0 {
+					t.Errorf("failures = %v, want none", failures)
+				}
+				return
+			}
+			if len(failures) != 1 || failures[0].Resource != "user_profiles.email_1" {
+				t.Fatalf("failures = %v, want user_profiles.email_1", failures)
+			}
+			if !strings.Contains(failures[0].Err.Error(), "not unique") {
+				t.Errorf("error = %v, want it to say the index is not unique", failures[0].Err)
+			}
+		})
+	}
+}